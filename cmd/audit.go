@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/eryajf/zenops/internal/audit"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditPlatform   string
+	auditUser       string
+	auditTool       string
+	auditStatus     string
+	auditSince      time.Duration
+	auditLimit      int
+	auditOutputType string
+)
+
+// auditCmd 查询工具调用审计日志
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "查询工具调用审计日志",
+	Long:  `按平台、用户、工具、状态和时间范围查询工具调用审计日志,按时间倒序输出。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter := audit.Filter{
+			Platform: auditPlatform,
+			User:     auditUser,
+			Tool:     auditTool,
+			Status:   auditStatus,
+			Limit:    auditLimit,
+		}
+		if auditSince > 0 {
+			filter.Since = time.Now().Add(-auditSince)
+		}
+
+		entries, err := audit.Query(cfg.Audit.Path, cfg.Audit.MaxBackups, filter)
+		if err != nil {
+			return fmt.Errorf("failed to query audit log: %w", err)
+		}
+
+		// 输出结果
+		if auditOutputType == "json" {
+			data, _ := json.MarshalIndent(entries, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		// 使用 lipgloss/table 表格输出
+		rows := [][]string{}
+		for _, entry := range entries {
+			user := entry.UserName
+			if user == "" {
+				user = entry.UserID
+			}
+			args := ""
+			if len(entry.Arguments) > 0 {
+				data, _ := json.Marshal(entry.Arguments)
+				args = string(data)
+			}
+			rows = append(rows, []string{
+				entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Platform, user,
+				entry.Tool, args, strconv.FormatInt(entry.DurationMS, 10) + "ms", entry.Status,
+			})
+		}
+
+		t := table.New().
+			Border(lipgloss.NormalBorder()).
+			BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+			Headers("Time", "Platform", "User", "Tool", "Arguments", "Duration", "Status").
+			Rows(rows...)
+
+		fmt.Println(t)
+		fmt.Println()
+		logx.Info("Query completed, count %d, path %s", len(entries), cfg.Audit.Path)
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().StringVar(&auditPlatform, "platform", "", "按平台过滤 (dingtalk, feishu, wecom, openai, http, mcp)")
+	auditCmd.Flags().StringVar(&auditUser, "user", "", "按用户 ID 或用户名过滤")
+	auditCmd.Flags().StringVar(&auditTool, "tool", "", "按工具名过滤 (支持前缀匹配)")
+	auditCmd.Flags().StringVar(&auditStatus, "status", "", "按状态过滤 (success, error)")
+	auditCmd.Flags().DurationVar(&auditSince, "since", 24*time.Hour, "查询最近一段时间内的记录 (如 1h, 24h)")
+	auditCmd.Flags().IntVar(&auditLimit, "limit", 50, "最多返回的记录数")
+	auditCmd.Flags().StringVarP(&auditOutputType, "output", "o", "table", "输出格式 (table, json)")
+}
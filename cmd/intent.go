@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/eryajf/zenops/internal/intent"
+	"github.com/spf13/cobra"
+)
+
+var (
+	intentRulesFile  string
+	intentCorpusFile string
+)
+
+// intentCmd 意图规则工具
+var intentCmd = &cobra.Command{
+	Use:   "intent",
+	Short: "意图规则工具",
+	Long:  `未启用 LLM 时,机器人按意图规则将自然语言消息映射为工具调用。此命令用于调试规则和运行语料库检查。`,
+}
+
+// intentParseCmd 解析一条消息
+var intentParseCmd = &cobra.Command{
+	Use:   "parse <message>",
+	Short: "解析一条消息,输出匹配的规则、工具和参数",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		engine, err := newIntentEngine()
+		if err != nil {
+			return err
+		}
+
+		in, ok := engine.Parse(strings.Join(args, " "))
+		if !ok {
+			return fmt.Errorf("未匹配任何意图规则")
+		}
+
+		data, _ := json.MarshalIndent(in, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+// intentCheckCmd 运行语料库检查
+var intentCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "使用语料库检查意图规则",
+	Long:  `逐条解析语料库中的消息,与期望的工具和参数对比,存在未通过的用例时返回非零退出码。默认使用内置语料库。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		engine, err := newIntentEngine()
+		if err != nil {
+			return err
+		}
+
+		var cases []intent.Case
+		if intentCorpusFile != "" {
+			cases, err = intent.LoadCorpus(intentCorpusFile)
+		} else {
+			cases, err = intent.BuiltinCorpus()
+		}
+		if err != nil {
+			return err
+		}
+
+		failures := engine.Check(cases)
+		if len(failures) == 0 {
+			fmt.Printf("✅ %d 条用例全部通过\n", len(cases))
+			return nil
+		}
+
+		rows := [][]string{}
+		for _, f := range failures {
+			rows = append(rows, []string{f.Case.Text, f.Case.Tool, f.Reason})
+		}
+
+		t := table.New().
+			Border(lipgloss.NormalBorder()).
+			BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+			Headers("Text", "Expected", "Reason").
+			Rows(rows...)
+
+		fmt.Println(t)
+		fmt.Println()
+
+		return fmt.Errorf("%d/%d 条用例未通过", len(failures), len(cases))
+	},
+}
+
+// newIntentEngine 使用命令行或配置文件中的规则创建意图引擎
+func newIntentEngine() (*intent.Engine, error) {
+	rulesFile := intentRulesFile
+	if rulesFile == "" {
+		rulesFile = cfg.Intent.RulesFile
+	}
+	return intent.New(rulesFile)
+}
+
+func init() {
+	rootCmd.AddCommand(intentCmd)
+	intentCmd.AddCommand(intentParseCmd)
+	intentCmd.AddCommand(intentCheckCmd)
+
+	intentCmd.PersistentFlags().StringVar(&intentRulesFile, "rules", "", "自定义规则文件 (默认使用配置中的 intent.rules_file)")
+	intentCheckCmd.Flags().StringVar(&intentCorpusFile, "corpus", "", "语料库文件 (默认使用内置语料库)")
+}
@@ -60,6 +60,7 @@ var aliyunECSListCmd = &cobra.Command{
 
 		// 初始化 Provider
 		providerConfig := map[string]any{
+			"account":           aliyunConfig.Name,
 			"access_key_id":     aliyunConfig.AK,
 			"access_key_secret": aliyunConfig.SK,
 			"regions":           interfaceSlice(aliyunConfig.Regions),
@@ -180,6 +181,7 @@ var aliyunECSGetCmd = &cobra.Command{
 
 		// 初始化 Provider
 		providerConfig := map[string]any{
+			"account":           aliyunConfig.Name,
 			"access_key_id":     aliyunConfig.AK,
 			"access_key_secret": aliyunConfig.SK,
 			"regions":           interfaceSlice(aliyunConfig.Regions),
@@ -232,6 +234,7 @@ var aliyunRDSListCmd = &cobra.Command{
 
 		// 初始化 Provider
 		providerConfig := map[string]any{
+			"account":           aliyunConfig.Name,
 			"access_key_id":     aliyunConfig.AK,
 			"access_key_secret": aliyunConfig.SK,
 			"regions":           interfaceSlice(aliyunConfig.Regions),
@@ -344,6 +347,7 @@ var aliyunRDSGetCmd = &cobra.Command{
 
 		// 初始化 Provider
 		providerConfig := map[string]any{
+			"account":           aliyunConfig.Name,
 			"access_key_id":     aliyunConfig.AK,
 			"access_key_secret": aliyunConfig.SK,
 			"regions":           interfaceSlice(aliyunConfig.Regions),
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/spf13/cobra"
+)
+
+// aliyunCDNCmd 阿里云 CDN 命令组
+var aliyunCDNCmd = &cobra.Command{
+	Use:   "cdn",
+	Short: "查询阿里云 CDN 加速域名",
+}
+
+// aliyunCDNListCmd 列出阿里云 CDN 加速域名
+var aliyunCDNListCmd = &cobra.Command{
+	Use:   "list [keyword]",
+	Short: "列出 CDN 加速域名",
+	Long:  `列出阿里云 CDN 加速域名,包含 CNAME、状态、源站和 HTTPS 证书配置,可按域名关键字模糊匹配。`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return listCDNDomains(p, account, args, aliyunOutputType)
+	},
+}
+
+// tencentCDNCmd 腾讯云 CDN 命令组
+var tencentCDNCmd = &cobra.Command{
+	Use:   "cdn",
+	Short: "查询腾讯云 CDN 加速域名",
+}
+
+// tencentCDNListCmd 列出腾讯云 CDN 加速域名
+var tencentCDNListCmd = &cobra.Command{
+	Use:   "list [keyword]",
+	Short: "列出 CDN 加速域名",
+	Long:  `列出腾讯云 CDN 加速域名,包含 CNAME、状态、源站和 HTTPS 证书配置,可按域名关键字模糊匹配。`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return listCDNDomains(p, account, args, tencentOutputType)
+	},
+}
+
+// listCDNDomains 列出 CDN 加速域名并输出
+func listCDNDomains(p provider.Provider, account string, args []string, output string) error {
+	keyword := ""
+	if len(args) > 0 {
+		keyword = args[0]
+	}
+
+	domains, err := p.ListCDNDomains(context.Background(), &provider.QueryOptions{
+		Filters: map[string]string{"keyword": keyword},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list CDN domains: %w", err)
+	}
+
+	if output == "json" {
+		data, _ := json.MarshalIndent(domains, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	rows := [][]string{}
+	for _, d := range domains {
+		origins := make([]string, 0, len(d.Origins))
+		for _, o := range d.Origins {
+			origin := o.Address
+			if o.Priority == model.CDNOriginBackup {
+				origin += "(backup)"
+			}
+			origins = append(origins, origin)
+		}
+		https, certExpire := "off", "-"
+		if d.HTTPS {
+			https = "on"
+		}
+		if d.CertExpireAt != nil {
+			certExpire = d.CertExpireAt.Local().Format("2006-01-02")
+		}
+		rows = append(rows, []string{
+			d.Name, d.Status, d.BusinessType, d.Area, strings.Join(origins, ","), https, certExpire, d.CNAME,
+		})
+	}
+	fmt.Println(networkTable([]string{"Domain", "Status", "Type", "Area", "Origins", "HTTPS", "Cert Expire", "CNAME"}, rows))
+	fmt.Println()
+	logx.Info("Query completed, count %d, account %s", len(domains), account)
+	return nil
+}
+
+func init() {
+	aliyunCmd.AddCommand(aliyunCDNCmd)
+	aliyunCDNCmd.AddCommand(aliyunCDNListCmd)
+
+	tencentCmd.AddCommand(tencentCDNCmd)
+	tencentCDNCmd.AddCommand(tencentCDNListCmd)
+}
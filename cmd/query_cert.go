@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/certs"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	certCloud      string // 云平台,指定后只查询云证书
+	certAccount    string // 账号名称,指定后只查询云证书
+	certSource     string // 证书来源: cloud, tls,为空时查询全部
+	certOutputType string
+	certDays       int // 剩余有效天数阈值
+)
+
+// certCmd 证书查询命令组
+var certCmd = &cobra.Command{
+	Use:   "cert",
+	Short: "查询 SSL 证书",
+	Long:  `查询阿里云数字证书管理服务、腾讯云 SSL 证书中的证书,以及 certificates.hosts 中主机的线上证书。`,
+}
+
+// certListCmd 列出证书
+var certListCmd = &cobra.Command{
+	Use:   "list [keyword]",
+	Short: "列出证书",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		checker, source, err := certs.Load(cfg, certCloud, certAccount, certSource)
+		if err != nil {
+			return err
+		}
+
+		keyword := ""
+		if len(args) > 0 {
+			keyword = args[0]
+		}
+		list, err := checker.List(context.Background(), keyword, source)
+		if err != nil {
+			return fmt.Errorf("failed to list certificates: %w", err)
+		}
+		return printCertificates(list)
+	},
+}
+
+// certExpiringCmd 列出即将过期的证书
+var certExpiringCmd = &cobra.Command{
+	Use:   "expiring",
+	Short: "列出即将过期或已过期的证书",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		checker, source, err := certs.Load(cfg, certCloud, certAccount, certSource)
+		if err != nil {
+			return err
+		}
+
+		list, err := checker.Expiring(context.Background(), certDays, source)
+		if err != nil {
+			return fmt.Errorf("failed to list certificates: %w", err)
+		}
+		return printCertificates(list)
+	},
+}
+
+// certCheckCmd 检查主机的线上证书
+var certCheckCmd = &cobra.Command{
+	Use:   "check <host>",
+	Short: "与主机进行 TLS 握手并查看线上证书",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cert, err := certs.Probe(context.Background(), args[0], time.Duration(cfg.Certificates.Timeout)*time.Second)
+		if err != nil {
+			return err
+		}
+		return printCertificates([]*model.Certificate{cert})
+	},
+}
+
+// printCertificates 输出证书列表
+func printCertificates(list []*model.Certificate) error {
+	if certOutputType == "json" {
+		data, _ := json.MarshalIndent(list, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	now := time.Now()
+	rows := [][]string{}
+	for _, c := range list {
+		source := c.Host
+		if c.Source == model.CertSourceCloud {
+			source = c.Provider + "/" + c.Account + " " + c.ID
+		}
+		rows = append(rows, []string{
+			c.Domain, strconv.Itoa(len(c.SANs)), c.NotAfter.Local().Format("2006-01-02"),
+			strconv.Itoa(c.DaysLeft(now)), c.Issuer, c.Status, source, strings.Join(c.Products, ","),
+		})
+	}
+	fmt.Println(networkTable([]string{"Domain", "SANs", "Not After", "Days", "Issuer", "Status", "Source", "Products"}, rows))
+	fmt.Println()
+	logx.Info("Query completed, count %d", len(list))
+	return nil
+}
+
+func init() {
+	queryCmd.AddCommand(certCmd)
+	certCmd.AddCommand(certListCmd)
+	certCmd.AddCommand(certExpiringCmd)
+	certCmd.AddCommand(certCheckCmd)
+
+	certCmd.PersistentFlags().StringVar(&certCloud, "cloud", "", "云平台 (aliyun, tencent),指定后只查询云证书")
+	certCmd.PersistentFlags().StringVarP(&certAccount, "account", "a", "", "账号名称,指定后只查询云证书")
+	certCmd.PersistentFlags().StringVar(&certSource, "source", "", "证书来源 (cloud, tls),默认查询全部")
+	certCmd.PersistentFlags().StringVarP(&certOutputType, "output", "o", "table", "输出格式 (table, json)")
+	certExpiringCmd.Flags().IntVar(&certDays, "days", 30, "剩余有效天数阈值")
+}
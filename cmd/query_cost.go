@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/cost"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	costCloud      string // 云平台,为空时查询阿里云和腾讯云
+	costAccount    string // 账号名称,为空时查询所有启用的账号
+	costPeriod     string // 账期: current, last 或 YYYY-MM
+	costGroupBy    string // 拆分维度: account, product, region, tag
+	costTagKey     string // 按标签拆分时的标签键
+	costTop        int    // 费用最高实例的数量
+	costOutputType string
+)
+
+// costCmd 费用查询命令组
+var costCmd = &cobra.Command{
+	Use:   "cost",
+	Short: "查询云账单费用",
+	Long: `汇总阿里云费用中心、腾讯云费用中心的账单,默认查询所有启用的账号。
+金额为优惠后、代金券抵扣前的应付金额,账单通常有 1 天左右的延迟。`,
+}
+
+// costSummaryCmd 各账号本月至今和上月的费用
+var costSummaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "查看各账号本月至今和上月的费用",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reporter, err := cost.Load(cfg, costCloud, costAccount)
+		if err != nil {
+			return err
+		}
+
+		summaries, err := reporter.Summary(context.Background(), time.Now())
+		if err != nil {
+			return err
+		}
+		if costOutputType == "json" {
+			return printCostJSON(summaries)
+		}
+
+		rows := [][]string{}
+		for _, s := range summaries {
+			rows = append(rows, []string{s.Provider, s.Account, formatAmount(s.MonthToDate), formatAmount(s.LastMonth), s.Currency})
+		}
+		fmt.Println(networkTable([]string{"Cloud", "Account", "Month To Date", "Last Month", "Currency"}, rows))
+		fmt.Println()
+		logx.Info("Query completed, count %d", len(summaries))
+		return nil
+	},
+}
+
+// costBreakdownCmd 按维度拆分费用
+var costBreakdownCmd = &cobra.Command{
+	Use:   "breakdown",
+	Short: "按账号、产品、地域或标签拆分费用",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cycle, items, err := loadCostItems()
+		if err != nil {
+			return err
+		}
+
+		breakdown, err := cost.Breakdown(items, cycle, costGroupBy, costTagKey)
+		if err != nil {
+			return err
+		}
+		if costOutputType == "json" {
+			return printCostJSON(breakdown)
+		}
+
+		rows := [][]string{}
+		for _, g := range breakdown.Groups {
+			share := "-"
+			if breakdown.Total > 0 {
+				share = fmt.Sprintf("%.1f%%", g.Amount/breakdown.Total*100)
+			}
+			rows = append(rows, []string{g.Key, formatAmount(g.Amount), share, strconv.Itoa(g.Instances)})
+		}
+		fmt.Println(networkTable([]string{"Key", "Amount", "Share", "Instances"}, rows))
+		fmt.Printf("\n%s total: %s %s\n\n", cycle, formatAmount(breakdown.Total), breakdown.Currency)
+		return nil
+	},
+}
+
+// costTopCmd 费用最高的实例
+var costTopCmd = &cobra.Command{
+	Use:   "top",
+	Short: "列出费用最高的实例",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, items, err := loadCostItems()
+		if err != nil {
+			return err
+		}
+
+		list := cost.Top(items, costTop)
+		if costOutputType == "json" {
+			return printCostJSON(list)
+		}
+
+		rows := [][]string{}
+		for _, item := range list {
+			rows = append(rows, []string{
+				item.InstanceID, item.InstanceName, item.Product, item.Region,
+				item.Provider + "/" + item.Account, formatAmount(item.Amount), item.Currency,
+			})
+		}
+		fmt.Println(networkTable([]string{"Instance ID", "Name", "Product", "Region", "Account", "Amount", "Currency"}, rows))
+		fmt.Println()
+		logx.Info("Query completed, count %d", len(list))
+		return nil
+	},
+}
+
+// loadCostItems 按命令行参数查询账期内的实例费用
+func loadCostItems() (string, []*model.CostItem, error) {
+	cycle, err := cost.Cycle(costPeriod, time.Now())
+	if err != nil {
+		return "", nil, err
+	}
+	reporter, err := cost.Load(cfg, costCloud, costAccount)
+	if err != nil {
+		return "", nil, err
+	}
+	items, err := reporter.Items(context.Background(), cycle)
+	if err != nil {
+		return "", nil, err
+	}
+	return cycle, items, nil
+}
+
+// printCostJSON 以 JSON 输出查询结果
+func printCostJSON(v any) error {
+	data, _ := json.MarshalIndent(v, "", "  ")
+	fmt.Println(string(data))
+	return nil
+}
+
+// formatAmount 金额保留两位小数
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+func init() {
+	queryCmd.AddCommand(costCmd)
+	costCmd.AddCommand(costSummaryCmd)
+	costCmd.AddCommand(costBreakdownCmd)
+	costCmd.AddCommand(costTopCmd)
+
+	costCmd.PersistentFlags().StringVar(&costCloud, "cloud", "", "云平台 (aliyun, tencent),默认查询全部")
+	costCmd.PersistentFlags().StringVarP(&costAccount, "account", "a", "", "账号名称,默认查询所有启用的账号")
+	costCmd.PersistentFlags().StringVarP(&costOutputType, "output", "o", "table", "输出格式 (table, json)")
+	costBreakdownCmd.Flags().StringVar(&costPeriod, "period", cost.PeriodCurrent, "账期 (current, last 或 YYYY-MM)")
+	costBreakdownCmd.Flags().StringVar(&costGroupBy, "by", model.CostGroupProduct, "拆分维度 (account, product, region, tag)")
+	costBreakdownCmd.Flags().StringVar(&costTagKey, "tag-key", "", "按标签拆分时的标签键")
+	costTopCmd.Flags().StringVar(&costPeriod, "period", cost.PeriodCurrent, "账期 (current, last 或 YYYY-MM)")
+	costTopCmd.Flags().IntVar(&costTop, "top", 10, "返回数量")
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/cloudaccount"
+	"github.com/eryajf/zenops/internal/dns"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dnsCloud      string // 云平台,为空时查询全部
+	dnsAccount    string // 账号名称,为空时查询所有启用的账号
+	dnsOutputType string
+	dnsRR         string // 按主机记录过滤
+	dnsType       string // 按记录类型过滤
+)
+
+// dnsCmd DNS 查询命令组
+var dnsCmd = &cobra.Command{
+	Use:   "dns",
+	Short: "查询云解析 DNS",
+	Long:  `查询阿里云云解析 DNS 和腾讯云 DNSPod 托管的域名及解析记录,默认查询所有启用的账号。`,
+}
+
+// dnsDomainsCmd 列出托管域名
+var dnsDomainsCmd = &cobra.Command{
+	Use:   "domains [keyword]",
+	Short: "列出托管域名",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolver, err := newDNSResolver()
+		if err != nil {
+			return err
+		}
+
+		keyword := ""
+		if len(args) > 0 {
+			keyword = args[0]
+		}
+		domains, err := resolver.ListDomains(context.Background(), keyword)
+		if err != nil {
+			return fmt.Errorf("failed to list domains: %w", err)
+		}
+
+		if dnsOutputType == "json" {
+			data, _ := json.MarshalIndent(domains, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		rows := [][]string{}
+		for _, d := range domains {
+			rows = append(rows, []string{d.Name, d.Provider, d.Account, strconv.Itoa(d.RecordCount), d.Status})
+		}
+		fmt.Println(networkTable([]string{"Domain", "Provider", "Account", "Records", "Status"}, rows))
+		fmt.Println()
+		logx.Info("Query completed, count %d", len(domains))
+		return nil
+	},
+}
+
+// dnsRecordsCmd 列出域名解析记录
+var dnsRecordsCmd = &cobra.Command{
+	Use:   "records <domain>",
+	Short: "列出域名解析记录",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolver, err := newDNSResolver()
+		if err != nil {
+			return err
+		}
+
+		records, err := resolver.ListRecords(context.Background(), args[0], map[string]string{"rr": dnsRR, "type": dnsType})
+		if err != nil {
+			return fmt.Errorf("failed to list DNS records: %w", err)
+		}
+		return printDNSRecords(records)
+	},
+}
+
+// dnsResolveCmd 将主机名解析到云资源
+var dnsResolveCmd = &cobra.Command{
+	Use:   "resolve <hostname>",
+	Short: "沿 A/CNAME 记录将主机名解析到云资源",
+	Long:  `沿托管的 A/CNAME 解析记录跟随主机名,找到最终提供服务的 ECS/CVM 实例、负载均衡、CDN 或对象存储。`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolver, err := newDNSResolver()
+		if err != nil {
+			return err
+		}
+
+		resolution, err := resolver.Resolve(context.Background(), args[0])
+		if err != nil {
+			return err
+		}
+
+		if dnsOutputType == "json" {
+			data, _ := json.MarshalIndent(resolution, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Println(dnsRecordsTable(resolution.Records))
+		rows := [][]string{}
+		for _, t := range resolution.Targets {
+			rows = append(rows, []string{t.Address, t.Type, t.ResourceID, t.ResourceName, t.Provider, t.Account, t.Region})
+		}
+		fmt.Println(networkTable([]string{"Address", "Type", "Resource ID", "Name", "Provider", "Account", "Region"}, rows))
+		fmt.Println()
+		return nil
+	},
+}
+
+// dnsReverseCmd 按 IP 反查解析记录
+var dnsReverseCmd = &cobra.Command{
+	Use:   "reverse <ip>",
+	Short: "按 IP 反查指向它的解析记录",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolver, err := newDNSResolver()
+		if err != nil {
+			return err
+		}
+
+		records, err := resolver.ReverseLookup(context.Background(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to search DNS records: %w", err)
+		}
+		return printDNSRecords(records)
+	},
+}
+
+// newDNSResolver 按 --cloud、--account 创建 DNS 解析器
+func newDNSResolver() (*dns.Resolver, error) {
+	accounts, err := cloudaccount.Load(cfg, dnsCloud, dnsAccount)
+	if err != nil {
+		return nil, err
+	}
+	return dns.NewResolver(accounts), nil
+}
+
+// printDNSRecords 输出解析记录
+func printDNSRecords(records []*model.DNSRecord) error {
+	if dnsOutputType == "json" {
+		data, _ := json.MarshalIndent(records, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println(dnsRecordsTable(records))
+	fmt.Println()
+	logx.Info("Query completed, count %d", len(records))
+	return nil
+}
+
+// dnsRecordsTable 解析记录表格
+func dnsRecordsTable(records []*model.DNSRecord) string {
+	rows := [][]string{}
+	for _, r := range records {
+		rows = append(rows, []string{r.FQDN, r.Type, r.Value, strconv.Itoa(r.TTL), r.Line, r.Status, r.Provider, r.Account})
+	}
+	return networkTable([]string{"Name", "Type", "Value", "TTL", "Line", "Status", "Provider", "Account"}, rows).String()
+}
+
+func init() {
+	queryCmd.AddCommand(dnsCmd)
+	dnsCmd.AddCommand(dnsDomainsCmd)
+	dnsCmd.AddCommand(dnsRecordsCmd)
+	dnsCmd.AddCommand(dnsResolveCmd)
+	dnsCmd.AddCommand(dnsReverseCmd)
+
+	dnsCmd.PersistentFlags().StringVar(&dnsCloud, "cloud", "", "云平台 (aliyun, tencent),默认查询全部")
+	dnsCmd.PersistentFlags().StringVarP(&dnsAccount, "account", "a", "", "账号名称,默认查询所有启用的账号")
+	dnsCmd.PersistentFlags().StringVarP(&dnsOutputType, "output", "o", "table", "输出格式 (table, json)")
+	dnsRecordsCmd.Flags().StringVar(&dnsRR, "rr", "", "按主机记录过滤,@ 表示主域名")
+	dnsRecordsCmd.Flags().StringVar(&dnsType, "type", "", "按记录类型过滤 (A, CNAME 等)")
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/spf13/cobra"
+)
+
+var (
+	eipStatus  string // 按状态过滤公网 IP
+	eipType    string // 按类型过滤公网 IP
+	eipAddress string // 按 IP 地址过滤公网 IP
+)
+
+// aliyunEIPCmd 阿里云公网 IP 命令组
+var aliyunEIPCmd = &cobra.Command{
+	Use:   "eip",
+	Short: "查询阿里云弹性公网 IP 及 ECS 固定公网 IP",
+}
+
+// aliyunEIPListCmd 列出阿里云公网 IP
+var aliyunEIPListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出公网 IP",
+	Long:  `列出阿里云弹性公网 IP 及 ECS 固定公网 IP,包含带宽、计费方式和绑定资源。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return listEIPs(p, account, aliyunRegion, aliyunOutputType)
+	},
+}
+
+// aliyunEIPUnassociatedCmd 列出阿里云未绑定仍计费的 EIP
+var aliyunEIPUnassociatedCmd = &cobra.Command{
+	Use:   "unassociated",
+	Short: "列出未绑定但仍在计费的弹性公网 IP",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return reportUnassociatedEIPs(p, account, aliyunRegion, aliyunOutputType)
+	},
+}
+
+// tencentEIPCmd 腾讯云公网 IP 命令组
+var tencentEIPCmd = &cobra.Command{
+	Use:   "eip",
+	Short: "查询腾讯云弹性公网 IP 及普通公网 IP",
+}
+
+// tencentEIPListCmd 列出腾讯云公网 IP
+var tencentEIPListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出公网 IP",
+	Long:  `列出腾讯云弹性公网 IP 及普通公网 IP,包含带宽、计费方式和绑定资源。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return listEIPs(p, account, tencentRegion, tencentOutputType)
+	},
+}
+
+// tencentEIPUnassociatedCmd 列出腾讯云未绑定仍计费的 EIP
+var tencentEIPUnassociatedCmd = &cobra.Command{
+	Use:   "unassociated",
+	Short: "列出未绑定但仍在计费的弹性公网 IP",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return reportUnassociatedEIPs(p, account, tencentRegion, tencentOutputType)
+	},
+}
+
+// listEIPs 列出公网 IP 并输出
+func listEIPs(p provider.Provider, account, region, output string) error {
+	eips, err := p.ListEIPs(context.Background(), &provider.QueryOptions{
+		Region: region,
+		Filters: map[string]string{
+			"status":  eipStatus,
+			"type":    eipType,
+			"address": eipAddress,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list public IPs: %w", err)
+	}
+
+	return printEIPs(eips, account, output)
+}
+
+// reportUnassociatedEIPs 列出未绑定但仍在计费的弹性公网 IP 并输出
+func reportUnassociatedEIPs(p provider.Provider, account, region, output string) error {
+	eips, err := p.ListEIPs(context.Background(), &provider.QueryOptions{
+		Region: region,
+		Filters: map[string]string{
+			"type":   model.EIPTypeEIP,
+			"status": model.EIPStatusAvailable,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list public IPs: %w", err)
+	}
+
+	idle := make([]*model.EIP, 0, len(eips))
+	for _, eip := range eips {
+		if eip.IdleBilling() {
+			idle = append(idle, eip)
+		}
+	}
+	return printEIPs(idle, account, output)
+}
+
+// printEIPs 按输出格式打印公网 IP
+func printEIPs(eips []*model.EIP, account, output string) error {
+	if output == "json" {
+		data, _ := json.MarshalIndent(eips, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	rows := [][]string{}
+	for _, e := range eips {
+		bound := "-"
+		if e.InstanceID != "" {
+			bound = e.InstanceType + " " + e.InstanceID
+		}
+		rows = append(rows, []string{
+			e.Address, e.Type, e.ID, e.Region, e.Status, strconv.Itoa(e.Bandwidth) + "Mbps",
+			e.InternetChargeType + "/" + e.ChargeType, bound,
+		})
+	}
+	fmt.Println(networkTable([]string{"Address", "Type", "ID", "Region", "Status", "Bandwidth", "Billing", "Bound"}, rows))
+	fmt.Println()
+	logx.Info("Query completed, count %d, account %s", len(eips), account)
+	return nil
+}
+
+func init() {
+	aliyunCmd.AddCommand(aliyunEIPCmd)
+	aliyunEIPCmd.AddCommand(aliyunEIPListCmd)
+	aliyunEIPCmd.AddCommand(aliyunEIPUnassociatedCmd)
+
+	tencentCmd.AddCommand(tencentEIPCmd)
+	tencentEIPCmd.AddCommand(tencentEIPListCmd)
+	tencentEIPCmd.AddCommand(tencentEIPUnassociatedCmd)
+
+	for _, c := range []*cobra.Command{aliyunEIPListCmd, tencentEIPListCmd} {
+		c.Flags().StringVar(&eipStatus, "status", "", "按状态过滤: in_use, available")
+		c.Flags().StringVar(&eipType, "type", "", "按类型过滤: eip, public_ip")
+		c.Flags().StringVar(&eipAddress, "address", "", "按 IP 地址过滤")
+	}
+}
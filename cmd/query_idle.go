@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/idle"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	idleCloud      string // 云平台,为空时查询阿里云和腾讯云
+	idleAccount    string // 账号名称,为空时查询所有启用的账号
+	idleType       string // 资源类型: instance, database,为空时全部检测
+	idleDays       int    // 统计天数,为 0 时使用 idle.days 配置
+	idleOutputType string
+)
+
+// idleCmd 检测闲置资源
+var idleCmd = &cobra.Command{
+	Use:   "idle",
+	Short: "检测闲置的实例和数据库",
+	Long: `根据阿里云云监控、腾讯云可观测平台的 CPU、内存和网络指标,找出最近 N 天持续低于 idle 配置阈值的
+ECS/CVM 实例和 RDS/CDB 数据库,并附带上月费用或同规格实例的估算费用。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		detector, err := idle.Load(cfg, idleCloud, idleAccount)
+		if err != nil {
+			return err
+		}
+
+		list, err := detector.Detect(context.Background(), idleType, idleDays)
+		if err != nil {
+			return err
+		}
+		if idleOutputType == "json" {
+			data, _ := json.MarshalIndent(list, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		rows := [][]string{}
+		for _, item := range list {
+			memory, cost := "-", "-"
+			if item.MemoryAvg != nil {
+				memory = fmt.Sprintf("%.1f", *item.MemoryAvg)
+			}
+			if item.MonthlyCost != nil {
+				cost = formatAmount(*item.MonthlyCost)
+				if item.CostSource == model.IdleCostEstimate {
+					cost += " (估算)"
+				}
+			}
+			rows = append(rows, []string{
+				item.ID, item.Name, item.Provider + "/" + item.Account, item.Region, item.Spec,
+				fmt.Sprintf("%.1f", item.CPUAvg), fmt.Sprintf("%.1f", item.CPUMax), memory,
+				fmt.Sprintf("%.1f", item.NetworkKbps), cost, item.Suggestion,
+			})
+		}
+		fmt.Println(networkTable([]string{"ID", "Name", "Account", "Region", "Spec", "CPU Avg%", "CPU Max%", "Mem%", "Net Kbps", "Monthly Cost", "Suggestion"}, rows))
+		fmt.Println()
+		logx.Info("Query completed, count %d", len(list))
+		return nil
+	},
+}
+
+func init() {
+	queryCmd.AddCommand(idleCmd)
+
+	idleCmd.Flags().StringVar(&idleCloud, "cloud", "", "云平台 (aliyun, tencent),默认查询全部")
+	idleCmd.Flags().StringVarP(&idleAccount, "account", "a", "", "账号名称,默认查询所有启用的账号")
+	idleCmd.Flags().StringVar(&idleType, "type", "", "资源类型 (instance, database),默认全部")
+	idleCmd.Flags().IntVar(&idleDays, "days", 0, "统计天数,默认使用 idle.days 配置,最多 30 天")
+	idleCmd.Flags().StringVarP(&idleOutputType, "output", "o", "table", "输出格式 (table, json)")
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lbType string // 负载均衡类型 (仅阿里云: slb, alb)
+	lbVIP  string // 按服务地址过滤
+)
+
+// aliyunSLBCmd 阿里云负载均衡命令组
+var aliyunSLBCmd = &cobra.Command{
+	Use:   "slb",
+	Short: "查询阿里云负载均衡 (SLB/ALB)",
+	Long:  `查询阿里云传统型负载均衡 SLB 和应用型负载均衡 ALB 的列表和详情,详情包含监听、后端服务器及健康状态。`,
+}
+
+// aliyunSLBListCmd 列出阿里云负载均衡
+var aliyunSLBListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出负载均衡",
+	Long:  `列出阿里云负载均衡,可按类型和服务地址过滤。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return listLoadBalancers(p, account, aliyunRegion, aliyunOutputType)
+	},
+}
+
+// aliyunSLBGetCmd 获取阿里云负载均衡详情
+var aliyunSLBGetCmd = &cobra.Command{
+	Use:   "get <lb-id>",
+	Short: "获取负载均衡详情",
+	Long:  `获取指定负载均衡的监听、后端服务器及健康状态,后端服务器关联 ECS 实例。`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, _, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return getLoadBalancer(p, args[0], aliyunOutputType)
+	},
+}
+
+// tencentCLBCmd 腾讯云 CLB 命令组
+var tencentCLBCmd = &cobra.Command{
+	Use:   "clb",
+	Short: "查询腾讯云 CLB 负载均衡",
+	Long:  `查询腾讯云 CLB 负载均衡的列表和详情,详情包含监听、后端服务器及健康状态。`,
+}
+
+// tencentCLBListCmd 列出腾讯云 CLB
+var tencentCLBListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出 CLB",
+	Long:  `列出腾讯云 CLB 负载均衡,可按服务地址过滤。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return listLoadBalancers(p, account, tencentRegion, tencentOutputType)
+	},
+}
+
+// tencentCLBGetCmd 获取腾讯云 CLB 详情
+var tencentCLBGetCmd = &cobra.Command{
+	Use:   "get <lb-id>",
+	Short: "获取 CLB 详情",
+	Long:  `获取指定 CLB 的监听、后端服务器及健康状态,后端服务器关联 CVM 实例。`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, _, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return getLoadBalancer(p, args[0], tencentOutputType)
+	},
+}
+
+// newCloudProvider 按云平台和账号创建并初始化 Provider
+func newCloudProvider(cloud, accountName string) (provider.Provider, string, error) {
+	var providerConfig map[string]any
+	var account string
+
+	if cloud == "aliyun" {
+		aliyunConfig, err := getAliyunConfig(accountName)
+		if err != nil {
+			return nil, "", err
+		}
+		account = aliyunConfig.Name
+		providerConfig = map[string]any{
+			"account":           aliyunConfig.Name,
+			"access_key_id":     aliyunConfig.AK,
+			"access_key_secret": aliyunConfig.SK,
+			"regions":           interfaceSlice(aliyunConfig.Regions),
+		}
+	} else {
+		tencentConfig, err := getTencentConfig(accountName)
+		if err != nil {
+			return nil, "", err
+		}
+		account = tencentConfig.Name
+		providerConfig = map[string]any{
+			"account":    tencentConfig.Name,
+			"secret_id":  tencentConfig.AK,
+			"secret_key": tencentConfig.SK,
+			"regions":    interfaceSlice(tencentConfig.Regions),
+		}
+	}
+
+	p, err := provider.GetProvider(cloud)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get %s provider: %w", cloud, err)
+	}
+	if err := p.Initialize(providerConfig); err != nil {
+		return nil, "", fmt.Errorf("failed to initialize %s provider: %w", cloud, err)
+	}
+	return p, account, nil
+}
+
+// listLoadBalancers 列出负载均衡并输出
+func listLoadBalancers(p provider.Provider, account, region, output string) error {
+	opts := &provider.QueryOptions{
+		Region: region,
+		Filters: map[string]string{
+			"type":    lbType,
+			"address": lbVIP,
+		},
+	}
+
+	lbs, err := p.ListLoadBalancers(context.Background(), opts)
+	if err != nil {
+		return fmt.Errorf("failed to list load balancers: %w", err)
+	}
+
+	if output == "json" {
+		data, _ := json.MarshalIndent(lbs, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	rows := [][]string{}
+	for _, lb := range lbs {
+		rows = append(rows, []string{
+			lb.ID, lb.Name, strings.ToUpper(lb.Type), lb.Region, lb.Status,
+			lb.AddressType, strings.Join(lb.Addresses, ","),
+		})
+	}
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+		Headers("ID", "Name", "Type", "Region", "Status", "Address Type", "Addresses").
+		Rows(rows...)
+
+	fmt.Println(t)
+	fmt.Println()
+	logx.Info("Query completed, count %d, account %s", len(lbs), account)
+	return nil
+}
+
+// getLoadBalancer 获取负载均衡详情并输出,表格模式下列出监听和后端服务器
+func getLoadBalancer(p provider.Provider, lbID, output string) error {
+	lb, err := p.GetLoadBalancer(context.Background(), lbID)
+	if err != nil {
+		return fmt.Errorf("failed to get load balancer: %w", err)
+	}
+
+	if output == "json" {
+		data, _ := json.MarshalIndent(lb, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("%s (%s) %s %s %s\n\n", lb.Name, lb.ID, strings.ToUpper(lb.Type), lb.Status, strings.Join(lb.Addresses, ","))
+
+	listenerRows := [][]string{}
+	for _, l := range lb.Listeners {
+		listenerRows = append(listenerRows, []string{l.Protocol, strconv.Itoa(l.Port), l.Name})
+	}
+	fmt.Println(table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+		Headers("Protocol", "Port", "Name").
+		Rows(listenerRows...))
+
+	backendRows := [][]string{}
+	for _, b := range lb.Backends {
+		backendRows = append(backendRows, []string{
+			strconv.Itoa(b.ListenerPort), b.ServerID, backendInstanceName(b),
+			b.IP, strconv.Itoa(b.Port), strconv.Itoa(b.Weight), b.Health,
+		})
+	}
+	fmt.Println(table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+		Headers("Listener Port", "Server ID", "Instance Name", "IP", "Port", "Weight", "Health").
+		Rows(backendRows...))
+	fmt.Println()
+
+	return nil
+}
+
+// backendInstanceName 后端关联实例的名称
+func backendInstanceName(b *model.Backend) string {
+	if b.Instance == nil {
+		return ""
+	}
+	return b.Instance.Name
+}
+
+func init() {
+	aliyunCmd.AddCommand(aliyunSLBCmd)
+	aliyunSLBCmd.AddCommand(aliyunSLBListCmd)
+	aliyunSLBCmd.AddCommand(aliyunSLBGetCmd)
+
+	tencentCmd.AddCommand(tencentCLBCmd)
+	tencentCLBCmd.AddCommand(tencentCLBListCmd)
+	tencentCLBCmd.AddCommand(tencentCLBGetCmd)
+
+	aliyunSLBListCmd.Flags().StringVar(&lbType, "type", "", "负载均衡类型 (slb, alb,默认: 全部)")
+	aliyunSLBListCmd.Flags().StringVar(&lbVIP, "vip", "", "按服务地址过滤")
+	tencentCLBListCmd.Flags().StringVar(&lbVIP, "vip", "", "按服务地址过滤")
+}
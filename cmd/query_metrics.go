@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/eryajf/zenops/internal/monitor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	metricsCloud      string // 云平台,为空时在阿里云和腾讯云中查找
+	metricsAccount    string // 账号名称,为空时在所有启用的账号中查找
+	metricsType       string // 资源类型: instance, database,为空时按 ID 前缀推断
+	metricsNames      string // 逗号分隔的指标名称,为空时查询全部
+	metricsWindow     string // 时间范围,如 30m、6h、7d
+	metricsOutputType string
+)
+
+// metricsCmd 查询实例或数据库的监控指标
+var metricsCmd = &cobra.Command{
+	Use:   "metrics <resource>",
+	Short: "查询实例或数据库的监控指标",
+	Long: `根据实例 ID、数据库 ID 或实例 IP 查询 ECS/CVM 实例、RDS/CDB 数据库在最近一段时间内的
+CPU、内存、磁盘和网络流量,输出当前值、平均值、峰值和趋势。`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		window, err := monitor.ParseWindow(metricsWindow)
+		if err != nil {
+			return err
+		}
+		metrics, err := monitor.ParseMetrics(metricsNames)
+		if err != nil {
+			return err
+		}
+
+		querier, err := monitor.Load(cfg, metricsCloud, metricsAccount)
+		if err != nil {
+			return err
+		}
+
+		result, err := querier.Query(context.Background(), args[0], metricsType, metrics, window)
+		if err != nil {
+			return err
+		}
+		if metricsOutputType == "json" {
+			data, _ := json.MarshalIndent(result, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("%s %s | %s/%s %s | %s\n", result.ResourceID, result.Name, result.Provider, result.Account, result.Region, result.Spec)
+		fmt.Printf("%s ~ %s\n", result.Start.Format("2006-01-02 15:04"), result.End.Format("2006-01-02 15:04"))
+
+		rows := [][]string{}
+		for _, m := range result.Metrics {
+			rows = append(rows, []string{
+				m.Metric,
+				monitor.FormatValue(m.Current, m.Unit),
+				monitor.FormatValue(m.Avg, m.Unit),
+				monitor.FormatValue(m.Max, m.Unit),
+				monitor.Sparkline(m),
+			})
+		}
+		fmt.Println(networkTable([]string{"Metric", "Current", "Avg", "Max", "Trend"}, rows))
+		fmt.Println()
+		return nil
+	},
+}
+
+func init() {
+	queryCmd.AddCommand(metricsCmd)
+
+	metricsCmd.Flags().StringVar(&metricsCloud, "cloud", "", "云平台 (aliyun, tencent),默认全部")
+	metricsCmd.Flags().StringVarP(&metricsAccount, "account", "a", "", "账号名称,默认在所有启用的账号中查找")
+	metricsCmd.Flags().StringVar(&metricsType, "type", "", "资源类型 (instance, database),默认按 ID 前缀推断")
+	metricsCmd.Flags().StringVarP(&metricsNames, "metrics", "m", "", "指标,逗号分隔 (cpu, memory, disk, network, network_in, network_out),默认全部")
+	metricsCmd.Flags().StringVarP(&metricsWindow, "window", "w", "1h", "时间范围,如 30m、6h、24h、7d,最长 30d")
+	metricsCmd.Flags().StringVarP(&metricsOutputType, "output", "o", "table", "输出格式 (table, json)")
+}
@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkVpcID     string // 按 VPC 过滤子网和安全组
+	networkPort      int    // 评估的端口
+	networkProtocol  string // 评估的协议
+	networkCIDR      string // 评估的对端网段
+	networkDirection string // 评估的方向
+)
+
+// aliyunVPCCmd 阿里云 VPC 命令组
+var aliyunVPCCmd = &cobra.Command{
+	Use:   "vpc",
+	Short: "查询阿里云 VPC",
+}
+
+// aliyunVPCListCmd 列出阿里云 VPC
+var aliyunVPCListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出 VPC",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return listVPCs(p, account, aliyunRegion, aliyunOutputType)
+	},
+}
+
+// aliyunVSwitchCmd 阿里云交换机命令组
+var aliyunVSwitchCmd = &cobra.Command{
+	Use:   "vswitch",
+	Short: "查询阿里云交换机",
+}
+
+// aliyunVSwitchListCmd 列出阿里云交换机
+var aliyunVSwitchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出交换机",
+	Long:  `列出阿里云交换机 (vSwitch),可按 VPC 过滤。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return listSubnets(p, account, aliyunRegion, aliyunOutputType)
+	},
+}
+
+// aliyunSGCmd 阿里云安全组命令组
+var aliyunSGCmd = &cobra.Command{
+	Use:   "sg",
+	Short: "查询阿里云安全组",
+}
+
+// aliyunSGListCmd 列出阿里云安全组
+var aliyunSGListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出安全组",
+	Long:  `列出阿里云安全组,可按 VPC 过滤。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return listSecurityGroups(p, account, aliyunRegion, aliyunOutputType)
+	},
+}
+
+// aliyunSGGetCmd 获取阿里云安全组详情
+var aliyunSGGetCmd = &cobra.Command{
+	Use:   "get <sg-id>",
+	Short: "获取安全组详情及规则",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, _, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return getSecurityGroup(p, args[0], aliyunOutputType)
+	},
+}
+
+// aliyunECSNetworkCmd 查询 ECS 实例网络及生效规则
+var aliyunECSNetworkCmd = &cobra.Command{
+	Use:   "network <instance-id>",
+	Short: "查询实例网络及生效的安全组规则",
+	Long:  `查询 ECS 实例所在的 VPC、交换机、绑定的安全组及按匹配顺序排列的生效规则,指定 --port 时评估该端口是否对指定网段放行。`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, _, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return getInstanceNetwork(p, args[0], aliyunOutputType)
+	},
+}
+
+// tencentVPCCmd 腾讯云 VPC 命令组
+var tencentVPCCmd = &cobra.Command{
+	Use:   "vpc",
+	Short: "查询腾讯云 VPC",
+}
+
+// tencentVPCListCmd 列出腾讯云 VPC
+var tencentVPCListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出 VPC",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return listVPCs(p, account, tencentRegion, tencentOutputType)
+	},
+}
+
+// tencentSubnetCmd 腾讯云子网命令组
+var tencentSubnetCmd = &cobra.Command{
+	Use:   "subnet",
+	Short: "查询腾讯云子网",
+}
+
+// tencentSubnetListCmd 列出腾讯云子网
+var tencentSubnetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出子网",
+	Long:  `列出腾讯云子网,可按 VPC 过滤。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return listSubnets(p, account, tencentRegion, tencentOutputType)
+	},
+}
+
+// tencentSGCmd 腾讯云安全组命令组
+var tencentSGCmd = &cobra.Command{
+	Use:   "sg",
+	Short: "查询腾讯云安全组",
+}
+
+// tencentSGListCmd 列出腾讯云安全组
+var tencentSGListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出安全组",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return listSecurityGroups(p, account, tencentRegion, tencentOutputType)
+	},
+}
+
+// tencentSGGetCmd 获取腾讯云安全组详情
+var tencentSGGetCmd = &cobra.Command{
+	Use:   "get <sg-id>",
+	Short: "获取安全组详情及规则",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, _, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return getSecurityGroup(p, args[0], tencentOutputType)
+	},
+}
+
+// tencentCVMNetworkCmd 查询 CVM 实例网络及生效规则
+var tencentCVMNetworkCmd = &cobra.Command{
+	Use:   "network <instance-id>",
+	Short: "查询实例网络及生效的安全组规则",
+	Long:  `查询 CVM 实例所在的 VPC、子网、绑定的安全组及按匹配顺序排列的生效规则,指定 --port 时评估该端口是否对指定网段放行。`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, _, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return getInstanceNetwork(p, args[0], tencentOutputType)
+	},
+}
+
+// listVPCs 列出 VPC 并输出
+func listVPCs(p provider.Provider, account, region, output string) error {
+	vpcs, err := p.ListVPCs(context.Background(), &provider.QueryOptions{Region: region})
+	if err != nil {
+		return fmt.Errorf("failed to list VPCs: %w", err)
+	}
+
+	if output == "json" {
+		data, _ := json.MarshalIndent(vpcs, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	rows := [][]string{}
+	for _, vpc := range vpcs {
+		rows = append(rows, []string{vpc.ID, vpc.Name, vpc.Region, vpc.CIDR, vpc.Status, strconv.FormatBool(vpc.IsDefault)})
+	}
+	fmt.Println(networkTable([]string{"ID", "Name", "Region", "CIDR", "Status", "Default"}, rows))
+	fmt.Println()
+	logx.Info("Query completed, count %d, account %s", len(vpcs), account)
+	return nil
+}
+
+// listSubnets 列出子网并输出
+func listSubnets(p provider.Provider, account, region, output string) error {
+	subnets, err := p.ListSubnets(context.Background(), &provider.QueryOptions{
+		Region:  region,
+		Filters: map[string]string{"vpc_id": networkVpcID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list subnets: %w", err)
+	}
+
+	if output == "json" {
+		data, _ := json.MarshalIndent(subnets, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	rows := [][]string{}
+	for _, s := range subnets {
+		rows = append(rows, []string{s.ID, s.Name, s.VpcID, s.Zone, s.CIDR, strconv.Itoa(s.AvailableIPs)})
+	}
+	fmt.Println(networkTable([]string{"ID", "Name", "VPC", "Zone", "CIDR", "Available IPs"}, rows))
+	fmt.Println()
+	logx.Info("Query completed, count %d, account %s", len(subnets), account)
+	return nil
+}
+
+// listSecurityGroups 列出安全组并输出
+func listSecurityGroups(p provider.Provider, account, region, output string) error {
+	groups, err := p.ListSecurityGroups(context.Background(), &provider.QueryOptions{
+		Region:  region,
+		Filters: map[string]string{"vpc_id": networkVpcID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list security groups: %w", err)
+	}
+
+	if output == "json" {
+		data, _ := json.MarshalIndent(groups, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	rows := [][]string{}
+	for _, sg := range groups {
+		rows = append(rows, []string{sg.ID, sg.Name, sg.Region, sg.VpcID, sg.Type, sg.Description})
+	}
+	fmt.Println(networkTable([]string{"ID", "Name", "Region", "VPC", "Type", "Description"}, rows))
+	fmt.Println()
+	logx.Info("Query completed, count %d, account %s", len(groups), account)
+	return nil
+}
+
+// getSecurityGroup 获取安全组详情并输出规则
+func getSecurityGroup(p provider.Provider, sgID, output string) error {
+	sg, err := p.GetSecurityGroup(context.Background(), sgID)
+	if err != nil {
+		return fmt.Errorf("failed to get security group: %w", err)
+	}
+
+	if output == "json" {
+		data, _ := json.MarshalIndent(sg, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("%s (%s) %s\n\n", sg.Name, sg.ID, sg.Region)
+	fmt.Println(rulesTable(sg.Rules))
+	fmt.Println()
+	return nil
+}
+
+// getInstanceNetwork 获取实例网络及生效规则并输出,指定端口时输出评估结果
+func getInstanceNetwork(p provider.Provider, instanceID, output string) error {
+	network, err := p.GetInstanceNetwork(context.Background(), instanceID)
+	if err != nil {
+		return fmt.Errorf("failed to get instance network: %w", err)
+	}
+
+	var check *model.AccessCheck
+	if networkPort > 0 {
+		check, err = network.CheckAccess(networkDirection, networkProtocol, networkPort, networkCIDR)
+		if err != nil {
+			return err
+		}
+	}
+
+	if output == "json" {
+		data, _ := json.MarshalIndent(map[string]any{"network": network, "check": check}, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	inst := network.Instance
+	fmt.Printf("%s (%s) VPC %s Subnet %s\n\n", inst.Name, inst.ID, inst.VpcID, inst.SubnetID)
+
+	fmt.Printf("Ingress (default %s)\n", network.DefaultIngress)
+	fmt.Println(rulesTable(network.Ingress))
+	fmt.Printf("Egress (default %s)\n", network.DefaultEgress)
+	fmt.Println(rulesTable(network.Egress))
+
+	if check != nil {
+		verdict := "ALLOWED"
+		if !check.Allowed {
+			verdict = "DENIED"
+		}
+		fmt.Printf("\n%s %s/%d from %s: %s\n%s\n", check.Direction, check.Protocol, check.Port, check.CIDR, verdict, check.Reason)
+	}
+	fmt.Println()
+	return nil
+}
+
+// rulesTable 按匹配顺序输出安全组规则
+func rulesTable(rules []*model.SecurityGroupRule) *table.Table {
+	rows := [][]string{}
+	for _, r := range rules {
+		peer := r.CIDR
+		if peer == "" {
+			peer = r.PeerGroupID
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(r.Priority), r.Direction, r.Policy, r.Protocol, r.PortRange, peer, r.SecurityGroupID, r.Description,
+		})
+	}
+	return networkTable([]string{"Priority", "Direction", "Policy", "Protocol", "Ports", "Peer", "Security Group", "Description"}, rows)
+}
+
+// networkTable 创建统一样式的表格
+func networkTable(headers []string, rows [][]string) *table.Table {
+	return table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+		Headers(headers...).
+		Rows(rows...)
+}
+
+func init() {
+	aliyunCmd.AddCommand(aliyunVPCCmd)
+	aliyunVPCCmd.AddCommand(aliyunVPCListCmd)
+	aliyunCmd.AddCommand(aliyunVSwitchCmd)
+	aliyunVSwitchCmd.AddCommand(aliyunVSwitchListCmd)
+	aliyunCmd.AddCommand(aliyunSGCmd)
+	aliyunSGCmd.AddCommand(aliyunSGListCmd)
+	aliyunSGCmd.AddCommand(aliyunSGGetCmd)
+	aliyunECSCmd.AddCommand(aliyunECSNetworkCmd)
+
+	tencentCmd.AddCommand(tencentVPCCmd)
+	tencentVPCCmd.AddCommand(tencentVPCListCmd)
+	tencentCmd.AddCommand(tencentSubnetCmd)
+	tencentSubnetCmd.AddCommand(tencentSubnetListCmd)
+	tencentCmd.AddCommand(tencentSGCmd)
+	tencentSGCmd.AddCommand(tencentSGListCmd)
+	tencentSGCmd.AddCommand(tencentSGGetCmd)
+	tencentCVMCmd.AddCommand(tencentCVMNetworkCmd)
+
+	aliyunVSwitchListCmd.Flags().StringVar(&networkVpcID, "vpc", "", "按 VPC ID 过滤")
+	aliyunSGListCmd.Flags().StringVar(&networkVpcID, "vpc", "", "按 VPC ID 过滤")
+	tencentSubnetListCmd.Flags().StringVar(&networkVpcID, "vpc", "", "按 VPC ID 过滤")
+
+	for _, c := range []*cobra.Command{aliyunECSNetworkCmd, tencentCVMNetworkCmd} {
+		c.Flags().IntVar(&networkPort, "port", 0, "评估该端口是否放行")
+		c.Flags().StringVar(&networkProtocol, "protocol", "tcp", "评估的协议 (tcp, udp, icmp)")
+		c.Flags().StringVar(&networkCIDR, "cidr", "0.0.0.0/0", "对端 IP 或网段")
+		c.Flags().StringVar(&networkDirection, "direction", model.DirectionIngress, "评估方向 (ingress, egress)")
+	}
+}
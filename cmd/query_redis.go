@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/spf13/cobra"
+)
+
+var (
+	redisName    string // 按名称过滤 Redis 实例
+	redisAddress string // 按连接地址过滤 Redis 实例
+)
+
+// aliyunRedisCmd 阿里云 Redis 命令组
+var aliyunRedisCmd = &cobra.Command{
+	Use:   "redis",
+	Short: "查询阿里云 Redis/Tair",
+}
+
+// aliyunRedisListCmd 列出阿里云 Redis 实例
+var aliyunRedisListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出 Redis/Tair 实例",
+	Long:  `列出阿里云 Redis/Tair 实例,可按名称或连接地址 (域名、私网 IP 或 host:port) 过滤。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return listRedis(p, account, aliyunRegion, aliyunOutputType)
+	},
+}
+
+// aliyunRedisGetCmd 获取阿里云 Redis 实例详情
+var aliyunRedisGetCmd = &cobra.Command{
+	Use:   "get <instance-id>",
+	Short: "获取 Redis/Tair 实例详情",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, _, err := newCloudProvider("aliyun", aliyunAccount)
+		if err != nil {
+			return err
+		}
+		return getRedis(p, args[0], aliyunOutputType)
+	},
+}
+
+// tencentRedisCmd 腾讯云 Redis 命令组
+var tencentRedisCmd = &cobra.Command{
+	Use:   "redis",
+	Short: "查询腾讯云 Redis",
+}
+
+// tencentRedisListCmd 列出腾讯云 Redis 实例
+var tencentRedisListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出 Redis 实例",
+	Long:  `列出腾讯云 Redis 实例,可按名称或连接地址 (VIP、外网域名或 host:port) 过滤。`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, account, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return listRedis(p, account, tencentRegion, tencentOutputType)
+	},
+}
+
+// tencentRedisGetCmd 获取腾讯云 Redis 实例详情
+var tencentRedisGetCmd = &cobra.Command{
+	Use:   "get <instance-id>",
+	Short: "获取 Redis 实例详情",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, _, err := newCloudProvider("tencent", tencentAccount)
+		if err != nil {
+			return err
+		}
+		return getRedis(p, args[0], tencentOutputType)
+	},
+}
+
+// listRedis 列出 Redis 实例并输出
+func listRedis(p provider.Provider, account, region, output string) error {
+	databases, err := p.ListDatabases(context.Background(), &provider.QueryOptions{
+		Region: region,
+		Filters: map[string]string{
+			"engine":  "redis",
+			"name":    redisName,
+			"address": redisAddress,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list Redis instances: %w", err)
+	}
+
+	if output == "json" {
+		data, _ := json.MarshalIndent(databases, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println(redisTable(databases))
+	fmt.Println()
+	logx.Info("Query completed, count %d, account %s", len(databases), account)
+	return nil
+}
+
+// getRedis 获取 Redis 实例详情并输出
+func getRedis(p provider.Provider, instanceID, output string) error {
+	database, err := p.GetDatabase(context.Background(), instanceID)
+	if err != nil {
+		return fmt.Errorf("failed to get Redis instance: %w", err)
+	}
+
+	if output == "json" {
+		data, _ := json.MarshalIndent(database, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println(redisTable([]*model.Database{database}))
+	if database.ConsoleURL != "" {
+		fmt.Println(database.ConsoleURL)
+	}
+	fmt.Println()
+	return nil
+}
+
+// redisTable 输出 Redis 实例表格
+func redisTable(databases []*model.Database) string {
+	rows := [][]string{}
+	for _, db := range databases {
+		rows = append(rows, []string{
+			db.ID, db.Name, db.Region, db.EngineVersion, db.Status, db.Endpoint + ":" + strconv.Itoa(db.Port),
+		})
+	}
+	return networkTable([]string{"ID", "Name", "Region", "Version", "Status", "Address"}, rows).String()
+}
+
+func init() {
+	aliyunCmd.AddCommand(aliyunRedisCmd)
+	aliyunRedisCmd.AddCommand(aliyunRedisListCmd)
+	aliyunRedisCmd.AddCommand(aliyunRedisGetCmd)
+
+	tencentCmd.AddCommand(tencentRedisCmd)
+	tencentRedisCmd.AddCommand(tencentRedisListCmd)
+	tencentRedisCmd.AddCommand(tencentRedisGetCmd)
+
+	for _, c := range []*cobra.Command{aliyunRedisListCmd, tencentRedisListCmd} {
+		c.Flags().StringVar(&redisName, "name", "", "按名称过滤 (模糊匹配)")
+		c.Flags().StringVar(&redisAddress, "address", "", "按连接地址过滤 (域名、IP 或 host:port)")
+	}
+}
@@ -59,6 +59,7 @@ var tencentCVMListCmd = &cobra.Command{
 
 		// 初始化 Provider
 		providerConfig := map[string]any{
+			"account":    tencentConfig.Name,
 			"secret_id":  tencentConfig.AK,
 			"secret_key": tencentConfig.SK,
 			"regions":    interfaceSlice(tencentConfig.Regions),
@@ -178,6 +179,7 @@ var tencentCVMGetCmd = &cobra.Command{
 
 		// 初始化 Provider
 		providerConfig := map[string]any{
+			"account":    tencentConfig.Name,
 			"secret_id":  tencentConfig.AK,
 			"secret_key": tencentConfig.SK,
 			"regions":    interfaceSlice(tencentConfig.Regions),
@@ -230,6 +232,7 @@ var tencentCDBListCmd = &cobra.Command{
 
 		// 初始化 Provider
 		providerConfig := map[string]any{
+			"account":    tencentConfig.Name,
 			"secret_id":  tencentConfig.AK,
 			"secret_key": tencentConfig.SK,
 			"regions":    interfaceSlice(tencentConfig.Regions),
@@ -341,6 +344,7 @@ var tencentCDBGetCmd = &cobra.Command{
 
 		// 初始化 Provider
 		providerConfig := map[string]any{
+			"account":    tencentConfig.Name,
 			"secret_id":  tencentConfig.AK,
 			"secret_key": tencentConfig.SK,
 			"regions":    interfaceSlice(tencentConfig.Regions),
@@ -420,6 +424,7 @@ var tencentCOSListCmd = &cobra.Command{
 
 		// 初始化 Provider
 		providerConfig := map[string]any{
+			"account":    tencentConfig.Name,
 			"secret_id":  tencentConfig.AK,
 			"secret_key": tencentConfig.SK,
 			"regions":    interfaceSlice(tencentConfig.Regions),
@@ -529,6 +534,7 @@ var tencentCOSGetCmd = &cobra.Command{
 
 		// 初始化 Provider
 		providerConfig := map[string]any{
+			"account":    tencentConfig.Name,
 			"secret_id":  tencentConfig.AK,
 			"secret_key": tencentConfig.SK,
 			"regions":    interfaceSlice(tencentConfig.Regions),
@@ -10,13 +10,16 @@ import (
 	"time"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/audit"
 	"github.com/eryajf/zenops/internal/config"
 	"github.com/eryajf/zenops/internal/imcp"
 	"github.com/eryajf/zenops/internal/mcpclient"
+	"github.com/eryajf/zenops/internal/notify"
 	_ "github.com/eryajf/zenops/internal/provider/aliyun"  // 注册 aliyun provider
 	_ "github.com/eryajf/zenops/internal/provider/jenkins" // 注册 jenkins provider
 	_ "github.com/eryajf/zenops/internal/provider/tencent" // 注册 tencent provider
 	"github.com/eryajf/zenops/internal/server"
+	"github.com/eryajf/zenops/internal/tracing"
 	"github.com/spf13/cobra"
 )
 
@@ -120,6 +123,24 @@ var runCmd = &cobra.Command{
 		// 错误通道
 		errCh := make(chan error, 3)
 
+		// 初始化审计日志
+		if err := audit.Init(cfg.Audit); err != nil {
+			return err
+		}
+		defer func() { _ = audit.Close() }()
+
+		// 初始化链路追踪
+		if err := tracing.Init(cfg.Tracing); err != nil {
+			return err
+		}
+		defer func() {
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer shutdownCancel()
+			if err := tracing.Shutdown(shutdownCtx); err != nil {
+				logx.Warn("Failed to flush traces: %v", err)
+			}
+		}()
+
 		// 1. 创建 MCP 客户端管理器
 		mcpClientManager := mcpclient.NewManager()
 
@@ -184,6 +205,47 @@ var runCmd = &cobra.Command{
 			}()
 		}
 
+		// 启动 Slack 服务 (Socket Mode)
+		if cfg.Slack.Enabled {
+			go func() {
+				// 创建 Slack 服务
+				slackService, err := server.NewSlackSocketServer(cfg, mcpServer)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to create slack service: %w", err)
+					return
+				}
+
+				// 启动 Slack 服务
+				if err := slackService.Start(ctx); err != nil {
+					errCh <- fmt.Errorf("slack service error: %w", err)
+					return
+				}
+			}()
+		}
+
+		// 启动 Telegram 服务 (长轮询)
+		if cfg.Telegram.Enabled {
+			go func() {
+				// 创建 Telegram 服务
+				telegramService, err := server.NewTelegramPollingServer(cfg, mcpServer)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to create telegram service: %w", err)
+					return
+				}
+
+				// 启动 Telegram 服务
+				if err := telegramService.Start(ctx); err != nil {
+					errCh <- fmt.Errorf("telegram service error: %w", err)
+					return
+				}
+			}()
+		}
+
+		// 启动证书过期群通知
+		if cfg.Certificates.Alert.Enabled {
+			go notify.RunCertAlert(ctx, cfg)
+		}
+
 		// 启动 HTTP 服务
 		if startHTTP {
 			logx.Info("🌐 Starting HTTP server...")
@@ -214,7 +276,7 @@ var runCmd = &cobra.Command{
 		}
 
 		// 如果没有任何服务启动，给出提示
-		if !startHTTP && !startMCP && !cfg.DingTalk.Enabled && !cfg.Feishu.Enabled {
+		if !startHTTP && !startMCP && !cfg.DingTalk.Enabled && !cfg.Feishu.Enabled && !cfg.Slack.Enabled && !cfg.Telegram.Enabled {
 			logx.Warn("⚠️  No services enabled. Please check your configuration or use --http-only or --mcp-only flags.")
 		}
 
@@ -295,12 +357,20 @@ var listToolsCmd = &cobra.Command{
 				internalToolNames := []string{
 					"search_ecs_by_ip", "search_ecs_by_name", "list_ecs",
 					"search_rds_by_name", "list_rds", "get_rds_info",
-					"list_slb", "get_slb_info", "search_slb_by_ip",
+					"list_slb", "get_slb", "get_slb_info", "search_slb_by_ip",
+					"list_clb", "get_clb", "search_clb_by_ip",
 					"list_oss_buckets", "get_oss_bucket_info",
-					"list_redis", "get_redis_info",
-					"search_eip_by_ip", "list_eip",
+					"list_redis", "get_redis_info", "search_redis_by_name", "search_redis_by_address",
+					"search_eip_by_ip", "list_eip", "report_unassociated_eips",
 					"search_nat_by_ip", "list_nat",
 					"list_cvm", "search_cvm_by_ip", "search_cvm_by_name",
+					"list_vpcs", "list_subnets", "list_security_groups",
+					"get_security_group", "get_instance_network",
+					"list_domains", "list_dns_records", "resolve_domain_to_resources", "search_dns_by_ip",
+					"list_certificates", "list_expiring_certificates", "check_certificate",
+					"list_cdn_domains", "refresh_cdn_cache",
+					"get_cost_summary", "get_cost_breakdown", "list_top_cost_instances",
+					"report_idle_resources", "get_instance_metrics",
 				}
 				for _, name := range internalToolNames {
 					if tool.Name == name {
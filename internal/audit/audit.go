@@ -0,0 +1,190 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// StatusSuccess 调用成功
+	StatusSuccess = "success"
+	// StatusError 调用失败
+	StatusError = "error"
+
+	// maxErrorLength 错误信息的最大记录长度
+	maxErrorLength = 500
+	// redactedValue 敏感参数的替换值
+	redactedValue = "******"
+)
+
+// sensitiveKeys 参数名包含这些关键词时不记录原值
+var sensitiveKeys = []string{"password", "passwd", "secret", "token", "access_key", "api_key", "credential"}
+
+// Entry 一条审计记录
+type Entry struct {
+	Time         time.Time      `json:"time"`
+	Platform     string         `json:"platform"` // dingtalk, feishu, wecom, openai, http, mcp
+	UserID       string         `json:"user_id,omitempty"`
+	UserName     string         `json:"user_name,omitempty"`
+	Conversation string         `json:"conversation,omitempty"` // 群/会话 ID
+	Tool         string         `json:"tool"`
+	Arguments    map[string]any `json:"arguments,omitempty"`
+	DurationMS   int64          `json:"duration_ms"`
+	Status       string         `json:"status"`
+	Error        string         `json:"error,omitempty"`
+}
+
+// Caller 调用方身份
+type Caller struct {
+	Platform     string
+	UserID       string
+	UserName     string
+	Conversation string
+}
+
+type callerKey struct{}
+
+// WithCaller 在 context 中设置调用方身份
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext 从 context 中获取调用方身份
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(Caller)
+	return caller, ok
+}
+
+var (
+	defaultWriter *Writer
+	writerMu      sync.RWMutex
+)
+
+// Init 根据配置初始化审计日志,未启用时不记录
+func Init(cfg config.AuditConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	w, err := NewWriter(cfg.Path, cfg.MaxSizeMB, cfg.MaxBackups)
+	if err != nil {
+		return fmt.Errorf("failed to init audit log: %w", err)
+	}
+
+	writerMu.Lock()
+	defaultWriter = w
+	writerMu.Unlock()
+
+	logx.Info("📝 Audit log enabled, path %s", cfg.Path)
+	return nil
+}
+
+// Close 关闭审计日志
+func Close() error {
+	writerMu.Lock()
+	defer writerMu.Unlock()
+
+	if defaultWriter == nil {
+		return nil
+	}
+	err := defaultWriter.Close()
+	defaultWriter = nil
+	return err
+}
+
+// Record 写入一条审计记录,未启用审计时忽略
+func Record(entry Entry) {
+	writerMu.RLock()
+	w := defaultWriter
+	writerMu.RUnlock()
+
+	if w == nil {
+		return
+	}
+
+	if err := w.Write(entry); err != nil {
+		logx.Error("Failed to write audit log: %v", err)
+	}
+}
+
+// RecordToolCall 记录一次工具调用,调用方身份从 context 中获取
+func RecordToolCall(ctx context.Context, tool string, arguments map[string]any, start time.Time, result *mcp.CallToolResult, err error) {
+	caller, _ := CallerFromContext(ctx)
+
+	entry := Entry{
+		Time:         start,
+		Platform:     caller.Platform,
+		UserID:       caller.UserID,
+		UserName:     caller.UserName,
+		Conversation: caller.Conversation,
+		Tool:         tool,
+		Arguments:    RedactArguments(arguments),
+		DurationMS:   time.Since(start).Milliseconds(),
+		Status:       StatusSuccess,
+	}
+
+	switch {
+	case err != nil:
+		entry.Status = StatusError
+		entry.Error = truncate(err.Error())
+	case result != nil && result.IsError:
+		entry.Status = StatusError
+		entry.Error = truncate(resultText(result))
+	}
+
+	Record(entry)
+}
+
+// RedactArguments 复制参数并隐藏敏感字段
+func RedactArguments(arguments map[string]any) map[string]any {
+	if len(arguments) == 0 {
+		return nil
+	}
+
+	redacted := make(map[string]any, len(arguments))
+	for k, v := range arguments {
+		if isSensitiveKey(k) {
+			redacted[k] = redactedValue
+			continue
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+// isSensitiveKey 判断参数名是否为敏感字段
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// resultText 提取工具结果中的文本内容
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if textContent, ok := content.(mcp.TextContent); ok {
+			return textContent.Text
+		}
+	}
+	return ""
+}
+
+// truncate 截断过长的错误信息
+func truncate(s string) string {
+	runes := []rune(s)
+	if len(runes) <= maxErrorLength {
+		return s
+	}
+	return string(runes[:maxErrorLength]) + "..."
+}
@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultQueryLimit 默认返回的最大记录数
+const defaultQueryLimit = 100
+
+// Filter 审计记录查询条件,空值表示不过滤
+type Filter struct {
+	Platform     string
+	User         string // 匹配 user_id 或 user_name
+	Conversation string
+	Tool         string // 工具名,支持前缀匹配(如 cnb_)
+	Status       string
+	Since        time.Time
+	Until        time.Time
+	Limit        int
+}
+
+// match 判断记录是否满足查询条件
+func (f Filter) match(entry Entry) bool {
+	if f.Platform != "" && !strings.EqualFold(entry.Platform, f.Platform) {
+		return false
+	}
+	if f.User != "" && entry.UserID != f.User && entry.UserName != f.User {
+		return false
+	}
+	if f.Conversation != "" && entry.Conversation != f.Conversation {
+		return false
+	}
+	if f.Tool != "" && !strings.HasPrefix(entry.Tool, f.Tool) {
+		return false
+	}
+	if f.Status != "" && entry.Status != f.Status {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && entry.Time.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Query 从审计日志文件(包含历史文件)中查询记录,按时间倒序返回
+func Query(path string, maxBackups int, filter Filter) ([]Entry, error) {
+	if path == "" {
+		return nil, fmt.Errorf("audit log path is required")
+	}
+	if maxBackups <= 0 {
+		maxBackups = defaultMaxBackups
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultQueryLimit
+	}
+
+	files := []string{path}
+	for i := 1; i <= maxBackups; i++ {
+		files = append(files, backupPath(path, i))
+	}
+
+	var entries []Entry
+	for _, file := range files {
+		fileEntries, err := readEntries(file, filter)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.After(entries[j].Time)
+	})
+
+	if len(entries) > filter.Limit {
+		entries = entries[:filter.Limit]
+	}
+
+	return entries, nil
+}
+
+// readEntries 读取单个文件中满足条件的记录,跳过无法解析的行
+func readEntries(path string, filter Filter) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if filter.match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log %s: %w", path, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,149 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// defaultMaxSizeMB 默认单个文件最大大小(MB)
+	defaultMaxSizeMB = 100
+	// defaultMaxBackups 默认保留的历史文件数量
+	defaultMaxBackups = 10
+)
+
+// Writer 按大小轮转的 JSONL 审计日志写入器
+// 当前文件为 path,历史文件依次为 path.1(最新) ... path.N(最旧)
+type Writer struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewWriter 创建审计日志写入器
+func NewWriter(path string, maxSizeMB, maxBackups int) (*Writer, error) {
+	if path == "" {
+		return nil, fmt.Errorf("audit log path is required")
+	}
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultMaxSizeMB
+	}
+	if maxBackups <= 0 {
+		maxBackups = defaultMaxBackups
+	}
+
+	w := &Writer{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+	}
+
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Write 追加一条记录,超过大小上限时先轮转
+// 轮转失败时记录仍写入当前文件,并返回轮转的错误
+func (w *Writer) Write(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return fmt.Errorf("audit log is closed")
+	}
+
+	var rotateErr error
+	if w.size > 0 && w.size+int64(len(data)) > w.maxSize {
+		rotateErr = w.rotate()
+		if w.file == nil {
+			return rotateErr
+		}
+	}
+
+	n, err := w.file.Write(data)
+	w.size += int64(n)
+	if err != nil {
+		return errors.Join(rotateErr, fmt.Errorf("failed to write audit entry: %w", err))
+	}
+
+	return rotateErr
+}
+
+// Close 关闭文件
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// open 打开(或创建)当前日志文件
+func (w *Writer) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+// rotate 轮转日志文件: path.N-1 -> path.N, ..., path -> path.1
+// 轮转失败时以追加模式重新打开当前文件并返回错误,只有重新打开也失败时 w.file 为 nil
+func (w *Writer) rotate() error {
+	if err := w.file.Close(); err != nil {
+		w.file = nil
+		return errors.Join(fmt.Errorf("failed to close audit log: %w", err), w.open())
+	}
+	w.file = nil
+
+	_ = os.Remove(backupPath(w.path, w.maxBackups))
+	for i := w.maxBackups - 1; i >= 1; i-- {
+		src := backupPath(w.path, i)
+		if _, err := os.Stat(src); err == nil {
+			_ = os.Rename(src, backupPath(w.path, i+1))
+		}
+	}
+	if err := os.Rename(w.path, backupPath(w.path, 1)); err != nil {
+		return errors.Join(fmt.Errorf("failed to rotate audit log: %w", err), w.open())
+	}
+
+	return w.open()
+}
+
+// backupPath 历史文件路径
+func backupPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// countLines 返回文件的行数
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines
+}
+
+func TestWriterRotateFailureKeepsWriting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	w, err := NewWriter(path, 1, 1)
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	// path.1 为非空目录,轮转时无法重命名
+	if err := os.MkdirAll(filepath.Join(backupPath(path, 1), "keep"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Write(Entry{Tool: "first"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	w.size = w.maxSize // 下一次写入触发轮转
+
+	if err := w.Write(Entry{Tool: "second"}); err == nil {
+		t.Fatal("Write() error = nil, want rotation error")
+	}
+	// 重新打开的文件可以继续写入,之后仍会尝试轮转
+	w.size = w.maxSize
+	if err := w.Write(Entry{Tool: "third"}); err == nil {
+		t.Fatal("Write() error = nil, want rotation error")
+	}
+
+	if got := countLines(t, path); got != 3 {
+		t.Errorf("audit log has %d lines, want 3", got)
+	}
+}
+
+func TestWriterRotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	w, err := NewWriter(path, 1, 2)
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	for i := 0; i < 3; i++ {
+		if err := w.Write(Entry{Tool: "tool"}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		w.size = w.maxSize
+	}
+
+	for _, p := range []string{path, backupPath(path, 1), backupPath(path, 2)} {
+		if got := countLines(t, p); got != 1 {
+			t.Errorf("%s has %d lines, want 1", p, got)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/imcp"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// 卡片按钮: 工具结果附带后续操作按钮(查看详情、翻页、构建日志、重新构建),点击后由平台适配器原地更新按钮所在的卡片
+
+// 工具结果的按钮动作
+const (
+	ActionRunTool = "zenops_tool"    // 调用工具,value 为 toolCall 的 JSON
+	ActionPage    = "zenops_page"    // 翻页,value 为 "<result_id>:<page>"
+	ActionConfirm = "zenops_confirm" // 确认待确认的操作,value 为 token
+	ActionCancel  = "zenops_cancel"  // 取消待确认的操作,value 为 token
+)
+
+const (
+	// pageLines 工具结果每页的行数
+	pageLines = 30
+	// pagedResultTTL 分页结果的保留时间
+	pagedResultTTL = 30 * time.Minute
+	// maxPagedResults 最多保留的分页结果数量,超出时淘汰最早的结果
+	maxPagedResults = 200
+)
+
+// Button 卡片按钮
+type Button struct {
+	Text     string
+	ActionID string
+	Value    string
+	Danger   bool // 危险操作(如重新构建),使用警示样式
+}
+
+// ActionReplier 支持按钮的适配器实现此接口
+// 处理按钮回调时传入的适配器应原地更新按钮所在的卡片(Reply 同样原地更新)
+type ActionReplier interface {
+	// SupportsActions 当前是否能展示按钮,如钉钉未配置卡片模板时只能发送文本
+	SupportsActions() bool
+	ReplyWithActions(ctx context.Context, content string, buttons []Button) error
+}
+
+// actionReplier 返回能展示按钮的适配器,不支持时返回 false
+func actionReplier(r Replier) (ActionReplier, bool) {
+	ar, ok := r.(ActionReplier)
+	if !ok || !ar.SupportsActions() {
+		return nil, false
+	}
+	return ar, true
+}
+
+// reply 回复内容,适配器支持按钮时附带按钮
+func reply(ctx context.Context, r Replier, content string, buttons []Button) error {
+	if ar, ok := actionReplier(r); ok && len(buttons) > 0 {
+		return ar.ReplyWithActions(ctx, content, buttons)
+	}
+	return r.Reply(ctx, content)
+}
+
+// toolCall 工具按钮携带的调用
+type toolCall struct {
+	Tool string         `json:"tool"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+// toolButton 创建调用工具的按钮
+func toolButton(text, tool string, args map[string]any, danger bool) Button {
+	value, _ := json.Marshal(toolCall{Tool: tool, Args: args})
+	return Button{Text: text, ActionID: ActionRunTool, Value: string(value), Danger: danger}
+}
+
+// followUp 工具结果的后续操作
+type followUp struct {
+	text   string
+	tool   string
+	danger bool
+	// args 根据原调用参数和结果生成后续调用的参数,返回 nil 表示不适用
+	args func(args map[string]any, data *resultData) map[string]any
+}
+
+// resultData 工具结构化结果中用于生成后续操作的字段
+type resultData struct {
+	Items   []map[string]any `json:"items"`
+	JobName string           `json:"job_name"`
+}
+
+var (
+	ecsDetail  = followUp{text: "查看详情", tool: "get_ecs", args: instanceDetailArgs}
+	cvmDetail  = followUp{text: "查看详情", tool: "get_cvm", args: instanceDetailArgs}
+	slbDetail  = followUp{text: "查看详情", tool: "get_slb", args: lbDetailArgs}
+	clbDetail  = followUp{text: "查看详情", tool: "get_clb", args: lbDetailArgs}
+	ecsNetwork = followUp{text: "网络与安全组", tool: "get_instance_network", args: instanceNetworkArgs("aliyun")}
+	cvmNetwork = followUp{text: "网络与安全组", tool: "get_instance_network", args: instanceNetworkArgs("tencent")}
+	jobBuilds  = followUp{text: "构建历史", tool: "list_jenkins_builds", args: jobBuildsArgs}
+	buildLog   = followUp{text: "构建日志", tool: "get_jenkins_build_log", args: buildArgs}
+	rerunBuild = followUp{text: "重新构建", tool: "rerun_jenkins_build", args: buildArgs, danger: true}
+)
+
+// followUps 各工具结果的后续操作,只展示已注册且在当前会话工具集中的工具
+var followUps = map[string][]followUp{
+	"search_ecs_by_ip":      {ecsDetail, ecsNetwork},
+	"search_ecs_by_name":    {ecsDetail, ecsNetwork},
+	"list_ecs":              {ecsDetail, ecsNetwork},
+	"search_cvm_by_ip":      {cvmDetail, cvmNetwork},
+	"search_cvm_by_name":    {cvmDetail, cvmNetwork},
+	"list_cvm":              {cvmDetail, cvmNetwork},
+	"list_slb":              {slbDetail},
+	"list_clb":              {clbDetail},
+	"list_jenkins_jobs":     {jobBuilds},
+	"get_jenkins_job":       {jobBuilds},
+	"list_jenkins_builds":   {buildLog, rerunBuild},
+	"get_jenkins_build_log": {rerunBuild},
+}
+
+// needsConfirm 危险操作(如重新构建)点击后需要二次确认,变更云上资源的工具(如刷新 CDN 缓存)始终需要确认
+func needsConfirm(tool string) bool {
+	if imcp.RequiresConfirmation(tool) {
+		return true
+	}
+	for _, candidates := range followUps {
+		for _, f := range candidates {
+			if f.tool == tool && f.danger {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// instanceDetailArgs 结果只有一台实例时查看详情
+func instanceDetailArgs(args map[string]any, data *resultData) map[string]any {
+	if len(data.Items) != 1 || data.Items[0]["id"] == nil {
+		return nil
+	}
+	return withAccount(map[string]any{"instance_id": data.Items[0]["id"]}, args)
+}
+
+// instanceNetworkArgs 结果只有一台实例时查看网络和生效的安全组规则
+func instanceNetworkArgs(cloud string) func(args map[string]any, data *resultData) map[string]any {
+	return func(args map[string]any, data *resultData) map[string]any {
+		detail := instanceDetailArgs(args, data)
+		if detail != nil {
+			detail["cloud"] = cloud
+		}
+		return detail
+	}
+}
+
+// lbDetailArgs 结果只有一个负载均衡时查看监听和后端
+func lbDetailArgs(args map[string]any, data *resultData) map[string]any {
+	if len(data.Items) != 1 || data.Items[0]["id"] == nil {
+		return nil
+	}
+	return withAccount(map[string]any{"lb_id": data.Items[0]["id"]}, args)
+}
+
+// jobBuildsArgs 结果只有一个 Job 时查看构建历史
+func jobBuildsArgs(_ map[string]any, data *resultData) map[string]any {
+	if len(data.Items) != 1 || data.Items[0]["name"] == nil {
+		return nil
+	}
+	return map[string]any{"job_name": data.Items[0]["name"]}
+}
+
+// buildArgs 针对最近一次构建
+func buildArgs(_ map[string]any, data *resultData) map[string]any {
+	if data.JobName == "" || len(data.Items) == 0 || data.Items[0]["number"] == nil {
+		return nil
+	}
+	return map[string]any{"job_name": data.JobName, "build_number": data.Items[0]["number"]}
+}
+
+// withAccount 沿用原调用的账号
+func withAccount(next, args map[string]any) map[string]any {
+	if account, ok := args["account"].(string); ok && account != "" {
+		next["account"] = account
+	}
+	return next
+}
+
+// followUpButtons 根据工具结果生成后续操作按钮
+func (c *Core) followUpButtons(ctx context.Context, toolName string, args map[string]any, result *mcp.CallToolResult) []Button {
+	candidates := followUps[toolName]
+	if len(candidates) == 0 || result.IsError || result.StructuredContent == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		return nil
+	}
+	var data resultData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil
+	}
+
+	tools, err := c.listTools(ctx)
+	if err != nil {
+		return nil
+	}
+
+	var buttons []Button
+	for _, f := range candidates {
+		if _, ok := tools[f.tool]; !ok || !c.toolAllowed(ctx, f.tool) {
+			continue
+		}
+		if next := f.args(args, &data); next != nil {
+			buttons = append(buttons, toolButton(f.text, f.tool, next, f.danger))
+		}
+	}
+	return buttons
+}
+
+// pagedResult 分页展示的工具结果
+type pagedResult struct {
+	header    string
+	pages     []string
+	buttons   []Button // 每页都展示的后续操作按钮
+	createdAt time.Time
+}
+
+// pageStore 保存分页结果,供翻页按钮使用
+type pageStore struct {
+	mu      sync.Mutex
+	results map[string]*pagedResult
+}
+
+// newPageStore 创建分页结果存储
+func newPageStore() *pageStore {
+	return &pageStore{results: make(map[string]*pagedResult)}
+}
+
+// store 保存分页结果并返回 result_id
+func (s *pageStore) store(result *pagedResult) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, r := range s.results {
+		if time.Since(r.createdAt) > pagedResultTTL {
+			delete(s.results, id)
+		}
+	}
+
+	// 超出数量上限时淘汰最早的结果
+	for len(s.results) >= maxPagedResults {
+		var oldestID string
+		var oldest time.Time
+		for id, r := range s.results {
+			if oldestID == "" || r.createdAt.Before(oldest) {
+				oldestID, oldest = id, r.createdAt
+			}
+		}
+		delete(s.results, oldestID)
+	}
+
+	resultID := uuid.New().String()[:8]
+	result.createdAt = time.Now()
+	s.results[resultID] = result
+	return resultID
+}
+
+// get 获取分页结果,过期或不存在时返回 nil
+func (s *pageStore) get(resultID string) *pagedResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.results[resultID]
+	if !ok || time.Since(r.createdAt) > pagedResultTTL {
+		return nil
+	}
+	return r
+}
+
+// splitPages 按行切分文本
+func splitPages(text string, lines int) []string {
+	all := strings.Split(text, "\n")
+	var pages []string
+	for start := 0; start < len(all); start += lines {
+		end := min(start+lines, len(all))
+		pages = append(pages, strings.Join(all[start:end], "\n"))
+	}
+	return pages
+}
+
+// renderPage 渲染分页结果的指定页及翻页按钮
+func (c *Core) renderPage(resultID string, page int) (string, []Button, bool) {
+	result := c.pages.get(resultID)
+	if result == nil || page < 1 || page > len(result.pages) {
+		return "", nil, false
+	}
+
+	content := fmt.Sprintf("%s%s\n\n📄 第 %d/%d 页%s", result.header, result.pages[page-1], page, len(result.pages), Footer())
+
+	var buttons []Button
+	if page > 1 {
+		buttons = append(buttons, Button{Text: "上一页", ActionID: ActionPage, Value: fmt.Sprintf("%s:%d", resultID, page-1)})
+	}
+	if page < len(result.pages) {
+		buttons = append(buttons, Button{Text: "下一页", ActionID: ActionPage, Value: fmt.Sprintf("%s:%d", resultID, page+1)})
+	}
+	return content, append(buttons, result.buttons...), true
+}
+
+// handlePage 处理翻页按钮
+func (c *Core) handlePage(ctx context.Context, value string, r Replier) error {
+	resultID, pageValue, _ := strings.Cut(value, ":")
+	page, _ := strconv.Atoi(pageValue)
+
+	content, buttons, ok := c.renderPage(resultID, page)
+	if !ok {
+		return r.Reply(ctx, "⌛ 查询结果已过期,请重新查询")
+	}
+	return reply(ctx, r, content, buttons)
+}
+
+// handleRunTool 处理调用工具的按钮
+func (c *Core) handleRunTool(ctx context.Context, msg *Message, value string, r Replier) error {
+	var call toolCall
+	if err := json.Unmarshal([]byte(value), &call); err != nil || call.Tool == "" {
+		logx.Warn("Invalid %s tool action value: %s", c.opts.DisplayName, value)
+		return nil
+	}
+	if call.Args == nil {
+		call.Args = make(map[string]any)
+	}
+	if !c.toolAllowed(ctx, call.Tool) {
+		return r.Reply(ctx, toolUnavailable(call.Tool))
+	}
+
+	// 按钮内容由客户端回传,危险操作一律在服务端保存后确认
+	if needsConfirm(call.Tool) {
+		return c.confirmTool(ctx, msg, call, r)
+	}
+
+	content, buttons := c.runTool(ctx, call.Tool, call.Args, r)
+	return reply(ctx, r, content, buttons)
+}
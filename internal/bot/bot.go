@@ -0,0 +1,331 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/audit"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/imcp"
+	"github.com/eryajf/zenops/internal/intent"
+	"github.com/eryajf/zenops/internal/llm"
+	"github.com/eryajf/zenops/internal/metrics"
+	"github.com/eryajf/zenops/internal/tracing"
+)
+
+// 机器人核心: 各聊天平台只负责收发消息(适配器),帮助、LLM 调用、流式节流和错误回复统一在这里处理
+
+// defaultUpdateInterval 默认的流式更新间隔
+const defaultUpdateInterval = 300 * time.Millisecond
+
+// 内置的卡片按钮动作,各平台按钮使用相同的 action ID
+const (
+	ActionRegenerate = "zenops_regenerate" // 重新生成,value 为原问题
+	ActionHelp       = "zenops_help"       // 查看帮助
+)
+
+// Message 平台无关的入站消息
+type Message struct {
+	ConversationID string // 群/会话 ID
+	UserID         string
+	UserName       string
+	Text           string // 已去除 @机器人 的消息内容
+}
+
+// Action 卡片按钮回调
+type Action struct {
+	Message         // 点击者和所在会话,Text 为空
+	ActionID string // 按钮的 action ID
+	Value    string // 按钮携带的值
+}
+
+// Replier 平台适配器: 向消息来源回复,每条入站消息创建一个
+type Replier interface {
+	// Reply 发送一条完整的 Markdown 回复
+	Reply(ctx context.Context, content string) error
+	// StartStream 开始流式回复(可编辑的消息或卡片),question 为用户的问题
+	StartStream(ctx context.Context, question string) (Stream, error)
+}
+
+// Stream 流式回复,content 均为截至当前的完整内容
+type Stream interface {
+	// Update 更新中间内容
+	Update(ctx context.Context, content string) error
+	// Finish 发送最终内容并结束流式回复
+	Finish(ctx context.Context, content string) error
+}
+
+// Options 平台相关的选项
+type Options struct {
+	Platform       string        // 平台标识(dingtalk, feishu, wecom, slack, telegram),用于指标、审计、链路追踪和工具路由
+	DisplayName    string        // 平台显示名称,用于帮助信息和提示语
+	UpdateInterval time.Duration // 流式更新的最小间隔,受平台接口频率限制
+}
+
+// Core 机器人核心
+type Core struct {
+	opts      Options
+	config    *config.Config
+	mcpServer *imcp.MCPServer
+	llmClient *llm.Client
+	intents   *intent.Engine // 未启用 LLM 时的意图解析
+	pages     *pageStore
+	confirms  *confirmStore // 不支持按钮的平台上待确认的操作
+}
+
+// NewCore 创建机器人核心
+func NewCore(cfg *config.Config, mcpServer *imcp.MCPServer, opts Options) *Core {
+	if opts.UpdateInterval <= 0 {
+		opts.UpdateInterval = defaultUpdateInterval
+	}
+	if opts.DisplayName == "" {
+		opts.DisplayName = opts.Platform
+	}
+
+	core := &Core{
+		opts:      opts,
+		config:    cfg,
+		mcpServer: mcpServer,
+		pages:     newPageStore(),
+		confirms:  newConfirmStore(),
+	}
+
+	// 初始化 LLM 客户端
+	if cfg.LLM.Enabled {
+		llmConfig := llm.NewConfig(cfg.LLM)
+		llmConfig.ExcludeTools = imcp.ConfirmTools() // 变更操作需通过命令或按钮确认后执行
+		core.llmClient = llm.NewClient(llmConfig, mcpServer)
+		logx.Info("LLM client initialized for %s, model %s", opts.DisplayName, core.llmClient.Model())
+	} else {
+		core.intents = newIntentEngine(cfg.Intent.RulesFile)
+	}
+
+	return core
+}
+
+// LLMEnabled 是否启用了 LLM 对话
+func (c *Core) LLMEnabled() bool {
+	return c.llmClient != nil
+}
+
+// HandleMessage 处理一条入站消息
+func (c *Core) HandleMessage(ctx context.Context, msg *Message, r Replier) error {
+	ctx, span := tracing.StartWithKind(ctx, c.opts.Platform+".HandleMessage", tracing.SpanKindServer,
+		tracing.String("bot.platform", c.opts.Platform),
+		tracing.String("bot.conversation_id", msg.ConversationID),
+		tracing.String("bot.sender", msg.UserID))
+	defer span.End()
+
+	question := strings.TrimSpace(msg.Text)
+	if question == "" {
+		return nil
+	}
+
+	metrics.IncBotMessage(c.opts.Platform)
+	ctx = c.withCaller(ctx, msg)
+
+	logx.Info("Received message from %s: user %s, conversation %s, message %s",
+		c.opts.DisplayName, msg.UserID, msg.ConversationID, question)
+
+	err := c.dispatch(ctx, msg, question, r)
+	span.RecordError(err)
+	return err
+}
+
+// dispatch 按命令 → 帮助 → LLM → 意图解析的顺序分发消息
+func (c *Core) dispatch(ctx context.Context, msg *Message, question string, r Replier) error {
+	// 斜杠命令不经过 LLM
+	if isCommand(question) {
+		return c.handleCommand(ctx, msg, question, r)
+	}
+
+	// 帮助
+	if isHelp(question) {
+		return r.Reply(ctx, c.HelpMessage(ctx))
+	}
+
+	// 如果启用了 LLM,使用 LLM 处理
+	if c.llmClient != nil {
+		return c.chat(ctx, question, r)
+	}
+
+	// 未启用 LLM 时按意图规则解析
+	if c.intents != nil {
+		if in, ok := c.intents.Parse(question); ok {
+			return c.handleIntent(ctx, msg, in, r)
+		}
+	}
+
+	return r.Reply(ctx, fmt.Sprintf("ZenOps %s已收到您的消息。当前未启用 LLM 对话功能,无法理解这条消息,发送 \"帮助\" 查看支持的查询和命令。", botName(c.opts.DisplayName)))
+}
+
+// HandleAction 处理卡片按钮回调
+func (c *Core) HandleAction(ctx context.Context, action *Action, r Replier) error {
+	ctx, span := tracing.StartWithKind(ctx, c.opts.Platform+".HandleAction", tracing.SpanKindServer,
+		tracing.String("bot.platform", c.opts.Platform),
+		tracing.String("bot.conversation_id", action.ConversationID),
+		tracing.String("bot.action_id", action.ActionID))
+	defer span.End()
+
+	ctx = c.withCaller(ctx, &action.Message)
+
+	logx.Info("Received action from %s: user %s, conversation %s, action %s",
+		c.opts.DisplayName, action.UserID, action.ConversationID, action.ActionID)
+
+	var err error
+	switch action.ActionID {
+	case ActionRegenerate:
+		question := strings.TrimSpace(action.Value)
+		if question == "" || c.llmClient == nil {
+			return nil
+		}
+		metrics.IncBotMessage(c.opts.Platform)
+		err = c.chat(ctx, question, r)
+	case ActionHelp:
+		err = r.Reply(ctx, c.HelpMessage(ctx))
+	case ActionRunTool:
+		err = c.handleRunTool(ctx, &action.Message, action.Value, r)
+	case ActionPage:
+		err = c.handlePage(ctx, action.Value, r)
+	case ActionConfirm, ActionCancel:
+		err = c.handleConfirm(ctx, &action.Message, action.Value, action.ActionID == ActionConfirm, r)
+	default:
+		logx.Debug("Ignoring unknown %s action %s", c.opts.DisplayName, action.ActionID)
+	}
+
+	span.RecordError(err)
+	return err
+}
+
+// withCaller 记录调用方身份和会话范围,用于工具调用审计和按机器人/群选择工具集
+func (c *Core) withCaller(ctx context.Context, msg *Message) context.Context {
+	ctx = llm.WithToolScope(ctx, c.opts.Platform, msg.ConversationID)
+	return audit.WithCaller(ctx, audit.Caller{
+		Platform:     c.opts.Platform,
+		UserID:       msg.UserID,
+		UserName:     msg.UserName,
+		Conversation: msg.ConversationID,
+	})
+}
+
+// toolAllowed 工具是否在当前机器人/群的工具集中,会话范围由 withCaller 设置
+func (c *Core) toolAllowed(ctx context.Context, name string) bool {
+	return llm.ToolAllowed(ctx, c.config.LLM.ToolRouter, name)
+}
+
+// toolUnavailable 工具不在当前会话工具集中时的回复
+func toolUnavailable(name string) string {
+	return fmt.Sprintf("⛔ 工具 `%s` 在当前会话中不可用", name)
+}
+
+// chat 调用 LLM 并流式回复
+func (c *Core) chat(ctx context.Context, question string, r Replier) error {
+	stream, err := r.StartStream(ctx, question)
+	if err != nil {
+		logx.Error("Failed to start %s stream: %v", c.opts.DisplayName, err)
+		return err
+	}
+
+	// 调用 LLM 流式对话 (按机器人/群选择工具集,会话范围由 withCaller 设置)
+	responseCh, err := c.llmClient.ChatWithToolsAndStream(ctx, question)
+	if err != nil {
+		logx.Error("Failed to call LLM: %v", err)
+		return stream.Finish(ctx, fmt.Sprintf("❌ LLM 调用失败: %v", err))
+	}
+
+	return c.streamResponse(ctx, stream, responseCh)
+}
+
+// streamResponse 按更新间隔节流,将 LLM 输出写入流式回复
+func (c *Core) streamResponse(ctx context.Context, stream Stream, responseCh <-chan string) error {
+	var fullResponse strings.Builder
+
+	updateTicker := time.NewTicker(c.opts.UpdateInterval)
+	defer updateTicker.Stop()
+
+	lastUpdate := ""
+
+	for {
+		select {
+		case content, ok := <-responseCh:
+			if !ok {
+				// 流结束,发送最终内容
+				answer := fullResponse.String()
+				if strings.TrimSpace(answer) == "" {
+					answer = "未获取到回答,请稍后重试。"
+				}
+				if err := stream.Finish(ctx, answer+Footer()); err != nil {
+					logx.Error("Failed to send final update: %v", err)
+					return err
+				}
+				logx.Info("LLM conversation completed for %s", c.opts.DisplayName)
+				return nil
+			}
+			fullResponse.WriteString(content)
+
+		case <-updateTicker.C:
+			// 定时更新
+			currentContent := fullResponse.String()
+			if currentContent != lastUpdate && currentContent != "" {
+				if err := stream.Update(ctx, currentContent); err != nil {
+					logx.Warn("Failed to update %s stream: %v", c.opts.DisplayName, err)
+				} else {
+					lastUpdate = currentContent
+				}
+			}
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Footer 回复末尾的时间戳
+func Footer() string {
+	return fmt.Sprintf("\n\n---\n⏰ %s", time.Now().Format("2006-01-02 15:04:05"))
+}
+
+// botName 机器人名称,英文平台名与"机器人"之间加空格
+func botName(displayName string) string {
+	r, _ := utf8.DecodeLastRuneInString(displayName)
+	if r < utf8.RuneSelf {
+		return displayName + " 机器人"
+	}
+	return displayName + "机器人"
+}
+
+// isHelp 是否为帮助
+func isHelp(text string) bool {
+	return text == "帮助" || strings.EqualFold(text, "help")
+}
+
+// HelpMessage 生成帮助信息,命令部分根据已注册工具的 Schema 生成
+func (c *Core) HelpMessage(ctx context.Context) string {
+	name := botName(c.opts.DisplayName)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# ZenOps %s使用指南\n\n", name))
+	sb.WriteString(fmt.Sprintf("ZenOps 是一个运维工具集成平台,支持通过%s查询云资源和 CI/CD 信息。私聊或在群里 @机器人 都可以使用。\n\n", name))
+
+	sb.WriteString("## 智能对话\n")
+	switch {
+	case c.llmClient != nil:
+		sb.WriteString("直接发送问题,机器人会通过 AI 大模型调用工具为您解答,例如:\n")
+		sb.WriteString("- \"帮我查询阿里云 ECS 列表\"\n")
+		sb.WriteString("- \"查看 Jenkins 最近的构建任务\"\n\n")
+	case c.intents != nil:
+		sb.WriteString("未启用 LLM,支持简单的自然语言查询,例如:\n")
+		sb.WriteString("- \"列出阿里云杭州的 ECS\" / \"list tencent cvm\"\n")
+		sb.WriteString("- \"找一下 IP 为 192.168.1.1 的服务器\"\n")
+		sb.WriteString("- \"看一下 deploy-api 的构建历史\" / \"build log for deploy-api\"\n\n")
+	default:
+		sb.WriteString("未启用 LLM,请使用下面的命令查询。\n\n")
+	}
+
+	sb.WriteString(c.commandsMessage(ctx))
+	return sb.String()
+}
@@ -0,0 +1,441 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/imcp"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// 斜杠命令: 以 / 开头的消息不经过 LLM,直接映射到已注册的工具执行
+// 参数按工具 Schema 解析: 必填参数在前、可选参数按名称排序依次填写,也可以使用 key=value 指定
+
+// commandPrefix 命令前缀
+const commandPrefix = "/"
+
+// shortcut 快捷命令,按参数选择对应的工具
+type shortcut struct {
+	name     string
+	listTool string // 不带参数时调用的工具
+	ipTool   string // 第一个参数为 IP 时调用的工具
+	tool     string // 其余情况调用的工具
+}
+
+// shortcuts 快捷命令,只展示和执行已注册的工具
+var shortcuts = []shortcut{
+	{name: "ecs", listTool: "list_ecs", ipTool: "search_ecs_by_ip", tool: "search_ecs_by_name"},
+	{name: "rds", listTool: "list_rds", tool: "search_rds_by_name"},
+	{name: "cvm", listTool: "list_cvm", ipTool: "search_cvm_by_ip", tool: "search_cvm_by_name"},
+	{name: "cdb", listTool: "list_cdb", tool: "search_cdb_by_name"},
+	{name: "redis", listTool: "list_redis", ipTool: "search_redis_by_address", tool: "search_redis_by_name"},
+	{name: "slb", listTool: "list_slb", ipTool: "search_slb_by_ip", tool: "get_slb"},
+	{name: "clb", listTool: "list_clb", ipTool: "search_clb_by_ip", tool: "get_clb"},
+	{name: "eip", ipTool: "search_eip_by_ip", tool: "list_eip"},
+	{name: "sg", listTool: "list_security_groups", tool: "get_security_group"},
+	{name: "dns", listTool: "list_domains", ipTool: "search_dns_by_ip", tool: "resolve_domain_to_resources"},
+	{name: "certs", listTool: "list_expiring_certificates", tool: "list_certificates"},
+	{name: "cdn", tool: "list_cdn_domains"},
+	{name: "purge", tool: "refresh_cdn_cache"},
+	{name: "cost", tool: "get_cost_summary"},
+	{name: "idle", tool: "report_idle_resources"},
+	{name: "metrics", tool: "get_instance_metrics"},
+	{name: "jobs", listTool: "list_jenkins_jobs", tool: "get_jenkins_job"},
+	{name: "builds", tool: "list_jenkins_builds"},
+	{name: "log", tool: "get_jenkins_build_log"},
+}
+
+// builtinCommands 内置命令及说明
+var builtinCommands = []struct {
+	name        string
+	description string
+}{
+	{"help", "查看帮助"},
+	{"tools", "列出全部工具及参数"},
+	{"accounts", "列出已配置的云账号"},
+	{"whoami", "查看当前用户和会话信息"},
+	{"confirm", "确认执行待确认的操作,如 /confirm <token>"},
+	{"cancel", "取消待确认的操作,如 /cancel <token>"},
+	{"reset", "说明对话上下文(每条消息独立处理,无需重置)"},
+}
+
+// isCommand 是否为斜杠命令
+func isCommand(text string) bool {
+	return strings.HasPrefix(text, commandPrefix) && len(text) > len(commandPrefix)
+}
+
+// handleCommand 执行斜杠命令
+func (c *Core) handleCommand(ctx context.Context, msg *Message, text string, r Replier) error {
+	fields := strings.Fields(strings.TrimPrefix(text, commandPrefix))
+	name, args := strings.ToLower(fields[0]), fields[1:]
+
+	logx.Info("Handling %s command /%s, args %v", c.opts.DisplayName, name, args)
+
+	switch name {
+	case "help", "start":
+		return r.Reply(ctx, c.HelpMessage(ctx))
+	case "tools":
+		return r.Reply(ctx, c.toolsMessage(ctx))
+	case "accounts":
+		return r.Reply(ctx, c.accountsMessage())
+	case "whoami":
+		return r.Reply(ctx, c.whoamiMessage(msg))
+	case "confirm", "cancel":
+		if len(args) != 1 {
+			return r.Reply(ctx, "❌ 用法: `/confirm <token>` 或 `/cancel <token>`")
+		}
+		return c.handleConfirm(ctx, msg, args[0], name == "confirm", r)
+	case "reset":
+		// 当前对话不保留上下文,每条消息都是独立的请求,无需重置
+		return r.Reply(ctx, "ℹ️ 当前对话不保存上下文,每条消息都作为新的对话处理,无需重置。")
+	}
+
+	tools, err := c.listTools(ctx)
+	if err != nil {
+		return r.Reply(ctx, fmt.Sprintf("❌ 获取工具列表失败: %v", err))
+	}
+
+	toolName := resolveTool(name, args)
+	tool, ok := tools[toolName]
+	if !ok {
+		return r.Reply(ctx, fmt.Sprintf("❌ 未知命令 `/%s`,发送 `/help` 查看可用命令", name))
+	}
+	if !c.toolAllowed(ctx, toolName) {
+		return r.Reply(ctx, toolUnavailable(toolName))
+	}
+
+	arguments, err := parseArguments(tool, args)
+	if err != nil {
+		return r.Reply(ctx, fmt.Sprintf("❌ %v\n\n用法: `%s`", err, usage("/"+name, tool)))
+	}
+
+	// 变更操作先确认后执行
+	if imcp.RequiresConfirmation(toolName) {
+		return c.confirmTool(ctx, msg, toolCall{Tool: toolName, Args: arguments}, r)
+	}
+
+	content, buttons := c.runTool(ctx, toolName, arguments, r)
+	return reply(ctx, r, content, buttons)
+}
+
+// resolveTool 将命令解析为工具名: 快捷命令按参数选择工具,否则命令名即工具名
+func resolveTool(name string, args []string) string {
+	for _, s := range shortcuts {
+		if s.name != name {
+			continue
+		}
+		switch {
+		case len(args) == 0 && s.listTool != "":
+			return s.listTool
+		case len(args) > 0 && s.ipTool != "" && net.ParseIP(args[0]) != nil:
+			return s.ipTool
+		default:
+			return s.tool
+		}
+	}
+	return name
+}
+
+// runTool 调用工具,返回回复内容和后续操作按钮
+// 结果较长时在支持按钮的平台上分页展示,其余平台返回完整结果,由适配器拆分为多条消息
+func (c *Core) runTool(ctx context.Context, toolName string, arguments map[string]any, r Replier) (string, []Button) {
+	if c.mcpServer == nil {
+		return "❌ MCP 服务未初始化", nil
+	}
+
+	// 结果直接展示给用户,不使用给 LLM 的精简摘要
+	result, err := c.mcpServer.CallTool(imcp.WithFullText(ctx), toolName, arguments)
+	if err != nil {
+		logx.Error("Failed to call tool %s from command: %v", toolName, err)
+		return fmt.Sprintf("❌ 调用 %s 失败: %v", toolName, err), nil
+	}
+
+	var sb strings.Builder
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			sb.WriteString(text.Text)
+			sb.WriteString("\n")
+		}
+	}
+
+	output := strings.TrimSpace(sb.String())
+	if output == "" {
+		output = "查询完成,但未返回结果"
+	}
+	if result.IsError {
+		return fmt.Sprintf("❌ `%s` 执行失败\n\n%s", toolName, output), nil
+	}
+
+	header := fmt.Sprintf("✅ `%s`\n\n", toolName)
+	buttons := c.followUpButtons(ctx, toolName, arguments, result)
+
+	if _, ok := actionReplier(r); !ok {
+		return header + output + Footer(), buttons
+	}
+	if pages := splitPages(output, pageLines); len(pages) > 1 {
+		resultID := c.pages.store(&pagedResult{header: header, pages: pages, buttons: buttons})
+		content, pageButtons, _ := c.renderPage(resultID, 1)
+		return content, pageButtons
+	}
+
+	return header + output + Footer(), buttons
+}
+
+// listTools 获取已注册的工具
+func (c *Core) listTools(ctx context.Context) (map[string]mcp.Tool, error) {
+	if c.mcpServer == nil {
+		return nil, fmt.Errorf("MCP server not initialized")
+	}
+
+	result, err := c.mcpServer.ListTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := make(map[string]mcp.Tool, len(result.Tools))
+	for _, tool := range result.Tools {
+		tools[tool.Name] = tool
+	}
+	return tools, nil
+}
+
+// param 工具参数
+type param struct {
+	name     string
+	typ      string
+	required bool
+}
+
+// toolParams 按命令行顺序返回工具参数: 必填参数按 Schema 顺序在前,可选参数按名称排序
+func toolParams(tool mcp.Tool) []param {
+	required := make(map[string]bool, len(tool.InputSchema.Required))
+	var params []param
+	for _, name := range tool.InputSchema.Required {
+		if _, ok := tool.InputSchema.Properties[name]; ok && !required[name] {
+			required[name] = true
+			params = append(params, newParam(name, tool.InputSchema.Properties[name], true))
+		}
+	}
+
+	optional := make([]string, 0, len(tool.InputSchema.Properties))
+	for name := range tool.InputSchema.Properties {
+		if !required[name] {
+			optional = append(optional, name)
+		}
+	}
+	sort.Strings(optional)
+	for _, name := range optional {
+		params = append(params, newParam(name, tool.InputSchema.Properties[name], false))
+	}
+
+	return params
+}
+
+// newParam 从 JSON Schema 属性创建参数
+func newParam(name string, property any, required bool) param {
+	p := param{name: name, typ: "string", required: required}
+	if m, ok := property.(map[string]any); ok {
+		if typ, ok := m["type"].(string); ok {
+			p.typ = typ
+		}
+	}
+	return p
+}
+
+// parseArguments 按工具 Schema 解析命令参数,支持位置参数和 key=value
+func parseArguments(tool mcp.Tool, args []string) (map[string]any, error) {
+	params := toolParams(tool)
+	byName := make(map[string]param, len(params))
+	for _, p := range params {
+		byName[p.name] = p
+	}
+
+	arguments := make(map[string]any)
+	position := 0
+	for _, arg := range args {
+		var p param
+		value := arg
+		if key, v, ok := strings.Cut(arg, "="); ok && byName[key].name != "" {
+			p, value = byName[key], v
+		} else {
+			// 跳过已通过 key=value 指定的参数
+			for position < len(params) && arguments[params[position].name] != nil {
+				position++
+			}
+			if position >= len(params) {
+				return nil, fmt.Errorf("参数过多: %s", arg)
+			}
+			p = params[position]
+			position++
+		}
+
+		typed, err := convertArgument(p, value)
+		if err != nil {
+			return nil, err
+		}
+		arguments[p.name] = typed
+	}
+
+	for _, p := range params {
+		if p.required && arguments[p.name] == nil {
+			return nil, fmt.Errorf("缺少参数 %s", p.name)
+		}
+	}
+
+	return arguments, nil
+}
+
+// convertArgument 按参数类型转换
+func convertArgument(p param, value string) (any, error) {
+	switch p.typ {
+	case "number":
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("参数 %s 需要数字: %s", p.name, value)
+		}
+		return v, nil
+	case "integer":
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("参数 %s 需要整数: %s", p.name, value)
+		}
+		return v, nil
+	case "boolean":
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("参数 %s 需要 true 或 false: %s", p.name, value)
+		}
+		return v, nil
+	case "array":
+		return strings.Split(value, ","), nil
+	}
+	return value, nil
+}
+
+// usage 根据工具 Schema 生成命令用法
+func usage(command string, tool mcp.Tool) string {
+	parts := []string{command}
+	for _, p := range toolParams(tool) {
+		if p.required {
+			parts = append(parts, "<"+p.name+">")
+		} else {
+			parts = append(parts, "["+p.name+"]")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// commandsMessage 生成命令列表,快捷命令的用法和说明来自工具 Schema
+func (c *Core) commandsMessage(ctx context.Context) string {
+	var sb strings.Builder
+	sb.WriteString("## 命令\n")
+	for _, cmd := range builtinCommands {
+		sb.WriteString(fmt.Sprintf("- `/%s` %s\n", cmd.name, cmd.description))
+	}
+
+	tools, err := c.listTools(ctx)
+	if err != nil {
+		logx.Warn("Failed to list tools for help message: %v", err)
+		return sb.String()
+	}
+
+	var lines []string
+	for _, s := range shortcuts {
+		if tool, ok := tools[s.listTool]; ok {
+			lines = append(lines, fmt.Sprintf("- `/%s` %s", s.name, tool.Description))
+		}
+		if tool, ok := tools[s.ipTool]; ok {
+			lines = append(lines, fmt.Sprintf("- `%s` %s", usage("/"+s.name, tool), tool.Description))
+		}
+		if tool, ok := tools[s.tool]; ok {
+			lines = append(lines, fmt.Sprintf("- `%s` %s", usage("/"+s.name, tool), tool.Description))
+		}
+	}
+	if len(lines) > 0 {
+		sb.WriteString("\n## 快捷查询\n")
+		sb.WriteString(strings.Join(lines, "\n"))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n其他工具可以通过 `/工具名 参数...` 调用,参数按 `/tools` 中的顺序填写,也可以使用 `key=value` 指定。\n")
+	return sb.String()
+}
+
+// toolsMessage 列出全部工具及参数
+func (c *Core) toolsMessage(ctx context.Context) string {
+	tools, err := c.listTools(ctx)
+	if err != nil {
+		return fmt.Sprintf("❌ 获取工具列表失败: %v", err)
+	}
+	if len(tools) == 0 {
+		return "当前没有可用的工具"
+	}
+
+	names := make([]string, 0, len(tools))
+	for name := range tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## 可用工具 (%d)\n\n", len(names)))
+	for _, name := range names {
+		tool := tools[name]
+		sb.WriteString(fmt.Sprintf("- `%s`\n  %s\n", usage("/"+name, tool), tool.Description))
+	}
+	return sb.String()
+}
+
+// accountsMessage 列出已配置的云账号,不包含密钥
+func (c *Core) accountsMessage() string {
+	var sb strings.Builder
+	sb.WriteString("## 云账号\n\n")
+
+	count := 0
+	for _, provider := range []struct {
+		name     string
+		accounts []config.ProviderConfig
+	}{
+		{name: "阿里云", accounts: c.config.Providers.Aliyun},
+		{name: "腾讯云", accounts: c.config.Providers.Tencent},
+	} {
+		for _, account := range provider.accounts {
+			status := "启用"
+			if !account.Enabled {
+				status = "禁用"
+			}
+			sb.WriteString(fmt.Sprintf("- %s `%s` (%s) 区域: %s\n", provider.name, account.Name, status, strings.Join(account.Regions, ", ")))
+			count++
+		}
+	}
+
+	if c.config.CICD.Jenkins.Enabled {
+		sb.WriteString("- Jenkins (启用)\n")
+		count++
+	}
+
+	if count == 0 {
+		return "当前未配置云账号"
+	}
+	return sb.String()
+}
+
+// whoamiMessage 当前用户和会话信息
+func (c *Core) whoamiMessage(msg *Message) string {
+	return fmt.Sprintf("## 当前用户\n\n- 平台: %s\n- 用户 ID: %s\n- 用户名: %s\n- 会话 ID: %s\n",
+		c.opts.DisplayName, valueOrDash(msg.UserID), valueOrDash(msg.UserName), valueOrDash(msg.ConversationID))
+}
+
+// valueOrDash 空值显示为 -
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
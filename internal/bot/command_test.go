@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/imcp"
+)
+
+// textReplier 记录文本回复,不支持按钮
+type textReplier struct {
+	replies []string
+}
+
+func (r *textReplier) Reply(_ context.Context, content string) error {
+	r.replies = append(r.replies, content)
+	return nil
+}
+
+func (r *textReplier) StartStream(context.Context, string) (Stream, error) {
+	return nil, nil
+}
+
+func TestToolSetAppliesToDirectCalls(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.CDN.AllowRefresh = true
+	cfg.LLM.ToolRouter = config.ToolRouterConfig{
+		Enabled:  true,
+		ToolSets: []config.ToolSetConfig{{Bot: "test", Group: "g1", Tools: []string{"list_*"}}},
+	}
+	c := NewCore(cfg, imcp.NewMCPServer(cfg), Options{Platform: "test"})
+
+	tests := []struct {
+		name  string
+		group string
+		run   func(ctx context.Context, msg *Message, r Replier) error
+		want  string
+	}{
+		{
+			name:  "command",
+			group: "g1",
+			run: func(ctx context.Context, msg *Message, r Replier) error {
+				return c.handleCommand(ctx, msg, "/purge https://static.example.com/app.js", r)
+			},
+			want: "不可用",
+		},
+		{
+			name:  "intent",
+			group: "g1",
+			run: func(ctx context.Context, msg *Message, r Replier) error {
+				msg.Text = "刷新 CDN 缓存 https://static.example.com/app.js"
+				return c.HandleMessage(ctx, msg, r)
+			},
+			want: "不可用",
+		},
+		{
+			name:  "button",
+			group: "g1",
+			run: func(ctx context.Context, msg *Message, r Replier) error {
+				return c.HandleAction(ctx, &Action{Message: *msg, ActionID: ActionRunTool,
+					Value: `{"tool":"rerun_jenkins_build","args":{"job_name":"app","build_number":1}}`}, r)
+			},
+			want: "不可用",
+		},
+		{
+			name:  "other group",
+			group: "g2",
+			run: func(ctx context.Context, msg *Message, r Replier) error {
+				return c.handleCommand(ctx, msg, "/purge https://static.example.com/app.js", r)
+			},
+			want: "/confirm",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &Message{ConversationID: tt.group, UserID: "u1"}
+			r := &textReplier{}
+			if err := tt.run(c.withCaller(context.Background(), msg), msg, r); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if len(r.replies) != 1 || !strings.Contains(r.replies[0], tt.want) {
+				t.Errorf("replies = %q, want %q", r.replies, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/google/uuid"
+)
+
+// 操作确认: 变更操作先保存为待确认操作,发起者点击确认按钮或回复 /confirm <token> 后执行
+// 按钮只携带 token,调用内容和发起者保存在服务端,其他用户无法代为确认
+
+const (
+	// pendingCallTTL 待确认操作的有效期
+	pendingCallTTL = 5 * time.Minute
+	// maxPendingCalls 最多保留的待确认操作数量,超出时淘汰最早的操作
+	maxPendingCalls = 200
+)
+
+var (
+	// errPendingCallNotFound token 不存在、已过期或不属于当前会话
+	errPendingCallNotFound = errors.New("pending call not found")
+	// errNotInitiator 确认者不是操作的发起者
+	errNotInitiator = errors.New("only the initiator can confirm the call")
+)
+
+// pendingCall 待确认的操作,只能由发起者在同一会话中确认
+type pendingCall struct {
+	call           toolCall
+	conversationID string
+	userID         string
+	createdAt      time.Time
+}
+
+// confirmStore 保存待确认的操作
+type confirmStore struct {
+	mu    sync.Mutex
+	calls map[string]*pendingCall
+}
+
+// newConfirmStore 创建待确认操作存储
+func newConfirmStore() *confirmStore {
+	return &confirmStore{calls: make(map[string]*pendingCall)}
+}
+
+// store 保存待确认操作并返回 token
+func (s *confirmStore) store(msg *Message, call toolCall) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for token, p := range s.calls {
+		if time.Since(p.createdAt) > pendingCallTTL {
+			delete(s.calls, token)
+		}
+	}
+
+	// 超出数量上限时淘汰最早的操作
+	for len(s.calls) >= maxPendingCalls {
+		var oldestToken string
+		var oldest time.Time
+		for token, p := range s.calls {
+			if oldestToken == "" || p.createdAt.Before(oldest) {
+				oldestToken, oldest = token, p.createdAt
+			}
+		}
+		delete(s.calls, oldestToken)
+	}
+
+	token := uuid.New().String()[:8]
+	s.calls[token] = &pendingCall{
+		call:           call,
+		conversationID: msg.ConversationID,
+		userID:         msg.UserID,
+		createdAt:      time.Now(),
+	}
+	return token
+}
+
+// take 取出待确认操作,取出后即删除,每个 token 只能使用一次
+// 不是发起者时返回 errNotInitiator 和待确认的调用,操作保留给发起者继续确认
+func (s *confirmStore) take(msg *Message, token string) (toolCall, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.calls[token]
+	if !ok || p.conversationID != msg.ConversationID {
+		return toolCall{}, errPendingCallNotFound
+	}
+	if time.Since(p.createdAt) > pendingCallTTL {
+		delete(s.calls, token)
+		return toolCall{}, errPendingCallNotFound
+	}
+	if p.userID != msg.UserID {
+		return p.call, errNotInitiator
+	}
+	delete(s.calls, token)
+	return p.call, nil
+}
+
+// confirmTool 保存待确认操作,支持按钮的平台展示确认按钮,其余平台要求回复 /confirm <token> 确认
+func (c *Core) confirmTool(ctx context.Context, msg *Message, call toolCall, r Replier) error {
+	token := c.confirms.store(msg, call)
+	if _, ok := actionReplier(r); ok {
+		return reply(ctx, r, confirmPrompt(call), confirmButtons(token))
+	}
+	return r.Reply(ctx, confirmPrompt(call)+fmt.Sprintf("\n发送 `/confirm %s` 确认执行,发送 `/cancel %s` 取消,%d 分钟内有效",
+		token, token, int(pendingCallTTL.Minutes())))
+}
+
+// confirmPrompt 确认提示,列出工具和参数
+func confirmPrompt(call toolCall) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("⚠️ **确认执行 `%s`?**\n\n", call.Tool))
+	names := make([]string, 0, len(call.Args))
+	for name := range call.Args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("- %s: %v\n", name, call.Args[name]))
+	}
+	return sb.String()
+}
+
+// confirmButtons 确认和取消按钮,只携带 token
+func confirmButtons(token string) []Button {
+	return []Button{
+		{Text: "确认", ActionID: ActionConfirm, Value: token, Danger: true},
+		{Text: "取消", ActionID: ActionCancel, Value: token},
+	}
+}
+
+// handleConfirm 处理 /confirm、/cancel 命令和确认、取消按钮
+func (c *Core) handleConfirm(ctx context.Context, msg *Message, token string, confirmed bool, r Replier) error {
+	call, err := c.confirms.take(msg, token)
+	switch {
+	case errors.Is(err, errNotInitiator):
+		logx.Warn("Rejected %s confirmation of %s from user %s who did not initiate it", c.opts.DisplayName, call.Tool, msg.UserID)
+		// 按钮回调会原地更新卡片,保留确认按钮供发起者继续操作
+		return reply(ctx, r, "⛔ 只有发起者可以确认或取消此操作\n\n"+confirmPrompt(call), confirmButtons(token))
+	case err != nil:
+		return r.Reply(ctx, fmt.Sprintf("⌛ 未找到待确认的操作 `%s`,可能已过期或已处理,请重新发起", token))
+	}
+	if !confirmed {
+		return r.Reply(ctx, "🚫 已取消操作")
+	}
+
+	content, buttons := c.runTool(ctx, call.Tool, call.Args, r)
+	return reply(ctx, r, content, buttons)
+}
@@ -0,0 +1,36 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfirmStore(t *testing.T) {
+	s := newConfirmStore()
+	owner := &Message{ConversationID: "c1", UserID: "u1"}
+	call := toolCall{Tool: "refresh_cdn_cache", Args: map[string]any{"paths": "https://example.com/a.js"}}
+
+	token := s.store(owner, call)
+	if _, err := s.take(&Message{ConversationID: "c2", UserID: "u1"}, token); !errors.Is(err, errPendingCallNotFound) {
+		t.Fatalf("take() from another conversation error = %v, want not found", err)
+	}
+	// 其他用户无法确认,操作保留给发起者
+	if got, err := s.take(&Message{ConversationID: "c1", UserID: "u2"}, token); !errors.Is(err, errNotInitiator) || got.Tool != call.Tool {
+		t.Fatalf("take() by another user = %v, %v, want not initiator", got, err)
+	}
+
+	got, err := s.take(owner, token)
+	if err != nil || got.Tool != call.Tool {
+		t.Fatalf("take() = %v, %v, want %s", got, err, call.Tool)
+	}
+	if _, err := s.take(owner, token); !errors.Is(err, errPendingCallNotFound) {
+		t.Fatal("token used twice")
+	}
+
+	expired := s.store(owner, call)
+	s.calls[expired].createdAt = time.Now().Add(-pendingCallTTL - time.Second)
+	if _, err := s.take(owner, expired); !errors.Is(err, errPendingCallNotFound) {
+		t.Fatal("expired call confirmed")
+	}
+}
@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/imcp"
+	"github.com/eryajf/zenops/internal/intent"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// 意图解析: 未启用 LLM 时,按意图规则将自然语言消息映射为工具调用,执行方式与斜杠命令相同
+
+// newIntentEngine 创建意图引擎,自定义规则加载失败时只使用内置规则
+func newIntentEngine(rulesFile string) *intent.Engine {
+	engine, err := intent.New(rulesFile)
+	if err == nil {
+		return engine
+	}
+	logx.Error("Failed to load intent rules from %s, using builtin rules: %v", rulesFile, err)
+
+	engine, err = intent.New("")
+	if err != nil {
+		logx.Error("Failed to load builtin intent rules: %v", err)
+		return nil
+	}
+	return engine
+}
+
+// handleIntent 执行意图对应的工具
+func (c *Core) handleIntent(ctx context.Context, msg *Message, in *intent.Intent, r Replier) error {
+	logx.Info("Intent parsed for %s, rule %s, tool %s, args %v", c.opts.DisplayName, in.Rule, in.Tool, in.Args)
+
+	tools, err := c.listTools(ctx)
+	if err != nil {
+		return r.Reply(ctx, fmt.Sprintf("❌ 获取工具列表失败: %v", err))
+	}
+	tool, ok := tools[in.Tool]
+	if !ok {
+		return r.Reply(ctx, fmt.Sprintf("⚠️ 工具 `%s` 未启用,请检查对应的云账号或 CI/CD 配置", in.Tool))
+	}
+	if !c.toolAllowed(ctx, in.Tool) {
+		return r.Reply(ctx, toolUnavailable(in.Tool))
+	}
+
+	args, err := intentArguments(tool, in.Args)
+	if err != nil {
+		return r.Reply(ctx, fmt.Sprintf("❌ %v", err))
+	}
+
+	// 自然语言可能被误解析,变更操作必须确认后执行
+	if imcp.RequiresConfirmation(in.Tool) {
+		return c.confirmTool(ctx, msg, toolCall{Tool: in.Tool, Args: args}, r)
+	}
+
+	content, buttons := c.runTool(ctx, in.Tool, args, r)
+	return reply(ctx, r, content, buttons)
+}
+
+// intentArguments 按工具 Schema 转换意图参数的类型,Schema 中没有的参数原样传入
+func intentArguments(tool mcp.Tool, values map[string]string) (map[string]any, error) {
+	args := make(map[string]any, len(values))
+	for name, value := range values {
+		args[name] = value
+	}
+	for _, p := range toolParams(tool) {
+		value, ok := values[p.name]
+		if !ok {
+			continue
+		}
+		v, err := convertArgument(p, value)
+		if err != nil {
+			return nil, err
+		}
+		args[p.name] = v
+	}
+	return args, nil
+}
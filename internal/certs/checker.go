@@ -0,0 +1,172 @@
+// Package certs 汇总云证书服务中的 SSL 证书,并对配置的主机进行主动 TLS 检查
+package certs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/cloudaccount"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+// Checker 查询多个账号的证书及主机的线上证书
+type Checker struct {
+	accounts []*cloudaccount.Account
+	hosts    []string
+	timeout  time.Duration
+}
+
+// NewChecker 创建证书检查器,accounts 为空时只检查配置的主机
+func NewChecker(accounts []*cloudaccount.Account, cfg config.CertificatesConfig) *Checker {
+	return &Checker{
+		accounts: accounts,
+		hosts:    cfg.Hosts,
+		timeout:  time.Duration(cfg.Timeout) * time.Second,
+	}
+}
+
+// Load 按 cloud、account 加载账号并创建检查器,返回实际查询的证书来源
+// 指定 cloud 或 account 时只查询云证书;未配置云账号时仍可检查主机证书
+func Load(cfg *config.Config, cloud, account, source string) (*Checker, string, error) {
+	if source == "" && (cloud != "" || account != "") {
+		source = model.CertSourceCloud
+	}
+
+	var accounts []*cloudaccount.Account
+	if source != model.CertSourceTLS {
+		var err error
+		accounts, err = cloudaccount.Load(cfg, cloud, account)
+		if err != nil {
+			if source == model.CertSourceCloud || len(cfg.Certificates.Hosts) == 0 {
+				return nil, "", err
+			}
+			logx.Warn("Skip cloud certificates: %v", err)
+		}
+	}
+	return NewChecker(accounts, cfg.Certificates), source, nil
+}
+
+// List 列出证书,source 为 cloud 或 tls 时只查询对应来源,keyword 按域名、名称或主机过滤
+// 单个账号或主机查询失败时记录告警并跳过
+func (c *Checker) List(ctx context.Context, keyword, source string) ([]*model.Certificate, error) {
+	if source != "" && source != model.CertSourceCloud && source != model.CertSourceTLS {
+		return nil, fmt.Errorf("unsupported source %q, must be cloud or tls", source)
+	}
+
+	var certs []*model.Certificate
+	if source != model.CertSourceTLS {
+		for _, acc := range c.accounts {
+			list, err := acc.Provider.ListCertificates(ctx, &provider.QueryOptions{
+				Filters: map[string]string{"keyword": keyword},
+			})
+			if err != nil {
+				logx.Warn("Failed to list certificates, cloud %s, account %s, error %v", acc.Cloud, acc.Name, err)
+				continue
+			}
+			certs = append(certs, list...)
+		}
+	}
+	if source != model.CertSourceCloud {
+		for _, cert := range c.probeHosts(ctx) {
+			if keyword == "" || matches(cert, keyword) {
+				certs = append(certs, cert)
+			}
+		}
+	}
+	return certs, nil
+}
+
+// Expiring 返回剩余有效期不超过 days 天的证书(含已过期),按过期时间升序排列
+func (c *Checker) Expiring(ctx context.Context, days int, source string) ([]*model.Certificate, error) {
+	certs, err := c.List(ctx, "", source)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiring := make([]*model.Certificate, 0)
+	for _, cert := range certs {
+		if !cert.NotAfter.IsZero() && cert.DaysLeft(now) <= days {
+			expiring = append(expiring, cert)
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].NotAfter.Before(expiring[j].NotAfter)
+	})
+	return expiring, nil
+}
+
+// probeHosts 并发检查配置的主机,返回顺序与配置一致
+func (c *Checker) probeHosts(ctx context.Context) []*model.Certificate {
+	results := make([]*model.Certificate, len(c.hosts))
+	var wg sync.WaitGroup
+	for i, host := range c.hosts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cert, err := Probe(ctx, host, c.timeout)
+			if err != nil {
+				logx.Warn("Failed to probe certificate, host %s, error %v", host, err)
+				return
+			}
+			results[i] = cert
+		}()
+	}
+	wg.Wait()
+
+	certs := make([]*model.Certificate, 0, len(results))
+	for _, cert := range results {
+		if cert != nil {
+			certs = append(certs, cert)
+		}
+	}
+	return certs
+}
+
+// matches 判断主动检查的证书是否匹配关键字
+func matches(cert *model.Certificate, keyword string) bool {
+	keyword = strings.ToLower(keyword)
+	for _, s := range append([]string{cert.Host, cert.Domain}, cert.SANs...) {
+		if strings.Contains(strings.ToLower(s), keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// Describe 单行描述证书,用于列表和群通知
+func Describe(cert *model.Certificate, now time.Time) string {
+	var sb strings.Builder
+	days := cert.DaysLeft(now)
+	if days < 0 {
+		sb.WriteString(fmt.Sprintf("已过期 %d 天", -days))
+	} else {
+		sb.WriteString(fmt.Sprintf("剩余 %d 天", days))
+	}
+	sb.WriteString(fmt.Sprintf(" | %s | %s", cert.Domain, cert.NotAfter.Local().Format("2006-01-02")))
+	if len(cert.SANs) > 0 {
+		sb.WriteString(fmt.Sprintf(" | +%d 个域名", len(cert.SANs)))
+	}
+	if cert.Source == model.CertSourceTLS {
+		sb.WriteString(" | 主机 " + cert.Host)
+		if cert.Status != "valid" {
+			sb.WriteString(" | 校验失败: " + cert.Status)
+		}
+	} else {
+		sb.WriteString(fmt.Sprintf(" | %s/%s %s", cert.Provider, cert.Account, cert.ID))
+		if len(cert.Products) > 0 {
+			sb.WriteString(" | 部署于 " + strings.Join(cert.Products, ","))
+		}
+	}
+	if cert.Issuer != "" {
+		sb.WriteString(" | " + cert.Issuer)
+	}
+	return sb.String()
+}
@@ -0,0 +1,93 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/eryajf/zenops/internal/model"
+)
+
+// defaultTimeout 未配置握手超时时使用
+const defaultTimeout = 5 * time.Second
+
+// Probe 与主机进行 TLS 握手并读取服务端证书,host 支持 host、host:port 或 URL,默认端口 443
+// 证书链或域名校验失败时不返回错误,校验结果记录在 Status 中
+func Probe(ctx context.Context, host string, timeout time.Duration) (*model.Certificate, error) {
+	address, serverName := normalizeHost(host)
+	if serverName == "" {
+		return nil, fmt.Errorf("invalid host %q", host)
+	}
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: timeout},
+		// 先跳过校验以便读取过期或自签名证书,随后单独校验
+		Config: &tls.Config{ServerName: serverName, InsecureSkipVerify: true},
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("TLS handshake with %s failed: %w", address, err)
+	}
+	defer conn.Close()
+
+	peers := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("no certificate presented by %s", address)
+	}
+	leaf := peers[0]
+
+	intermediates := x509.NewCertPool()
+	for _, c := range peers[1:] {
+		intermediates.AddCert(c)
+	}
+	status := "valid"
+	if _, err := leaf.Verify(x509.VerifyOptions{DNSName: serverName, Intermediates: intermediates}); err != nil {
+		status = err.Error()
+	}
+
+	cert := &model.Certificate{
+		ID:        fmt.Sprintf("%X", leaf.SerialNumber),
+		Source:    model.CertSourceTLS,
+		Host:      address,
+		Domain:    leaf.Subject.CommonName,
+		Issuer:    leaf.Issuer.CommonName,
+		Status:    status,
+		NotBefore: leaf.NotBefore,
+		NotAfter:  leaf.NotAfter,
+	}
+	if cert.Issuer == "" && len(leaf.Issuer.Organization) > 0 {
+		cert.Issuer = leaf.Issuer.Organization[0]
+	}
+	for _, name := range leaf.DNSNames {
+		if name != cert.Domain {
+			cert.SANs = append(cert.SANs, name)
+		}
+	}
+	if cert.Domain == "" && len(leaf.DNSNames) > 0 {
+		cert.Domain, cert.SANs = leaf.DNSNames[0], cert.SANs[1:]
+	}
+	return cert, nil
+}
+
+// normalizeHost 返回拨号地址和 SNI 主机名
+func normalizeHost(host string) (address, serverName string) {
+	host = strings.TrimSpace(host)
+	host = strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return host, h
+	}
+	return net.JoinHostPort(host, "443"), host
+}
@@ -0,0 +1,97 @@
+// Package cloudaccount 按配置初始化多个阿里云、腾讯云账号,供跨账号汇总查询使用
+package cloudaccount
+
+import (
+	"fmt"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/eryajf/zenops/internal/provider/aliyun"
+	"github.com/eryajf/zenops/internal/provider/tencent"
+)
+
+// Account 参与跨账号查询的云账号
+type Account struct {
+	Cloud    string // aliyun, tencent
+	Name     string
+	Provider provider.Provider
+}
+
+// Load 初始化参与查询的账号
+// cloud、account 为空时返回所有启用的阿里云和腾讯云账号,单个账号初始化失败时记录告警并跳过
+func Load(cfg *config.Config, cloud, account string) ([]*Account, error) {
+	if cloud != "" && cloud != "aliyun" && cloud != "tencent" {
+		return nil, fmt.Errorf("unsupported cloud %q, must be aliyun or tencent", cloud)
+	}
+
+	var accounts []*Account
+	for _, group := range []struct {
+		cloud    string
+		accounts []config.ProviderConfig
+	}{
+		{cloud: "aliyun", accounts: cfg.Providers.Aliyun},
+		{cloud: "tencent", accounts: cfg.Providers.Tencent},
+	} {
+		if cloud != "" && cloud != group.cloud {
+			continue
+		}
+		for _, acc := range group.accounts {
+			// 指定账号时不要求启用,与其他工具的账号选择保持一致
+			if account != "" && acc.Name != account {
+				continue
+			}
+			if account == "" && !acc.Enabled {
+				continue
+			}
+
+			p, err := newProvider(group.cloud, acc)
+			if err != nil {
+				logx.Warn("Failed to initialize %s account %s, error %v", group.cloud, acc.Name, err)
+				continue
+			}
+			accounts = append(accounts, &Account{Cloud: group.cloud, Name: acc.Name, Provider: p})
+		}
+	}
+
+	if len(accounts) == 0 {
+		if account != "" {
+			return nil, fmt.Errorf("account '%s' not found", account)
+		}
+		return nil, fmt.Errorf("no enabled aliyun or tencent account configured")
+	}
+	return accounts, nil
+}
+
+// newProvider 按账号配置创建并初始化 Provider
+// 注册表中的 Provider 为单例,多个账号同时使用时需各自创建实例,避免相互覆盖
+func newProvider(cloud string, cfg config.ProviderConfig) (provider.Provider, error) {
+	var p provider.Provider
+	if cloud == "aliyun" {
+		p = aliyun.NewProvider()
+	} else {
+		p = tencent.NewTencentProvider()
+	}
+
+	regions := make([]any, len(cfg.Regions))
+	for i, r := range cfg.Regions {
+		regions[i] = r
+	}
+
+	providerConfig := map[string]any{
+		"account": cfg.Name,
+		"regions": regions,
+	}
+	if cloud == "aliyun" {
+		providerConfig["access_key_id"] = cfg.AK
+		providerConfig["access_key_secret"] = cfg.SK
+	} else {
+		providerConfig["secret_id"] = cfg.AK
+		providerConfig["secret_key"] = cfg.SK
+	}
+
+	if err := p.Initialize(providerConfig); err != nil {
+		return nil, fmt.Errorf("failed to initialize provider for account %s: %w", cfg.Name, err)
+	}
+	return p, nil
+}
@@ -2,16 +2,25 @@ package config
 
 // Config 应用配置
 type Config struct {
-	Server           ServerConfig    `mapstructure:"server"`
-	Providers        ProvidersConfig `mapstructure:"providers"`
-	CICD             CICDConfig      `mapstructure:"cicd"`
-	DingTalk         DingTalkConfig  `mapstructure:"dingtalk"`
-	Feishu           FeishuConfig    `mapstructure:"feishu"`
-	Wecom            WecomConfig     `mapstructure:"wecom"`
-	LLM              LLMConfig       `mapstructure:"llm"`
-	Auth             AuthConfig      `mapstructure:"auth"`
-	Cache            CacheConfig     `mapstructure:"cache"`
-	MCPServersConfig string          `mapstructure:"mcp_servers_config"` // 外部 MCP Servers 配置文件路径
+	Server           ServerConfig       `mapstructure:"server"`
+	Providers        ProvidersConfig    `mapstructure:"providers"`
+	CICD             CICDConfig         `mapstructure:"cicd"`
+	DingTalk         DingTalkConfig     `mapstructure:"dingtalk"`
+	Feishu           FeishuConfig       `mapstructure:"feishu"`
+	Wecom            WecomConfig        `mapstructure:"wecom"`
+	Slack            SlackConfig        `mapstructure:"slack"`
+	Telegram         TelegramConfig     `mapstructure:"telegram"`
+	LLM              LLMConfig          `mapstructure:"llm"`
+	Intent           IntentConfig       `mapstructure:"intent"`
+	Auth             AuthConfig         `mapstructure:"auth"`
+	Cache            CacheConfig        `mapstructure:"cache"`
+	Audit            AuditConfig        `mapstructure:"audit"`
+	Metrics          MetricsConfig      `mapstructure:"metrics"`
+	Tracing          TracingConfig      `mapstructure:"tracing"`
+	Certificates     CertificatesConfig `mapstructure:"certificates"`
+	CDN              CDNConfig          `mapstructure:"cdn"`
+	Idle             IdleConfig         `mapstructure:"idle"`
+	MCPServersConfig string             `mapstructure:"mcp_servers_config"` // 外部 MCP Servers 配置文件路径
 }
 
 // ProvidersConfig 云服务提供商配置集合
@@ -58,18 +67,54 @@ type CICDConfig struct {
 
 // JenkinsConfig Jenkins 配置
 type JenkinsConfig struct {
-	Enabled  bool   `mapstructure:"enabled"`
-	URL      string `mapstructure:"url"`
-	Username string `mapstructure:"username"`
-	Token    string `mapstructure:"token"`
+	Enabled    bool   `mapstructure:"enabled"`
+	URL        string `mapstructure:"url"`
+	Username   string `mapstructure:"username"`
+	Token      string `mapstructure:"token"`
+	AllowRerun bool   `mapstructure:"allow_rerun"` // 是否允许通过 rerun_jenkins_build 工具重新触发构建,默认关闭
 }
 
 // LLMConfig LLM 配置
 type LLMConfig struct {
-	Enabled bool   `mapstructure:"enabled"`
+	Enabled  bool               `mapstructure:"enabled"`
+	Model    string             `mapstructure:"model"`
+	APIKey   string             `mapstructure:"api_key"`
+	BaseURL  string             `mapstructure:"base_url"` // 自定义 API 端点
+	Timeout  int                `mapstructure:"timeout"`  // 单次请求超时(秒),后端未单独配置时使用
+	Backends []LLMBackendConfig `mapstructure:"backends"` // 按顺序故障转移的后端列表,为空时使用上面的单个端点
+
+	ToolResultMaxTokens int `mapstructure:"tool_result_max_tokens"` // 单次工具结果放入上下文的最大 token 数,超出时分页
+
+	ToolRouter ToolRouterConfig `mapstructure:"tool_router"` // 按请求筛选发送给模型的工具
+}
+
+// IntentConfig 意图解析配置(未启用 LLM 时的自然语言查询)
+type IntentConfig struct {
+	RulesFile string `mapstructure:"rules_file"` // 自定义规则文件路径,其中的规则优先于内置规则匹配
+}
+
+// ToolRouterConfig 工具路由配置
+type ToolRouterConfig struct {
+	Enabled   bool            `mapstructure:"enabled"`
+	MaxTools  int             `mapstructure:"max_tools"`  // 每次请求最多发送的工具数量(不含核心工具)
+	CoreTools []string        `mapstructure:"core_tools"` // 始终发送的核心工具,支持通配符
+	ToolSets  []ToolSetConfig `mapstructure:"tool_sets"`  // 按机器人/群限定候选工具
+}
+
+// ToolSetConfig 机器人或群的工具集
+type ToolSetConfig struct {
+	Bot   string   `mapstructure:"bot"`   // 机器人: dingtalk, feishu, wecom, slack, telegram, openai
+	Group string   `mapstructure:"group"` // 群/会话 ID,为空时对该机器人的所有会话生效
+	Tools []string `mapstructure:"tools"` // 工具名,支持通配符(如 cnb_*)
+}
+
+// LLMBackendConfig LLM 后端配置
+type LLMBackendConfig struct {
+	Name    string `mapstructure:"name"` // 后端名称,用于日志和健康检查展示
 	Model   string `mapstructure:"model"`
 	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"` // 自定义 API 端点
+	BaseURL string `mapstructure:"base_url"`
+	Timeout int    `mapstructure:"timeout"` // 单次请求超时(秒)
 }
 
 // DingTalkConfig 钉钉配置
@@ -95,6 +140,23 @@ type WecomConfig struct {
 	EncodingAESKey string `mapstructure:"encoding_aes_key"` // 消息加密密钥
 }
 
+// SlackConfig Slack 配置
+type SlackConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	AppToken string `mapstructure:"app_token"` // 应用级 Token (xapp-),用于 Socket Mode 建立连接
+	BotToken string `mapstructure:"bot_token"` // Bot User OAuth Token (xoxb-),用于发送和更新消息
+	APIURL   string `mapstructure:"api_url"`   // Web API 地址,可指向本地模拟服务用于测试
+}
+
+// TelegramConfig Telegram 配置
+type TelegramConfig struct {
+	Enabled        bool    `mapstructure:"enabled"`
+	BotToken       string  `mapstructure:"bot_token"`        // BotFather 分配的 Token
+	APIURL         string  `mapstructure:"api_url"`          // Bot API 地址,可指向自建 Bot API Server
+	AllowedChatIDs []int64 `mapstructure:"allowed_chat_ids"` // 允许使用机器人的会话 ID,为空时拒绝所有会话
+	PollTimeout    int     `mapstructure:"poll_timeout"`     // 长轮询超时(秒)
+}
+
 // AuthConfig 认证配置
 type AuthConfig struct {
 	Enabled bool     `mapstructure:"enabled"`
@@ -109,6 +171,65 @@ type CacheConfig struct {
 	TTL     int    `mapstructure:"ttl"`  // 秒
 }
 
+// AuditConfig 工具调用审计日志配置
+type AuditConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Path       string `mapstructure:"path"`        // JSONL 文件路径
+	MaxSizeMB  int    `mapstructure:"max_size_mb"` // 单个文件最大大小(MB),超出后轮转
+	MaxBackups int    `mapstructure:"max_backups"` // 保留的历史文件数量
+}
+
+// MetricsConfig Prometheus 指标配置
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"` // HTTP 服务上的指标路径
+}
+
+// TracingConfig OpenTelemetry 链路追踪配置
+type TracingConfig struct {
+	Enabled     bool              `mapstructure:"enabled"`
+	Endpoint    string            `mapstructure:"endpoint"`     // OTLP HTTP 地址,如 http://localhost:4318
+	ServiceName string            `mapstructure:"service_name"` // 上报的服务名
+	SampleRatio float64           `mapstructure:"sample_ratio"` // 采样比例 (0, 1]
+	Headers     map[string]string `mapstructure:"headers"`      // 导出时附加的请求头(如鉴权)
+}
+
+// CertificatesConfig SSL 证书检查配置
+type CertificatesConfig struct {
+	Hosts   []string        `mapstructure:"hosts"`   // 主动 TLS 握手检查的主机,格式为 host 或 host:port,默认端口 443
+	Timeout int             `mapstructure:"timeout"` // 单个主机的握手超时(秒)
+	Alert   CertAlertConfig `mapstructure:"alert"`   // 即将过期证书的群通知
+}
+
+// CertAlertConfig 证书过期群通知配置
+type CertAlertConfig struct {
+	Enabled  bool           `mapstructure:"enabled"`
+	Days     int            `mapstructure:"days"`     // 剩余有效天数不超过该值时通知
+	Interval int            `mapstructure:"interval"` // 检查间隔(小时)
+	Targets  []NotifyTarget `mapstructure:"targets"`
+}
+
+// NotifyTarget 群通知目标
+type NotifyTarget struct {
+	Bot     string `mapstructure:"bot"`     // feishu, slack, telegram 使用已配置的机器人发送; dingtalk, wecom 使用群机器人 Webhook
+	ChatID  string `mapstructure:"chat_id"` // 飞书 chat_id、Slack 频道 ID 或 Telegram 会话 ID
+	Webhook string `mapstructure:"webhook"` // 钉钉、企业微信群机器人 Webhook 地址
+}
+
+// IdleConfig 闲置资源检测配置,统计周期内各项指标均低于阈值的资源判定为闲置
+type IdleConfig struct {
+	Days           int     `mapstructure:"days"`             // 统计天数,最多 30 天
+	CPUPercent     float64 `mapstructure:"cpu_percent"`      // 平均 CPU 使用率阈值 (%)
+	CPUPeakPercent float64 `mapstructure:"cpu_peak_percent"` // 小时平均 CPU 使用率最大值的阈值 (%)
+	MemoryPercent  float64 `mapstructure:"memory_percent"`   // 平均内存使用率阈值 (%),无内存数据时忽略
+	NetworkKbps    float64 `mapstructure:"network_kbps"`     // 平均入方向与出方向流量之和的阈值 (Kbps)
+}
+
+// CDNConfig CDN 配置
+type CDNConfig struct {
+	AllowRefresh bool `mapstructure:"allow_refresh"` // 是否允许通过 refresh_cdn_cache 工具刷新缓存,默认关闭
+}
+
 var globalConfig *Config
 
 // SetGlobalConfig 设置全局配置
@@ -61,6 +61,18 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.mcp.enabled", false)
 	v.SetDefault("server.mcp.port", 8081)
 
+	// Slack 默认配置
+	v.SetDefault("slack.api_url", "https://slack.com/api/")
+
+	// Telegram 默认配置
+	v.SetDefault("telegram.api_url", "https://api.telegram.org")
+	v.SetDefault("telegram.poll_timeout", 30)
+
+	// LLM 默认配置
+	v.SetDefault("llm.timeout", 120)
+	v.SetDefault("llm.tool_result_max_tokens", 4000)
+	v.SetDefault("llm.tool_router.max_tools", 20)
+
 	// Auth 默认配置
 	v.SetDefault("auth.enabled", false)
 	v.SetDefault("auth.type", "token")
@@ -69,6 +81,38 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("cache.enabled", false)
 	v.SetDefault("cache.type", "memory")
 	v.SetDefault("cache.ttl", 300)
+
+	// Audit 默认配置
+	v.SetDefault("audit.enabled", false)
+	v.SetDefault("audit.path", "logs/audit.jsonl")
+	v.SetDefault("audit.max_size_mb", 100)
+	v.SetDefault("audit.max_backups", 10)
+
+	// Metrics 默认配置
+	v.SetDefault("metrics.enabled", true)
+	v.SetDefault("metrics.path", "/metrics")
+
+	// Tracing 默认配置
+	v.SetDefault("tracing.enabled", false)
+	v.SetDefault("tracing.endpoint", "http://localhost:4318")
+	v.SetDefault("tracing.service_name", "zenops")
+	v.SetDefault("tracing.sample_ratio", 1.0)
+
+	// Certificates 默认配置
+	v.SetDefault("certificates.timeout", 5)
+	v.SetDefault("certificates.alert.enabled", false)
+	v.SetDefault("certificates.alert.days", 30)
+	v.SetDefault("certificates.alert.interval", 24)
+
+	// CDN 默认配置
+	v.SetDefault("cdn.allow_refresh", false)
+
+	// Idle 默认配置
+	v.SetDefault("idle.days", 7)
+	v.SetDefault("idle.cpu_percent", 5)
+	v.SetDefault("idle.cpu_peak_percent", 20)
+	v.SetDefault("idle.memory_percent", 20)
+	v.SetDefault("idle.network_kbps", 100)
 }
 
 // expandEnvVars 展开环境变量
@@ -94,6 +138,24 @@ func expandEnvVars(config *Config) {
 	config.DingTalk.AppSecret = os.ExpandEnv(config.DingTalk.AppSecret)
 	config.DingTalk.AgentID = os.ExpandEnv(config.DingTalk.AgentID)
 
+	// 展开 Slack 配置中的环境变量
+	config.Slack.AppToken = os.ExpandEnv(config.Slack.AppToken)
+	config.Slack.BotToken = os.ExpandEnv(config.Slack.BotToken)
+
+	// 展开 Telegram 配置中的环境变量
+	config.Telegram.BotToken = os.ExpandEnv(config.Telegram.BotToken)
+
+	// 展开 LLM 配置中的环境变量
+	config.LLM.APIKey = os.ExpandEnv(config.LLM.APIKey)
+	for i := range config.LLM.Backends {
+		config.LLM.Backends[i].APIKey = os.ExpandEnv(config.LLM.Backends[i].APIKey)
+	}
+
+	// 展开证书通知 Webhook 中的环境变量
+	for i := range config.Certificates.Alert.Targets {
+		config.Certificates.Alert.Targets[i].Webhook = os.ExpandEnv(config.Certificates.Alert.Targets[i].Webhook)
+	}
+
 	// 展开 Auth 配置中的环境变量
 	for i, token := range config.Auth.Tokens {
 		config.Auth.Tokens[i] = os.ExpandEnv(token)
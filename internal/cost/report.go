@@ -0,0 +1,221 @@
+// Package cost 汇总多个云账号的账单,按账号、产品、地域、标签拆分费用并列出费用最高的实例
+package cost
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/cloudaccount"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+// 账期取值,除此之外还支持 YYYY-MM
+const (
+	PeriodCurrent = "current" // 本月至今
+	PeriodLast    = "last"    // 上月
+)
+
+// Reporter 查询多个账号的账单
+type Reporter struct {
+	accounts []*cloudaccount.Account
+}
+
+// NewReporter 创建账单查询器
+func NewReporter(accounts []*cloudaccount.Account) *Reporter {
+	return &Reporter{accounts: accounts}
+}
+
+// Load 按 cloud、account 加载账号并创建账单查询器
+func Load(cfg *config.Config, cloud, account string) (*Reporter, error) {
+	accounts, err := cloudaccount.Load(cfg, cloud, account)
+	if err != nil {
+		return nil, err
+	}
+	return NewReporter(accounts), nil
+}
+
+// Cycle 将 current、last 或 YYYY-MM 转换为账期,period 为空时为本月
+func Cycle(period string, now time.Time) (string, error) {
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	switch period {
+	case "", PeriodCurrent, "mtd":
+		return month.Format("2006-01"), nil
+	case PeriodLast:
+		return month.AddDate(0, -1, 0).Format("2006-01"), nil
+	}
+
+	t, err := time.ParseInLocation("2006-01", period, now.Location())
+	if err != nil {
+		return "", fmt.Errorf("invalid period %q, must be current, last or YYYY-MM", period)
+	}
+	if t.After(month) {
+		return "", fmt.Errorf("period %s is in the future", period)
+	}
+	return period, nil
+}
+
+// Items 查询账期内所有账号的实例费用,单个账号查询失败时记录告警并跳过
+// 所有账号都失败时返回错误,避免把查询失败当作零费用
+func (r *Reporter) Items(ctx context.Context, billingCycle string) ([]*model.CostItem, error) {
+	var items []*model.CostItem
+	var lastErr error
+	for _, acc := range r.accounts {
+		list, err := acc.Provider.ListCostItems(ctx, &provider.QueryOptions{
+			Filters: map[string]string{"billing_cycle": billingCycle},
+		})
+		if err != nil {
+			logx.Warn("Failed to list cost items, cloud %s, account %s, error %v", acc.Cloud, acc.Name, err)
+			lastErr = err
+			continue
+		}
+		items = append(items, list...)
+	}
+	if items == nil && lastErr != nil {
+		return nil, fmt.Errorf("failed to query bills: %w", lastErr)
+	}
+	return items, nil
+}
+
+// Summary 返回每个账号本月至今和上月的费用合计,按上月费用降序排列
+func (r *Reporter) Summary(ctx context.Context, now time.Time) ([]*model.CostSummary, error) {
+	current, _ := Cycle(PeriodCurrent, now)
+	last, _ := Cycle(PeriodLast, now)
+
+	summaries := make([]*model.CostSummary, 0, len(r.accounts))
+	var lastErr error
+	for _, acc := range r.accounts {
+		summary := &model.CostSummary{Provider: acc.Cloud, Account: acc.Name, Currency: "CNY"}
+		ok := true
+		for _, cycle := range []struct {
+			value  string
+			amount *float64
+		}{
+			{current, &summary.MonthToDate},
+			{last, &summary.LastMonth},
+		} {
+			items, err := acc.Provider.ListCostItems(ctx, &provider.QueryOptions{
+				Filters: map[string]string{"billing_cycle": cycle.value},
+			})
+			if err != nil {
+				logx.Warn("Failed to list cost items, cloud %s, account %s, cycle %s, error %v", acc.Cloud, acc.Name, cycle.value, err)
+				lastErr, ok = err, false
+				break
+			}
+			*cycle.amount = total(items)
+			if len(items) > 0 {
+				summary.Currency = currency(items)
+			}
+		}
+		if ok {
+			summaries = append(summaries, summary)
+		}
+	}
+	if len(summaries) == 0 && lastErr != nil {
+		return nil, fmt.Errorf("failed to query bills: %w", lastErr)
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].LastMonth > summaries[j].LastMonth
+	})
+	return summaries, nil
+}
+
+// Breakdown 按维度汇总费用,groupBy 为 account、product、region 或 tag,按 tag 拆分时 tagKey 必填
+// 未打该标签的实例归入 (未设置)
+func Breakdown(items []*model.CostItem, billingCycle, groupBy, tagKey string) (*model.CostBreakdown, error) {
+	var key func(*model.CostItem) string
+	switch groupBy {
+	case "", model.CostGroupProduct:
+		groupBy = model.CostGroupProduct
+		key = func(item *model.CostItem) string { return item.Product }
+	case model.CostGroupAccount:
+		key = func(item *model.CostItem) string { return item.Provider + "/" + item.Account }
+	case model.CostGroupRegion:
+		key = func(item *model.CostItem) string { return item.Region }
+	case model.CostGroupTag:
+		if tagKey == "" {
+			return nil, fmt.Errorf("tag_key is required when grouping by tag")
+		}
+		key = func(item *model.CostItem) string { return item.Tags[tagKey] }
+	default:
+		return nil, fmt.Errorf("unsupported group_by %q, must be account, product, region or tag", groupBy)
+	}
+
+	breakdown := &model.CostBreakdown{
+		BillingCycle: billingCycle,
+		GroupBy:      groupBy,
+		Currency:     currency(items),
+		Groups:       make([]*model.CostGroup, 0),
+	}
+	if groupBy == model.CostGroupTag {
+		breakdown.TagKey = tagKey
+	}
+
+	groups := make(map[string]*model.CostGroup)
+	for _, item := range items {
+		k := key(item)
+		if k == "" {
+			k = "(未设置)"
+		}
+		group, ok := groups[k]
+		if !ok {
+			group = &model.CostGroup{Key: k}
+			groups[k] = group
+			breakdown.Groups = append(breakdown.Groups, group)
+		}
+		group.Amount += item.Amount
+		group.Instances++
+		breakdown.Total += item.Amount
+	}
+	sort.SliceStable(breakdown.Groups, func(i, j int) bool {
+		return breakdown.Groups[i].Amount > breakdown.Groups[j].Amount
+	})
+	return breakdown, nil
+}
+
+// Top 返回费用最高的 n 个实例,不含没有实例 ID 的费用 (如按账号计费的产品)
+func Top(items []*model.CostItem, n int) []*model.CostItem {
+	top := make([]*model.CostItem, 0, len(items))
+	for _, item := range items {
+		if item.InstanceID != "" {
+			top = append(top, item)
+		}
+	}
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].Amount > top[j].Amount
+	})
+	if n > 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// total 合计费用
+func total(items []*model.CostItem) float64 {
+	var sum float64
+	for _, item := range items {
+		sum += item.Amount
+	}
+	return sum
+}
+
+// currency 返回账单币种,各账号币种不一致时返回 MIXED,无账单时默认 CNY
+func currency(items []*model.CostItem) string {
+	result := ""
+	for _, item := range items {
+		if result == "" {
+			result = item.Currency
+		} else if item.Currency != result {
+			return "MIXED"
+		}
+	}
+	if result == "" {
+		return "CNY"
+	}
+	return result
+}
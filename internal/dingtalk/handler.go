@@ -3,25 +3,22 @@ package dingtalk
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/bot"
 	"github.com/eryajf/zenops/internal/config"
 	"github.com/eryajf/zenops/internal/imcp"
-	"github.com/eryajf/zenops/internal/llm"
 	"github.com/google/uuid"
-	"github.com/mark3labs/mcp-go/mcp"
 )
 
 // MessageHandler 消息处理器
 type MessageHandler struct {
 	client    *Client
-	parser    *IntentParser
 	mcpServer *imcp.MCPServer
 	config    *config.Config
 	streamMgr *StreamManager
-	llmClient *llm.Client
+	core      *bot.Core
 }
 
 // // NewMessageHandler 创建消息处理器
@@ -41,33 +38,22 @@ type MessageHandler struct {
 // 		return nil, fmt.Errorf("failed to create callback crypto: %w", err)
 // 	}
 
-// 	// 初始化 LLM 客户端
-// 	var llmClient *llm.Client
-// 	if cfg.LLM.Enabled && cfg.DingTalk.EnableLLMConversation {
-// 		llmConfig := &llm.Config{
-// 			Provider: cfg.LLM.Provider,
-// 			Model:    cfg.LLM.Model,
-// 			APIKey:   cfg.LLM.APIKey,
-// 			BaseURL:  cfg.LLM.BaseURL,
-// 		}
-// 		llmClient = llm.NewClient(llmConfig, mcpServer)
-// 		logx.Info("LLM client initialized provider %s, model %s",
-// 			cfg.LLM.Provider,
-// 			cfg.LLM.Model)
-// 	}
-
-// 	return &MessageHandler{
+// 	h := &MessageHandler{
 // 		client:    client,
 // 		crypto:    crypto,
-// 		parser:    NewIntentParser(),
 // 		mcpServer: mcpServer,
 // 		config:    cfg,
 // 		streamMgr: NewStreamManager(client),
-// 		llmClient: llmClient,
-// 	}, nil
+// 	}
+// 	h.core = bot.NewCore(cfg, mcpServer, bot.Options{
+// 		Platform:       "dingtalk",
+// 		DisplayName:    "钉钉",
+// 		UpdateInterval: 200 * time.Millisecond,
+// 	})
+// 	return h, nil
 // }
 
-// HandleMessage 处理消息
+// HandleMessage 处理消息,立即返回确认消息,查询结果通过流式消息或卡片异步推送
 func (h *MessageHandler) HandleMessage(ctx context.Context, msg *CallbackMessage) (*CallbackResponse, error) {
 	logx.Info("Handling message: sender %s, msg_id %s, conversation_id %s",
 		msg.SenderNick,
@@ -80,213 +66,75 @@ func (h *MessageHandler) HandleMessage(ctx context.Context, msg *CallbackMessage
 		return CreateTextResponse("请输入您的查询内容"), nil
 	}
 
-	logx.Debug("User message %s", userMessage)
-
-	// 特殊命令处理
-	if strings.Contains(userMessage, "帮助") || strings.Contains(userMessage, "help") {
-		return CreateMarkdownResponse("使用帮助", GetHelpMessage()), nil
+	botMsg := &bot.Message{
+		ConversationID: msg.ConversationID,
+		UserID:         msg.SenderStaffID,
+		UserName:       msg.SenderNick,
+		Text:           userMessage,
 	}
 
-	// 如果启用了 LLM,使用 LLM 处理
-	if h.config.LLM.Enabled && h.llmClient != nil {
-		// 如果启用了流式卡片,使用卡片流式交互
-		if h.config.DingTalk.CardTemplateID != "" {
-			go h.processLLMWithStreamCard(ctx, msg, userMessage)
-			return CreateTextResponse("🤖 正在思考中,请稍候..."), nil
+	go func() {
+		if err := h.core.HandleMessage(context.WithoutCancel(ctx), botMsg, &replier{handler: h, msg: msg}); err != nil {
+			logx.Error("Failed to handle DingTalk message: %v", err)
 		}
-		// 否则使用普通流式消息
-		go h.processLLMWithStream(ctx, msg, userMessage)
-		return CreateTextResponse("🤖 正在思考中,请稍候..."), nil
-	}
-
-	// 传统的意图解析模式
-	intent, err := h.parser.Parse(userMessage)
-	if err != nil {
-		logx.Warn("Failed to parse intent: %v", err)
-		return CreateTextResponse(fmt.Sprintf("抱歉,%s\n\n发送\"帮助\"查看使用说明", err.Error())), nil
-	}
-
-	// 立即返回确认消息
-	go h.processQueryAsync(ctx, msg, intent)
+	}()
 
-	return CreateTextResponse(fmt.Sprintf("🔍 正在查询 %s %s,请稍候...", h.getProviderName(intent.Provider), h.getResourceName(intent.Resource))), nil
+	return CreateTextResponse("🤖 正在处理,请稍候..."), nil
 }
 
-// processQueryAsync 异步处理查询
-func (h *MessageHandler) processQueryAsync(ctx context.Context, msg *CallbackMessage, intent *Intent) {
-	logx.Info("Processing query asynchronously mcp_tool %s, params %v",
-		intent.MCPTool,
-		intent.Params)
-
-	// 创建流式推送
-	streamID := fmt.Sprintf("stream_%s_%d", msg.MsgID, time.Now().Unix())
-
-	// 发送进度消息
-	_ = h.streamMgr.Send(ctx, msg.ConversationID, streamID, "⏳ 正在连接服务...\n\n", false)
-
-	// 调用 MCP 工具
-	result, err := h.callMCPTool(ctx, intent)
-	if err != nil {
-		logx.Error("Failed to call MCP tool: %v", err)
-		_ = h.streamMgr.Send(ctx, msg.ConversationID, streamID,
-			fmt.Sprintf("❌ 查询失败: %v", err), true)
-		return
-	}
-
-	// 格式化结果
-	formatted := h.formatResult(intent, result)
-
-	// 流式发送结果
-	_ = h.streamMgr.SendInChunks(ctx, msg.ConversationID, streamID, formatted)
+// replier 钉钉回调模式的回复适配器: 配置了卡片模板时使用 AI 流式卡片,否则使用流式消息
+type replier struct {
+	handler *MessageHandler
+	msg     *CallbackMessage
 }
 
-// callMCPTool 调用 MCP 工具
-func (h *MessageHandler) callMCPTool(ctx context.Context, intent *Intent) (string, error) {
-	logx.Debug("Calling MCP tool: tool %s, params %v",
-		intent.MCPTool,
-		intent.Params)
-
-	// 使用 MCP Server 的 CallTool 方法
-	result, err := h.mcpServer.CallTool(ctx, intent.MCPTool, h.convertParams(intent.Params))
-	if err != nil {
-		return "", fmt.Errorf("failed to call MCP tool: %w", err)
-	}
-
-	// 提取文本结果
-	if len(result.Content) > 0 {
-		if textContent, ok := result.Content[0].(mcp.TextContent); ok {
-			return textContent.Text, nil
-		}
-	}
-
-	return "查询完成,但未返回结果", nil
-}
-
-// convertParams 转换参数为 map[string]any
-func (h *MessageHandler) convertParams(params map[string]string) map[string]any {
-	result := make(map[string]any)
-	for k, v := range params {
-		result[k] = v
-	}
-	return result
-}
-
-// formatResult 格式化查询结果
-func (h *MessageHandler) formatResult(intent *Intent, result string) string {
-	var builder strings.Builder
-
-	// 添加头部
-	builder.WriteString(fmt.Sprintf("✅ **%s %s 查询完成**\n\n",
-		h.getProviderName(intent.Provider),
-		h.getResourceName(intent.Resource)))
-
-	// 添加结果内容
-	builder.WriteString(result)
-
-	// 添加时间戳
-	builder.WriteString(fmt.Sprintf("\n\n---\n⏰ 查询时间: %s",
-		time.Now().Format("2006-01-02 15:04:05")))
-
-	return builder.String()
-}
-
-// getProviderName 获取云平台名称
-func (h *MessageHandler) getProviderName(provider string) string {
-	names := map[string]string{
-		"aliyun":  "阿里云",
-		"tencent": "腾讯云",
-		"jenkins": "Jenkins",
-	}
-	if name, ok := names[provider]; ok {
-		return name
-	}
-	return provider
+// Reply 发送一条完整回复
+func (r *replier) Reply(ctx context.Context, content string) error {
+	streamID := fmt.Sprintf("stream_%s_%d", r.msg.MsgID, time.Now().UnixNano())
+	return r.handler.streamMgr.Send(ctx, r.msg.ConversationID, streamID, content, true)
 }
 
-// getResourceName 获取资源名称
-func (h *MessageHandler) getResourceName(resource string) string {
-	names := map[string]string{
-		"ecs":   "ECS",
-		"rds":   "RDS",
-		"cvm":   "CVM",
-		"cdb":   "CDB",
-		"job":   "Job",
-		"build": "Build",
-	}
-	if name, ok := names[resource]; ok {
-		return name
+// StartStream 优先创建流式卡片,失败时降级为普通流式消息
+func (r *replier) StartStream(ctx context.Context, question string) (bot.Stream, error) {
+	if r.handler.config.DingTalk.CardTemplateID != "" {
+		trackID, err := r.handler.createStreamCard(ctx, r.msg)
+		if err == nil {
+			stream := &cardStream{client: r.handler.client, trackID: trackID, header: fmt.Sprintf("**%s**\n\n", question)}
+			if err := stream.Update(ctx, "正在思考中..."); err != nil {
+				logx.Warn("Failed to update initial card: %v", err)
+			}
+			return stream, nil
+		}
+		logx.Error("Failed to create stream card, fallback to stream message: %v", err)
 	}
-	return resource
-}
-
-// processLLMWithStream 使用普通流式消息处理 LLM 对话
-func (h *MessageHandler) processLLMWithStream(ctx context.Context, msg *CallbackMessage, userMessage string) {
-	logx.Info("Processing LLM with stream: user %s, message %s",
-		msg.SenderNick,
-		userMessage)
-
-	// 创建流式推送
-	streamID := fmt.Sprintf("llm_stream_%s_%d", msg.MsgID, time.Now().Unix())
-
-	// 发送初始消息
-	_ = h.streamMgr.Send(ctx, msg.ConversationID, streamID, "🤖 正在思考...\n\n", false)
 
-	// 调用 LLM 流式对话
-	responseCh, err := h.llmClient.ChatWithToolsAndStream(ctx, userMessage)
-	if err != nil {
-		logx.Error("Failed to call LLM: %v", err)
-		_ = h.streamMgr.Send(ctx, msg.ConversationID, streamID,
-			fmt.Sprintf("❌ LLM 调用失败: %v", err), true)
-		return
+	stream := &messageStream{
+		streamMgr:      r.handler.streamMgr,
+		conversationID: r.msg.ConversationID,
+		streamID:       fmt.Sprintf("llm_stream_%s_%d", r.msg.MsgID, time.Now().UnixNano()),
+		header:         fmt.Sprintf("**问题:** %s\n\n**回答:**\n\n", question),
 	}
-
-	// 累积响应内容
-	var fullResponse strings.Builder
-	fullResponse.WriteString(fmt.Sprintf("**问题:** %s\n\n", userMessage))
-	fullResponse.WriteString("**回答:**\n\n")
-
-	headerLen := fullResponse.Len()
-
-	// 流式接收并发送
-	for content := range responseCh {
-		fullResponse.WriteString(content)
-		// 每接收一定量内容就发送一次更新
-		if fullResponse.Len()-headerLen > 500 {
-			_ = h.streamMgr.Send(ctx, msg.ConversationID, streamID, fullResponse.String(), false)
-		}
+	if err := stream.Update(ctx, "🤖 正在思考..."); err != nil {
+		return nil, err
 	}
-
-	// 发送最终内容
-	fullResponse.WriteString(fmt.Sprintf("\n\n---\n⏰ %s", time.Now().Format("2006-01-02 15:04:05")))
-	_ = h.streamMgr.Send(ctx, msg.ConversationID, streamID, fullResponse.String(), true)
-
-	logx.Info("LLM conversation completed user %s", msg.SenderNick)
+	return stream, nil
 }
 
-// processLLMWithStreamCard 使用流式卡片处理 LLM 对话
-func (h *MessageHandler) processLLMWithStreamCard(ctx context.Context, msg *CallbackMessage, userMessage string) {
-	logx.Info("Processing LLM with stream card: user %s, message %s",
-		msg.SenderNick,
-		userMessage)
-
+// createStreamCard 创建并投放 AI 流式卡片,返回追踪ID
+func (h *MessageHandler) createStreamCard(ctx context.Context, msg *CallbackMessage) (string, error) {
 	// 生成唯一追踪ID
 	trackID := uuid.New().String()
 
 	// 获取访问令牌
 	accessToken, err := h.client.GetAccessToken(ctx)
 	if err != nil {
-		logx.Error("Failed to get access token: %v", err)
-		// 降级为普通流式消息
-		h.processLLMWithStream(ctx, msg, userMessage)
-		return
+		return "", fmt.Errorf("failed to get access token: %w", err)
 	}
 
 	// 创建流式卡片客户端
 	cardClient, err := NewStreamCardClient()
 	if err != nil {
-		logx.Error("Failed to create stream card client: %v", err)
-		h.processLLMWithStream(ctx, msg, userMessage)
-		return
+		return "", fmt.Errorf("failed to create stream card client: %w", err)
 	}
 
 	// 构建 OpenSpaceID
@@ -322,69 +170,42 @@ func (h *MessageHandler) processLLMWithStreamCard(ctx context.Context, msg *Call
 	}
 
 	if err := cardClient.CreateAndDeliverCard(accessToken, createReq); err != nil {
-		logx.Error("Failed to create card: %v", err)
-		// 降级为普通流式消息
-		h.processLLMWithStream(ctx, msg, userMessage)
-		return
+		return "", err
 	}
+	return trackID, nil
+}
 
-	// 发送初始状态
-	initialContent := fmt.Sprintf("**%s**\n\n正在思考中...", userMessage)
-	if err := h.client.UpdateAIStreamCard(trackID, initialContent, false); err != nil {
-		logx.Warn("Failed to update initial card: %v", err)
-	}
-
-	// 调用 LLM 流式对话
-	responseCh, err := h.llmClient.ChatWithToolsAndStream(ctx, userMessage)
-	if err != nil {
-		logx.Error("Failed to call LLM %v", err)
-		errorMsg := fmt.Sprintf("**%s**\n\n❌ 调用失败: %v", userMessage, err)
-		_ = h.client.UpdateAIStreamCardWithError(trackID, errorMsg)
-		return
-	}
-
-	// 构建响应内容
-	questionHeader := fmt.Sprintf("**%s**\n\n", userMessage)
-	fullContent := questionHeader
-
-	// 改进的缓冲机制
-	updateBuffer := ""
-	minUpdateInterval := 200 * time.Millisecond // 减少到200ms,提升响应速度
-	minBufferSize := 5                          // 至少累积5个字符再更新
-
-	ticker := time.NewTicker(minUpdateInterval)
-	defer ticker.Stop()
+// cardStream AI 流式卡片
+type cardStream struct {
+	client  *Client
+	trackID string
+	header  string // 卡片顶部显示的问题
+}
 
-	for {
-		select {
-		case content, ok := <-responseCh:
-			if !ok {
-				// 流结束,发送最终更新
-				if updateBuffer != "" {
-					fullContent += updateBuffer
-				}
-				fullContent += fmt.Sprintf("\n\n---\n⏰ %s", time.Now().Format("2006-01-02 15:04:05"))
-				if err := h.client.UpdateAIStreamCard(trackID, fullContent, true); err != nil {
-					logx.Error("Failed to finalize card: %v", err)
-				}
-				return
-			}
+// Update 更新卡片内容
+func (s *cardStream) Update(_ context.Context, content string) error {
+	return s.client.UpdateAIStreamCard(s.trackID, s.header+content, false)
+}
 
-			// 累积到缓冲区
-			updateBuffer += content
+// Finish 发送最终内容并结束卡片流式更新
+func (s *cardStream) Finish(_ context.Context, content string) error {
+	return s.client.UpdateAIStreamCard(s.trackID, s.header+content, true)
+}
 
-		case <-ticker.C:
-			// 定时检查是否需要更新
-			if updateBuffer != "" && len(updateBuffer) >= minBufferSize {
-				fullContent += updateBuffer
-				updateBuffer = ""
+// messageStream 普通流式消息
+type messageStream struct {
+	streamMgr      *StreamManager
+	conversationID string
+	streamID       string
+	header         string
+}
 
-				// 更新卡片
-				if err := h.client.UpdateAIStreamCard(trackID, fullContent, false); err != nil {
-					logx.Warn("Failed to update card: %v", err)
-				}
-			}
-		}
-	}
+// Update 推送中间内容
+func (s *messageStream) Update(ctx context.Context, content string) error {
+	return s.streamMgr.Send(ctx, s.conversationID, s.streamID, s.header+content, false)
+}
 
+// Finish 推送最终内容
+func (s *messageStream) Finish(ctx context.Context, content string) error {
+	return s.streamMgr.Send(ctx, s.conversationID, s.streamID, s.header+content, true)
 }
@@ -0,0 +1,375 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/cloudaccount"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+// 沿 A/CNAME 记录将主机名解析到托管的云资源,以及按记录值反查解析记录
+// 只查询云解析托管的记录,不发起真实的 DNS 查询
+
+// maxCNAMEDepth CNAME 最大跟随层数
+const maxCNAMEDepth = 8
+
+// cdnSuffixes CDN 分配的 CNAME 后缀
+var cdnSuffixes = []struct {
+	suffix   string
+	provider string
+}{
+	{"alikunlun.com", "aliyun"},
+	{"alikunlun.net", "aliyun"},
+	{"kunlunsl.com", "aliyun"},
+	{"kunlungr.com", "aliyun"},
+	{"kunlunca.com", "aliyun"},
+	{"kunlunea.com", "aliyun"},
+	{"kunlunaq.com", "aliyun"},
+	{"kunlunle.com", "aliyun"},
+	{"cdngslb.com", "aliyun"},
+	{"cdn.dnsv1.com", "tencent"},
+	{"cdn.dnsv1.com.cn", "tencent"},
+	{"dsa.dnsv1.com", "tencent"},
+	{"cdntip.com", "tencent"},
+	{"ovscdns.com", "tencent"},
+	{"tcdn.qq.com", "tencent"},
+}
+
+// clbIDPattern 腾讯云 CLB 域名中的实例 ID,如 lb-abcd1234-xxx.clb.gz-tencentclb.com
+var clbIDPattern = regexp.MustCompile(`^lb-[0-9a-z]{8}`)
+
+// Resolver 在多个账号托管的域名中查找解析记录,并将记录值关联到云资源
+type Resolver struct {
+	accounts []*cloudaccount.Account
+	zones    []*zone // 按域名长度降序排列,首次使用时加载
+	loaded   bool
+	targets  map[string]*model.DNSTarget // 地址 -> 已关联的资源
+}
+
+// zone 托管域名及所属账号
+type zone struct {
+	domain  *model.Domain
+	account *cloudaccount.Account
+}
+
+// NewResolver 创建解析器
+func NewResolver(accounts []*cloudaccount.Account) *Resolver {
+	return &Resolver{
+		accounts: accounts,
+		targets:  make(map[string]*model.DNSTarget),
+	}
+}
+
+// ListDomains 列出所有账号托管的域名,keyword 为空时返回全部
+func (r *Resolver) ListDomains(ctx context.Context, keyword string) ([]*model.Domain, error) {
+	if err := r.loadZones(ctx); err != nil {
+		return nil, err
+	}
+
+	domains := make([]*model.Domain, 0, len(r.zones))
+	for _, z := range r.zones {
+		if keyword == "" || strings.Contains(z.domain.Name, strings.ToLower(keyword)) {
+			domains = append(domains, z.domain)
+		}
+	}
+	sort.Slice(domains, func(i, j int) bool { return domains[i].Name < domains[j].Name })
+	return domains, nil
+}
+
+// ListRecords 列出域名的解析记录,自动识别域名所属账号,filters 支持 rr、type、value
+func (r *Resolver) ListRecords(ctx context.Context, domain string, filters map[string]string) ([]*model.DNSRecord, error) {
+	if err := r.loadZones(ctx); err != nil {
+		return nil, err
+	}
+
+	domain = normalize(domain)
+	for _, z := range r.zones {
+		if z.domain.Name == domain {
+			return r.queryRecords(ctx, z, filters)
+		}
+	}
+	return nil, fmt.Errorf("domain %s is not managed by any configured account", domain)
+}
+
+// Resolve 沿 A/CNAME 记录解析主机名,返回经过的记录和最终指向的资源
+func (r *Resolver) Resolve(ctx context.Context, hostname string) (*model.DNSResolution, error) {
+	if err := r.loadZones(ctx); err != nil {
+		return nil, err
+	}
+
+	host := normalize(hostname)
+	if z, _ := r.findZone(host); z == nil {
+		return nil, fmt.Errorf("%s is not under any managed domain", host)
+	}
+
+	result := &model.DNSResolution{
+		Hostname: host,
+		Records:  []*model.DNSRecord{},
+		Targets:  []*model.DNSTarget{},
+	}
+	if err := r.follow(ctx, result, host, "", 0, make(map[string]bool)); err != nil {
+		return nil, err
+	}
+	if len(result.Records) == 0 {
+		return nil, fmt.Errorf("no enabled A/AAAA/CNAME record found for %s", host)
+	}
+	return result, nil
+}
+
+// ReverseLookup 查找所有账号中记录值为 value 的解析记录,value 通常为 IP,也可以是 CNAME 目标
+func (r *Resolver) ReverseLookup(ctx context.Context, value string) ([]*model.DNSRecord, error) {
+	if err := r.loadZones(ctx); err != nil {
+		return nil, err
+	}
+
+	value = normalize(value)
+	records := make([]*model.DNSRecord, 0)
+	for _, z := range r.zones {
+		items, err := r.queryRecords(ctx, z, map[string]string{"value": value})
+		if err != nil {
+			logx.Warn("Failed to query DNS records, domain %s, account %s, error %v", z.domain.Name, z.account.Name, err)
+			continue
+		}
+		records = append(records, items...)
+	}
+	return records, nil
+}
+
+// follow 查询主机名的记录并继续跟随 CNAME,from 为 CNAME 指向该主机名的来源域名
+func (r *Resolver) follow(ctx context.Context, result *model.DNSResolution, host, from string, depth int, visited map[string]bool) error {
+	if depth > maxCNAMEDepth || visited[host] {
+		logx.Warn("Stop following CNAME, host %s, depth %d", host, depth)
+		return nil
+	}
+	visited[host] = true
+
+	// 指向托管域名之外时按 CNAME 特征识别资源
+	z, rr := r.findZone(host)
+	if z == nil {
+		r.addTarget(result, r.matchCNAME(ctx, host, from))
+		return nil
+	}
+
+	records, err := r.lookup(ctx, z, rr)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 && depth > 0 {
+		r.addTarget(result, &model.DNSTarget{Address: host, Type: model.DNSTargetExternal})
+		return nil
+	}
+
+	for _, record := range records {
+		result.Records = append(result.Records, record)
+		switch strings.ToUpper(record.Type) {
+		case "A", "AAAA":
+			r.addTarget(result, r.matchIP(ctx, record.Value))
+		case "CNAME":
+			if err := r.follow(ctx, result, normalize(record.Value), record.FQDN, depth+1, visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lookup 查询主机记录的 A/AAAA/CNAME 记录,未命中时依次尝试泛解析记录
+func (r *Resolver) lookup(ctx context.Context, z *zone, rr string) ([]*model.DNSRecord, error) {
+	candidates := []string{rr}
+	if rr != "@" {
+		labels := strings.Split(rr, ".")
+		for i := range labels {
+			candidates = append(candidates, strings.Join(append([]string{"*"}, labels[i+1:]...), "."))
+		}
+	}
+
+	for _, candidate := range candidates {
+		records, err := r.queryRecords(ctx, z, map[string]string{"rr": candidate})
+		if err != nil {
+			return nil, err
+		}
+
+		var matched []*model.DNSRecord
+		for _, record := range records {
+			if record.Status == model.DNSRecordDisabled {
+				continue
+			}
+			switch strings.ToUpper(record.Type) {
+			case "A", "AAAA", "CNAME":
+				matched = append(matched, record)
+			}
+		}
+		if len(matched) > 0 {
+			return matched, nil
+		}
+	}
+	return nil, nil
+}
+
+// matchIP 查找 IP 对应的实例或负载均衡
+func (r *Resolver) matchIP(ctx context.Context, ip string) *model.DNSTarget {
+	if target, ok := r.targets[ip]; ok {
+		return target
+	}
+
+	target := &model.DNSTarget{Address: ip, Type: model.DNSTargetExternal}
+	r.targets[ip] = target
+
+	opts := &provider.QueryOptions{Filters: map[string]string{"ip": ip}}
+	for _, acc := range r.accounts {
+		instances, err := acc.Provider.ListInstances(ctx, opts)
+		if err != nil {
+			logx.Warn("Failed to search instance by IP, ip %s, account %s, error %v", ip, acc.Name, err)
+		} else if len(instances) > 0 {
+			inst := instances[0]
+			target.Type = model.DNSTargetInstance
+			target.Provider, target.Account, target.Region = acc.Cloud, acc.Name, inst.Region
+			target.ResourceID, target.ResourceName, target.ConsoleURL = inst.ID, inst.Name, inst.ConsoleURL
+			return target
+		}
+	}
+
+	opts = &provider.QueryOptions{Filters: map[string]string{"address": ip}}
+	for _, acc := range r.accounts {
+		lbs, err := acc.Provider.ListLoadBalancers(ctx, opts)
+		if err != nil {
+			logx.Warn("Failed to search load balancer by IP, ip %s, account %s, error %v", ip, acc.Name, err)
+		} else if len(lbs) > 0 {
+			setLoadBalancer(target, acc, lbs[0])
+			return target
+		}
+	}
+	return target
+}
+
+// matchCNAME 按 CNAME 目标的域名特征识别 ALB/CLB、CDN、对象存储
+func (r *Resolver) matchCNAME(ctx context.Context, host, from string) *model.DNSTarget {
+	if target, ok := r.targets[host]; ok {
+		return target
+	}
+
+	target := &model.DNSTarget{Address: host, Type: model.DNSTargetExternal}
+	r.targets[host] = target
+	label := strings.SplitN(host, ".", 2)[0]
+
+	switch {
+	case strings.HasSuffix(host, ".alb.aliyuncs.com"):
+		r.findLoadBalancer(ctx, target, "aliyun", label)
+	case strings.Contains(host, "tencentclb"):
+		if id := clbIDPattern.FindString(host); id != "" {
+			r.findLoadBalancer(ctx, target, "tencent", id)
+		}
+	case strings.Contains(host, ".oss-") && strings.HasSuffix(host, ".aliyuncs.com"):
+		target.Type, target.Provider, target.ResourceID = model.DNSTargetOSS, "aliyun", label
+	case strings.Contains(host, ".cos.") && strings.HasSuffix(host, ".myqcloud.com"):
+		target.Type, target.Provider, target.ResourceID = model.DNSTargetOSS, "tencent", label
+	default:
+		for _, cdn := range cdnSuffixes {
+			if strings.HasSuffix(host, "."+cdn.suffix) {
+				// CDN 加速域名即为 CNAME 到 CDN 的来源域名
+				target.Type, target.Provider, target.ResourceID = model.DNSTargetCDN, cdn.provider, from
+				break
+			}
+		}
+	}
+	return target
+}
+
+// findLoadBalancer 在指定云平台的账号中查找负载均衡,未找到时仍按负载均衡类型返回实例 ID
+func (r *Resolver) findLoadBalancer(ctx context.Context, target *model.DNSTarget, cloud, lbID string) {
+	target.Type, target.Provider, target.ResourceID = model.DNSTargetLoadBalancer, cloud, lbID
+	for _, acc := range r.accounts {
+		if acc.Cloud != cloud {
+			continue
+		}
+		lb, err := acc.Provider.GetLoadBalancer(ctx, lbID)
+		if err != nil {
+			logx.Debug("Load balancer not found, lb_id %s, account %s, error %v", lbID, acc.Name, err)
+			continue
+		}
+		setLoadBalancer(target, acc, lb)
+		return
+	}
+}
+
+// setLoadBalancer 将负载均衡信息写入解析目标
+func setLoadBalancer(target *model.DNSTarget, acc *cloudaccount.Account, lb *model.LoadBalancer) {
+	target.Type = model.DNSTargetLoadBalancer
+	target.Provider, target.Account, target.Region = acc.Cloud, acc.Name, lb.Region
+	target.ResourceID, target.ResourceName, target.ConsoleURL = lb.ID, lb.Name, lb.ConsoleURL
+}
+
+// addTarget 添加解析目标,多条线路指向同一地址时只保留一个
+func (r *Resolver) addTarget(result *model.DNSResolution, target *model.DNSTarget) {
+	for _, t := range result.Targets {
+		if t.Address == target.Address {
+			return
+		}
+	}
+	result.Targets = append(result.Targets, target)
+}
+
+// loadZones 加载所有账号托管的域名,单个账号失败时记录告警并跳过
+func (r *Resolver) loadZones(ctx context.Context) error {
+	if r.loaded {
+		return nil
+	}
+
+	var lastErr error
+	for _, acc := range r.accounts {
+		domains, err := acc.Provider.ListDomains(ctx, nil)
+		if err != nil {
+			logx.Warn("Failed to list DNS domains, account %s, error %v", acc.Name, err)
+			lastErr = err
+			continue
+		}
+		for _, d := range domains {
+			d.Name = normalize(d.Name)
+			r.zones = append(r.zones, &zone{domain: d, account: acc})
+		}
+	}
+	if len(r.zones) == 0 && lastErr != nil {
+		return fmt.Errorf("failed to list DNS domains: %w", lastErr)
+	}
+
+	// 优先匹配更长的域名,子域名单独托管时以子域名为准
+	sort.SliceStable(r.zones, func(i, j int) bool {
+		return len(r.zones[i].domain.Name) > len(r.zones[j].domain.Name)
+	})
+	r.loaded = true
+	return nil
+}
+
+// findZone 查找主机名所属的托管域名,返回主机记录
+func (r *Resolver) findZone(host string) (*zone, string) {
+	for _, z := range r.zones {
+		name := z.domain.Name
+		if host == name {
+			return z, "@"
+		}
+		if strings.HasSuffix(host, "."+name) {
+			return z, strings.TrimSuffix(host, "."+name)
+		}
+	}
+	return nil, ""
+}
+
+// queryRecords 查询托管域名的解析记录
+func (r *Resolver) queryRecords(ctx context.Context, z *zone, filters map[string]string) ([]*model.DNSRecord, error) {
+	query := map[string]string{"domain": z.domain.Name}
+	for k, v := range filters {
+		query[k] = v
+	}
+	return z.account.Provider.ListDNSRecords(ctx, &provider.QueryOptions{Filters: query})
+}
+
+// normalize 转为小写并去掉末尾的点
+func normalize(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
@@ -0,0 +1,104 @@
+package feishu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/bot"
+	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
+)
+
+// ResultCard 构建带按钮的结果卡片(卡片 JSON 2.0),按钮点击后通过 card.action.trigger 回调
+func ResultCard(content string, buttons []bot.Button) (string, error) {
+	elements := []map[string]any{
+		{
+			"tag":     "markdown",
+			"content": content,
+		},
+	}
+
+	if len(buttons) > 0 {
+		columns := make([]map[string]any, 0, len(buttons))
+		for _, b := range buttons {
+			buttonType := "default"
+			if b.Danger {
+				buttonType = "danger"
+			}
+			columns = append(columns, map[string]any{
+				"tag":   "column",
+				"width": "auto",
+				"elements": []map[string]any{
+					{
+						"tag":  "button",
+						"type": buttonType,
+						"text": map[string]any{
+							"tag":     "plain_text",
+							"content": b.Text,
+						},
+						"behaviors": []map[string]any{
+							{
+								"type": "callback",
+								"value": map[string]any{
+									"action_id": b.ActionID,
+									"value":     b.Value,
+								},
+							},
+						},
+					},
+				},
+			})
+		}
+		elements = append(elements, map[string]any{
+			"tag":                "column_set",
+			"horizontal_spacing": "8px",
+			"columns":            columns,
+		})
+	}
+
+	card := map[string]any{
+		"schema": "2.0",
+		"config": map[string]any{
+			// 卡片更新后所有人可见
+			"update_multi": true,
+		},
+		"header": map[string]any{
+			"title": map[string]any{
+				"content": "ZenOps",
+				"tag":     "plain_text",
+			},
+		},
+		"body": map[string]any{
+			"elements": elements,
+		},
+	}
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal result card: %w", err)
+	}
+	return string(data), nil
+}
+
+// PatchCard 更新已发送的卡片消息,用于按钮回调后原地更新
+func (c *Client) PatchCard(ctx context.Context, messageID, cardContent string) error {
+	req := larkim.NewPatchMessageReqBuilder().
+		MessageId(messageID).
+		Body(larkim.NewPatchMessageReqBodyBuilder().
+			Content(cardContent).
+			Build()).
+		Build()
+
+	resp, err := c.client.Im.Message.Patch(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to patch card message: %w", err)
+	}
+
+	if !resp.Success() {
+		return fmt.Errorf("failed to patch card message: code=%d, msg=%s", resp.Code, resp.Msg)
+	}
+
+	logx.Debug("Patched card message %s", messageID)
+	return nil
+}
@@ -4,13 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/bot"
 	"github.com/eryajf/zenops/internal/config"
 	"github.com/eryajf/zenops/internal/imcp"
-	"github.com/eryajf/zenops/internal/llm"
+	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher/callback"
 	larkcontact "github.com/larksuite/oapi-sdk-go/v3/service/contact/v3"
 	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
 )
@@ -20,30 +20,24 @@ type MessageHandler struct {
 	client    *Client
 	config    *config.Config
 	mcpServer *imcp.MCPServer
-	llmClient *llm.Client
+	core      *bot.Core
 }
 
 // NewMessageHandler 创建消息处理器
 func NewMessageHandler(cfg *config.Config, mcpServer *imcp.MCPServer) (*MessageHandler, error) {
 	client := NewClient(cfg.Feishu.AppID, cfg.Feishu.AppSecret)
 
-	// 初始化 LLM 客户端
-	var llmClient *llm.Client
-	if cfg.LLM.Enabled {
-		llmConfig := &llm.Config{
-			Model:   cfg.LLM.Model,
-			APIKey:  cfg.LLM.APIKey,
-			BaseURL: cfg.LLM.BaseURL,
-		}
-		llmClient = llm.NewClient(llmConfig, mcpServer)
-		logx.Info("LLM client initialized for Feishu, model %s", cfg.LLM.Model)
-	}
+	core := bot.NewCore(cfg, mcpServer, bot.Options{
+		Platform:       "feishu",
+		DisplayName:    "飞书",
+		UpdateInterval: 300 * time.Millisecond, // 每 300ms 更新一次卡片
+	})
 
 	return &MessageHandler{
 		client:    client,
 		config:    cfg,
 		mcpServer: mcpServer,
-		llmClient: llmClient,
+		core:      core,
 	}, nil
 }
 
@@ -61,133 +55,162 @@ func (h *MessageHandler) HandleTextMessage(ctx context.Context, event *larkim.P2
 		return err
 	}
 
-	userMessage := strings.TrimSpace(content.Text)
-	if userMessage == "" {
-		return nil
+	msg := &bot.Message{
+		ConversationID: *event.Event.Message.ChatId,
+		UserID:         *event.Event.Sender.SenderId.OpenId,
+		Text:           content.Text,
 	}
 
-	logx.Info("Received message from Feishu: user %s, message %s",
-		*event.Event.Sender.SenderId.OpenId,
-		userMessage)
+	return h.core.HandleMessage(ctx, msg, h.newReplier(event))
+}
 
-	// 特殊命令处理
-	if strings.Contains(userMessage, "帮助") || strings.Contains(userMessage, "help") {
-		return h.sendHelpMessage(ctx, event)
+// HandleCardAction 处理卡片按钮回调,立即返回提示,处理结果原地更新按钮所在的卡片
+func (h *MessageHandler) HandleCardAction(ctx context.Context, event *callback.CardActionTriggerEvent) (*callback.CardActionTriggerResponse, error) {
+	if event.Event == nil || event.Event.Action == nil || event.Event.Context == nil {
+		return nil, nil
 	}
 
-	// 如果启用了 LLM,使用 LLM 处理
-	if h.config.LLM.Enabled && h.llmClient != nil {
-		return h.processLLMMessage(ctx, event, userMessage)
+	actionID, _ := event.Event.Action.Value["action_id"].(string)
+	value, _ := event.Event.Action.Value["value"].(string)
+	if actionID == "" {
+		return nil, nil
 	}
 
-	// 否则返回默认消息
-	receiveIDType := "open_id"
-	receiveID := *event.Event.Sender.SenderId.OpenId
-	if *event.Event.Message.ChatType == "group" {
-		receiveIDType = "chat_id"
-		receiveID = *event.Event.Message.ChatId
+	chatID := event.Event.Context.OpenChatID
+	action := &bot.Action{
+		Message:  bot.Message{ConversationID: chatID},
+		ActionID: actionID,
+		Value:    value,
+	}
+	if event.Event.Operator != nil {
+		action.UserID = event.Event.Operator.OpenID
+	}
+
+	r := &cardUpdater{
+		replier:   replier{client: h.client, receiveIDType: "chat_id", receiveID: chatID},
+		messageID: event.Event.Context.OpenMessageID,
 	}
 
-	return h.client.SendTextMessage(ctx, receiveIDType, receiveID,
-		"ZenOps 飞书机器人已收到您的消息。当前未启用 LLM 对话功能,请联系管理员配置。")
+	// 回调需在 3 秒内响应,工具调用异步执行
+	go func() {
+		if err := h.core.HandleAction(context.WithoutCancel(ctx), action, r); err != nil {
+			logx.Error("Failed to handle Feishu card action: %v", err)
+		}
+	}()
+
+	return &callback.CardActionTriggerResponse{
+		Toast: &callback.Toast{Type: "info", Content: "正在处理..."},
+	}, nil
 }
 
-// processLLMMessage 使用 LLM 处理消息(流式卡片更新)
-func (h *MessageHandler) processLLMMessage(ctx context.Context, event *larkim.P2MessageReceiveV1, userMessage string) error {
-	receiveIDType := "open_id"
-	receiveID := *event.Event.Sender.SenderId.OpenId
+// newReplier 群聊回复到群,私聊回复给发送者
+func (h *MessageHandler) newReplier(event *larkim.P2MessageReceiveV1) *replier {
+	r := &replier{
+		client:        h.client,
+		receiveIDType: "open_id",
+		receiveID:     *event.Event.Sender.SenderId.OpenId,
+	}
 	if *event.Event.Message.ChatType == "group" {
-		receiveIDType = "chat_id"
-		receiveID = *event.Event.Message.ChatId
+		r.receiveIDType = "chat_id"
+		r.receiveID = *event.Event.Message.ChatId
 	}
+	return r
+}
+
+// replier 飞书回复适配器
+type replier struct {
+	client        *Client
+	receiveIDType string
+	receiveID     string
+}
 
-	// 调用 LLM 流式对话
-	responseCh, err := h.llmClient.ChatWithToolsAndStream(ctx, userMessage)
+// Reply 发送富文本消息
+func (r *replier) Reply(ctx context.Context, content string) error {
+	_, err := r.client.SendMarkdownMessage(ctx, r.receiveIDType, r.receiveID, "ZenOps", content)
+	return err
+}
+
+// SupportsActions 飞书消息卡片始终支持按钮
+func (r *replier) SupportsActions() bool {
+	return true
+}
+
+// ReplyWithActions 发送带按钮的结果卡片
+func (r *replier) ReplyWithActions(ctx context.Context, content string, buttons []bot.Button) error {
+	card, err := ResultCard(content, buttons)
 	if err != nil {
-		logx.Error("Failed to call LLM: %v", err)
-		return h.client.SendTextMessage(ctx, receiveIDType, receiveID,
-			fmt.Sprintf("LLM 调用失败: %v", err))
+		return err
 	}
+	return r.client.SendInteractiveCard(ctx, r.receiveIDType, r.receiveID, card)
+}
 
-	// 创建流式卡片
-	// 标题显示问题
-	cardTitle := fmt.Sprintf("问题: %s", userMessage)
-	// 内容从"回答:"开始
-	answerHeader := "**回答:**\n\n"
-	initialContent := answerHeader + "正在思考中..."
+// cardUpdater 按钮回调的回复适配器: 结果原地更新按钮所在的卡片,流式回复(重新生成)发送新卡片
+type cardUpdater struct {
+	replier
+	messageID string
+}
 
-	// 添加时间戳到 context
-	ctxWithTimestamp := context.WithValue(ctx, "timestamp", time.Now().UnixNano())
+// Reply 原地更新卡片,不带按钮
+func (u *cardUpdater) Reply(ctx context.Context, content string) error {
+	return u.ReplyWithActions(ctx, content, nil)
+}
 
-	cardID, err := h.client.CreateStreamingCard(ctxWithTimestamp, cardTitle, initialContent)
+// ReplyWithActions 原地更新卡片
+func (u *cardUpdater) ReplyWithActions(ctx context.Context, content string, buttons []bot.Button) error {
+	card, err := ResultCard(content, buttons)
 	if err != nil {
-		logx.Error("Failed to create streaming card: %v", err)
-		return h.client.SendTextMessage(ctx, receiveIDType, receiveID,
-			fmt.Sprintf("创建卡片失败: %v", err))
+		return err
 	}
+	return u.client.PatchCard(ctx, u.messageID, card)
+}
+
+// answerHeader 卡片内容从"回答:"开始,标题显示问题
+const answerHeader = "**回答:**\n\n"
 
-	// 发送卡片消息
-	_, err = h.client.SendCardMessage(ctx, receiveIDType, receiveID, cardID)
+// StartStream 创建并发送流式卡片
+func (r *replier) StartStream(ctx context.Context, question string) (bot.Stream, error) {
+	s := &cardStream{
+		client:    r.client,
+		timestamp: time.Now().UnixNano(),
+	}
+
+	cardID, err := r.client.CreateStreamingCard(s.withTimestamp(ctx), fmt.Sprintf("问题: %s", question), answerHeader+"正在思考中...")
 	if err != nil {
-		logx.Error("Failed to send card message: %v", err)
-		return err
+		logx.Error("Failed to create streaming card: %v", err)
+		return nil, err
 	}
+	s.cardID = cardID
 
-	// 流式接收并更新卡片
-	var fullResponse strings.Builder
-	fullResponse.WriteString(answerHeader)
-
-	updateTicker := time.NewTicker(300 * time.Millisecond) // 每 300ms 更新一次
-	defer updateTicker.Stop()
-
-	sequence := 0
-	lastUpdate := ""
-
-	for {
-		select {
-		case content, ok := <-responseCh:
-			if !ok {
-				// 流结束,发送最终更新
-				finalContent := fullResponse.String()
-				finalContent += fmt.Sprintf("\n\n---\n⏰ *%s*", time.Now().Format("2006-01-02 15:04:05"))
-				sequence++
-				if err := h.client.UpdateCardElement(ctxWithTimestamp, cardID, "markdown_content", finalContent, sequence); err != nil {
-					logx.Error("Failed to send final update: %v", err)
-				}
-				return nil
-			}
-			fullResponse.WriteString(content)
-
-		case <-updateTicker.C:
-			// 定时更新卡片
-			currentContent := fullResponse.String()
-			if currentContent != lastUpdate && len(currentContent) > len(answerHeader) {
-				sequence++
-				if err := h.client.UpdateCardElement(ctxWithTimestamp, cardID, "markdown_content", currentContent, sequence); err != nil {
-					logx.Warn("Failed to update card element: %v", err)
-				} else {
-					lastUpdate = currentContent
-				}
-			}
-
-		case <-ctx.Done():
-			return ctx.Err()
-		}
+	if _, err := r.client.SendCardMessage(ctx, r.receiveIDType, r.receiveID, cardID); err != nil {
+		logx.Error("Failed to send card message: %v", err)
+		return nil, err
 	}
+
+	return s, nil
 }
 
-// sendHelpMessage 发送帮助消息
-func (h *MessageHandler) sendHelpMessage(ctx context.Context, event *larkim.P2MessageReceiveV1) error {
-	receiveIDType := "open_id"
-	receiveID := *event.Event.Sender.SenderId.OpenId
-	if *event.Event.Message.ChatType == "group" {
-		receiveIDType = "chat_id"
-		receiveID = *event.Event.Message.ChatId
-	}
+// cardStream 飞书流式卡片
+type cardStream struct {
+	client    *Client
+	cardID    string
+	timestamp int64 // 与 sequence 组合为更新请求的唯一 ID
+	sequence  int
+}
 
-	helpText := GetHelpMessage()
-	_, err := h.client.SendMarkdownMessage(ctx, receiveIDType, receiveID, "使用帮助", helpText)
-	return err
+// withTimestamp 将时间戳写入 context,UpdateCardElement 用它生成请求 ID
+func (s *cardStream) withTimestamp(ctx context.Context) context.Context {
+	return context.WithValue(ctx, "timestamp", s.timestamp)
+}
+
+// Update 更新卡片内容
+func (s *cardStream) Update(ctx context.Context, content string) error {
+	s.sequence++
+	return s.client.UpdateCardElement(s.withTimestamp(ctx), s.cardID, "markdown_content", answerHeader+content, s.sequence)
+}
+
+// Finish 发送最终内容
+func (s *cardStream) Finish(ctx context.Context, content string) error {
+	return s.Update(ctx, content)
 }
 
 // GetUserInfo 获取用户信息
@@ -208,35 +231,3 @@ func (h *MessageHandler) GetUserInfo(ctx context.Context, userOpenID string) (*l
 
 	return resp.Data.User, nil
 }
-
-// GetHelpMessage 获取帮助信息
-func GetHelpMessage() string {
-	return `# ZenOps 飞书机器人使用指南
-
-## 功能说明
-ZenOps 是一个运维工具集成平台,支持通过飞书机器人与云平台交互。
-
-## 支持的功能
-
-### 1. LLM 智能对话
-直接发送问题,机器人会通过 AI 大模型为您解答。
-
-示例:
-- "帮我查询阿里云 ECS 列表"
-- "列出腾讯云的 CVM 实例"
-- "查看 Jenkins 最近的构建任务"
-
-### 2. 云平台查询
-支持查询以下云平台资源:
-- 阿里云: ECS、RDS 等
-- 腾讯云: CVM、CDB 等
-- Jenkins: 构建任务、Job 状态等
-
-## 使用提示
-- 发送 "帮助" 或 "help" 查看此帮助信息
-- 私聊或在群里 @机器人 都可以使用
-
-## 技术支持
-如有问题,请联系运维团队。
-`
-}
@@ -0,0 +1,341 @@
+// Package idle 基于云监控指标找出长期低负载的实例和数据库,并附带上月费用用于降配评估
+package idle
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/cloudaccount"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/cost"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+const (
+	// maxDays 按小时粒度查询,单次查询不超过 1440 个数据点
+	maxDays = 30
+
+	// metricPeriod 指标数据点间隔(秒),CPU 峰值为小时平均值的最大值
+	metricPeriod = 3600
+
+	// concurrency 单个账号并发查询指标的资源数,避免触发云监控限流
+	// 各区域客户端按 endpoint 缓存的通用 API 客户端已加锁,可以并发调用
+	concurrency = 4
+
+	// listPageSize 列出实例和数据库时的分页大小
+	listPageSize = 100
+)
+
+// Detector 查询多个账号的闲置资源
+type Detector struct {
+	accounts []*cloudaccount.Account
+	cfg      config.IdleConfig
+}
+
+// NewDetector 创建闲置资源检测器
+func NewDetector(accounts []*cloudaccount.Account, cfg config.IdleConfig) *Detector {
+	return &Detector{accounts: accounts, cfg: cfg}
+}
+
+// Load 按 cloud、account 加载账号并创建闲置资源检测器
+func Load(cfg *config.Config, cloud, account string) (*Detector, error) {
+	accounts, err := cloudaccount.Load(cfg, cloud, account)
+	if err != nil {
+		return nil, err
+	}
+	return NewDetector(accounts, cfg.Idle), nil
+}
+
+// resource 待检测的实例或数据库
+type resource struct {
+	typ        string
+	id         string
+	name       string
+	region     string
+	spec       string
+	consoleURL string
+}
+
+// Detect 检测最近 days 天内的闲置资源,resourceType 为 instance 或 database,为空时全部检测
+// days <= 0 时使用配置的天数;单个账号或资源查询失败时记录告警并跳过,结果按月费用降序排列
+func (d *Detector) Detect(ctx context.Context, resourceType string, days int) ([]*model.IdleResource, error) {
+	if resourceType != "" && resourceType != model.MetricResourceInstance && resourceType != model.MetricResourceDatabase {
+		return nil, fmt.Errorf("unsupported type %q, must be instance or database", resourceType)
+	}
+	if days <= 0 {
+		days = d.cfg.Days
+	}
+	if days > maxDays {
+		return nil, fmt.Errorf("days must not exceed %d", maxDays)
+	}
+
+	end := time.Now().Truncate(time.Hour)
+	start := end.AddDate(0, 0, -days)
+
+	result := make([]*model.IdleResource, 0)
+	for _, acc := range d.accounts {
+		resources, err := listResources(ctx, acc, resourceType)
+		if err != nil {
+			logx.Warn("Failed to list resources for idle detection, cloud %s, account %s, error %v", acc.Cloud, acc.Name, err)
+			continue
+		}
+
+		idle := d.detectAccount(ctx, acc, resources, start, end, days)
+		if len(idle) > 0 {
+			attachCost(ctx, acc, resources, idle)
+		}
+		result = append(result, idle...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return costOf(result[i]) > costOf(result[j])
+	})
+	return result, nil
+}
+
+// detectAccount 并发查询账号下资源的指标,返回判定为闲置的资源
+func (d *Detector) detectAccount(ctx context.Context, acc *cloudaccount.Account, resources []*resource, start, end time.Time, days int) []*model.IdleResource {
+	results := make([]*model.IdleResource, len(resources))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, r := range resources {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+
+			series, err := acc.Provider.GetMetrics(ctx, &provider.MetricQuery{
+				ResourceType: r.typ,
+				ResourceID:   r.id,
+				Region:       r.region,
+				Metrics:      []string{model.MetricCPU, model.MetricMemory, model.MetricNetworkIn, model.MetricNetworkOut},
+				Start:        start,
+				End:          end,
+				Period:       metricPeriod,
+			})
+			if err != nil {
+				logx.Warn("Failed to get metrics, account %s, resource %s, error %v", acc.Name, r.id, err)
+				return
+			}
+			results[i] = d.evaluate(acc, r, series, days)
+		}()
+	}
+	wg.Wait()
+
+	idle := make([]*model.IdleResource, 0)
+	for _, r := range results {
+		if r != nil {
+			idle = append(idle, r)
+		}
+	}
+	return idle
+}
+
+// evaluate 判断资源是否闲置,没有 CPU 数据的资源(如刚创建或已停机)不判定
+func (d *Detector) evaluate(acc *cloudaccount.Account, r *resource, series []*model.MetricSeries, days int) *model.IdleResource {
+	metrics := make(map[string]*model.MetricSeries, len(series))
+	for _, s := range series {
+		metrics[s.Metric] = s
+	}
+
+	cpu, ok := metrics[model.MetricCPU]
+	if !ok {
+		return nil
+	}
+	cpuAvg, ok := cpu.Avg()
+	if !ok {
+		return nil
+	}
+	cpuMax, _ := cpu.Max()
+	if cpuAvg >= d.cfg.CPUPercent || cpuMax >= d.cfg.CPUPeakPercent {
+		return nil
+	}
+
+	var network float64
+	for _, name := range []string{model.MetricNetworkIn, model.MetricNetworkOut} {
+		if s, ok := metrics[name]; ok {
+			if avg, ok := s.Avg(); ok {
+				network += avg / 1000
+			}
+		}
+	}
+	if network >= d.cfg.NetworkKbps {
+		return nil
+	}
+
+	item := &model.IdleResource{
+		Type:        r.typ,
+		ID:          r.id,
+		Name:        r.name,
+		Provider:    acc.Cloud,
+		Account:     acc.Name,
+		Region:      r.region,
+		Spec:        r.spec,
+		Days:        days,
+		CPUAvg:      cpuAvg,
+		CPUMax:      cpuMax,
+		NetworkKbps: network,
+		Suggestion:  model.IdleSuggestDownscale,
+		ConsoleURL:  r.consoleURL,
+	}
+	if s, ok := metrics[model.MetricMemory]; ok {
+		if avg, ok := s.Avg(); ok {
+			if avg >= d.cfg.MemoryPercent {
+				return nil
+			}
+			item.MemoryAvg = &avg
+		}
+	}
+	// 峰值也未超过平均值阈值,说明资源基本未被使用
+	if cpuMax < d.cfg.CPUPercent {
+		item.Suggestion = model.IdleSuggestRelease
+	}
+	return item
+}
+
+// listResources 列出账号下运行中的实例和 RDS/CDB 数据库
+func listResources(ctx context.Context, acc *cloudaccount.Account, resourceType string) ([]*resource, error) {
+	var resources []*resource
+	if resourceType != model.MetricResourceDatabase {
+		instances, err := listPages(func(opts *provider.QueryOptions) ([]*model.Instance, error) {
+			return acc.Provider.ListInstances(ctx, opts)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, inst := range instances {
+			if !strings.EqualFold(inst.Status, "running") {
+				continue
+			}
+			resources = append(resources, &resource{
+				typ: model.MetricResourceInstance, id: inst.ID, name: inst.Name,
+				region: inst.Region, spec: inst.InstanceType, consoleURL: inst.ConsoleURL,
+			})
+		}
+	}
+	if resourceType != model.MetricResourceInstance {
+		databases, err := listPages(func(opts *provider.QueryOptions) ([]*model.Database, error) {
+			return acc.Provider.ListDatabases(ctx, opts)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, db := range databases {
+			resources = append(resources, &resource{
+				typ: model.MetricResourceDatabase, id: db.ID, name: db.Name,
+				region: db.Region, spec: strings.TrimSpace(db.Engine + " " + db.EngineVersion), consoleURL: db.ConsoleURL,
+			})
+		}
+	}
+	return resources, nil
+}
+
+// listPages 逐页查询直到返回数量小于分页大小
+func listPages[T any](list func(*provider.QueryOptions) ([]T, error)) ([]T, error) {
+	var all []T
+	for page := 1; ; page++ {
+		items, err := list(&provider.QueryOptions{PageSize: listPageSize, PageNum: page})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) < listPageSize {
+			return all, nil
+		}
+	}
+}
+
+// attachCost 附加上月费用: 优先使用该资源的账单,没有账单的实例按同规格实例的平均费用估算
+// 账单查询失败时记录告警,不影响检测结果
+func attachCost(ctx context.Context, acc *cloudaccount.Account, resources []*resource, idle []*model.IdleResource) {
+	cycle, _ := cost.Cycle(cost.PeriodLast, time.Now())
+	items, err := cost.NewReporter([]*cloudaccount.Account{acc}).Items(ctx, cycle)
+	if err != nil {
+		logx.Warn("Failed to get cost for idle resources, account %s, error %v", acc.Name, err)
+		return
+	}
+
+	bills := make(map[string]*model.CostItem)
+	for _, item := range items {
+		if item.InstanceID != "" {
+			if bill, ok := bills[item.InstanceID]; ok {
+				bill.Amount += item.Amount
+			} else {
+				copied := *item
+				bills[item.InstanceID] = &copied
+			}
+		}
+	}
+
+	// 同规格实例的平均费用
+	type specCost struct {
+		total float64
+		count int
+	}
+	specs := make(map[string]*specCost)
+	for _, r := range resources {
+		bill, ok := bills[r.id]
+		if !ok || r.typ != model.MetricResourceInstance || r.spec == "" {
+			continue
+		}
+		if specs[r.spec] == nil {
+			specs[r.spec] = &specCost{}
+		}
+		specs[r.spec].total += bill.Amount
+		specs[r.spec].count++
+	}
+
+	for _, item := range idle {
+		if bill, ok := bills[item.ID]; ok {
+			amount := bill.Amount
+			item.MonthlyCost, item.CostSource, item.Currency = &amount, model.IdleCostBill, bill.Currency
+			continue
+		}
+		if sc, ok := specs[item.Spec]; ok && item.Type == model.MetricResourceInstance {
+			amount := sc.total / float64(sc.count)
+			item.MonthlyCost, item.CostSource, item.Currency = &amount, model.IdleCostEstimate, "CNY"
+		}
+	}
+}
+
+// costOf 资源的月费用,未知时为 0
+func costOf(item *model.IdleResource) float64 {
+	if item.MonthlyCost == nil {
+		return 0
+	}
+	return *item.MonthlyCost
+}
+
+// Describe 单行描述闲置资源,用于列表展示
+func Describe(item *model.IdleResource) string {
+	var sb strings.Builder
+	sb.WriteString(item.ID)
+	if item.Name != "" && item.Name != item.ID {
+		sb.WriteString(" (" + item.Name + ")")
+	}
+	sb.WriteString(fmt.Sprintf(" | %s/%s %s | %s", item.Provider, item.Account, item.Region, item.Spec))
+	sb.WriteString(fmt.Sprintf(" | CPU 平均 %.1f%% 峰值 %.1f%%", item.CPUAvg, item.CPUMax))
+	if item.MemoryAvg != nil {
+		sb.WriteString(fmt.Sprintf(" | 内存 %.1f%%", *item.MemoryAvg))
+	}
+	sb.WriteString(fmt.Sprintf(" | 流量 %.1f Kbps", item.NetworkKbps))
+	if item.MonthlyCost != nil {
+		label := "上月费用"
+		if item.CostSource == model.IdleCostEstimate {
+			label = "估算月费用"
+		}
+		sb.WriteString(fmt.Sprintf(" | %s %.2f %s", label, *item.MonthlyCost, item.Currency))
+	}
+	if item.Suggestion == model.IdleSuggestRelease {
+		sb.WriteString(" | 建议释放")
+	} else {
+		sb.WriteString(" | 建议降配")
+	}
+	return sb.String()
+}
@@ -0,0 +1,100 @@
+package idle
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eryajf/zenops/internal/cloudaccount"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+// fakeProvider 返回固定的实例、指标和账单,记录 GetMetrics 的最大并发数
+type fakeProvider struct {
+	provider.Provider
+
+	instances []*model.Instance
+	cpu       map[string]float64
+	bills     []*model.CostItem
+
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (p *fakeProvider) ListInstances(ctx context.Context, opts *provider.QueryOptions) ([]*model.Instance, error) {
+	return p.instances, nil
+}
+
+func (p *fakeProvider) ListDatabases(ctx context.Context, opts *provider.QueryOptions) ([]*model.Database, error) {
+	return nil, nil
+}
+
+func (p *fakeProvider) ListCostItems(ctx context.Context, opts *provider.QueryOptions) ([]*model.CostItem, error) {
+	return p.bills, nil
+}
+
+func (p *fakeProvider) GetMetrics(ctx context.Context, query *provider.MetricQuery) ([]*model.MetricSeries, error) {
+	n := p.inFlight.Add(1)
+	defer p.inFlight.Add(-1)
+	for {
+		peak := p.peak.Load()
+		if n <= peak || p.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	cpu, ok := p.cpu[query.ResourceID]
+	if !ok {
+		return nil, fmt.Errorf("instance %s not found", query.ResourceID)
+	}
+	point := model.MetricPoint{Timestamp: query.Start, Value: cpu}
+	return []*model.MetricSeries{
+		{Metric: model.MetricCPU, Unit: model.MetricUnitPercent, Points: []model.MetricPoint{point}},
+	}, nil
+}
+
+func TestDetect(t *testing.T) {
+	p := &fakeProvider{cpu: map[string]float64{}}
+	for i := 0; i < 12; i++ {
+		id := fmt.Sprintf("i-%02d", i)
+		p.instances = append(p.instances, &model.Instance{ID: id, Region: "cn-hangzhou", Status: "Running", InstanceType: "ecs.g6.large"})
+		// 偶数实例闲置,奇数实例繁忙
+		p.cpu[id] = 1
+		if i%2 == 1 {
+			p.cpu[id] = 60
+		}
+	}
+	p.bills = []*model.CostItem{{InstanceID: "i-00", Amount: 300, Currency: "CNY"}, {InstanceID: "i-01", Amount: 100, Currency: "CNY"}}
+
+	d := NewDetector([]*cloudaccount.Account{{Cloud: "aliyun", Name: "test", Provider: p}},
+		config.IdleConfig{Days: 7, CPUPercent: 5, CPUPeakPercent: 20, MemoryPercent: 20, NetworkKbps: 100})
+
+	list, err := d.Detect(context.Background(), model.MetricResourceInstance, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 6 {
+		t.Fatalf("expected 6 idle instances, got %d", len(list))
+	}
+	if peak := p.peak.Load(); peak > concurrency {
+		t.Fatalf("expected at most %d concurrent metric queries, got %d", concurrency, peak)
+	}
+
+	first := list[0]
+	if first.ID != "i-00" || first.CostSource != model.IdleCostBill || *first.MonthlyCost != 300 {
+		t.Fatalf("expected billed instance i-00 first, got %+v", first)
+	}
+	if first.Suggestion != model.IdleSuggestRelease {
+		t.Fatalf("expected release suggestion, got %s", first.Suggestion)
+	}
+	// 没有账单的实例按同规格实例的平均费用估算
+	if list[1].CostSource != model.IdleCostEstimate || *list[1].MonthlyCost != 200 {
+		t.Fatalf("expected estimated cost 200, got %+v", list[1])
+	}
+}
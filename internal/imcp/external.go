@@ -83,16 +83,11 @@ func (s *MCPServer) registerProxyTool(ctx context.Context, mcpClient *mcpclient.
 
 	// 创建代理处理函数
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// 转发请求到外部 MCP
-		proxyReq := mcp.CallToolRequest{}
-		proxyReq.Params.Name = originalToolName // 使用原始工具名
-		proxyReq.Params.Arguments = request.Params.Arguments
-
 		logx.Debug("🔄 Proxy call: %s -> %s.%s",
 			toolName, clientName, originalToolName)
 
-		// 调用外部 MCP
-		result, err := mcpClient.Client.CallTool(ctx, proxyReq)
+		// 转发请求到外部 MCP (使用原始工具名)
+		result, err := mcpClient.CallTool(ctx, originalToolName, request.Params.Arguments)
 		if err != nil {
 			logx.Error("❌ Proxy call failed: %s -> %s.%s: %v",
 				toolName, clientName, originalToolName, err)
@@ -35,11 +35,12 @@ func (s *MCPServer) handleSearchECSByIP(ctx context.Context, request mcp.CallToo
 	// 使用增强的 IP 查询功能
 	instance, err := client.GetECSInstanceByIP(ctx, ip, ipType)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("未找到 IP 为 %s 的 ECS 实例: %v", ip, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("未找到 IP 为 %s 的 ECS 实例: %v", ip, err)), nil
 	}
 
-	result := formatInstances([]*model.Instance{instance}, aliyunConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	items := []*model.Instance{instance}
+	result := formatInstances(ctx, items, aliyunConfig.Name)
+	return newListResult(result, items, map[string]any{"account": aliyunConfig.Name}), nil
 }
 
 // handleSearchECSByName 处理根据名称搜索 ECS 的请求
@@ -65,11 +66,12 @@ func (s *MCPServer) handleSearchECSByName(ctx context.Context, request mcp.CallT
 	// 使用增强的名称查询功能
 	instance, err := client.GetECSInstanceByName(ctx, name)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("未找到名称为 %s 的 ECS 实例: %v", name, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("未找到名称为 %s 的 ECS 实例: %v", name, err)), nil
 	}
 
-	result := formatInstances([]*model.Instance{instance}, aliyunConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	items := []*model.Instance{instance}
+	result := formatInstances(ctx, items, aliyunConfig.Name)
+	return newListResult(result, items, map[string]any{"account": aliyunConfig.Name}), nil
 }
 
 // handleListECS 处理列出 ECS 实例的请求
@@ -116,8 +118,8 @@ func (s *MCPServer) handleListECS(ctx context.Context, request mcp.CallToolReque
 		pageNum++
 	}
 
-	result := formatInstances(allInstances, aliyunConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	result := formatInstances(ctx, allInstances, aliyunConfig.Name)
+	return newListResult(result, allInstances, map[string]any{"account": aliyunConfig.Name}), nil
 }
 
 // handleGetECS 处理获取 ECS 实例详情的请求
@@ -141,11 +143,12 @@ func (s *MCPServer) handleGetECS(ctx context.Context, request mcp.CallToolReques
 
 	instance, err := p.GetInstance(ctx, instanceID)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("未找到实例 ID 为 %s 的 ECS 实例: %v", instanceID, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("未找到实例 ID 为 %s 的 ECS 实例: %v", instanceID, err)), nil
 	}
 
-	result := formatInstances([]*model.Instance{instance}, aliyunConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	items := []*model.Instance{instance}
+	result := formatInstances(ctx, items, aliyunConfig.Name)
+	return newListResult(result, items, map[string]any{"account": aliyunConfig.Name}), nil
 }
 
 // handleListRDS 处理列出 RDS 实例的请求
@@ -188,8 +191,8 @@ func (s *MCPServer) handleListRDS(ctx context.Context, request mcp.CallToolReque
 		pageNum++
 	}
 
-	result := formatDatabases(allDatabases, aliyunConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	result := formatDatabases(ctx, allDatabases, aliyunConfig.Name)
+	return newListResult(result, allDatabases, map[string]any{"account": aliyunConfig.Name}), nil
 }
 
 // handleSearchRDSByName 处理根据名称搜索 RDS 的请求
@@ -240,9 +243,9 @@ func (s *MCPServer) handleSearchRDSByName(ctx context.Context, request mcp.CallT
 	}
 
 	if len(matchedDatabases) == 0 {
-		return mcp.NewToolResultText(fmt.Sprintf("未找到名称为 %s 的 RDS 实例", name)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("未找到名称为 %s 的 RDS 实例", name)), nil
 	}
 
-	result := formatDatabases(matchedDatabases, aliyunConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	result := formatDatabases(ctx, matchedDatabases, aliyunConfig.Name)
+	return newListResult(result, matchedDatabases, map[string]any{"account": aliyunConfig.Name}), nil
 }
@@ -3,6 +3,7 @@ package imcp
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
 	"github.com/eryajf/zenops/internal/model"
@@ -56,8 +57,8 @@ func (s *MCPServer) handleListOSS(ctx context.Context, request mcp.CallToolReque
 		pageNum++
 	}
 
-	result := formatOSSBuckets(allBuckets, aliyunConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	result := formatOSSBuckets(ctx, allBuckets, aliyunConfig.Name)
+	return newListResult(result, allBuckets, map[string]any{"account": aliyunConfig.Name}), nil
 }
 
 // handleGetOSS 处理获取 OSS 存储桶详情的请求
@@ -94,15 +95,16 @@ func (s *MCPServer) handleGetOSS(ctx context.Context, request mcp.CallToolReques
 
 	bucket, err := ossClient.GetOSSBucket(ctx, bucketName)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("未找到存储桶 %s: %v", bucketName, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("未找到存储桶 %s: %v", bucketName, err)), nil
 	}
 
-	result := formatOSSBuckets([]*model.OSSBucket{bucket}, aliyunConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	items := []*model.OSSBucket{bucket}
+	result := formatOSSBuckets(ctx, items, aliyunConfig.Name)
+	return newListResult(result, items, map[string]any{"account": aliyunConfig.Name}), nil
 }
 
 // formatOSSBuckets 格式化 OSS 存储桶列表
-func formatOSSBuckets(buckets []*model.OSSBucket, accountName string) string {
+func formatOSSBuckets(ctx context.Context, buckets []*model.OSSBucket, accountName string) string {
 	if len(buckets) == 0 {
 		return "未找到任何 OSS 存储桶"
 	}
@@ -110,6 +112,16 @@ func formatOSSBuckets(buckets []*model.OSSBucket, accountName string) string {
 	result := fmt.Sprintf("## 阿里云 OSS 存储桶列表 (账号: %s)\n\n", accountName)
 	result += fmt.Sprintf("总数: %d\n\n", len(buckets))
 
+	// 存储桶较多时每个存储桶只输出一行摘要
+	if compactDetails(ctx, len(buckets)) {
+		var sb strings.Builder
+		writeCompactLines(ctx, &sb, len(buckets), func(i int) string {
+			bucket := buckets[i]
+			return fmt.Sprintf("%s | %s | %s", bucket.Name, bucket.Region, bucket.StorageClass)
+		})
+		return result + sb.String()
+	}
+
 	for _, bucket := range buckets {
 		result += fmt.Sprintf("### %s\n", bucket.Name)
 		result += fmt.Sprintf("- **区域**: %s\n", bucket.Region)
@@ -0,0 +1,122 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handleListSLB 处理列出阿里云负载均衡 (SLB/ALB) 的请求
+func (s *MCPServer) handleListSLB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	accountName, _ := args["account"].(string)
+	region, _ := args["region"].(string)
+	lbType, _ := args["type"].(string)
+
+	p, aliyunConfig, err := s.getAliyunProvider(accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	opts := &provider.QueryOptions{
+		Region:  region,
+		Filters: map[string]string{"type": lbType},
+	}
+
+	lbs, err := p.ListLoadBalancers(ctx, opts)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询负载均衡失败: %v", err)), nil
+	}
+
+	result := formatLoadBalancers(ctx, lbs, aliyunConfig.Name)
+	return newListResult(result, lbs, map[string]any{"account": aliyunConfig.Name}), nil
+}
+
+// handleGetSLB 处理获取阿里云负载均衡详情的请求
+func (s *MCPServer) handleGetSLB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	lbID, ok := args["lb_id"].(string)
+	if !ok || lbID == "" {
+		return mcp.NewToolResultError("lb_id parameter is required"), nil
+	}
+
+	accountName, _ := args["account"].(string)
+
+	p, aliyunConfig, err := s.getAliyunProvider(accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	lb, err := p.GetLoadBalancer(ctx, lbID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("未找到 ID 为 %s 的负载均衡: %v", lbID, err)), nil
+	}
+
+	items := []*model.LoadBalancer{lb}
+	result := formatLoadBalancers(ctx, items, aliyunConfig.Name)
+	return newListResult(result, items, map[string]any{"account": aliyunConfig.Name}), nil
+}
+
+// handleSearchSLBByIP 处理根据服务地址 (VIP) 搜索阿里云负载均衡的请求
+func (s *MCPServer) handleSearchSLBByIP(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	ip, ok := args["ip"].(string)
+	if !ok || ip == "" {
+		return mcp.NewToolResultError("ip parameter is required"), nil
+	}
+
+	accountName, _ := args["account"].(string)
+
+	p, aliyunConfig, err := s.getAliyunProvider(accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	lbs, err := searchLoadBalancersByIP(ctx, p, ip)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询负载均衡失败: %v", err)), nil
+	}
+	if len(lbs) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("未找到服务地址为 %s 的负载均衡", ip)), nil
+	}
+
+	result := formatLoadBalancers(ctx, lbs, aliyunConfig.Name)
+	return newListResult(result, lbs, map[string]any{"account": aliyunConfig.Name}), nil
+}
+
+// searchLoadBalancersByIP 按服务地址查询负载均衡,并返回包含监听和后端的详情
+func searchLoadBalancersByIP(ctx context.Context, p provider.Provider, ip string) ([]*model.LoadBalancer, error) {
+	lbs, err := p.ListLoadBalancers(ctx, &provider.QueryOptions{
+		Filters: map[string]string{"address": ip},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.LoadBalancer, 0, len(lbs))
+	for _, lb := range lbs {
+		detail, err := p.GetLoadBalancer(ctx, lb.ID)
+		if err != nil {
+			logx.Warn("Failed to get load balancer detail, id %s, error %v", lb.ID, err)
+			detail = lb
+		}
+		result = append(result, detail)
+	}
+	return result, nil
+}
@@ -0,0 +1,175 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CDN 工具同时支持阿里云和腾讯云,通过 cloud 参数选择,默认阿里云
+
+// handleListCDNDomains 处理列出 CDN 加速域名的请求
+func (s *MCPServer) handleListCDNDomains(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+	keyword, _ := args["keyword"].(string)
+
+	p, cfg, err := s.getCloudProvider(cloud, accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	domains, err := p.ListCDNDomains(ctx, &provider.QueryOptions{
+		Filters: map[string]string{"keyword": keyword},
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询 CDN 域名失败: %v", err)), nil
+	}
+
+	var result strings.Builder
+	if len(domains) == 1 {
+		result.WriteString(formatCDNDomain(domains[0]))
+	} else {
+		result.WriteString(fmt.Sprintf("找到 %d 个 CDN 加速域名 (账号: %s):\n\n", len(domains), cfg.Name))
+		writeCDNDomains(ctx, &result, domains)
+	}
+	return newListResult(result.String(), domains, map[string]any{"account": cfg.Name}), nil
+}
+
+// handleRefreshCDNCache 处理刷新 CDN 缓存的请求,会真实提交刷新任务
+func (s *MCPServer) handleRefreshCDNCache(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 未开启时工具不会注册,这里再校验一次,避免绕过注册直接调用
+	if !s.config.CDN.AllowRefresh {
+		return mcp.NewToolResultError("refresh_cdn_cache is disabled, set cdn.allow_refresh to enable it"), nil
+	}
+
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	value, _ := args["paths"].(string)
+	paths := splitCDNPaths(value)
+	if len(paths) == 0 {
+		return mcp.NewToolResultError("paths parameter is required"), nil
+	}
+
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+
+	p, cfg, err := s.getCloudProvider(cloud, accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tasks, err := p.RefreshCDNCache(ctx, paths)
+	if err != nil {
+		msg := fmt.Sprintf("提交 CDN 刷新任务失败: %v", err)
+		if len(tasks) > 0 {
+			msg += "\n\n已提交的任务:\n" + formatCDNRefreshTasks(tasks)
+		}
+		return mcp.NewToolResultError(msg), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("已提交 CDN 缓存刷新 (账号: %s),通常在 5 分钟内生效:\n\n", cfg.Name))
+	result.WriteString(formatCDNRefreshTasks(tasks))
+	return newListResult(result.String(), tasks, map[string]any{"account": cfg.Name}), nil
+}
+
+// splitCDNPaths 拆分以逗号、空格或换行分隔的刷新路径
+func splitCDNPaths(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '，' || r == ' ' || r == '\n' || r == '\t'
+	})
+}
+
+// writeCDNDomains 逐行写入 CDN 加速域名摘要
+func writeCDNDomains(ctx context.Context, sb *strings.Builder, domains []*model.CDNDomain) {
+	writeCompactLines(ctx, sb, len(domains), func(i int) string {
+		d := domains[i]
+		line := fmt.Sprintf("%s | %s | %s | %s | 源站 %s", d.Name, d.Status, d.BusinessType, d.Area, cdnOrigins(d.Origins))
+		if d.HTTPS {
+			line += " | HTTPS"
+			if d.CertExpireAt != nil {
+				line += " 证书到期 " + d.CertExpireAt.Local().Format("2006-01-02")
+			}
+		}
+		return line
+	})
+}
+
+// formatCDNDomain 格式化单个 CDN 加速域名详情
+func formatCDNDomain(d *model.CDNDomain) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("CDN 域名: %s\n", d.Name))
+	result.WriteString(fmt.Sprintf("  CNAME: %s\n", d.CNAME))
+	result.WriteString(fmt.Sprintf("  状态: %s\n", d.Status))
+	result.WriteString(fmt.Sprintf("  业务类型: %s\n", d.BusinessType))
+	result.WriteString(fmt.Sprintf("  加速区域: %s\n", d.Area))
+	result.WriteString("  源站:\n")
+	for _, o := range d.Origins {
+		address := o.Address
+		if o.Port > 0 {
+			address = fmt.Sprintf("%s:%d", o.Address, o.Port)
+		}
+		result.WriteString(fmt.Sprintf("    - %s (%s, %s)\n", address, o.Type, o.Priority))
+	}
+	if d.HTTPS {
+		result.WriteString("  HTTPS: 已开启\n")
+		if d.CertName != "" {
+			result.WriteString(fmt.Sprintf("  证书: %s\n", d.CertName))
+		}
+		if d.CertExpireAt != nil {
+			result.WriteString(fmt.Sprintf("  证书到期: %s\n", d.CertExpireAt.Local().Format("2006-01-02 15:04:05")))
+		}
+	} else {
+		result.WriteString("  HTTPS: 未开启\n")
+	}
+	if !d.CreatedAt.IsZero() {
+		result.WriteString(fmt.Sprintf("  创建时间: %s\n", d.CreatedAt.Local().Format("2006-01-02 15:04:05")))
+	}
+	result.WriteString(fmt.Sprintf("  控制台: %s\n", d.ConsoleURL))
+	return result.String()
+}
+
+// formatCDNRefreshTasks 格式化已提交的刷新任务
+func formatCDNRefreshTasks(tasks []*model.CDNRefreshTask) string {
+	var result strings.Builder
+	for _, t := range tasks {
+		kind := "URL"
+		if t.Type == model.CDNRefreshDirectory {
+			kind = "目录"
+		}
+		result.WriteString(fmt.Sprintf("- %s 刷新 %d 个,任务 ID: %s\n", kind, len(t.Paths), t.TaskID))
+		for _, path := range t.Paths {
+			result.WriteString(fmt.Sprintf("  - %s\n", path))
+		}
+	}
+	return result.String()
+}
+
+// cdnOrigins 源站地址摘要,备源站标注 (备)
+func cdnOrigins(origins []model.CDNOrigin) string {
+	if len(origins) == 0 {
+		return "-"
+	}
+	addresses := make([]string, 0, len(origins))
+	for _, o := range origins {
+		address := o.Address
+		if o.Priority == model.CDNOriginBackup {
+			address += "(备)"
+		}
+		addresses = append(addresses, address)
+	}
+	return strings.Join(addresses, ",")
+}
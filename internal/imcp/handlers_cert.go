@@ -0,0 +1,137 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eryajf/zenops/internal/certs"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// 证书工具默认汇总所有启用账号的云证书以及 certificates.hosts 中主机的线上证书
+
+// defaultCertDays 查询即将过期证书的默认天数
+const defaultCertDays = 30
+
+// handleListCertificates 处理列出 SSL 证书的请求
+func (s *MCPServer) handleListCertificates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	keyword, _ := args["keyword"].(string)
+	checker, source, err := s.getCertChecker(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	list, err := checker.List(ctx, keyword, source)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询证书失败: %v", err)), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("找到 %d 个证书:\n\n", len(list)))
+	writeCertificates(ctx, &result, list)
+	return newListResult(result.String(), list, nil), nil
+}
+
+// handleListExpiringCertificates 处理查询即将过期证书的请求
+func (s *MCPServer) handleListExpiringCertificates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	days := defaultCertDays
+	if value, ok := args["days"].(float64); ok && value > 0 {
+		days = int(value)
+	}
+
+	checker, source, err := s.getCertChecker(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	list, err := checker.Expiring(ctx, days, source)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询证书失败: %v", err)), nil
+	}
+	if len(list) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("未发现 %d 天内过期的证书", days)), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("发现 %d 个证书将在 %d 天内过期或已过期:\n\n", len(list), days))
+	writeCertificates(ctx, &result, list)
+	return newListResult(result.String(), list, map[string]any{"days": days}), nil
+}
+
+// handleCheckCertificate 处理对指定主机进行 TLS 握手检查证书的请求
+func (s *MCPServer) handleCheckCertificate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	host, ok := args["host"].(string)
+	if !ok || host == "" {
+		return mcp.NewToolResultError("host parameter is required"), nil
+	}
+
+	timeout := time.Duration(s.config.Certificates.Timeout) * time.Second
+	cert, err := certs.Probe(ctx, host, timeout)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("检查证书失败: %v", err)), nil
+	}
+	return newListResult(formatCertificate(cert), []*model.Certificate{cert}, nil), nil
+}
+
+// getCertChecker 按 cloud、account、source 参数创建证书检查器
+func (s *MCPServer) getCertChecker(args map[string]any) (*certs.Checker, string, error) {
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+	source, _ := args["source"].(string)
+	return certs.Load(s.config, cloud, accountName, source)
+}
+
+// writeCertificates 逐行写入证书摘要
+func writeCertificates(ctx context.Context, sb *strings.Builder, list []*model.Certificate) {
+	now := time.Now()
+	writeCompactLines(ctx, sb, len(list), func(i int) string {
+		return certs.Describe(list[i], now)
+	})
+}
+
+// formatCertificate 格式化单个证书详情
+func formatCertificate(c *model.Certificate) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("证书: %s\n", c.Domain))
+	if c.Host != "" {
+		result.WriteString(fmt.Sprintf("  主机: %s\n", c.Host))
+	}
+	if c.ID != "" {
+		result.WriteString(fmt.Sprintf("  ID: %s\n", c.ID))
+	}
+	if c.Name != "" {
+		result.WriteString(fmt.Sprintf("  名称: %s\n", c.Name))
+	}
+	if len(c.SANs) > 0 {
+		result.WriteString(fmt.Sprintf("  备用域名: %s\n", strings.Join(c.SANs, ", ")))
+	}
+	result.WriteString(fmt.Sprintf("  颁发者: %s\n", c.Issuer))
+	result.WriteString(fmt.Sprintf("  状态: %s\n", c.Status))
+	result.WriteString(fmt.Sprintf("  生效时间: %s\n", c.NotBefore.Local().Format("2006-01-02 15:04:05")))
+	result.WriteString(fmt.Sprintf("  过期时间: %s (剩余 %d 天)\n", c.NotAfter.Local().Format("2006-01-02 15:04:05"), c.DaysLeft(time.Now())))
+	if len(c.Products) > 0 {
+		result.WriteString(fmt.Sprintf("  已部署: %s\n", strings.Join(c.Products, ", ")))
+	}
+	if c.ConsoleURL != "" {
+		result.WriteString(fmt.Sprintf("  控制台: %s\n", c.ConsoleURL))
+	}
+	return result.String()
+}
@@ -0,0 +1,159 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eryajf/zenops/internal/cost"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// 费用工具默认汇总所有启用的阿里云和腾讯云账号,账单通常有 1 天左右的延迟
+
+// defaultCostTop 费用最高实例的默认数量
+const defaultCostTop = 10
+
+// handleGetCostSummary 处理查询各账号本月至今和上月费用的请求
+func (s *MCPServer) handleGetCostSummary(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	reporter, err := s.getCostReporter(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	summaries, err := reporter.Summary(ctx, time.Now())
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询费用失败: %v", err)), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("%d 个账号的费用 (本月至今 / 上月):\n\n", len(summaries)))
+	writeCompactLines(ctx, &result, len(summaries), func(i int) string {
+		c := summaries[i]
+		return fmt.Sprintf("%s (%s) | 本月至今 %s | 上月 %s", c.Account, c.Provider,
+			formatCostAmount(c.MonthToDate, c.Currency), formatCostAmount(c.LastMonth, c.Currency))
+	})
+	return newListResult(result.String(), summaries, nil), nil
+}
+
+// handleGetCostBreakdown 处理按账号、产品、地域或标签拆分费用的请求
+func (s *MCPServer) handleGetCostBreakdown(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	period, _ := args["period"].(string)
+	groupBy, _ := args["group_by"].(string)
+	tagKey, _ := args["tag_key"].(string)
+
+	cycle, err := cost.Cycle(period, time.Now())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	reporter, err := s.getCostReporter(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	items, err := reporter.Items(ctx, cycle)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询费用失败: %v", err)), nil
+	}
+	breakdown, err := cost.Breakdown(items, cycle, groupBy, tagKey)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	dimension := breakdown.GroupBy
+	if breakdown.TagKey != "" {
+		dimension += " " + breakdown.TagKey
+	}
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("%s 费用合计 %s,按 %s 拆分:\n\n", cycle, formatCostAmount(breakdown.Total, breakdown.Currency), dimension))
+	writeCompactLines(ctx, &result, len(breakdown.Groups), func(i int) string {
+		g := breakdown.Groups[i]
+		return fmt.Sprintf("%s | %s | %s | %d 个实例", g.Key, formatCostAmount(g.Amount, breakdown.Currency),
+			costShare(g.Amount, breakdown.Total), g.Instances)
+	})
+	return newListResult(result.String(), breakdown.Groups, map[string]any{
+		"billing_cycle": cycle,
+		"group_by":      breakdown.GroupBy,
+		"total":         breakdown.Total,
+		"currency":      breakdown.Currency,
+	}), nil
+}
+
+// handleListTopCostInstances 处理列出费用最高实例的请求
+func (s *MCPServer) handleListTopCostInstances(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	period, _ := args["period"].(string)
+	top := defaultCostTop
+	if value, ok := args["top"].(float64); ok && value > 0 {
+		top = int(value)
+	}
+
+	cycle, err := cost.Cycle(period, time.Now())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	reporter, err := s.getCostReporter(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	items, err := reporter.Items(ctx, cycle)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询费用失败: %v", err)), nil
+	}
+	list := cost.Top(items, top)
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("%s 费用最高的 %d 个实例:\n\n", cycle, len(list)))
+	writeCostItems(ctx, &result, list)
+	return newListResult(result.String(), list, map[string]any{"billing_cycle": cycle}), nil
+}
+
+// getCostReporter 按 cloud、account 参数创建账单查询器
+func (s *MCPServer) getCostReporter(args map[string]any) (*cost.Reporter, error) {
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+	return cost.Load(s.config, cloud, accountName)
+}
+
+// writeCostItems 逐行写入实例费用
+func writeCostItems(ctx context.Context, sb *strings.Builder, items []*model.CostItem) {
+	writeCompactLines(ctx, sb, len(items), func(i int) string {
+		item := items[i]
+		name := item.InstanceID
+		if item.InstanceName != "" && item.InstanceName != item.InstanceID {
+			name += " (" + item.InstanceName + ")"
+		}
+		return fmt.Sprintf("%s | %s | %s | %s | %s/%s", name, formatCostAmount(item.Amount, item.Currency),
+			item.Product, item.Region, item.Provider, item.Account)
+	})
+}
+
+// formatCostAmount 格式化金额,保留两位小数
+func formatCostAmount(amount float64, currency string) string {
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
+
+// costShare 计算费用占比
+func costShare(amount, total float64) string {
+	if total <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", amount/total*100)
+}
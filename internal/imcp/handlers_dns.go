@@ -0,0 +1,181 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/eryajf/zenops/internal/cloudaccount"
+	"github.com/eryajf/zenops/internal/dns"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// DNS 工具默认查询所有启用的阿里云和腾讯云账号,可通过 cloud、account 参数缩小范围
+
+// handleListDomains 处理列出托管域名的请求
+func (s *MCPServer) handleListDomains(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	keyword, _ := args["keyword"].(string)
+
+	resolver, err := s.getDNSResolver(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	domains, err := resolver.ListDomains(ctx, keyword)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询域名失败: %v", err)), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("找到 %d 个托管域名:\n\n", len(domains)))
+	writeCompactLines(ctx, &result, len(domains), func(i int) string {
+		d := domains[i]
+		return fmt.Sprintf("%s | %s/%s | 记录数 %d | %s", d.Name, d.Provider, d.Account, d.RecordCount, d.Status)
+	})
+	return newListResult(result.String(), domains, nil), nil
+}
+
+// handleListDNSRecords 处理列出域名解析记录的请求
+func (s *MCPServer) handleListDNSRecords(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	domain, ok := args["domain"].(string)
+	if !ok || domain == "" {
+		return mcp.NewToolResultError("domain parameter is required"), nil
+	}
+
+	rr, _ := args["rr"].(string)
+	recordType, _ := args["type"].(string)
+
+	resolver, err := s.getDNSResolver(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	records, err := resolver.ListRecords(ctx, domain, map[string]string{"rr": rr, "type": recordType})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询解析记录失败: %v", err)), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("域名 %s 共 %d 条解析记录:\n\n", domain, len(records)))
+	writeDNSRecords(ctx, &result, records)
+	return newListResult(result.String(), records, nil), nil
+}
+
+// handleResolveDomainToResources 处理将域名沿 A/CNAME 记录解析到云资源的请求
+func (s *MCPServer) handleResolveDomainToResources(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	hostname, ok := args["hostname"].(string)
+	if !ok || hostname == "" {
+		return mcp.NewToolResultError("hostname parameter is required"), nil
+	}
+
+	resolver, err := s.getDNSResolver(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resolution, err := resolver.Resolve(ctx, hostname)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("解析 %s 失败: %v", hostname, err)), nil
+	}
+
+	return mcp.NewToolResultStructured(map[string]any{
+		"resolution": resolution,
+	}, formatDNSResolution(ctx, resolution)), nil
+}
+
+// handleSearchDNSByIP 处理按记录值反查解析记录的请求
+func (s *MCPServer) handleSearchDNSByIP(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	ip, ok := args["ip"].(string)
+	if !ok || ip == "" {
+		return mcp.NewToolResultError("ip parameter is required"), nil
+	}
+
+	resolver, err := s.getDNSResolver(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	records, err := resolver.ReverseLookup(ctx, ip)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("反查解析记录失败: %v", err)), nil
+	}
+	if len(records) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("未找到指向 %s 的解析记录", ip)), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("找到 %d 条指向 %s 的解析记录:\n\n", len(records), ip))
+	writeDNSRecords(ctx, &result, records)
+	return newListResult(result.String(), records, nil), nil
+}
+
+// getDNSResolver 按 cloud、account 参数创建 DNS 解析器
+func (s *MCPServer) getDNSResolver(args map[string]any) (*dns.Resolver, error) {
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+
+	accounts, err := cloudaccount.Load(s.config, cloud, accountName)
+	if err != nil {
+		return nil, err
+	}
+	return dns.NewResolver(accounts), nil
+}
+
+// formatDNSResolution 格式化解析链路及最终指向的资源
+func formatDNSResolution(ctx context.Context, resolution *model.DNSResolution) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("%s 的解析链路:\n", resolution.Hostname))
+	writeDNSRecords(ctx, &result, resolution.Records)
+
+	result.WriteString(fmt.Sprintf("\n最终指向 (%d 个):\n", len(resolution.Targets)))
+	writeCompactLines(ctx, &result, len(resolution.Targets), func(i int) string {
+		t := resolution.Targets[i]
+		if t.ResourceID == "" {
+			return fmt.Sprintf("%s | %s | 未关联到托管资源", t.Address, t.Type)
+		}
+		line := fmt.Sprintf("%s | %s | %s %s", t.Address, t.Type, t.ResourceID, t.ResourceName)
+		if t.Account != "" {
+			line += fmt.Sprintf(" | %s/%s %s", t.Provider, t.Account, t.Region)
+		} else if t.Provider != "" {
+			line += " | " + t.Provider
+		}
+		return line
+	})
+	return result.String()
+}
+
+// writeDNSRecords 逐行写入解析记录
+func writeDNSRecords(ctx context.Context, sb *strings.Builder, records []*model.DNSRecord) {
+	writeCompactLines(ctx, sb, len(records), func(i int) string {
+		r := records[i]
+		line := fmt.Sprintf("%s %s → %s | TTL %d | %s/%s", r.FQDN, r.Type, r.Value, r.TTL, r.Provider, r.Account)
+		if r.Line != "" {
+			line += " | 线路 " + r.Line
+		}
+		if r.Status == model.DNSRecordDisabled {
+			line += " | 已暂停"
+		}
+		return line
+	})
+}
@@ -0,0 +1,173 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// 公网 IP 工具同时支持阿里云和腾讯云,通过 cloud 参数选择,默认阿里云
+
+// handleListEIP 处理列出弹性公网 IP 及普通公网 IP 的请求
+func (s *MCPServer) handleListEIP(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	region, _ := args["region"].(string)
+	filters := make(map[string]string)
+	for _, key := range []string{"status", "type"} {
+		if value, _ := args[key].(string); value != "" {
+			filters[key] = value
+		}
+	}
+
+	eips, accountName, errResult := s.queryEIPs(ctx, args, region, filters)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("找到 %d 个公网 IP (账号: %s):\n\n", len(eips), accountName))
+	writeEIPs(ctx, &result, eips)
+	return newListResult(result.String(), eips, map[string]any{"account": accountName}), nil
+}
+
+// handleSearchEIPByIP 处理根据 IP 查询公网 IP 详情的请求
+func (s *MCPServer) handleSearchEIPByIP(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	ip, ok := args["ip"].(string)
+	if !ok || ip == "" {
+		return mcp.NewToolResultError("ip parameter is required"), nil
+	}
+
+	eips, accountName, errResult := s.queryEIPs(ctx, args, "", map[string]string{"address": ip})
+	if errResult != nil {
+		return errResult, nil
+	}
+	if len(eips) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("未找到公网 IP %s (账号: %s)", ip, accountName)), nil
+	}
+
+	var result strings.Builder
+	for _, eip := range eips {
+		result.WriteString(formatEIP(eip))
+	}
+	return newListResult(result.String(), eips, map[string]any{"account": accountName}), nil
+}
+
+// handleReportUnassociatedEIPs 处理查找未绑定但仍在计费的弹性公网 IP 的请求
+func (s *MCPServer) handleReportUnassociatedEIPs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	region, _ := args["region"].(string)
+	eips, accountName, errResult := s.queryEIPs(ctx, args, region, map[string]string{
+		"type":   model.EIPTypeEIP,
+		"status": model.EIPStatusAvailable,
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	idle := make([]*model.EIP, 0, len(eips))
+	for _, eip := range eips {
+		if eip.IdleBilling() {
+			idle = append(idle, eip)
+		}
+	}
+	if len(idle) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("未发现未绑定且仍在计费的弹性公网 IP (账号: %s)", accountName)), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("发现 %d 个未绑定且仍在计费的弹性公网 IP (账号: %s),确认无用后可释放:\n\n", len(idle), accountName))
+	writeEIPs(ctx, &result, idle)
+	return newListResult(result.String(), idle, map[string]any{"account": accountName}), nil
+}
+
+// queryEIPs 按过滤条件查询公网 IP,出错时返回错误结果
+func (s *MCPServer) queryEIPs(ctx context.Context, args map[string]any, region string, filters map[string]string) ([]*model.EIP, string, *mcp.CallToolResult) {
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+
+	p, cfg, err := s.getCloudProvider(cloud, accountName)
+	if err != nil {
+		return nil, "", mcp.NewToolResultError(err.Error())
+	}
+
+	eips, err := p.ListEIPs(ctx, &provider.QueryOptions{
+		Region:  region,
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, "", mcp.NewToolResultError(fmt.Sprintf("查询公网 IP 失败: %v", err))
+	}
+	return eips, cfg.Name, nil
+}
+
+// writeEIPs 逐行写入公网 IP 摘要
+func writeEIPs(ctx context.Context, sb *strings.Builder, eips []*model.EIP) {
+	writeCompactLines(ctx, sb, len(eips), func(i int) string {
+		e := eips[i]
+		line := fmt.Sprintf("%s | %s | %s | %s | %dMbps %s/%s", e.Address, e.Type, e.Status, e.Region,
+			e.Bandwidth, e.InternetChargeType, e.ChargeType)
+		if e.Type == model.EIPTypeEIP {
+			line += fmt.Sprintf(" | %s %s", e.ID, e.Name)
+		}
+		if e.InstanceID != "" {
+			line += fmt.Sprintf(" | 绑定 %s %s", e.InstanceType, e.InstanceID)
+		}
+		if e.Locked {
+			line += " | 已锁定"
+		}
+		return line
+	})
+}
+
+// formatEIP 格式化单个公网 IP 详情
+func formatEIP(e *model.EIP) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("公网 IP: %s\n", e.Address))
+	result.WriteString(fmt.Sprintf("  类型: %s\n", e.Type))
+	if e.Type == model.EIPTypeEIP {
+		result.WriteString(fmt.Sprintf("  ID: %s\n", e.ID))
+	}
+	if e.Name != "" {
+		result.WriteString(fmt.Sprintf("  名称: %s\n", e.Name))
+	}
+	result.WriteString(fmt.Sprintf("  区域: %s\n", e.Region))
+	result.WriteString(fmt.Sprintf("  状态: %s\n", e.Status))
+	result.WriteString(fmt.Sprintf("  带宽: %d Mbps\n", e.Bandwidth))
+	result.WriteString(fmt.Sprintf("  计费: %s / %s\n", e.InternetChargeType, e.ChargeType))
+	if e.ISP != "" {
+		result.WriteString(fmt.Sprintf("  线路: %s\n", e.ISP))
+	}
+	if e.InstanceID != "" {
+		result.WriteString(fmt.Sprintf("  绑定资源: %s %s\n", e.InstanceType, e.InstanceID))
+	} else {
+		result.WriteString("  绑定资源: 未绑定\n")
+	}
+	if e.Locked {
+		result.WriteString("  已因欠费或安全原因锁定\n")
+	}
+	if !e.CreatedAt.IsZero() {
+		result.WriteString(fmt.Sprintf("  创建时间: %s\n", e.CreatedAt.Format("2006-01-02 15:04:05")))
+	}
+	if e.ExpiredAt != nil {
+		result.WriteString(fmt.Sprintf("  到期时间: %s\n", e.ExpiredAt.Format("2006-01-02 15:04:05")))
+	}
+	result.WriteString(fmt.Sprintf("  控制台: %s\n", e.ConsoleURL))
+	return result.String()
+}
@@ -0,0 +1,57 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/eryajf/zenops/internal/idle"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handleReportIdleResources 处理检测闲置实例和数据库的请求,默认检测所有启用的账号
+func (s *MCPServer) handleReportIdleResources(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+	resourceType, _ := args["type"].(string)
+	days := s.config.Idle.Days
+	if value, ok := args["days"].(float64); ok && value > 0 {
+		days = int(value)
+	}
+
+	detector, err := idle.Load(s.config, cloud, accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	list, err := detector.Detect(ctx, resourceType, days)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("检测闲置资源失败: %v", err)), nil
+	}
+	if len(list) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("最近 %d 天未发现闲置的实例或数据库", days)), nil
+	}
+
+	var total float64
+	for _, item := range list {
+		if item.MonthlyCost != nil {
+			total += *item.MonthlyCost
+		}
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("最近 %d 天发现 %d 个闲置资源", days, len(list)))
+	if total > 0 {
+		result.WriteString(fmt.Sprintf(",月费用合计约 %.2f", total))
+	}
+	result.WriteString(":\n\n")
+	writeCompactLines(ctx, &result, len(list), func(i int) string {
+		return idle.Describe(list[i])
+	})
+	return newListResult(result.String(), list, map[string]any{"days": days}), nil
+}
@@ -44,8 +44,8 @@ func (s *MCPServer) handleListJenkinsJobs(ctx context.Context, request mcp.CallT
 		pageNum++
 	}
 
-	result := formatJobs(allJobs)
-	return mcp.NewToolResultText(result), nil
+	result := formatJobs(ctx, allJobs)
+	return newListResult(result, allJobs, nil), nil
 }
 
 // handleGetJenkinsJob 处理获取 Jenkins Job 详情的请求
@@ -67,11 +67,12 @@ func (s *MCPServer) handleGetJenkinsJob(ctx context.Context, request mcp.CallToo
 
 	job, err := p.GetJob(ctx, jobName)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("未找到 Job '%s': %v", jobName, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("未找到 Job '%s': %v", jobName, err)), nil
 	}
 
-	result := formatJobs([]*model.Job{job})
-	return mcp.NewToolResultText(result), nil
+	items := []*model.Job{job}
+	result := formatJobs(ctx, items)
+	return newListResult(result, items, nil), nil
 }
 
 // handleListJenkinsBuilds 处理列出 Jenkins Build 历史的请求
@@ -99,21 +100,117 @@ func (s *MCPServer) handleListJenkinsBuilds(ctx context.Context, request mcp.Cal
 
 	builds, err := p.GetJobBuilds(ctx, jobName, limit)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("获取 Job '%s' 的构建历史失败: %v", jobName, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("获取 Job '%s' 的构建历史失败: %v", jobName, err)), nil
 	}
 
 	if len(builds) == 0 {
 		return mcp.NewToolResultText(fmt.Sprintf("Job '%s' 没有构建历史", jobName)), nil
 	}
 
-	result := formatBuilds(builds, jobName)
-	return mcp.NewToolResultText(result), nil
+	result := formatBuilds(ctx, builds, jobName)
+	return newListResult(result, builds, map[string]any{"job_name": jobName}), nil
+}
+
+// defaultBuildLogTailLines 默认返回的构建日志行数
+const defaultBuildLogTailLines = 100
+
+// handleGetJenkinsBuildLog 处理获取 Jenkins 构建日志的请求
+func (s *MCPServer) handleGetJenkinsBuildLog(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	jobName, ok := args["job_name"].(string)
+	if !ok || jobName == "" {
+		return mcp.NewToolResultError("job_name parameter is required"), nil
+	}
+
+	buildNumber := 0
+	if n, ok := args["build_number"].(float64); ok {
+		buildNumber = int(n)
+	}
+
+	tailLines := defaultBuildLogTailLines
+	if n, ok := args["tail_lines"].(float64); ok && n > 0 {
+		tailLines = int(n)
+	}
+
+	p, err := s.getJenkinsProvider()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	build, output, err := p.GetBuildLog(ctx, jobName, buildNumber)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("获取 Job '%s' 的构建日志失败: %v", jobName, err)), nil
+	}
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	omitted := 0
+	if len(lines) > tailLines {
+		omitted = len(lines) - tailLines
+		lines = lines[omitted:]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Job '%s' 构建 #%d 的日志 (状态: %s):\n\n", jobName, build.Number, build.Status))
+	if omitted > 0 {
+		sb.WriteString(fmt.Sprintf("... 已省略前 %d 行\n", omitted))
+	}
+	sb.WriteString(strings.Join(lines, "\n"))
+	sb.WriteString("\n")
+
+	return mcp.NewToolResultStructured(map[string]any{
+		"job_name":     jobName,
+		"build_number": build.Number,
+		"status":       build.Status,
+		"items":        []*model.Build{build},
+	}, sb.String()), nil
+}
+
+// handleRerunJenkinsBuild 处理重新触发 Jenkins 构建的请求
+func (s *MCPServer) handleRerunJenkinsBuild(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 未开启时工具不会注册,这里再校验一次,避免绕过注册直接调用
+	if !s.config.CICD.Jenkins.AllowRerun {
+		return mcp.NewToolResultError("rerun_jenkins_build is disabled, set cicd.jenkins.allow_rerun to enable it"), nil
+	}
+
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	jobName, ok := args["job_name"].(string)
+	if !ok || jobName == "" {
+		return mcp.NewToolResultError("job_name parameter is required"), nil
+	}
+
+	buildNumber := 0
+	if n, ok := args["build_number"].(float64); ok {
+		buildNumber = int(n)
+	}
+
+	p, err := s.getJenkinsProvider()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	queueID, err := p.RerunBuild(ctx, jobName, buildNumber)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("重新触发 Job '%s' 失败: %v", jobName, err)), nil
+	}
+
+	return mcp.NewToolResultStructured(map[string]any{
+		"job_name": jobName,
+		"queue_id": queueID,
+	}, fmt.Sprintf("已重新触发 Job '%s' 的构建,队列 ID: %d", jobName, queueID)), nil
 }
 
 // ==================== 格式化函数 ====================
 
 // formatJobs 格式化 Jenkins Job 列表为文本输出
-func formatJobs(jobs []*model.Job) string {
+func formatJobs(ctx context.Context, jobs []*model.Job) string {
 	if len(jobs) == 0 {
 		return "未找到任何 Jenkins Job"
 	}
@@ -121,6 +218,19 @@ func formatJobs(jobs []*model.Job) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("找到 %d 个 Jenkins Job:\n\n", len(jobs)))
 
+	// Job 较多时每个 Job 只输出一行摘要
+	if compactDetails(ctx, len(jobs)) {
+		writeCompactLines(ctx, &sb, len(jobs), func(i int) string {
+			job := jobs[i]
+			lastBuild := "无"
+			if job.LastBuild != nil {
+				lastBuild = fmt.Sprintf("#%d", job.LastBuild.Number)
+			}
+			return fmt.Sprintf("%s | 最后构建: %s", job.Name, lastBuild)
+		})
+		return sb.String()
+	}
+
 	for i, job := range jobs {
 		sb.WriteString(fmt.Sprintf("Job %d:\n", i+1))
 		sb.WriteString(fmt.Sprintf("  名称: %s\n", job.Name))
@@ -154,7 +264,7 @@ func formatJobs(jobs []*model.Job) string {
 }
 
 // formatBuilds 格式化 Jenkins Build 列表为文本输出
-func formatBuilds(builds []*model.Build, jobName string) string {
+func formatBuilds(ctx context.Context, builds []*model.Build, jobName string) string {
 	if len(builds) == 0 {
 		return fmt.Sprintf("Job '%s' 没有构建历史", jobName)
 	}
@@ -162,6 +272,16 @@ func formatBuilds(builds []*model.Build, jobName string) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("Job '%s' 的构建历史 (共 %d 个构建):\n\n", jobName, len(builds)))
 
+	// 构建较多时每个构建只输出一行摘要
+	if compactDetails(ctx, len(builds)) {
+		writeCompactLines(ctx, &sb, len(builds), func(i int) string {
+			build := builds[i]
+			return fmt.Sprintf("#%d | %s | %s | %s",
+				build.Number, build.Status, build.Result, build.Timestamp.Format("2006-01-02 15:04:05"))
+		})
+		return sb.String()
+	}
+
 	for i, build := range builds {
 		sb.WriteString(fmt.Sprintf("Build %d:\n", i+1))
 		sb.WriteString(fmt.Sprintf("  构建号: #%d\n", build.Number))
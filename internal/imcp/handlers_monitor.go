@@ -0,0 +1,90 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/monitor"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// metricLabels 指标在聊天卡片中的显示名称
+var metricLabels = map[string]string{
+	model.MetricCPU:        "CPU",
+	model.MetricMemory:     "内存",
+	model.MetricDisk:       "磁盘",
+	model.MetricNetworkIn:  "入流量",
+	model.MetricNetworkOut: "出流量",
+}
+
+// handleGetInstanceMetrics 处理查询实例或数据库监控指标的请求,resource 可以是实例 ID、数据库 ID 或实例 IP
+func (s *MCPServer) handleGetInstanceMetrics(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	resource, ok := args["resource"].(string)
+	if !ok || resource == "" {
+		return mcp.NewToolResultError("resource parameter is required"), nil
+	}
+	resourceType, _ := args["type"].(string)
+	metricNames, _ := args["metrics"].(string)
+	windowText, _ := args["window"].(string)
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+
+	window, err := monitor.ParseWindow(windowText)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	metrics, err := monitor.ParseMetrics(metricNames)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	querier, err := monitor.Load(s.config, cloud, accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := querier.Query(ctx, resource, resourceType, metrics, window)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询监控指标失败: %v", err)), nil
+	}
+
+	return mcp.NewToolResultStructured(map[string]any{
+		"metrics": result,
+	}, formatResourceMetrics(result)), nil
+}
+
+// formatResourceMetrics 格式化监控指标,每个指标输出当前值、平均值、峰值和趋势图
+func formatResourceMetrics(r *model.ResourceMetrics) string {
+	var sb strings.Builder
+	sb.WriteString(r.ResourceID)
+	if r.Name != "" && r.Name != r.ResourceID {
+		sb.WriteString(" (" + r.Name + ")")
+	}
+	sb.WriteString(fmt.Sprintf(" | %s/%s %s | %s\n", r.Provider, r.Account, r.Region, r.Spec))
+	sb.WriteString(fmt.Sprintf("监控时段: %s ~ %s\n\n", r.Start.Format("01-02 15:04"), r.End.Format("01-02 15:04")))
+
+	for _, m := range r.Metrics {
+		label := metricLabels[m.Metric]
+		if label == "" {
+			label = m.Metric
+		}
+		if m.Current == nil {
+			sb.WriteString(fmt.Sprintf("- %s: 无数据\n", label))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("- %s: 当前 %s | 平均 %s | 峰值 %s\n", label,
+			monitor.FormatValue(m.Current, m.Unit), monitor.FormatValue(m.Avg, m.Unit), monitor.FormatValue(m.Max, m.Unit)))
+		sb.WriteString(fmt.Sprintf("  `%s`\n", monitor.Sparkline(m)))
+	}
+	if r.ConsoleURL != "" {
+		sb.WriteString(fmt.Sprintf("\n控制台: %s\n", r.ConsoleURL))
+	}
+	return sb.String()
+}
@@ -0,0 +1,278 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// 网络工具同时支持阿里云和腾讯云,通过 cloud 参数选择,默认阿里云
+
+// handleListVPCs 处理列出 VPC 的请求
+func (s *MCPServer) handleListVPCs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+	region, _ := args["region"].(string)
+
+	p, cfg, err := s.getCloudProvider(cloud, accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	vpcs, err := p.ListVPCs(ctx, &provider.QueryOptions{Region: region})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询 VPC 失败: %v", err)), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("找到 %d 个 VPC (账号: %s):\n\n", len(vpcs), cfg.Name))
+	writeCompactLines(ctx, &result, len(vpcs), func(i int) string {
+		vpc := vpcs[i]
+		return fmt.Sprintf("%s | %s | %s | %s | 默认: %v", vpc.ID, vpc.Name, vpc.Region, vpc.CIDR, vpc.IsDefault)
+	})
+	return newListResult(result.String(), vpcs, map[string]any{"account": cfg.Name}), nil
+}
+
+// handleListSubnets 处理列出子网 (阿里云交换机) 的请求
+func (s *MCPServer) handleListSubnets(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+	region, _ := args["region"].(string)
+	vpcID, _ := args["vpc_id"].(string)
+
+	p, cfg, err := s.getCloudProvider(cloud, accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	subnets, err := p.ListSubnets(ctx, &provider.QueryOptions{
+		Region:  region,
+		Filters: map[string]string{"vpc_id": vpcID},
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询子网失败: %v", err)), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("找到 %d 个子网 (账号: %s):\n\n", len(subnets), cfg.Name))
+	writeCompactLines(ctx, &result, len(subnets), func(i int) string {
+		subnet := subnets[i]
+		return fmt.Sprintf("%s | %s | %s | %s | %s | 可用 IP %d",
+			subnet.ID, subnet.Name, subnet.VpcID, subnet.Zone, subnet.CIDR, subnet.AvailableIPs)
+	})
+	return newListResult(result.String(), subnets, map[string]any{"account": cfg.Name}), nil
+}
+
+// handleListSecurityGroups 处理列出安全组的请求
+func (s *MCPServer) handleListSecurityGroups(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+	region, _ := args["region"].(string)
+	vpcID, _ := args["vpc_id"].(string)
+
+	p, cfg, err := s.getCloudProvider(cloud, accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	groups, err := p.ListSecurityGroups(ctx, &provider.QueryOptions{
+		Region:  region,
+		Filters: map[string]string{"vpc_id": vpcID},
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询安全组失败: %v", err)), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("找到 %d 个安全组 (账号: %s):\n\n", len(groups), cfg.Name))
+	writeCompactLines(ctx, &result, len(groups), func(i int) string {
+		sg := groups[i]
+		return fmt.Sprintf("%s | %s | %s | %s", sg.ID, sg.Name, sg.Region, sg.Description)
+	})
+	return newListResult(result.String(), groups, map[string]any{"account": cfg.Name}), nil
+}
+
+// handleGetSecurityGroup 处理获取安全组详情 (含规则) 的请求
+func (s *MCPServer) handleGetSecurityGroup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	sgID, ok := args["sg_id"].(string)
+	if !ok || sgID == "" {
+		return mcp.NewToolResultError("sg_id parameter is required"), nil
+	}
+
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+
+	p, cfg, err := s.getCloudProvider(cloud, accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	sg, err := p.GetSecurityGroup(ctx, sgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("未找到 ID 为 %s 的安全组: %v", sgID, err)), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("安全组 %s (%s) 区域 %s (账号: %s)\n", sg.Name, sg.ID, sg.Region, cfg.Name))
+	writeRules(ctx, &result, "入方向规则", filterRules(sg.Rules, model.DirectionIngress))
+	writeRules(ctx, &result, "出方向规则", filterRules(sg.Rules, model.DirectionEgress))
+
+	return mcp.NewToolResultStructured(map[string]any{
+		"account":        cfg.Name,
+		"security_group": sg,
+	}, result.String()), nil
+}
+
+// handleGetInstanceNetwork 处理查询实例网络及生效安全组规则的请求,可选评估端口是否对指定网段放行
+func (s *MCPServer) handleGetInstanceNetwork(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	instanceID, ok := args["instance_id"].(string)
+	if !ok || instanceID == "" {
+		return mcp.NewToolResultError("instance_id parameter is required"), nil
+	}
+
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+
+	p, cfg, err := s.getCloudProvider(cloud, accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	network, err := p.GetInstanceNetwork(ctx, instanceID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询实例 %s 的网络信息失败: %v", instanceID, err)), nil
+	}
+
+	structured := map[string]any{
+		"account": cfg.Name,
+		"network": network,
+	}
+	summary := formatInstanceNetwork(ctx, network, cfg.Name)
+
+	// 指定端口时评估可达性
+	if port, ok := args["port"].(float64); ok && port > 0 {
+		protocol, _ := args["protocol"].(string)
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		cidr, _ := args["cidr"].(string)
+		if cidr == "" {
+			cidr = "0.0.0.0/0"
+		}
+		direction, _ := args["direction"].(string)
+		if direction == "" {
+			direction = model.DirectionIngress
+		}
+
+		check, err := network.CheckAccess(direction, protocol, int(port), cidr)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		structured["check"] = check
+		summary += formatAccessCheck(check)
+	}
+
+	return mcp.NewToolResultStructured(structured, summary), nil
+}
+
+// formatInstanceNetwork 格式化实例网络信息及生效规则
+func formatInstanceNetwork(ctx context.Context, network *model.InstanceNetwork, accountName string) string {
+	var result strings.Builder
+	inst := network.Instance
+	result.WriteString(fmt.Sprintf("实例 %s (%s) 网络信息 (账号: %s):\n", inst.Name, inst.ID, accountName))
+	if network.VPC != nil {
+		result.WriteString(fmt.Sprintf("  VPC: %s (%s) %s\n", network.VPC.Name, network.VPC.ID, network.VPC.CIDR))
+	} else if inst.VpcID != "" {
+		result.WriteString(fmt.Sprintf("  VPC: %s\n", inst.VpcID))
+	}
+	if network.Subnet != nil {
+		result.WriteString(fmt.Sprintf("  子网: %s (%s) %s %s\n",
+			network.Subnet.Name, network.Subnet.ID, network.Subnet.CIDR, network.Subnet.Zone))
+	} else if inst.SubnetID != "" {
+		result.WriteString(fmt.Sprintf("  子网: %s\n", inst.SubnetID))
+	}
+	if len(inst.PrivateIP) > 0 {
+		result.WriteString(fmt.Sprintf("  私网 IP: %s\n", strings.Join(inst.PrivateIP, ",")))
+	}
+	if len(inst.PublicIP) > 0 {
+		result.WriteString(fmt.Sprintf("  公网 IP: %s\n", strings.Join(inst.PublicIP, ",")))
+	}
+
+	names := make([]string, 0, len(network.SecurityGroups))
+	for _, sg := range network.SecurityGroups {
+		names = append(names, fmt.Sprintf("%s (%s)", sg.ID, sg.Name))
+	}
+	result.WriteString(fmt.Sprintf("  安全组: %s\n", strings.Join(names, ", ")))
+
+	writeRules(ctx, &result, fmt.Sprintf("生效的入方向规则 (未命中时 %s)", network.DefaultIngress), network.Ingress)
+	writeRules(ctx, &result, fmt.Sprintf("生效的出方向规则 (未命中时 %s)", network.DefaultEgress), network.Egress)
+	return result.String()
+}
+
+// formatAccessCheck 格式化端口可达性评估结果
+func formatAccessCheck(check *model.AccessCheck) string {
+	verdict := "放行"
+	if !check.Allowed {
+		verdict = "拒绝"
+	}
+	return fmt.Sprintf("\n评估结果: %s %s/%d 来自 %s → %s\n  %s\n",
+		check.Direction, check.Protocol, check.Port, check.CIDR, verdict, check.Reason)
+}
+
+// writeRules 按匹配顺序逐行写入安全组规则
+func writeRules(ctx context.Context, sb *strings.Builder, title string, rules []*model.SecurityGroupRule) {
+	sb.WriteString(fmt.Sprintf("\n%s (%d 条):\n", title, len(rules)))
+	writeCompactLines(ctx, sb, len(rules), func(i int) string {
+		rule := rules[i]
+		peer := rule.CIDR
+		if peer == "" {
+			peer = rule.PeerGroupID
+		}
+		line := fmt.Sprintf("[%d] %s %s %s %s (%s)",
+			rule.Priority, rule.Policy, rule.Protocol, rule.PortRange, peer, rule.SecurityGroupID)
+		if rule.Description != "" {
+			line += " " + rule.Description
+		}
+		return line
+	})
+}
+
+// filterRules 筛选指定方向的规则
+func filterRules(rules []*model.SecurityGroupRule, direction string) []*model.SecurityGroupRule {
+	result := make([]*model.SecurityGroupRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Direction == direction {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Redis 工具同时支持阿里云 Redis/Tair 和腾讯云 Redis,通过 cloud 参数选择,默认阿里云
+
+// handleListRedis 处理列出 Redis 实例的请求
+func (s *MCPServer) handleListRedis(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	region, _ := args["region"].(string)
+	return s.queryRedis(ctx, args, region, nil)
+}
+
+// handleSearchRedisByName 处理根据名称搜索 Redis 实例的请求
+func (s *MCPServer) handleSearchRedisByName(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("name parameter is required"), nil
+	}
+
+	return s.queryRedis(ctx, args, "", map[string]string{"name": name})
+}
+
+// handleSearchRedisByAddress 处理根据连接地址搜索 Redis 实例的请求,地址可以是域名、IP 或 host:port
+func (s *MCPServer) handleSearchRedisByAddress(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	address, ok := args["address"].(string)
+	if !ok || address == "" {
+		return mcp.NewToolResultError("address parameter is required"), nil
+	}
+
+	return s.queryRedis(ctx, args, "", map[string]string{"address": address})
+}
+
+// queryRedis 按过滤条件查询 Redis 实例并格式化结果
+func (s *MCPServer) queryRedis(ctx context.Context, args map[string]any, region string, filters map[string]string) (*mcp.CallToolResult, error) {
+	cloud, _ := args["cloud"].(string)
+	accountName, _ := args["account"].(string)
+
+	p, cfg, err := s.getCloudProvider(cloud, accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if filters == nil {
+		filters = make(map[string]string)
+	}
+	filters["engine"] = "redis"
+
+	// Redis 实例在本地分页,PageSize 为 0 时一次返回全部
+	databases, err := p.ListDatabases(ctx, &provider.QueryOptions{
+		Region:  region,
+		Filters: filters,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询 Redis 实例失败: %v", err)), nil
+	}
+
+	if len(databases) == 0 {
+		if address := filters["address"]; address != "" {
+			return mcp.NewToolResultError(fmt.Sprintf("未找到连接地址为 %s 的 Redis 实例 (账号: %s)", address, cfg.Name)), nil
+		}
+		if name := filters["name"]; name != "" {
+			return mcp.NewToolResultError(fmt.Sprintf("未找到名称包含 %s 的 Redis 实例 (账号: %s)", name, cfg.Name)), nil
+		}
+	}
+
+	result := formatDatabases(ctx, databases, cfg.Name)
+	return newListResult(result, databases, map[string]any{"account": cfg.Name}), nil
+}
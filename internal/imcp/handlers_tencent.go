@@ -73,11 +73,11 @@ func (s *MCPServer) handleSearchCVMByIP(ctx context.Context, request mcp.CallToo
 	}
 
 	if len(matchedInstances) == 0 {
-		return mcp.NewToolResultText(fmt.Sprintf("未找到 IP 为 %s 的腾讯云 CVM 实例", ip)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("未找到 IP 为 %s 的腾讯云 CVM 实例", ip)), nil
 	}
 
-	result := formatInstances(matchedInstances, tencentConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	result := formatInstances(ctx, matchedInstances, tencentConfig.Name)
+	return newListResult(result, matchedInstances, map[string]any{"account": tencentConfig.Name}), nil
 }
 
 // handleSearchCVMByName 处理根据名称搜索腾讯云 CVM 的请求
@@ -128,11 +128,11 @@ func (s *MCPServer) handleSearchCVMByName(ctx context.Context, request mcp.CallT
 	}
 
 	if len(matchedInstances) == 0 {
-		return mcp.NewToolResultText(fmt.Sprintf("未找到名称为 %s 的腾讯云 CVM 实例", name)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("未找到名称为 %s 的腾讯云 CVM 实例", name)), nil
 	}
 
-	result := formatInstances(matchedInstances, tencentConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	result := formatInstances(ctx, matchedInstances, tencentConfig.Name)
+	return newListResult(result, matchedInstances, map[string]any{"account": tencentConfig.Name}), nil
 }
 
 // handleListCVM 处理列出腾讯云 CVM 实例的请求
@@ -175,8 +175,8 @@ func (s *MCPServer) handleListCVM(ctx context.Context, request mcp.CallToolReque
 		pageNum++
 	}
 
-	result := formatInstances(allInstances, tencentConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	result := formatInstances(ctx, allInstances, tencentConfig.Name)
+	return newListResult(result, allInstances, map[string]any{"account": tencentConfig.Name}), nil
 }
 
 // handleGetCVM 处理获取腾讯云 CVM 实例详情的请求
@@ -200,11 +200,12 @@ func (s *MCPServer) handleGetCVM(ctx context.Context, request mcp.CallToolReques
 
 	instance, err := p.GetInstance(ctx, instanceID)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("未找到实例 ID 为 %s 的腾讯云 CVM 实例: %v", instanceID, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("未找到实例 ID 为 %s 的腾讯云 CVM 实例: %v", instanceID, err)), nil
 	}
 
-	result := formatInstances([]*model.Instance{instance}, tencentConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	items := []*model.Instance{instance}
+	result := formatInstances(ctx, items, tencentConfig.Name)
+	return newListResult(result, items, map[string]any{"account": tencentConfig.Name}), nil
 }
 
 // ==================== 腾讯云 CDB 处理函数 ====================
@@ -249,8 +250,8 @@ func (s *MCPServer) handleListCDB(ctx context.Context, request mcp.CallToolReque
 		pageNum++
 	}
 
-	result := formatDatabases(allDatabases, tencentConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	result := formatDatabases(ctx, allDatabases, tencentConfig.Name)
+	return newListResult(result, allDatabases, map[string]any{"account": tencentConfig.Name}), nil
 }
 
 // handleSearchCDBByName 处理根据名称搜索腾讯云 CDB 的请求
@@ -301,9 +302,9 @@ func (s *MCPServer) handleSearchCDBByName(ctx context.Context, request mcp.CallT
 	}
 
 	if len(matchedDatabases) == 0 {
-		return mcp.NewToolResultText(fmt.Sprintf("未找到名称为 %s 的腾讯云 CDB 实例", name)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("未找到名称为 %s 的腾讯云 CDB 实例", name)), nil
 	}
 
-	result := formatDatabases(matchedDatabases, tencentConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	result := formatDatabases(ctx, matchedDatabases, tencentConfig.Name)
+	return newListResult(result, matchedDatabases, map[string]any{"account": tencentConfig.Name}), nil
 }
@@ -0,0 +1,94 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handleListCLB 处理列出腾讯云 CLB 的请求
+func (s *MCPServer) handleListCLB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		args = make(map[string]any)
+	}
+
+	accountName, _ := args["account"].(string)
+	region, _ := args["region"].(string)
+
+	p, tencentConfig, err := s.getTencentProvider(accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	lbs, err := p.ListLoadBalancers(ctx, &provider.QueryOptions{Region: region})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询负载均衡失败: %v", err)), nil
+	}
+
+	result := formatLoadBalancers(ctx, lbs, tencentConfig.Name)
+	return newListResult(result, lbs, map[string]any{"account": tencentConfig.Name}), nil
+}
+
+// handleGetCLB 处理获取腾讯云 CLB 详情的请求
+func (s *MCPServer) handleGetCLB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	lbID, ok := args["lb_id"].(string)
+	if !ok || lbID == "" {
+		return mcp.NewToolResultError("lb_id parameter is required"), nil
+	}
+
+	accountName, _ := args["account"].(string)
+
+	p, tencentConfig, err := s.getTencentProvider(accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	lb, err := p.GetLoadBalancer(ctx, lbID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("未找到 ID 为 %s 的 CLB: %v", lbID, err)), nil
+	}
+
+	items := []*model.LoadBalancer{lb}
+	result := formatLoadBalancers(ctx, items, tencentConfig.Name)
+	return newListResult(result, items, map[string]any{"account": tencentConfig.Name}), nil
+}
+
+// handleSearchCLBByIP 处理根据服务地址 (VIP) 搜索腾讯云 CLB 的请求
+func (s *MCPServer) handleSearchCLBByIP(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments type"), nil
+	}
+
+	ip, ok := args["ip"].(string)
+	if !ok || ip == "" {
+		return mcp.NewToolResultError("ip parameter is required"), nil
+	}
+
+	accountName, _ := args["account"].(string)
+
+	p, tencentConfig, err := s.getTencentProvider(accountName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	lbs, err := searchLoadBalancersByIP(ctx, p, ip)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("查询负载均衡失败: %v", err)), nil
+	}
+	if len(lbs) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("未找到服务地址为 %s 的 CLB", ip)), nil
+	}
+
+	result := formatLoadBalancers(ctx, lbs, tencentConfig.Name)
+	return newListResult(result, lbs, map[string]any{"account": tencentConfig.Name}), nil
+}
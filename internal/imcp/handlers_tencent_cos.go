@@ -3,6 +3,7 @@ package imcp
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
 	"github.com/eryajf/zenops/internal/model"
@@ -30,6 +31,7 @@ func (s *MCPServer) handleListCOS(ctx context.Context, request mcp.CallToolReque
 	}
 
 	providerConfig := map[string]any{
+		"account":    tencentConfig.Name,
 		"secret_id":  tencentConfig.AK,
 		"secret_key": tencentConfig.SK,
 		"regions":    interfaceSlice(tencentConfig.Regions),
@@ -63,8 +65,8 @@ func (s *MCPServer) handleListCOS(ctx context.Context, request mcp.CallToolReque
 		pageNum++
 	}
 
-	result := formatCOSBuckets(allBuckets, tencentConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	result := formatCOSBuckets(ctx, allBuckets, tencentConfig.Name)
+	return newListResult(result, allBuckets, map[string]any{"account": tencentConfig.Name}), nil
 }
 
 // handleGetCOS 处理获取 COS 存储桶详情的请求
@@ -92,6 +94,7 @@ func (s *MCPServer) handleGetCOS(ctx context.Context, request mcp.CallToolReques
 	}
 
 	providerConfig := map[string]any{
+		"account":    tencentConfig.Name,
 		"secret_id":  tencentConfig.AK,
 		"secret_key": tencentConfig.SK,
 		"regions":    interfaceSlice(tencentConfig.Regions),
@@ -103,15 +106,16 @@ func (s *MCPServer) handleGetCOS(ctx context.Context, request mcp.CallToolReques
 
 	bucket, err := p.GetOSSBucket(ctx, bucketName)
 	if err != nil {
-		return mcp.NewToolResultText(fmt.Sprintf("未找到存储桶 %s: %v", bucketName, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("未找到存储桶 %s: %v", bucketName, err)), nil
 	}
 
-	result := formatCOSBuckets([]*model.OSSBucket{bucket}, tencentConfig.Name)
-	return mcp.NewToolResultText(result), nil
+	items := []*model.OSSBucket{bucket}
+	result := formatCOSBuckets(ctx, items, tencentConfig.Name)
+	return newListResult(result, items, map[string]any{"account": tencentConfig.Name}), nil
 }
 
 // formatCOSBuckets 格式化 COS 存储桶列表
-func formatCOSBuckets(buckets []*model.OSSBucket, accountName string) string {
+func formatCOSBuckets(ctx context.Context, buckets []*model.OSSBucket, accountName string) string {
 	if len(buckets) == 0 {
 		return "未找到任何 COS 存储桶"
 	}
@@ -119,6 +123,16 @@ func formatCOSBuckets(buckets []*model.OSSBucket, accountName string) string {
 	result := fmt.Sprintf("## 腾讯云 COS 存储桶列表 (账号: %s)\n\n", accountName)
 	result += fmt.Sprintf("总数: %d\n\n", len(buckets))
 
+	// 存储桶较多时每个存储桶只输出一行摘要
+	if compactDetails(ctx, len(buckets)) {
+		var sb strings.Builder
+		writeCompactLines(ctx, &sb, len(buckets), func(i int) string {
+			bucket := buckets[i]
+			return fmt.Sprintf("%s | %s | %s", bucket.Name, bucket.Region, bucket.StorageClass)
+		})
+		return result + sb.String()
+	}
+
 	for _, bucket := range buckets {
 		result += fmt.Sprintf("### %s\n", bucket.Name)
 		result += fmt.Sprintf("- **区域**: %s\n", bucket.Region)
@@ -1,13 +1,16 @@
 package imcp
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/eryajf/zenops/internal/config"
 	"github.com/eryajf/zenops/internal/model"
 	"github.com/eryajf/zenops/internal/provider"
 	"github.com/eryajf/zenops/internal/provider/aliyun"
+	"github.com/mark3labs/mcp-go/mcp"
 )
 
 // ==================== Provider 辅助函数 ====================
@@ -28,6 +31,7 @@ func (s *MCPServer) getAliyunProvider(accountName string) (provider.Provider, *c
 
 	// 初始化 Provider
 	providerConfig := map[string]any{
+		"account":           aliyunConfig.Name,
 		"access_key_id":     aliyunConfig.AK,
 		"access_key_secret": aliyunConfig.SK,
 		"regions":           interfaceSlice(aliyunConfig.Regions),
@@ -78,6 +82,7 @@ func (s *MCPServer) getTencentProvider(accountName string) (provider.Provider, *
 
 	// 初始化 Provider
 	providerConfig := map[string]any{
+		"account":    tencentConfig.Name,
 		"secret_id":  tencentConfig.AK,
 		"secret_key": tencentConfig.SK,
 		"regions":    interfaceSlice(tencentConfig.Regions),
@@ -90,6 +95,18 @@ func (s *MCPServer) getTencentProvider(accountName string) (provider.Provider, *
 	return p, tencentConfig, nil
 }
 
+// getCloudProvider 按云平台获取 Provider,cloud 为空时默认阿里云
+func (s *MCPServer) getCloudProvider(cloud, accountName string) (provider.Provider, *config.ProviderConfig, error) {
+	switch cloud {
+	case "", "aliyun":
+		return s.getAliyunProvider(accountName)
+	case "tencent":
+		return s.getTencentProvider(accountName)
+	default:
+		return nil, nil, fmt.Errorf("unsupported cloud %q, must be aliyun or tencent", cloud)
+	}
+}
+
 // getJenkinsProvider 获取 Jenkins Provider
 func (s *MCPServer) getJenkinsProvider() (provider.CICDProvider, error) {
 	// 创建 Provider
@@ -169,10 +186,86 @@ func interfaceSlice(s []string) []any {
 	return result
 }
 
+// ==================== 变更操作 ====================
+
+// confirmTools 会修改云上资源的工具,在聊天中调用前需要用户确认,且不提供给 LLM 自动调用
+var confirmTools = []string{"refresh_cdn_cache", "rerun_jenkins_build"}
+
+// RequiresConfirmation 工具在聊天中调用前是否需要用户确认
+func RequiresConfirmation(toolName string) bool {
+	return slices.Contains(confirmTools, toolName)
+}
+
+// ConfirmTools 返回需要用户确认的工具
+func ConfirmTools() []string {
+	return slices.Clone(confirmTools)
+}
+
+// ==================== 结构化输出 ====================
+
+const (
+	// summaryDetailLimit 文本摘要中逐条展示详细信息的最大条目数,超过时每条只展示一行
+	summaryDetailLimit = 5
+	// summaryLineLimit 文本摘要中最多展示的条目行数,完整数据见结构化内容
+	summaryLineLimit = 50
+)
+
+// newListResult 生成列表类工具的结果: 结构化 JSON 内容(meta 字段 + total + items) + 精简文本摘要
+func newListResult[T any](summary string, items []T, meta map[string]any) *mcp.CallToolResult {
+	if items == nil {
+		items = []T{}
+	}
+
+	structured := make(map[string]any, len(meta)+2)
+	for k, v := range meta {
+		if v != "" {
+			structured[k] = v
+		}
+	}
+	structured["total"] = len(items)
+	structured["items"] = items
+
+	return mcp.NewToolResultStructured(structured, summary)
+}
+
+// fullTextKey 上下文中标记工具结果直接展示给用户的键
+type fullTextKey struct{}
+
+// WithFullText 标记工具结果的文本直接展示给用户(如聊天中的命令和意图查询):
+// 逐条展示详细信息且不省略条目,精简摘要只用于 LLM 的工具结果
+func WithFullText(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fullTextKey{}, true)
+}
+
+// isFullText 是否需要输出完整文本
+func isFullText(ctx context.Context) bool {
+	full, _ := ctx.Value(fullTextKey{}).(bool)
+	return full
+}
+
+// compactDetails 条目数超过 summaryDetailLimit 且不需要完整文本时,每个条目只展示一行
+func compactDetails(ctx context.Context, total int) bool {
+	return total > summaryDetailLimit && !isFullText(ctx)
+}
+
+// writeCompactLines 每个条目写入一行摘要,超过 summaryLineLimit 时省略剩余条目,需要完整文本时不省略
+func writeCompactLines(ctx context.Context, sb *strings.Builder, total int, line func(i int) string) {
+	limit := summaryLineLimit
+	if isFullText(ctx) {
+		limit = total
+	}
+	for i := 0; i < total && i < limit; i++ {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, line(i)))
+	}
+	if total > limit {
+		sb.WriteString(fmt.Sprintf("... 其余 %d 条已省略,完整数据见结构化内容\n", total-limit))
+	}
+}
+
 // ==================== 格式化函数 ====================
 
 // formatInstances 格式化 ECS/CVM 实例信息
-func formatInstances(instances []*model.Instance, accountName string) string {
+func formatInstances(ctx context.Context, instances []*model.Instance, accountName string) string {
 	if len(instances) == 0 {
 		return "未找到任何实例"
 	}
@@ -180,6 +273,16 @@ func formatInstances(instances []*model.Instance, accountName string) string {
 	var result strings.Builder
 	result.WriteString(fmt.Sprintf("找到 %d 个实例 (账号: %s):\n\n", len(instances), accountName))
 
+	// 实例较多时每个实例只输出一行摘要
+	if compactDetails(ctx, len(instances)) {
+		writeCompactLines(ctx, &result, len(instances), func(i int) string {
+			inst := instances[i]
+			return fmt.Sprintf("%s | %s | %s | %s | %d核 %dMB | %s",
+				inst.ID, inst.Name, inst.Region, inst.Status, inst.CPU, inst.Memory, strings.Join(inst.PrivateIP, ","))
+		})
+		return result.String()
+	}
+
 	for i, inst := range instances {
 		result.WriteString(fmt.Sprintf("【实例 %d】\n", i+1))
 		result.WriteString(fmt.Sprintf("  实例 ID: %s\n", inst.ID))
@@ -212,7 +315,7 @@ func formatInstances(instances []*model.Instance, accountName string) string {
 }
 
 // formatDatabases 格式化 RDS/CDB 实例信息
-func formatDatabases(databases []*model.Database, accountName string) string {
+func formatDatabases(ctx context.Context, databases []*model.Database, accountName string) string {
 	if len(databases) == 0 {
 		return "未找到任何数据库实例"
 	}
@@ -220,6 +323,16 @@ func formatDatabases(databases []*model.Database, accountName string) string {
 	var result strings.Builder
 	result.WriteString(fmt.Sprintf("找到 %d 个数据库实例 (账号: %s):\n\n", len(databases), accountName))
 
+	// 实例较多时每个实例只输出一行摘要
+	if compactDetails(ctx, len(databases)) {
+		writeCompactLines(ctx, &result, len(databases), func(i int) string {
+			db := databases[i]
+			return fmt.Sprintf("%s | %s | %s | %s %s | %s",
+				db.ID, db.Name, db.Region, db.Engine, db.EngineVersion, db.Status)
+		})
+		return result.String()
+	}
+
 	for i, db := range databases {
 		result.WriteString(fmt.Sprintf("【实例 %d】\n", i+1))
 		result.WriteString(fmt.Sprintf("  实例 ID: %s\n", db.ID))
@@ -243,3 +356,78 @@ func formatDatabases(databases []*model.Database, accountName string) string {
 
 	return result.String()
 }
+
+// formatLoadBalancers 格式化 SLB/ALB/CLB 负载均衡信息,详情中包含监听和后端服务器
+func formatLoadBalancers(ctx context.Context, lbs []*model.LoadBalancer, accountName string) string {
+	if len(lbs) == 0 {
+		return "未找到任何负载均衡"
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("找到 %d 个负载均衡 (账号: %s):\n\n", len(lbs), accountName))
+
+	// 负载均衡较多时每个只输出一行摘要
+	if compactDetails(ctx, len(lbs)) {
+		writeCompactLines(ctx, &result, len(lbs), func(i int) string {
+			lb := lbs[i]
+			return fmt.Sprintf("%s | %s | %s | %s | %s | %s",
+				lb.ID, lb.Name, lb.Type, lb.Region, lb.Status, strings.Join(lb.Addresses, ","))
+		})
+		return result.String()
+	}
+
+	for i, lb := range lbs {
+		result.WriteString(fmt.Sprintf("【负载均衡 %d】\n", i+1))
+		result.WriteString(fmt.Sprintf("  ID: %s\n", lb.ID))
+		result.WriteString(fmt.Sprintf("  名称: %s\n", lb.Name))
+		result.WriteString(fmt.Sprintf("  类型: %s\n", strings.ToUpper(lb.Type)))
+		result.WriteString(fmt.Sprintf("  区域: %s\n", lb.Region))
+		result.WriteString(fmt.Sprintf("  状态: %s\n", lb.Status))
+		result.WriteString(fmt.Sprintf("  网络类型: %s\n", lb.AddressType))
+
+		if len(lb.Addresses) > 0 {
+			result.WriteString(fmt.Sprintf("  服务地址: %v\n", lb.Addresses))
+		}
+		if lb.DNSName != "" {
+			result.WriteString(fmt.Sprintf("  域名: %s\n", lb.DNSName))
+		}
+		if lb.VpcID != "" {
+			result.WriteString(fmt.Sprintf("  VPC: %s\n", lb.VpcID))
+		}
+
+		if len(lb.Listeners) > 0 {
+			result.WriteString("  监听:\n")
+			for _, l := range lb.Listeners {
+				result.WriteString(fmt.Sprintf("    - %s:%d", l.Protocol, l.Port))
+				if l.Name != "" {
+					result.WriteString(fmt.Sprintf(" (%s)", l.Name))
+				}
+				result.WriteString("\n")
+			}
+		}
+
+		if len(lb.Backends) > 0 {
+			result.WriteString("  后端服务器:\n")
+			for _, b := range lb.Backends {
+				name := b.ServerID
+				if b.Instance != nil && b.Instance.Name != "" {
+					name = fmt.Sprintf("%s (%s)", b.ServerID, b.Instance.Name)
+				}
+				result.WriteString(fmt.Sprintf("    - [%d] %s %s:%d 权重 %d 健康状态 %s\n",
+					b.ListenerPort, name, b.IP, b.Port, b.Weight, b.Health))
+			}
+		}
+
+		if !lb.CreatedAt.IsZero() {
+			result.WriteString(fmt.Sprintf("  创建时间: %s\n", lb.CreatedAt.Format("2006-01-02 15:04:05")))
+		}
+
+		if lb.ConsoleURL != "" {
+			result.WriteString(fmt.Sprintf("  控制台地址: %s\n", lb.ConsoleURL))
+		}
+
+		result.WriteString("\n")
+	}
+
+	return result.String()
+}
@@ -0,0 +1,43 @@
+package imcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWriteCompactLines(t *testing.T) {
+	line := func(i int) string { return fmt.Sprintf("item-%d", i) }
+
+	var compact strings.Builder
+	writeCompactLines(context.Background(), &compact, summaryLineLimit+10, line)
+	if got := strings.Count(compact.String(), "item-"); got != summaryLineLimit {
+		t.Fatalf("expected %d lines in compact summary, got %d", summaryLineLimit, got)
+	}
+	if !strings.Contains(compact.String(), "其余 10 条已省略") {
+		t.Fatalf("expected omitted notice, got %q", compact.String())
+	}
+
+	var full strings.Builder
+	writeCompactLines(WithFullText(context.Background()), &full, summaryLineLimit+10, line)
+	if got := strings.Count(full.String(), "item-"); got != summaryLineLimit+10 {
+		t.Fatalf("expected all %d lines in full text, got %d", summaryLineLimit+10, got)
+	}
+	if strings.Contains(full.String(), "已省略") {
+		t.Fatalf("full text should not omit lines, got %q", full.String())
+	}
+}
+
+func TestCompactDetails(t *testing.T) {
+	ctx := context.Background()
+	if compactDetails(ctx, summaryDetailLimit) {
+		t.Fatal("expected details when within the limit")
+	}
+	if !compactDetails(ctx, summaryDetailLimit+1) {
+		t.Fatal("expected compact lines above the limit")
+	}
+	if compactDetails(WithFullText(ctx), summaryDetailLimit+1) {
+		t.Fatal("expected details in full text")
+	}
+}
@@ -3,9 +3,13 @@ package imcp
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/audit"
 	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/metrics"
+	"github.com/eryajf/zenops/internal/tracing"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -24,6 +28,7 @@ func NewMCPServer(cfg *config.Config) *MCPServer {
 		"zenops",
 		"1.0.0",
 		server.WithToolCapabilities(true),
+		server.WithToolHandlerMiddleware(auditMiddleware),
 	)
 
 	s := &MCPServer{
@@ -168,6 +173,55 @@ func (s *MCPServer) registerTools() {
 		s.handleGetOSS,
 	)
 
+	// ==================== 阿里云负载均衡工具 ====================
+
+	// list_slb - 列出阿里云负载均衡
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_slb",
+			mcp.WithDescription("列出阿里云负载均衡(传统型 SLB 和应用型 ALB)"),
+			mcp.WithString("account",
+				mcp.Description("阿里云账号名称(可选)"),
+			),
+			mcp.WithString("region",
+				mcp.Description("区域(可选)"),
+			),
+			mcp.WithString("type",
+				mcp.Description("负载均衡类型(可选): slb, alb"),
+			),
+		),
+		s.handleListSLB,
+	)
+
+	// get_slb - 获取阿里云负载均衡详情
+	s.mcpServer.AddTool(
+		mcp.NewTool("get_slb",
+			mcp.WithDescription("获取阿里云负载均衡详情,包含监听、后端服务器(关联 ECS 实例)及健康状态"),
+			mcp.WithString("lb_id",
+				mcp.Required(),
+				mcp.Description("负载均衡 ID(lb- 或 alb- 开头)"),
+			),
+			mcp.WithString("account",
+				mcp.Description("阿里云账号名称(可选)"),
+			),
+		),
+		s.handleGetSLB,
+	)
+
+	// search_slb_by_ip - 根据服务地址搜索阿里云负载均衡
+	s.mcpServer.AddTool(
+		mcp.NewTool("search_slb_by_ip",
+			mcp.WithDescription("根据服务地址(VIP)搜索阿里云负载均衡,返回监听和后端服务器"),
+			mcp.WithString("ip",
+				mcp.Required(),
+				mcp.Description("负载均衡服务地址"),
+			),
+			mcp.WithString("account",
+				mcp.Description("阿里云账号名称(可选)"),
+			),
+		),
+		s.handleSearchSLBByIP,
+	)
+
 	// ==================== 腾讯云 CVM 工具 ====================
 
 	// 7. search_cvm_by_ip - 根据 IP 搜索腾讯云 CVM
@@ -288,6 +342,556 @@ func (s *MCPServer) registerTools() {
 		s.handleGetCOS,
 	)
 
+	// ==================== 腾讯云负载均衡工具 ====================
+
+	// list_clb - 列出腾讯云 CLB
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_clb",
+			mcp.WithDescription("列出所有腾讯云 CLB 负载均衡"),
+			mcp.WithString("account",
+				mcp.Description("腾讯云账号名称(可选)"),
+			),
+			mcp.WithString("region",
+				mcp.Description("区域(可选)"),
+			),
+		),
+		s.handleListCLB,
+	)
+
+	// get_clb - 获取腾讯云 CLB 详情
+	s.mcpServer.AddTool(
+		mcp.NewTool("get_clb",
+			mcp.WithDescription("获取腾讯云 CLB 详情,包含监听、后端服务器(关联 CVM 实例)及健康状态"),
+			mcp.WithString("lb_id",
+				mcp.Required(),
+				mcp.Description("CLB 实例 ID"),
+			),
+			mcp.WithString("account",
+				mcp.Description("腾讯云账号名称(可选)"),
+			),
+		),
+		s.handleGetCLB,
+	)
+
+	// search_clb_by_ip - 根据服务地址搜索腾讯云 CLB
+	s.mcpServer.AddTool(
+		mcp.NewTool("search_clb_by_ip",
+			mcp.WithDescription("根据服务地址(VIP)搜索腾讯云 CLB,返回监听和后端服务器"),
+			mcp.WithString("ip",
+				mcp.Required(),
+				mcp.Description("CLB 服务地址"),
+			),
+			mcp.WithString("account",
+				mcp.Description("腾讯云账号名称(可选)"),
+			),
+		),
+		s.handleSearchCLBByIP,
+	)
+
+	// ==================== 网络工具 (阿里云/腾讯云) ====================
+
+	// list_vpcs - 列出 VPC
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_vpcs",
+			mcp.WithDescription("列出阿里云或腾讯云的 VPC 专有网络"),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+			mcp.WithString("region",
+				mcp.Description("区域(可选)"),
+			),
+		),
+		s.handleListVPCs,
+	)
+
+	// list_subnets - 列出子网
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_subnets",
+			mcp.WithDescription("列出子网(阿里云交换机 vSwitch、腾讯云子网),可按 VPC 过滤"),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithString("vpc_id",
+				mcp.Description("VPC ID(可选)"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+			mcp.WithString("region",
+				mcp.Description("区域(可选)"),
+			),
+		),
+		s.handleListSubnets,
+	)
+
+	// list_security_groups - 列出安全组
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_security_groups",
+			mcp.WithDescription("列出安全组(不含规则),阿里云可按 VPC 过滤"),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithString("vpc_id",
+				mcp.Description("VPC ID(可选,仅阿里云)"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+			mcp.WithString("region",
+				mcp.Description("区域(可选)"),
+			),
+		),
+		s.handleListSecurityGroups,
+	)
+
+	// get_security_group - 获取安全组详情
+	s.mcpServer.AddTool(
+		mcp.NewTool("get_security_group",
+			mcp.WithDescription("获取安全组详情,包含按匹配顺序排列的入方向和出方向规则"),
+			mcp.WithString("sg_id",
+				mcp.Required(),
+				mcp.Description("安全组 ID"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+		),
+		s.handleGetSecurityGroup,
+	)
+
+	// get_instance_network - 查询实例网络及生效的安全组规则
+	s.mcpServer.AddTool(
+		mcp.NewTool("get_instance_network",
+			mcp.WithDescription("查询 ECS/CVM 实例所在的 VPC、子网、绑定的安全组及生效的入/出方向规则;指定 port 时评估该端口是否对指定网段放行"),
+			mcp.WithString("instance_id",
+				mcp.Required(),
+				mcp.Description("实例 ID"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithNumber("port",
+				mcp.Description("要评估的端口(可选)"),
+			),
+			mcp.WithString("protocol",
+				mcp.Description("要评估的协议(可选): tcp, udp, icmp,默认 tcp"),
+			),
+			mcp.WithString("cidr",
+				mcp.Description("对端 IP 或网段(可选),默认 0.0.0.0/0"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("评估方向(可选): ingress, egress,默认 ingress"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+		),
+		s.handleGetInstanceNetwork,
+	)
+
+	// ==================== Redis 工具 (阿里云/腾讯云) ====================
+
+	// list_redis - 列出 Redis 实例
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_redis",
+			mcp.WithDescription("列出阿里云 Redis/Tair 或腾讯云 Redis 实例"),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+			mcp.WithString("region",
+				mcp.Description("区域(可选)"),
+			),
+		),
+		s.handleListRedis,
+	)
+
+	// search_redis_by_name - 根据名称搜索 Redis 实例
+	s.mcpServer.AddTool(
+		mcp.NewTool("search_redis_by_name",
+			mcp.WithDescription("根据名称搜索阿里云 Redis/Tair 或腾讯云 Redis 实例"),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Redis 实例名称,模糊匹配"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+		),
+		s.handleSearchRedisByName,
+	)
+
+	// search_redis_by_address - 根据连接地址搜索 Redis 实例
+	s.mcpServer.AddTool(
+		mcp.NewTool("search_redis_by_address",
+			mcp.WithDescription("根据连接地址搜索 Redis 实例,例如 r-bp1xxx.redis.rds.aliyuncs.com、10.0.0.8 或 10.0.0.8:6379"),
+			mcp.WithString("address",
+				mcp.Required(),
+				mcp.Description("连接地址,支持域名、IP 或 host:port"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+		),
+		s.handleSearchRedisByAddress,
+	)
+
+	// ==================== 公网 IP 工具 (阿里云/腾讯云) ====================
+
+	// list_eip - 列出公网 IP
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_eip",
+			mcp.WithDescription("列出阿里云或腾讯云的弹性公网 IP (EIP) 及实例的普通公网 IP,包含带宽、计费方式和绑定资源"),
+			mcp.WithString("status",
+				mcp.Description("状态(可选): in_use(已绑定), available(未绑定)"),
+			),
+			mcp.WithString("type",
+				mcp.Description("类型(可选): eip(弹性公网 IP), public_ip(普通公网 IP)"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+			mcp.WithString("region",
+				mcp.Description("区域(可选)"),
+			),
+		),
+		s.handleListEIP,
+	)
+
+	// search_eip_by_ip - 根据 IP 查询公网 IP
+	s.mcpServer.AddTool(
+		mcp.NewTool("search_eip_by_ip",
+			mcp.WithDescription("根据 IP 查询弹性公网 IP 或普通公网 IP 的带宽、计费方式和绑定资源"),
+			mcp.WithString("ip",
+				mcp.Required(),
+				mcp.Description("公网 IP 地址"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+		),
+		s.handleSearchEIPByIP,
+	)
+
+	// report_unassociated_eips - 未绑定仍计费的 EIP 报告
+	s.mcpServer.AddTool(
+		mcp.NewTool("report_unassociated_eips",
+			mcp.WithDescription("找出未绑定任何资源但仍在计费的弹性公网 IP,用于清理闲置资源"),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+			mcp.WithString("region",
+				mcp.Description("区域(可选)"),
+			),
+		),
+		s.handleReportUnassociatedEIPs,
+	)
+
+	// ==================== DNS 工具 (阿里云云解析/腾讯云 DNSPod) ====================
+
+	// list_domains - 列出托管域名
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_domains",
+			mcp.WithDescription("列出阿里云云解析 DNS 和腾讯云 DNSPod 托管的域名,默认查询所有启用的账号"),
+			mcp.WithString("keyword",
+				mcp.Description("域名关键字(可选)"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认查询全部"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选),默认查询所有启用的账号"),
+			),
+		),
+		s.handleListDomains,
+	)
+
+	// list_dns_records - 列出域名解析记录
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_dns_records",
+			mcp.WithDescription("列出托管域名的解析记录,自动识别域名所属的云平台和账号"),
+			mcp.WithString("domain",
+				mcp.Required(),
+				mcp.Description("托管的主域名,例如 example.com"),
+			),
+			mcp.WithString("rr",
+				mcp.Description("主机记录(可选),例如 www,@ 表示主域名"),
+			),
+			mcp.WithString("type",
+				mcp.Description("记录类型(可选): A, AAAA, CNAME, MX, TXT 等"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认查询全部"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选),默认查询所有启用的账号"),
+			),
+		),
+		s.handleListDNSRecords,
+	)
+
+	// resolve_domain_to_resources - 解析域名到云资源
+	s.mcpServer.AddTool(
+		mcp.NewTool("resolve_domain_to_resources",
+			mcp.WithDescription("沿 A/CNAME 解析记录跟随主机名,找到最终提供服务的 ECS/CVM 实例、负载均衡、CDN 或对象存储"),
+			mcp.WithString("hostname",
+				mcp.Required(),
+				mcp.Description("主机名,例如 api.example.com"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认查询全部"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选),默认查询所有启用的账号"),
+			),
+		),
+		s.handleResolveDomainToResources,
+	)
+
+	// search_dns_by_ip - 根据 IP 反查解析记录
+	s.mcpServer.AddTool(
+		mcp.NewTool("search_dns_by_ip",
+			mcp.WithDescription("根据 IP 反查所有指向它的解析记录,也支持传入 CNAME 目标域名"),
+			mcp.WithString("ip",
+				mcp.Required(),
+				mcp.Description("记录值,通常为 IP"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认查询全部"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选),默认查询所有启用的账号"),
+			),
+		),
+		s.handleSearchDNSByIP,
+	)
+
+	// ==================== 证书工具 (阿里云 CAS/腾讯云 SSL/主动 TLS 检查) ====================
+
+	// list_certificates - 列出 SSL 证书
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_certificates",
+			mcp.WithDescription("列出阿里云数字证书管理服务和腾讯云 SSL 证书中的证书,以及对配置主机进行 TLS 握手获取的线上证书,包含域名、颁发者、过期时间和已部署的云产品"),
+			mcp.WithString("keyword",
+				mcp.Description("域名或名称关键字(可选)"),
+			),
+			mcp.WithString("source",
+				mcp.Description("证书来源(可选): cloud 云证书服务, tls 主动检查配置的主机,默认全部"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,指定后只查询云证书"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选),默认查询所有启用的账号"),
+			),
+		),
+		s.handleListCertificates,
+	)
+
+	// list_expiring_certificates - 列出即将过期的证书
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_expiring_certificates",
+			mcp.WithDescription("列出指定天数内过期或已过期的 SSL 证书,按过期时间排序,用于续期排查"),
+			mcp.WithNumber("days",
+				mcp.Description("剩余有效天数阈值(默认 30)"),
+			),
+			mcp.WithString("source",
+				mcp.Description("证书来源(可选): cloud, tls,默认全部"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,指定后只查询云证书"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选),默认查询所有启用的账号"),
+			),
+		),
+		s.handleListExpiringCertificates,
+	)
+
+	// check_certificate - 检查主机的线上证书
+	s.mcpServer.AddTool(
+		mcp.NewTool("check_certificate",
+			mcp.WithDescription("与指定主机进行 TLS 握手,查看线上实际使用的证书、过期时间和证书链校验结果"),
+			mcp.WithString("host",
+				mcp.Required(),
+				mcp.Description("主机,例如 www.example.com 或 www.example.com:8443"),
+			),
+		),
+		s.handleCheckCertificate,
+	)
+
+	// ==================== CDN 工具 (阿里云/腾讯云) ====================
+
+	// list_cdn_domains - 列出 CDN 加速域名
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_cdn_domains",
+			mcp.WithDescription("列出阿里云或腾讯云的 CDN 加速域名,包含 CNAME、状态、源站和 HTTPS 证书配置"),
+			mcp.WithString("keyword",
+				mcp.Description("域名关键字(可选),模糊匹配"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选)"),
+			),
+		),
+		s.handleListCDNDomains,
+	)
+
+	// refresh_cdn_cache - 刷新 CDN 缓存,会真实提交刷新任务,需在配置中显式开启
+	if s.config.CDN.AllowRefresh {
+		s.mcpServer.AddTool(
+			mcp.NewTool("refresh_cdn_cache",
+				mcp.WithDescription("刷新 CDN 缓存(会真实提交刷新任务,刷新后请求将回源),以 / 结尾的路径按目录刷新,其余按 URL 刷新"),
+				mcp.WithString("paths",
+					mcp.Required(),
+					mcp.Description("要刷新的 URL 或目录,多个以逗号分隔,例如 https://static.example.com/app.js,https://static.example.com/img/"),
+				),
+				mcp.WithString("cloud",
+					mcp.Description("云平台(可选): aliyun, tencent,默认 aliyun"),
+				),
+				mcp.WithString("account",
+					mcp.Description("账号名称(可选)"),
+				),
+			),
+			s.handleRefreshCDNCache,
+		)
+	}
+
+	// ==================== 费用工具 (阿里云 BSS/腾讯云账单,跨账号汇总) ====================
+
+	// get_cost_summary - 查询各账号本月至今和上月费用
+	s.mcpServer.AddTool(
+		mcp.NewTool("get_cost_summary",
+			mcp.WithDescription("查询阿里云、腾讯云各账号本月至今和上月的费用合计(应付金额),账单通常有 1 天左右延迟"),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选),默认查询所有启用的账号"),
+			),
+		),
+		s.handleGetCostSummary,
+	)
+
+	// get_cost_breakdown - 按维度拆分费用
+	s.mcpServer.AddTool(
+		mcp.NewTool("get_cost_breakdown",
+			mcp.WithDescription("按账号、产品、地域或标签拆分指定账期的费用,按金额降序排列并给出占比"),
+			mcp.WithString("period",
+				mcp.Description("账期(可选): current 本月至今, last 上月, 或 YYYY-MM,默认 current"),
+			),
+			mcp.WithString("group_by",
+				mcp.Description("拆分维度(可选): account, product, region, tag,默认 product"),
+			),
+			mcp.WithString("tag_key",
+				mcp.Description("标签键,group_by 为 tag 时必填,例如 team"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选),默认查询所有启用的账号"),
+			),
+		),
+		s.handleGetCostBreakdown,
+	)
+
+	// list_top_cost_instances - 列出费用最高的实例
+	s.mcpServer.AddTool(
+		mcp.NewTool("list_top_cost_instances",
+			mcp.WithDescription("列出指定账期内费用最高的实例,包含产品、地域和所属账号"),
+			mcp.WithString("period",
+				mcp.Description("账期(可选): current 本月至今, last 上月, 或 YYYY-MM,默认 current"),
+			),
+			mcp.WithNumber("top",
+				mcp.Description("返回数量(默认 10)"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选),默认查询所有启用的账号"),
+			),
+		),
+		s.handleListTopCostInstances,
+	)
+
+	// ==================== 闲置资源工具 (阿里云云监控/腾讯云可观测平台,跨账号汇总) ====================
+
+	// report_idle_resources - 检测闲置的实例和数据库
+	s.mcpServer.AddTool(
+		mcp.NewTool("report_idle_resources",
+			mcp.WithDescription("根据云监控的 CPU、内存和网络指标找出最近 N 天持续低负载的 ECS/CVM 实例和 RDS/CDB 数据库,附带上月费用或同规格估算费用,给出释放或降配建议"),
+			mcp.WithNumber("days",
+				mcp.Description("统计天数(可选),默认使用 idle.days 配置,最多 30 天"),
+			),
+			mcp.WithString("type",
+				mcp.Description("资源类型(可选): instance 实例, database 数据库,默认全部"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选),默认查询所有启用的账号"),
+			),
+		),
+		s.handleReportIdleResources,
+	)
+
+	// ==================== 监控指标工具 (阿里云云监控/腾讯云可观测平台) ====================
+
+	// get_instance_metrics - 查询实例或数据库的监控指标
+	s.mcpServer.AddTool(
+		mcp.NewTool("get_instance_metrics",
+			mcp.WithDescription("查询 ECS/CVM 实例或 RDS/CDB 数据库的 CPU、内存、磁盘和网络流量监控指标,返回当前值、平均值、峰值和降采样后的趋势,用于判断实例是否繁忙"),
+			mcp.WithString("resource",
+				mcp.Required(),
+				mcp.Description("实例 ID、数据库 ID 或实例 IP (私网、公网或弹性公网 IP)"),
+			),
+			mcp.WithString("metrics",
+				mcp.Description("指标(可选),逗号分隔: cpu, memory, disk(仅数据库), network, network_in, network_out,默认全部"),
+			),
+			mcp.WithString("window",
+				mcp.Description("时间范围(可选),如 30m、6h、24h、7d,默认 1h,最长 30d"),
+			),
+			mcp.WithString("type",
+				mcp.Description("资源类型(可选): instance 实例, database 数据库,默认按 ID 前缀推断"),
+			),
+			mcp.WithString("cloud",
+				mcp.Description("云平台(可选): aliyun, tencent"),
+			),
+			mcp.WithString("account",
+				mcp.Description("账号名称(可选),默认在所有启用的账号中查找"),
+			),
+		),
+		s.handleGetInstanceMetrics,
+	)
+
 	// ==================== Jenkins 工具 ====================
 
 	// 13. list_jenkins_jobs - 列出 Jenkins Jobs
@@ -324,6 +928,41 @@ func (s *MCPServer) registerTools() {
 		),
 		s.handleListJenkinsBuilds,
 	)
+
+	// 16. get_jenkins_build_log - 获取 Jenkins 构建日志
+	s.mcpServer.AddTool(
+		mcp.NewTool("get_jenkins_build_log",
+			mcp.WithDescription("获取 Jenkins 构建的控制台日志(默认返回最后 100 行)"),
+			mcp.WithString("job_name",
+				mcp.Required(),
+				mcp.Description("Job 名称"),
+			),
+			mcp.WithNumber("build_number",
+				mcp.Description("构建号(可选,默认最后一次构建)"),
+			),
+			mcp.WithNumber("tail_lines",
+				mcp.Description("返回日志的最后多少行(默认 100)"),
+			),
+		),
+		s.handleGetJenkinsBuildLog,
+	)
+
+	// 17. rerun_jenkins_build - 重新触发 Jenkins 构建,会真实触发构建,需在配置中显式开启
+	if s.config.CICD.Jenkins.AllowRerun {
+		s.mcpServer.AddTool(
+			mcp.NewTool("rerun_jenkins_build",
+				mcp.WithDescription("使用原构建的参数重新触发 Jenkins 构建(会真实触发一次新的构建)"),
+				mcp.WithString("job_name",
+					mcp.Required(),
+					mcp.Description("Job 名称"),
+				),
+				mcp.WithNumber("build_number",
+					mcp.Description("沿用参数的构建号(可选,默认最后一次构建)"),
+				),
+			),
+			s.handleRerunJenkinsBuild,
+		)
+	}
 }
 
 // Start 启动 MCP 服务器 (stdio 模式)
@@ -359,8 +998,50 @@ func (s *MCPServer) StopSSE(ctx context.Context) error {
 	return nil
 }
 
-// CallTool 调用 MCP 工具(公开方法,供其他包使用)
+// auditMiddleware 记录 MCP 客户端的工具调用(审计日志、指标和链路),调用方为 MCP 会话
+func auditMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, ok := audit.CallerFromContext(ctx); !ok {
+			caller := audit.Caller{Platform: "mcp"}
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				caller.Conversation = session.SessionID()
+			}
+			ctx = audit.WithCaller(ctx, caller)
+		}
+
+		ctx, span := tracing.Start(ctx, "mcp.CallTool", tracing.String("mcp.tool", request.Params.Name))
+		start := time.Now()
+		result, err := next(ctx, request)
+		recordToolCall(ctx, span, request.Params.Name, request.GetArguments(), start, result, err)
+		return result, err
+	}
+}
+
+// CallTool 调用 MCP 工具(公开方法,供其他包使用),每次调用都会写入审计日志
 func (s *MCPServer) CallTool(ctx context.Context, toolName string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	ctx, span := tracing.Start(ctx, "mcp.CallTool", tracing.String("mcp.tool", toolName))
+	start := time.Now()
+	result, err := s.callTool(ctx, toolName, arguments)
+	recordToolCall(ctx, span, toolName, arguments, start, result, err)
+	return result, err
+}
+
+// recordToolCall 记录工具调用的审计日志、指标,并结束链路 span
+func recordToolCall(ctx context.Context, span *tracing.Span, toolName string, arguments map[string]any, start time.Time, result *mcp.CallToolResult, err error) {
+	failed := err != nil || (result != nil && result.IsError)
+	metrics.ObserveToolCall(toolName, failed, time.Since(start))
+	audit.RecordToolCall(ctx, toolName, arguments, start, result, err)
+
+	if err != nil {
+		span.RecordError(err)
+	} else if failed {
+		span.RecordError(fmt.Errorf("tool returned error result"))
+	}
+	span.End()
+}
+
+// callTool 根据工具名称分发到对应的处理函数
+func (s *MCPServer) callTool(ctx context.Context, toolName string, arguments map[string]any) (*mcp.CallToolResult, error) {
 	request := mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
 			Name:      toolName,
@@ -392,6 +1073,14 @@ func (s *MCPServer) CallTool(ctx context.Context, toolName string, arguments map
 	case "get_oss":
 		return s.handleGetOSS(ctx, request)
 
+	// 阿里云负载均衡
+	case "list_slb":
+		return s.handleListSLB(ctx, request)
+	case "get_slb":
+		return s.handleGetSLB(ctx, request)
+	case "search_slb_by_ip":
+		return s.handleSearchSLBByIP(ctx, request)
+
 	// 腾讯云 CVM
 	case "search_cvm_by_ip":
 		return s.handleSearchCVMByIP(ctx, request)
@@ -414,6 +1103,82 @@ func (s *MCPServer) CallTool(ctx context.Context, toolName string, arguments map
 	case "get_cos":
 		return s.handleGetCOS(ctx, request)
 
+	// 腾讯云 CLB
+	case "list_clb":
+		return s.handleListCLB(ctx, request)
+	case "get_clb":
+		return s.handleGetCLB(ctx, request)
+	case "search_clb_by_ip":
+		return s.handleSearchCLBByIP(ctx, request)
+
+	// 网络
+	case "list_vpcs":
+		return s.handleListVPCs(ctx, request)
+	case "list_subnets":
+		return s.handleListSubnets(ctx, request)
+	case "list_security_groups":
+		return s.handleListSecurityGroups(ctx, request)
+	case "get_security_group":
+		return s.handleGetSecurityGroup(ctx, request)
+	case "get_instance_network":
+		return s.handleGetInstanceNetwork(ctx, request)
+
+	// Redis
+	case "list_redis":
+		return s.handleListRedis(ctx, request)
+	case "search_redis_by_name":
+		return s.handleSearchRedisByName(ctx, request)
+	case "search_redis_by_address":
+		return s.handleSearchRedisByAddress(ctx, request)
+
+	// 公网 IP
+	case "list_eip":
+		return s.handleListEIP(ctx, request)
+	case "search_eip_by_ip":
+		return s.handleSearchEIPByIP(ctx, request)
+	case "report_unassociated_eips":
+		return s.handleReportUnassociatedEIPs(ctx, request)
+
+	// DNS
+	case "list_domains":
+		return s.handleListDomains(ctx, request)
+	case "list_dns_records":
+		return s.handleListDNSRecords(ctx, request)
+	case "resolve_domain_to_resources":
+		return s.handleResolveDomainToResources(ctx, request)
+	case "search_dns_by_ip":
+		return s.handleSearchDNSByIP(ctx, request)
+
+	// 证书
+	case "list_certificates":
+		return s.handleListCertificates(ctx, request)
+	case "list_expiring_certificates":
+		return s.handleListExpiringCertificates(ctx, request)
+	case "check_certificate":
+		return s.handleCheckCertificate(ctx, request)
+
+	// CDN
+	case "list_cdn_domains":
+		return s.handleListCDNDomains(ctx, request)
+	case "refresh_cdn_cache":
+		return s.handleRefreshCDNCache(ctx, request)
+
+	// 费用
+	case "get_cost_summary":
+		return s.handleGetCostSummary(ctx, request)
+	case "get_cost_breakdown":
+		return s.handleGetCostBreakdown(ctx, request)
+	case "list_top_cost_instances":
+		return s.handleListTopCostInstances(ctx, request)
+
+	// 闲置资源
+	case "report_idle_resources":
+		return s.handleReportIdleResources(ctx, request)
+
+	// 监控指标
+	case "get_instance_metrics":
+		return s.handleGetInstanceMetrics(ctx, request)
+
 	// Jenkins
 	case "list_jenkins_jobs":
 		return s.handleListJenkinsJobs(ctx, request)
@@ -421,6 +1186,10 @@ func (s *MCPServer) CallTool(ctx context.Context, toolName string, arguments map
 		return s.handleGetJenkinsJob(ctx, request)
 	case "list_jenkins_builds":
 		return s.handleListJenkinsBuilds(ctx, request)
+	case "get_jenkins_build_log":
+		return s.handleGetJenkinsBuildLog(ctx, request)
+	case "rerun_jenkins_build":
+		return s.handleRerunJenkinsBuild(ctx, request)
 
 	default:
 		// 尝试从底层 MCP Server 调用工具(用于外部 MCP 工具,如 CNB)
@@ -0,0 +1,78 @@
+package intent
+
+import (
+	_ "embed"
+	"fmt"
+	"maps"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 语料库: 表驱动的用例(消息 → 期望的工具和参数),用于修改规则后检查是否回归
+
+//go:embed corpus.yaml
+var builtinCorpus []byte
+
+// Case 语料用例,Tool 为空表示不应匹配任何规则
+type Case struct {
+	Text string            `yaml:"text"`
+	Tool string            `yaml:"tool"`
+	Args map[string]string `yaml:"args"`
+}
+
+// Corpus 语料文件
+type Corpus struct {
+	Cases []Case `yaml:"cases"`
+}
+
+// Failure 未通过的用例
+type Failure struct {
+	Case   Case
+	Got    *Intent // 未匹配时为 nil
+	Reason string
+}
+
+// BuiltinCorpus 内置语料库
+func BuiltinCorpus() ([]Case, error) {
+	return parseCorpus(builtinCorpus)
+}
+
+// LoadCorpus 从文件加载语料库
+func LoadCorpus(path string) ([]Case, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read intent corpus: %w", err)
+	}
+	return parseCorpus(data)
+}
+
+// parseCorpus 解析 YAML 语料
+func parseCorpus(data []byte) ([]Case, error) {
+	var corpus Corpus
+	if err := yaml.Unmarshal(data, &corpus); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal intent corpus: %w", err)
+	}
+	return corpus.Cases, nil
+}
+
+// Check 使用语料库检查意图引擎,返回未通过的用例
+func (e *Engine) Check(cases []Case) []Failure {
+	var failures []Failure
+	for _, c := range cases {
+		got, ok := e.Parse(c.Text)
+		switch {
+		case !ok && c.Tool == "":
+			continue
+		case !ok:
+			failures = append(failures, Failure{Case: c, Reason: "未匹配任何规则"})
+		case c.Tool == "":
+			failures = append(failures, Failure{Case: c, Got: got, Reason: "不应匹配规则 " + got.Rule})
+		case got.Tool != c.Tool:
+			failures = append(failures, Failure{Case: c, Got: got, Reason: fmt.Sprintf("工具不符,期望 %s,实际 %s", c.Tool, got.Tool)})
+		case !maps.Equal(got.Args, c.Args):
+			failures = append(failures, Failure{Case: c, Got: got, Reason: fmt.Sprintf("参数不符,期望 %v,实际 %v", c.Args, got.Args)})
+		}
+	}
+	return failures
+}
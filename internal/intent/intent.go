@@ -0,0 +1,198 @@
+package intent
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"gopkg.in/yaml.v3"
+)
+
+// 意图引擎: 未启用 LLM 时,按 YAML 规则将自然语言消息映射为工具调用,各聊天平台共用
+
+//go:embed rules.yaml
+var builtinRules []byte
+
+// Rule 意图规则: 正则匹配消息,命名分组作为工具参数
+type Rule struct {
+	Name        string                       `yaml:"name"`
+	Description string                       `yaml:"description"`
+	Patterns    []string                     `yaml:"patterns"` // 任一匹配即可
+	Tool        string                       `yaml:"tool"`
+	Args        map[string]string            `yaml:"args"`     // 参数模板,$name / ${name} 引用命名分组
+	Keywords    map[string]map[string]string `yaml:"keywords"` // 消息包含关键字时设置参数,如 region: {杭州: cn-hangzhou}
+}
+
+// RuleSet 规则文件
+type RuleSet struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Intent 解析出的工具调用
+type Intent struct {
+	Rule string            `json:"rule"` // 匹配的规则名称
+	Tool string            `json:"tool"` // MCP 工具名称
+	Args map[string]string `json:"args"` // 工具参数
+}
+
+// compiledRule 编译后的规则
+type compiledRule struct {
+	Rule
+	regexps  []*regexp.Regexp
+	keywords map[string][]keyword
+}
+
+// keyword 关键字及对应的参数值
+type keyword struct {
+	text  string
+	value string
+}
+
+// Engine 意图引擎
+type Engine struct {
+	rules []compiledRule
+}
+
+// ParseRules 解析 YAML 规则
+func ParseRules(data []byte) ([]Rule, error) {
+	var set RuleSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal intent rules: %w", err)
+	}
+	return set.Rules, nil
+}
+
+// LoadRules 从文件加载规则
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read intent rules: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// BuiltinRules 内置规则
+func BuiltinRules() ([]Rule, error) {
+	return ParseRules(builtinRules)
+}
+
+// New 创建意图引擎: 规则文件中的规则优先匹配,之后是内置规则
+func New(rulesFile string) (*Engine, error) {
+	rules, err := BuiltinRules()
+	if err != nil {
+		return nil, err
+	}
+	if rulesFile != "" {
+		custom, err := LoadRules(rulesFile)
+		if err != nil {
+			return nil, err
+		}
+		logx.Info("Loaded %d intent rules from %s", len(custom), rulesFile)
+		rules = append(custom, rules...)
+	}
+	return NewEngine(rules)
+}
+
+// NewEngine 使用指定的规则创建意图引擎
+func NewEngine(rules []Rule) (*Engine, error) {
+	engine := &Engine{rules: make([]compiledRule, 0, len(rules))}
+	for i, rule := range rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule_%d", i+1)
+		}
+		if rule.Tool == "" {
+			return nil, fmt.Errorf("intent rule %s: tool is required", rule.Name)
+		}
+		if len(rule.Patterns) == 0 {
+			return nil, fmt.Errorf("intent rule %s: patterns is required", rule.Name)
+		}
+
+		compiled := compiledRule{Rule: rule, keywords: make(map[string][]keyword)}
+		for _, pattern := range rule.Patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("intent rule %s: invalid pattern %q: %w", rule.Name, pattern, err)
+			}
+			compiled.regexps = append(compiled.regexps, re)
+		}
+
+		// 关键字按长度倒序匹配,较长的关键字优先(结果稳定)
+		for param, values := range rule.Keywords {
+			list := make([]keyword, 0, len(values))
+			for text, value := range values {
+				list = append(list, keyword{text: strings.ToLower(text), value: value})
+			}
+			sort.Slice(list, func(i, j int) bool {
+				if len(list[i].text) != len(list[j].text) {
+					return len(list[i].text) > len(list[j].text)
+				}
+				return list[i].text < list[j].text
+			})
+			compiled.keywords[param] = list
+		}
+
+		engine.rules = append(engine.rules, compiled)
+	}
+	return engine, nil
+}
+
+// Rules 返回全部规则
+func (e *Engine) Rules() []Rule {
+	rules := make([]Rule, 0, len(e.rules))
+	for _, r := range e.rules {
+		rules = append(rules, r.Rule)
+	}
+	return rules
+}
+
+// Parse 解析消息,未匹配任何规则时返回 false
+func (e *Engine) Parse(text string) (*Intent, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, false
+	}
+
+	for _, rule := range e.rules {
+		for _, re := range rule.regexps {
+			match := re.FindStringSubmatchIndex(text)
+			if match == nil {
+				continue
+			}
+
+			intent := &Intent{Rule: rule.Name, Tool: rule.Tool, Args: rule.args(re, text, match)}
+			logx.Debug("Intent matched, rule %s, pattern %s, tool %s, args %v", rule.Name, re.String(), intent.Tool, intent.Args)
+			return intent, true
+		}
+	}
+
+	return nil, false
+}
+
+// args 展开参数模板并设置关键字参数
+func (r *compiledRule) args(re *regexp.Regexp, text string, match []int) map[string]string {
+	args := make(map[string]string)
+	for name, template := range r.Args {
+		value := strings.TrimSpace(string(re.ExpandString(nil, template, text, match)))
+		if value != "" {
+			args[name] = value
+		}
+	}
+
+	lower := strings.ToLower(text)
+	for param, keywords := range r.keywords {
+		if _, ok := args[param]; ok {
+			continue
+		}
+		for _, kw := range keywords {
+			if strings.Contains(lower, kw.text) {
+				args[param] = kw.value
+				break
+			}
+		}
+	}
+	return args
+}
@@ -0,0 +1,45 @@
+package intent
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestBuiltinCorpus(t *testing.T) {
+	rules, err := BuiltinRules()
+	if err != nil {
+		t.Fatalf("BuiltinRules() error = %v", err)
+	}
+	engine, err := NewEngine(rules)
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	cases, err := BuiltinCorpus()
+	if err != nil {
+		t.Fatalf("BuiltinCorpus() error = %v", err)
+	}
+	if len(cases) == 0 {
+		t.Fatal("builtin corpus is empty")
+	}
+
+	for _, c := range cases {
+		t.Run(c.Text, func(t *testing.T) {
+			got, ok := engine.Parse(c.Text)
+			if c.Tool == "" {
+				if ok {
+					t.Errorf("Parse(%q) matched rule %s (tool %s), want no match", c.Text, got.Rule, got.Tool)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("Parse(%q) matched no rule, want tool %s", c.Text, c.Tool)
+			}
+			if got.Tool != c.Tool {
+				t.Errorf("Parse(%q) tool = %s (rule %s), want %s", c.Text, got.Tool, got.Rule, c.Tool)
+			}
+			if !maps.Equal(got.Args, c.Args) {
+				t.Errorf("Parse(%q) args = %v, want %v", c.Text, got.Args, c.Args)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/config"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const (
+	// defaultBackendTimeout 后端未配置超时时的默认值
+	defaultBackendTimeout = 120 * time.Second
+	// unhealthyThreshold 连续失败多少次后标记为不健康
+	unhealthyThreshold = 3
+	// unhealthyCooldown 标记为不健康后的冷却时间,冷却期内优先跳过该后端
+	unhealthyCooldown = 30 * time.Second
+	// healthWindowSize 统计错误率时保留的最近请求数
+	healthWindowSize = 50
+)
+
+// backend 故障转移链中的一个 LLM 后端
+type backend struct {
+	name    string
+	timeout time.Duration
+	client  *OpenAIClient
+	health  *backendHealth
+}
+
+// newBackend 创建后端,名称、地址和模型都相同的后端共享健康状态
+func newBackend(cfg config.LLMBackendConfig, defaultTimeout int) *backend {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = time.Duration(defaultTimeout) * time.Second
+	}
+	if timeout <= 0 {
+		timeout = defaultBackendTimeout
+	}
+
+	return &backend{
+		name:    cfg.Name,
+		timeout: timeout,
+		client: NewOpenAIClient(&Config{
+			Model:   cfg.Model,
+			APIKey:  cfg.APIKey,
+			BaseURL: cfg.BaseURL,
+		}),
+		health: getBackendHealth(cfg.Name, cfg.Model, cfg.BaseURL),
+	}
+}
+
+// requestContext 为单次请求附加后端超时
+func (b *backend) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, b.timeout)
+}
+
+// BackendStatus 后端健康状态(用于健康检查接口展示)
+type BackendStatus struct {
+	Name                string     `json:"name"`
+	Model               string     `json:"model"`
+	BaseURL             string     `json:"base_url,omitempty"`
+	Healthy             bool       `json:"healthy"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	RecentRequests      int        `json:"recent_requests"`
+	RecentErrorRate     float64    `json:"recent_error_rate"`
+	TotalRequests       int64      `json:"total_requests"`
+	TotalFailures       int64      `json:"total_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastErrorAt         *time.Time `json:"last_error_at,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	UnhealthyUntil      *time.Time `json:"unhealthy_until,omitempty"`
+}
+
+// backendHealth 后端健康状态跟踪
+type backendHealth struct {
+	mu                  sync.Mutex
+	name                string
+	model               string
+	baseURL             string
+	consecutiveFailures int
+	unhealthyUntil      time.Time
+	recent              []bool // 最近请求结果,true 表示失败
+	totalRequests       int64
+	totalFailures       int64
+	lastError           string
+	lastErrorAt         time.Time
+	lastSuccessAt       time.Time
+}
+
+// healthKey 健康状态的键,默认名称(default、backend-N)可能对应不同的端点,只按名称区分会共享熔断状态
+type healthKey struct {
+	name    string
+	model   string
+	baseURL string
+}
+
+var (
+	healthRegistry   = make(map[healthKey]*backendHealth)
+	healthRegistryMu sync.Mutex
+)
+
+// getBackendHealth 获取(或创建)指定后端的健康状态
+func getBackendHealth(name, model, baseURL string) *backendHealth {
+	healthRegistryMu.Lock()
+	defer healthRegistryMu.Unlock()
+
+	key := healthKey{name: name, model: model, baseURL: baseURL}
+	if h, ok := healthRegistry[key]; ok {
+		return h
+	}
+
+	h := &backendHealth{name: name, model: model, baseURL: baseURL}
+	healthRegistry[key] = h
+	return h
+}
+
+// available 后端当前是否可用(不在冷却期内)
+func (h *backendHealth) available() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return time.Now().After(h.unhealthyUntil)
+}
+
+// recordSuccess 记录一次成功请求
+func (h *backendHealth) recordSuccess() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.record(false)
+	h.consecutiveFailures = 0
+	h.unhealthyUntil = time.Time{}
+	h.lastSuccessAt = time.Now()
+}
+
+// recordFailure 记录一次失败请求,只有可重试的错误(限流、超时、服务端错误)才计入连续失败次数
+func (h *backendHealth) recordFailure(err error, retriable bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.record(true)
+	h.lastError = err.Error()
+	h.lastErrorAt = time.Now()
+
+	if !retriable {
+		return
+	}
+
+	h.consecutiveFailures++
+	if h.consecutiveFailures >= unhealthyThreshold {
+		h.unhealthyUntil = time.Now().Add(unhealthyCooldown)
+		logx.Warn("LLM backend %s marked unhealthy for %s after %d consecutive failures",
+			h.name, unhealthyCooldown, h.consecutiveFailures)
+	}
+}
+
+// record 追加一条请求结果(调用方需持有锁)
+func (h *backendHealth) record(failed bool) {
+	h.totalRequests++
+	if failed {
+		h.totalFailures++
+	}
+
+	h.recent = append(h.recent, failed)
+	if len(h.recent) > healthWindowSize {
+		h.recent = h.recent[len(h.recent)-healthWindowSize:]
+	}
+}
+
+// status 生成健康状态快照
+func (h *backendHealth) status() BackendStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	failures := 0
+	for _, failed := range h.recent {
+		if failed {
+			failures++
+		}
+	}
+
+	status := BackendStatus{
+		Name:                h.name,
+		Model:               h.model,
+		BaseURL:             h.baseURL,
+		Healthy:             time.Now().After(h.unhealthyUntil),
+		ConsecutiveFailures: h.consecutiveFailures,
+		RecentRequests:      len(h.recent),
+		TotalRequests:       h.totalRequests,
+		TotalFailures:       h.totalFailures,
+		LastError:           h.lastError,
+	}
+
+	if len(h.recent) > 0 {
+		status.RecentErrorRate = float64(failures) / float64(len(h.recent))
+	}
+	if !h.lastErrorAt.IsZero() {
+		t := h.lastErrorAt
+		status.LastErrorAt = &t
+	}
+	if !h.lastSuccessAt.IsZero() {
+		t := h.lastSuccessAt
+		status.LastSuccessAt = &t
+	}
+	if !status.Healthy {
+		t := h.unhealthyUntil
+		status.UnhealthyUntil = &t
+	}
+
+	return status
+}
+
+// BackendsHealth 返回所有已创建 LLM 后端的健康状态
+func BackendsHealth() []BackendStatus {
+	healthRegistryMu.Lock()
+	healths := make([]*backendHealth, 0, len(healthRegistry))
+	for _, h := range healthRegistry {
+		healths = append(healths, h)
+	}
+	healthRegistryMu.Unlock()
+
+	statuses := make([]BackendStatus, 0, len(healths))
+	for _, h := range healths {
+		statuses = append(statuses, h.status())
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Name != statuses[j].Name {
+			return statuses[i].Name < statuses[j].Name
+		}
+		if statuses[i].BaseURL != statuses[j].BaseURL {
+			return statuses[i].BaseURL < statuses[j].BaseURL
+		}
+		return statuses[i].Model < statuses[j].Model
+	})
+
+	return statuses
+}
+
+// orderedBackends 按故障转移顺序返回后端: 从 start 开始,冷却中的后端排在最后
+func orderedBackends(backends []*backend, start int) []*backend {
+	if len(backends) == 0 {
+		return nil
+	}
+	if start < 0 || start >= len(backends) {
+		start = 0
+	}
+
+	available := make([]*backend, 0, len(backends))
+	coolingDown := make([]*backend, 0)
+	for i := 0; i < len(backends); i++ {
+		b := backends[(start+i)%len(backends)]
+		if b.health.available() {
+			available = append(available, b)
+		} else {
+			coolingDown = append(coolingDown, b)
+		}
+	}
+
+	// 所有后端都在冷却期时仍按顺序尝试,避免完全不可用
+	return append(available, coolingDown...)
+}
+
+// isRetriableError 判断错误是否应当切换到下一个后端重试
+func isRetriableError(ctx context.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// 调用方主动取消,不需要重试
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return isRetriableStatus(apiErr.HTTPStatusCode)
+	}
+
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return isRetriableStatus(reqErr.HTTPStatusCode)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, keyword := range []string{"connection refused", "connection reset", "no such host", "eof", "timeout"} {
+		if strings.Contains(msg, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRetriableStatus 判断 HTTP 状态码是否可重试(限流、超时和服务端错误)
+func isRetriableStatus(code int) bool {
+	return code == http.StatusTooManyRequests ||
+		code == http.StatusRequestTimeout ||
+		code >= http.StatusInternalServerError
+}
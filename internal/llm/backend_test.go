@@ -0,0 +1,16 @@
+package llm
+
+import "testing"
+
+func TestGetBackendHealthKey(t *testing.T) {
+	a := getBackendHealth("default", "gpt-4o", "https://a.example.com/v1")
+	if got := getBackendHealth("default", "gpt-4o", "https://a.example.com/v1"); got != a {
+		t.Error("same backend does not share health state")
+	}
+	if got := getBackendHealth("default", "gpt-4o", "https://b.example.com/v1"); got == a {
+		t.Error("backends with different base URLs share health state")
+	}
+	if got := getBackendHealth("default", "qwen-max", "https://a.example.com/v1"); got == a {
+		t.Error("backends with different models share health state")
+	}
+}
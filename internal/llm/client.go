@@ -3,14 +3,26 @@ package llm
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
+	"time"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/metrics"
+	"github.com/eryajf/zenops/internal/tracing"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// maxToolIterations 单次对话中工具调用的最大迭代次数(流式与非流式共用)
+const maxToolIterations = 10
+
+// ErrMaxToolIterations 工具调用迭代次数达到上限仍未得到最终回答
+var ErrMaxToolIterations = fmt.Errorf("reached max tool call iterations (%d)", maxToolIterations)
+
 // MCPServer MCP服务器接口(避免循环导入)
 type MCPServer interface {
 	ListTools(ctx context.Context) (*mcp.ListToolsResult, error)
@@ -21,21 +33,75 @@ type MCPServer interface {
 type Client struct {
 	config    *Config
 	mcpServer MCPServer
+	backends  []*backend // 按顺序故障转移的后端
+	pager     *resultPager
+	router    *toolRouter
 }
 
 // Config LLM 配置
 type Config struct {
-	Model   string `mapstructure:"model"`
-	APIKey  string `mapstructure:"api_key"`
-	BaseURL string `mapstructure:"base_url"`
+	Model    string                    `mapstructure:"model"`
+	APIKey   string                    `mapstructure:"api_key"`
+	BaseURL  string                    `mapstructure:"base_url"`
+	Timeout  int                       `mapstructure:"timeout"`  // 单次请求超时(秒)
+	Backends []config.LLMBackendConfig `mapstructure:"backends"` // 为空时使用 Model/APIKey/BaseURL 作为唯一后端
+
+	ToolResultMaxTokens int                     `mapstructure:"tool_result_max_tokens"` // 单次工具结果的 token 预算,超出时分页
+	ToolRouter          config.ToolRouterConfig `mapstructure:"tool_router"`            // 工具路由配置
+	ExcludeTools        []string                `mapstructure:"-"`                      // 不提供给模型的工具,如需要用户确认的变更操作
+}
+
+// NewConfig 根据应用配置生成 LLM 配置
+func NewConfig(cfg config.LLMConfig) *Config {
+	llmConfig := &Config{
+		Model:    cfg.Model,
+		APIKey:   cfg.APIKey,
+		BaseURL:  cfg.BaseURL,
+		Timeout:  cfg.Timeout,
+		Backends: slices.Clone(cfg.Backends),
+
+		ToolResultMaxTokens: cfg.ToolResultMaxTokens,
+		ToolRouter:          cfg.ToolRouter,
+	}
+
+	return llmConfig
 }
 
 // NewClient 创建 LLM 客户端
-func NewClient(config *Config, mcpServer MCPServer) *Client {
-	return &Client{
-		config:    config,
+func NewClient(cfg *Config, mcpServer MCPServer) *Client {
+	c := &Client{
+		config:    cfg,
 		mcpServer: mcpServer,
+		pager:     newResultPager(cfg.ToolResultMaxTokens),
+		router:    newToolRouter(cfg.ToolRouter),
+	}
+
+	backendConfigs := cfg.Backends
+	if len(backendConfigs) == 0 {
+		backendConfigs = []config.LLMBackendConfig{{
+			Name:    "default",
+			Model:   cfg.Model,
+			APIKey:  cfg.APIKey,
+			BaseURL: cfg.BaseURL,
+		}}
+	}
+
+	for i, bc := range backendConfigs {
+		if bc.Name == "" {
+			bc.Name = fmt.Sprintf("backend-%d", i+1)
+		}
+		c.backends = append(c.backends, newBackend(bc, cfg.Timeout))
+	}
+
+	return c
+}
+
+// Model 返回首选后端的模型名称
+func (c *Client) Model() string {
+	if len(c.backends) > 0 {
+		return c.backends[0].client.config.Model
 	}
+	return c.config.Model
 }
 
 // Message 消息结构
@@ -99,64 +165,52 @@ type ChatResponse struct {
 		} `json:"delta"`
 		FinishReason string `json:"finish_reason"`
 	} `json:"choices"`
+	Usage Usage `json:"usage"`
 }
 
-// Chat 与 LLM 对话 (非流式)
-func (c *Client) Chat(ctx context.Context, messages []Message) (string, error) {
-	// 获取 MCP 工具列表
-	tools, err := c.getMCPTools(ctx)
-	if err != nil {
-		logx.Warn("Failed to get MCP tools: %v", err)
-		tools = nil // 即使获取工具失败,也继续进行对话
-	}
+// Usage token 消耗
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
 
-	req := &ChatRequest{
-		Model:    c.config.Model,
-		Messages: messages,
-		Stream:   false,
-		Tools:    tools,
+// Chat 与 LLM 对话 (非流式),自动执行工具调用并返回最终回答
+// 未提供 system 消息时使用默认的系统提示词
+func (c *Client) Chat(ctx context.Context, messages []Message) (string, error) {
+	if len(messages) == 0 {
+		return "", fmt.Errorf("messages is empty")
 	}
 
-	// TODO: 这里需要根据不同的 provider 调用不同的 API
-	// 当前是一个简化的示例实现
-	logx.Debug("Chat request %v", req)
-
-	return "暂未实现完整的 LLM 调用,请配置实际的 API 调用逻辑", nil
+	history, tools := c.prepareConversation(ctx, messages)
+	return c.chatWithTools(ctx, history, tools, nil)
 }
 
-// ChatStream 与 LLM 流式对话
-func (c *Client) ChatStream(ctx context.Context, messages []Message) (<-chan string, error) {
-	// 获取 MCP 工具列表
-	tools, err := c.getMCPTools(ctx)
-	if err != nil {
-		logx.Warn("Failed to get MCP tools: %v", err)
-		tools = nil
+// ChatStream 与 LLM 流式对话,自动执行工具调用,通过通道输出回答内容(不含工具调用进度)
+// 对话失败或达到最大工具调用次数时通过错误通道返回错误,两个通道在对话结束后关闭
+// 未提供 system 消息时使用默认的系统提示词
+func (c *Client) ChatStream(ctx context.Context, messages []Message) (<-chan string, <-chan error, error) {
+	if len(messages) == 0 {
+		return nil, nil, fmt.Errorf("messages is empty")
 	}
 
-	req := &ChatRequest{
-		Model:    c.config.Model,
-		Messages: messages,
-		Stream:   true,
-		Tools:    tools,
-	}
-
-	logx.Debug("Chat stream request %v", req)
-
-	// 创建输出通道
-	responseCh := make(chan string, 100)
+	contentCh := make(chan string, 100)
+	errCh := make(chan error, 1)
 
-	// TODO: 这里需要根据不同的 provider 调用不同的 API
-	// 当前是一个简化的示例实现
 	go func() {
-		defer close(responseCh)
-		// 模拟流式响应
-		responseCh <- "暂未实现完整的 LLM 流式调用,请配置实际的 API 调用逻辑"
+		defer close(contentCh)
+		defer close(errCh)
+
+		history, tools := c.prepareConversation(ctx, messages)
+		if err := c.streamToolLoop(ctx, history, tools, contentCh); err != nil {
+			errCh <- err
+		}
 	}()
 
-	return responseCh, nil
+	return contentCh, errCh, nil
 }
 
-// ChatWithMCPTools 使用 MCP 工具与 LLM 对话
+// ChatWithMCPTools 使用 MCP 工具与 LLM 对话(非流式调用 LLM,通过通道输出工具调用进度和最终回答)
 func (c *Client) ChatWithMCPTools(ctx context.Context, userMessage string) (<-chan string, error) {
 	responseCh := make(chan string, 100)
 
@@ -164,59 +218,127 @@ func (c *Client) ChatWithMCPTools(ctx context.Context, userMessage string) (<-ch
 		defer close(responseCh)
 
 		// 初始化消息历史
-		messages := []Message{
-			{
-				Role:    "system",
-				Content: c.buildSystemPrompt(),
-			},
+		messages, tools := c.prepareConversation(ctx, []Message{
 			{
 				Role:    "user",
 				Content: userMessage,
 			},
+		})
+
+		answer, err := c.chatWithTools(ctx, messages, tools, func(event string) {
+			responseCh <- event
+		})
+		if errors.Is(err, ErrMaxToolIterations) {
+			responseCh <- "\n\n⚠️ 达到最大工具调用次数限制"
+			return
+		}
+		if err != nil {
+			responseCh <- fmt.Sprintf("❌ LLM 调用失败: %v", err)
+			return
 		}
 
-		maxIterations := 10 // 最大工具调用迭代次数
-		for i := 0; i < maxIterations; i++ {
-			// 调用 LLM
-			resp, err := c.callLLMWithTools(ctx, messages)
-			if err != nil {
-				responseCh <- fmt.Sprintf("❌ LLM 调用失败: %v", err)
-				return
-			}
+		if answer != "" {
+			responseCh <- answer
+		}
+	}()
 
-			// 检查是否有工具调用
-			if len(resp.ToolCalls) > 0 {
-				// 处理工具调用
-				for _, toolCall := range resp.ToolCalls {
-					responseCh <- fmt.Sprintf("🔧 调用工具: %s\n", toolCall.Function.Name)
-
-					result, err := c.executeToolCall(ctx, toolCall)
-					if err != nil {
-						responseCh <- fmt.Sprintf("❌ 工具调用失败: %v\n", err)
-						continue
-					}
-
-					// 添加工具调用结果到消息历史
-					messages = append(messages, Message{
-						Role:    "tool",
-						Content: result,
-					})
-				}
-				// 继续循环,让 LLM 处理工具结果
-				continue
-			}
+	return responseCh, nil
+}
 
-			// 没有工具调用,返回最终响应
-			if resp.Content != "" {
-				responseCh <- resp.Content
-			}
-			return
+// prepareConversation 为本次对话选择工具,并在未提供 system 消息时插入默认的系统提示词
+// 返回的消息历史是副本,不会修改调用方的切片
+func (c *Client) prepareConversation(ctx context.Context, messages []Message) ([]Message, []Tool) {
+	tools := c.selectTools(ctx, lastUserMessage(messages))
+
+	history := make([]Message, 0, len(messages)+1)
+	if len(messages) == 0 || messages[0].Role != "system" {
+		history = append(history, Message{
+			Role:    "system",
+			Content: c.buildSystemPrompt(tools),
+		})
+	}
+	history = append(history, messages...)
+
+	return history, tools
+}
+
+// lastUserMessage 返回最后一条用户消息的内容,用于工具路由
+func lastUserMessage(messages []Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return convertContent(messages[i].Content)
 		}
+	}
+	return ""
+}
+
+// chatWithTools 非流式的工具调用循环: 调用 LLM,执行其请求的工具并回传结果,直到得到最终回答
+// notify 用于输出工具调用进度,可以为 nil
+func (c *Client) chatWithTools(ctx context.Context, history []Message, tools []Tool, notify func(string)) (string, error) {
+	if notify == nil {
+		notify = func(string) {}
+	}
 
-		responseCh <- "\n\n⚠️ 达到最大工具调用次数限制"
+	ctx, span := tracing.Start(ctx, "llm.chat",
+		tracing.String("llm.mode", "chat"),
+		tracing.Int("llm.tools", len(tools)))
+	defer span.End()
+
+	// 同一会话内优先使用上一次成功的后端
+	preferred := 0
+
+	// 记录本次对话的工具调用轮数
+	iterations := 0
+	defer func() {
+		metrics.ObserveLLMToolIterations(iterations)
+		span.SetAttributes(tracing.Int("llm.tool_iterations", iterations))
 	}()
 
-	return responseCh, nil
+	for i := 0; i < maxToolIterations; i++ {
+		// 每一轮(LLM 请求 + 工具调用)对应一个 span
+		iterCtx, iterSpan := tracing.Start(ctx, "llm.iteration", tracing.Int("llm.iteration", i+1))
+
+		resp, used, err := c.callLLMWithTools(iterCtx, preferred, history, tools)
+		if err != nil {
+			iterSpan.RecordError(err)
+			iterSpan.End()
+			span.RecordError(err)
+			return "", err
+		}
+		preferred = used
+
+		// 没有工具调用,返回最终响应
+		if len(resp.ToolCalls) == 0 {
+			iterSpan.End()
+			return resp.Content, nil
+		}
+
+		iterations++
+
+		// 有工具调用,添加 assistant 消息到历史
+		history = append(history, Message{
+			Role:      "assistant",
+			Content:   resp.Content,
+			ToolCalls: resp.ToolCalls,
+		})
+
+		// 执行所有工具调用
+		for _, toolCall := range resp.ToolCalls {
+			notify(fmt.Sprintf("🔧 调用工具: %s\n", toolCall.Function.Name))
+
+			toolMessage, err := c.runToolCall(iterCtx, tools, toolCall)
+			if err != nil {
+				notify(fmt.Sprintf("❌ 工具调用失败: %v\n", err))
+			}
+			history = append(history, toolMessage)
+		}
+		iterSpan.SetAttributes(tracing.Int("llm.tool_calls", len(resp.ToolCalls)))
+		iterSpan.End()
+		// 继续循环,让 LLM 处理工具结果
+	}
+
+	span.RecordError(ErrMaxToolIterations)
+	return "", ErrMaxToolIterations
 }
 
 // LLMResponse LLM 响应结构
@@ -225,24 +347,96 @@ type LLMResponse struct {
 	ToolCalls []ToolCall
 }
 
-// callLLMWithTools 调用 LLM (支持工具)
-func (c *Client) callLLMWithTools(ctx context.Context, messages []Message) (*LLMResponse, error) {
-	// TODO: 实现实际的 LLM API 调用
-	// 这里是一个简化的示例实现
-	return &LLMResponse{
-		Content:   "暂未实现完整的 LLM 调用",
-		ToolCalls: nil,
-	}, nil
+// callLLMWithTools 调用 LLM (支持工具,非流式),可重试的失败会按顺序切换到下一个后端
+// 返回: (响应, 实际使用的后端序号, 错误)
+func (c *Client) callLLMWithTools(ctx context.Context, start int, messages []Message, tools []Tool) (*LLMResponse, int, error) {
+	var lastErr error
+
+	for _, b := range orderedBackends(c.backends, start) {
+		attemptCtx, cancel := b.requestContext(ctx)
+		attemptCtx, attemptSpan := tracing.StartWithKind(attemptCtx, "llm.request", tracing.SpanKindClient,
+			tracing.String("llm.backend", b.name),
+			tracing.String("llm.model", b.client.config.Model),
+			tracing.String("llm.mode", "chat"))
+		attemptStart := time.Now()
+		resp, err := b.client.ChatWithTools(attemptCtx, messages, tools)
+		cancel()
+		metrics.ObserveLLMRequest(b.name, "chat", err, time.Since(attemptStart))
+		if err == nil {
+			attemptSpan.SetAttributes(
+				tracing.Int("llm.prompt_tokens", resp.Usage.PromptTokens),
+				tracing.Int("llm.completion_tokens", resp.Usage.CompletionTokens))
+		}
+		attemptSpan.RecordError(err)
+		attemptSpan.End()
+
+		if err == nil {
+			b.health.recordSuccess()
+			metrics.AddLLMTokens(b.name, resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
+			message := resp.Choices[0].Message
+			return &LLMResponse{
+				Content:   message.Content,
+				ToolCalls: message.ToolCalls,
+			}, c.backendIndex(b), nil
+		}
+
+		retriable := isRetriableError(ctx, err)
+		b.health.recordFailure(err, retriable)
+		lastErr = fmt.Errorf("backend %s: %w", b.name, err)
+
+		if !retriable {
+			return nil, start, lastErr
+		}
+
+		logx.Warn("LLM backend %s failed, failing over to next backend: %v", b.name, err)
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no LLM backend configured")
+	}
+	return nil, start, lastErr
+}
+
+// runToolCall 执行工具调用并构造 tool 角色的消息,失败时消息内容为错误信息,以便 LLM 继续处理
+// tools 为本次对话提供给模型的工具,模型请求其他工具时拒绝执行
+func (c *Client) runToolCall(ctx context.Context, tools []Tool, toolCall ToolCall) (Message, error) {
+	result, err := c.executeToolCall(ctx, tools, toolCall)
+	if err != nil {
+		result = fmt.Sprintf("Error: %v", err)
+	}
+
+	return Message{
+		Role:       "tool",
+		Content:    result,
+		ToolCallID: toolCall.ID,
+		Name:       toolCall.Function.Name,
+	}, err
 }
 
-// executeToolCall 执行工具调用
-func (c *Client) executeToolCall(ctx context.Context, toolCall ToolCall) (string, error) {
+// executeToolCall 执行工具调用,结果超出 token 预算时分页返回第一页
+func (c *Client) executeToolCall(ctx context.Context, tools []Tool, toolCall ToolCall) (string, error) {
+	if slices.Contains(c.config.ExcludeTools, toolCall.Function.Name) {
+		return "", fmt.Errorf("tool %s requires user confirmation and cannot be called by the model", toolCall.Function.Name)
+	}
+
+	// 工具路由按机器人/群限定了工具集,模型只能调用本次提供的工具
+	if !slices.ContainsFunc(tools, func(t Tool) bool { return t.Function.Name == toolCall.Function.Name }) {
+		return "", fmt.Errorf("tool %s is not available in this conversation", toolCall.Function.Name)
+	}
+
 	// 解析参数
 	var params map[string]any
 	if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &params); err != nil {
 		return "", fmt.Errorf("failed to parse tool arguments: %w", err)
 	}
 
+	// 获取分页结果的后续页
+	if toolCall.Function.Name == NextPageToolName {
+		resultID, _ := params["result_id"].(string)
+		page, _ := params["page"].(float64)
+		return c.pager.page(resultID, int(page))
+	}
+
 	logx.Debug("Executing tool call, tool %s, params %v",
 		toolCall.Function.Name,
 		params)
@@ -253,11 +447,9 @@ func (c *Client) executeToolCall(ctx context.Context, toolCall ToolCall) (string
 		return "", fmt.Errorf("failed to call MCP tool: %w", err)
 	}
 
-	// 提取文本结果
-	if len(result.Content) > 0 {
-		if textContent, ok := result.Content[0].(mcp.TextContent); ok {
-			return textContent.Text, nil
-		}
+	// 优先使用结构化结果,按 token 预算截断或分页
+	if content := c.pager.fit(toolCall.Function.Name, result); content != "" {
+		return content, nil
 	}
 
 	return "工具执行完成,但未返回结果", nil
@@ -277,6 +469,9 @@ func (c *Client) getMCPTools(ctx context.Context) ([]Tool, error) {
 
 	var tools []Tool
 	for _, tool := range toolList.Tools {
+		if slices.Contains(c.config.ExcludeTools, tool.Name) {
+			continue
+		}
 		// 转换 MCP 工具定义为 OpenAI 工具格式
 		tools = append(tools, Tool{
 			Type: "function",
@@ -291,6 +486,20 @@ func (c *Client) getMCPTools(ctx context.Context) ([]Tool, error) {
 	return tools, nil
 }
 
+// selectTools 选择本次请求发送给模型的工具: 经工具路由筛选的 MCP 工具 + 分页工具
+func (c *Client) selectTools(ctx context.Context, query string) []Tool {
+	tools, err := c.getMCPTools(ctx)
+	if err != nil {
+		logx.Warn("Failed to get MCP tools, proceeding without tools: %v", err)
+		return nil
+	}
+
+	tools = c.router.route(ctx, tools, query)
+
+	// 大结果分页后用于获取后续页
+	return append(tools, c.pager.tool())
+}
+
 // convertMCPSchemaToOpenAI 转换 MCP Schema 为 OpenAI 格式
 func (c *Client) convertMCPSchemaToOpenAI(schema any) map[string]any {
 	// 如果已经是 map 格式,直接返回
@@ -318,24 +527,22 @@ func (c *Client) convertMCPSchemaToOpenAI(schema any) map[string]any {
 	return result
 }
 
-// buildSystemPrompt 构建系统提示词
-func (c *Client) buildSystemPrompt() string {
+// buildSystemPrompt 构建系统提示词,只列出本次请求选中的工具
+func (c *Client) buildSystemPrompt(tools []Tool) string {
 	var builder strings.Builder
 
 	builder.WriteString("你是一个智能运维助手,可以帮助用户查询和管理云资源、CI/CD 任务等。\n\n")
 	builder.WriteString("你可以使用以下工具来获取信息:\n")
 
-	// 获取可用的工具列表
-	if c.mcpServer != nil {
-		tools, err := c.mcpServer.ListTools(context.Background())
-		if err == nil {
-			for _, tool := range tools.Tools {
-				builder.WriteString(fmt.Sprintf("- %s: %s\n", tool.Name, tool.Description))
-			}
+	for _, tool := range tools {
+		if tool.Function.Name == NextPageToolName {
+			continue
 		}
+		builder.WriteString(fmt.Sprintf("- %s: %s\n", tool.Function.Name, tool.Function.Description))
 	}
 
 	builder.WriteString("\n当用户询问相关信息时,请主动调用相应的工具来获取准确的数据。")
+	builder.WriteString(fmt.Sprintf("工具结果较大时会分页返回,需要完整数据时请调用 %s 获取后续页。", NextPageToolName))
 	builder.WriteString("回复时请简洁明了,使用 Markdown 格式化输出。")
 
 	return builder.String()
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/metrics"
+	"github.com/eryajf/zenops/internal/tracing"
 	openai "github.com/sashabaranov/go-openai"
 )
 
@@ -70,6 +72,67 @@ func convertContent(content any) string {
 	return fmt.Sprintf("%v", content)
 }
 
+// toOpenAIMessages 转换消息为 OpenAI 格式(保留工具调用信息)
+func toOpenAIMessages(messages []Message) []openai.ChatCompletionMessage {
+	openaiMessages := make([]openai.ChatCompletionMessage, 0, len(messages))
+	for _, msg := range messages {
+		content := convertContent(msg.Content)
+		openaiMsg := openai.ChatCompletionMessage{
+			Role:    msg.Role,
+			Content: content,
+		}
+
+		// 处理 assistant 的工具调用
+		if len(msg.ToolCalls) > 0 {
+			toolCalls := make([]openai.ToolCall, 0, len(msg.ToolCalls))
+			for _, tc := range msg.ToolCalls {
+				toolCalls = append(toolCalls, openai.ToolCall{
+					ID:   tc.ID,
+					Type: openai.ToolType(tc.Type),
+					Function: openai.FunctionCall{
+						Name:      tc.Function.Name,
+						Arguments: tc.Function.Arguments,
+					},
+				})
+			}
+			openaiMsg.ToolCalls = toolCalls
+		}
+
+		// 处理 tool 角色的响应
+		if msg.ToolCallID != "" {
+			openaiMsg.ToolCallID = msg.ToolCallID
+		}
+		if msg.Name != "" {
+			openaiMsg.Name = msg.Name
+		}
+
+		openaiMessages = append(openaiMessages, openaiMsg)
+	}
+
+	return openaiMessages
+}
+
+// toOpenAITools 转换工具定义为 OpenAI 格式
+func toOpenAITools(tools []Tool) []openai.Tool {
+	if len(tools) == 0 {
+		return nil
+	}
+
+	openaiTools := make([]openai.Tool, 0, len(tools))
+	for _, tool := range tools {
+		openaiTools = append(openaiTools, openai.Tool{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        tool.Function.Name,
+				Description: tool.Function.Description,
+				Parameters:  tool.Function.Parameters,
+			},
+		})
+	}
+
+	return openaiTools
+}
+
 // ChatStream 流式对话
 func (c *OpenAIClient) ChatStream(ctx context.Context, req *ChatRequest) (<-chan string, <-chan error, error) {
 	messages := make([]openai.ChatCompletionMessage, 0, len(req.Messages))
@@ -92,18 +155,7 @@ func (c *OpenAIClient) ChatStream(ctx context.Context, req *ChatRequest) (<-chan
 
 	// 添加工具定义
 	if len(req.Tools) > 0 {
-		tools := make([]openai.Tool, 0, len(req.Tools))
-		for _, tool := range req.Tools {
-			tools = append(tools, openai.Tool{
-				Type: openai.ToolTypeFunction,
-				Function: &openai.FunctionDefinition{
-					Name:        tool.Function.Name,
-					Description: tool.Function.Description,
-					Parameters:  tool.Function.Parameters,
-				},
-			})
-		}
-		openaiReq.Tools = tools
+		openaiReq.Tools = toOpenAITools(req.Tools)
 		// 设置工具调用策略为 auto,让 AI 根据需要决定是否调用工具
 		openaiReq.ToolChoice = "auto"
 	}
@@ -158,40 +210,7 @@ func (c *OpenAIClient) ChatStream(ctx context.Context, req *ChatRequest) (<-chan
 
 // ChatWithTools 支持工具调用的对话(非流式)
 func (c *OpenAIClient) ChatWithTools(ctx context.Context, messages []Message, tools []Tool) (*ChatResponse, error) {
-	openaiMessages := make([]openai.ChatCompletionMessage, 0, len(messages))
-	for _, msg := range messages {
-		content := convertContent(msg.Content)
-		openaiMsg := openai.ChatCompletionMessage{
-			Role:    msg.Role,
-			Content: content,
-		}
-
-		// 处理 assistant 的工具调用
-		if len(msg.ToolCalls) > 0 {
-			toolCalls := make([]openai.ToolCall, 0, len(msg.ToolCalls))
-			for _, tc := range msg.ToolCalls {
-				toolCalls = append(toolCalls, openai.ToolCall{
-					ID:   tc.ID,
-					Type: openai.ToolType(tc.Type),
-					Function: openai.FunctionCall{
-						Name:      tc.Function.Name,
-						Arguments: tc.Function.Arguments,
-					},
-				})
-			}
-			openaiMsg.ToolCalls = toolCalls
-		}
-
-		// 处理 tool 角色的响应
-		if msg.ToolCallID != "" {
-			openaiMsg.ToolCallID = msg.ToolCallID
-		}
-		if msg.Name != "" {
-			openaiMsg.Name = msg.Name
-		}
-
-		openaiMessages = append(openaiMessages, openaiMsg)
-	}
+	openaiMessages := toOpenAIMessages(messages)
 
 	// 构建请求
 	req := openai.ChatCompletionRequest{
@@ -203,18 +222,7 @@ func (c *OpenAIClient) ChatWithTools(ctx context.Context, messages []Message, to
 
 	// 添加工具定义
 	if len(tools) > 0 {
-		openaiTools := make([]openai.Tool, 0, len(tools))
-		for _, tool := range tools {
-			openaiTools = append(openaiTools, openai.Tool{
-				Type: openai.ToolTypeFunction,
-				Function: &openai.FunctionDefinition{
-					Name:        tool.Function.Name,
-					Description: tool.Function.Description,
-					Parameters:  tool.Function.Parameters,
-				},
-			})
-		}
-		req.Tools = openaiTools
+		req.Tools = toOpenAITools(tools)
 		// 设置工具调用策略为 auto,让 AI 根据需要决定是否调用工具
 		req.ToolChoice = "auto"
 	}
@@ -235,6 +243,11 @@ func (c *OpenAIClient) ChatWithTools(ctx context.Context, messages []Message, to
 
 	// 转换响应
 	response := &ChatResponse{
+		Usage: Usage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		},
 		Choices: []struct {
 			Index   int `json:"index"`
 			Message struct {
@@ -354,144 +367,171 @@ func (c *Client) ChatWithToolsAndStream(ctx context.Context, userMessage string)
 	go func() {
 		defer close(responseCh)
 
-		// 构建消息
-		messages := []Message{
-			{
-				Role:    "system",
-				Content: c.buildSystemPrompt(),
-			},
+		// 构建消息并选择工具
+		messages, tools := c.prepareConversation(ctx, []Message{
 			{
 				Role:    "user",
 				Content: userMessage,
 			},
-		}
+		})
 
-		// 创建 OpenAI 客户端
-		openaiClient := NewOpenAIClient(c.config)
+		c.streamToolLoop(ctx, messages, tools, responseCh)
+	}()
 
-		// 获取工具列表
-		tools, err := c.getMCPTools(ctx)
-		if err != nil {
-			logx.Warn("Failed to get MCP tools, proceeding without tools: %v", err)
-			tools = nil
-		}
+	return responseCh, nil
+}
 
-		maxIterations := 10
-		for i := 0; i < maxIterations; i++ {
-			// 使用流式 API (支持工具调用)
-			result, hasToolCalls, err := c.streamChatWithTools(ctx, openaiClient, messages, tools, responseCh)
-			if err != nil {
-				responseCh <- fmt.Sprintf("❌ LLM 调用失败: %v", err)
-				return
-			}
+// streamToolLoop 流式的工具调用循环: 流式输出 LLM 回答,执行其请求的工具并回传结果,直到得到最终回答
+func (c *Client) streamToolLoop(ctx context.Context, messages []Message, tools []Tool, responseCh chan<- string) error {
+	ctx, span := tracing.Start(ctx, "llm.chat",
+		tracing.String("llm.mode", "stream"),
+		tracing.Int("llm.tools", len(tools)))
+	defer span.End()
+
+	// 同一会话内优先使用上一次成功的后端
+	preferred := 0
+
+	// 记录本次对话的工具调用轮数
+	iterations := 0
+	defer func() {
+		metrics.ObserveLLMToolIterations(iterations)
+		span.SetAttributes(tracing.Int("llm.tool_iterations", iterations))
+	}()
 
-			// 如果没有工具调用,说明对话结束
-			if !hasToolCalls {
-				return
-			}
+	for i := 0; i < maxToolIterations; i++ {
+		// 每一轮(LLM 请求 + 工具调用)对应一个 span
+		iterCtx, iterSpan := tracing.Start(ctx, "llm.iteration", tracing.Int("llm.iteration", i+1))
 
-			// 有工具调用,添加 assistant 消息到历史
-			messages = append(messages, Message{
-				Role:      "assistant",
-				Content:   result.Content,
-				ToolCalls: result.ToolCalls,
-			})
+		// 使用流式 API (支持工具调用),失败时按顺序切换后端
+		result, hasToolCalls, used, err := c.streamWithFallback(iterCtx, preferred, messages, tools, responseCh)
+		if err != nil {
+			iterSpan.RecordError(err)
+			iterSpan.End()
+			span.RecordError(err)
+			return err
+		}
+		preferred = used
 
-			// 执行所有工具调用
-			for _, toolCall := range result.ToolCalls {
-				responseCh <- fmt.Sprintf("\n🔧 调用工具: **%s**\n", toolCall.Function.Name)
+		// 如果没有工具调用,说明对话结束
+		if !hasToolCalls {
+			iterSpan.End()
+			return nil
+		}
 
-				// 执行工具调用
-				toolResult, err := c.executeToolCall(ctx, toolCall)
-				if err != nil {
-					responseCh <- fmt.Sprintf("❌ 工具调用失败: %v\n\n", err)
-					toolResult = fmt.Sprintf("Error: %v", err)
-				}
+		iterations++
 
-				// 添加工具结果到历史
-				messages = append(messages, Message{
-					Role:       "tool",
-					Content:    toolResult,
-					ToolCallID: toolCall.ID,
-					Name:       toolCall.Function.Name,
-				})
+		// 有工具调用,添加 assistant 消息到历史
+		messages = append(messages, Message{
+			Role:      "assistant",
+			Content:   result.Content,
+			ToolCalls: result.ToolCalls,
+		})
 
-				responseCh <- "✅ 工具执行完成\n\n"
+		// 执行所有工具调用
+		// 执行所有工具调用,失败时错误信息作为工具结果交给 LLM 处理
+		for _, toolCall := range result.ToolCalls {
+			toolMessage, err := c.runToolCall(iterCtx, tools, toolCall)
+			if err != nil {
+				logx.Warn("Tool call %s failed: %v", toolCall.Function.Name, err)
 			}
-			// 继续循环,让 LLM 处理工具结果
+			messages = append(messages, toolMessage)
 		}
+		iterSpan.SetAttributes(tracing.Int("llm.tool_calls", len(result.ToolCalls)))
+		iterSpan.End()
+		// 继续循环,让 LLM 处理工具结果
+	}
 
-		responseCh <- "\n\n⚠️ 达到最大工具调用次数限制"
-	}()
-
-	return responseCh, nil
+	span.RecordError(ErrMaxToolIterations)
+	return ErrMaxToolIterations
 }
 
-// streamChatWithTools 使用流式 API 进行对话(支持工具调用)
-// 返回: (累积的消息内容, 是否有工具调用, 错误)
-func (c *Client) streamChatWithTools(
+// streamWithFallback 按顺序在各个后端上进行流式对话
+// 可重试的失败(限流、超时、服务端错误)会切换到下一个后端;已经输出部分内容后不再切换,避免重复输出
+// 返回: (累积的消息内容, 是否有工具调用, 实际使用的后端序号, 错误)
+func (c *Client) streamWithFallback(
 	ctx context.Context,
-	openaiClient *OpenAIClient,
+	start int,
 	messages []Message,
 	tools []Tool,
 	responseCh chan<- string,
-) (*StreamResult, bool, error) {
-	// 构建 OpenAI 请求
-	openaiMessages := make([]openai.ChatCompletionMessage, 0, len(messages))
-	for _, msg := range messages {
-		content := convertContent(msg.Content)
-		openaiMsg := openai.ChatCompletionMessage{
-			Role:    msg.Role,
-			Content: content,
+) (*StreamResult, bool, int, error) {
+	var lastErr error
+
+	for _, b := range orderedBackends(c.backends, start) {
+		attemptCtx, cancel := b.requestContext(ctx)
+		attemptCtx, attemptSpan := tracing.StartWithKind(attemptCtx, "llm.request", tracing.SpanKindClient,
+			tracing.String("llm.backend", b.name),
+			tracing.String("llm.model", b.client.config.Model),
+			tracing.String("llm.mode", "stream"))
+		attemptStart := time.Now()
+		result, hasToolCalls, err := c.streamChatWithTools(attemptCtx, b.client, messages, tools, responseCh)
+		cancel()
+		metrics.ObserveLLMRequest(b.name, "stream", err, time.Since(attemptStart))
+		if result != nil {
+			metrics.AddLLMTokens(b.name, result.Usage.PromptTokens, result.Usage.CompletionTokens)
+			attemptSpan.SetAttributes(
+				tracing.Int("llm.prompt_tokens", result.Usage.PromptTokens),
+				tracing.Int("llm.completion_tokens", result.Usage.CompletionTokens))
 		}
+		attemptSpan.RecordError(err)
+		attemptSpan.End()
 
-		// 处理 assistant 的工具调用
-		if len(msg.ToolCalls) > 0 {
-			toolCalls := make([]openai.ToolCall, 0, len(msg.ToolCalls))
-			for _, tc := range msg.ToolCalls {
-				toolCalls = append(toolCalls, openai.ToolCall{
-					ID:   tc.ID,
-					Type: openai.ToolType(tc.Type),
-					Function: openai.FunctionCall{
-						Name:      tc.Function.Name,
-						Arguments: tc.Function.Arguments,
-					},
-				})
-			}
-			openaiMsg.ToolCalls = toolCalls
+		if err == nil {
+			b.health.recordSuccess()
+			return result, hasToolCalls, c.backendIndex(b), nil
 		}
 
-		// 处理 tool 角色的响应
-		if msg.ToolCallID != "" {
-			openaiMsg.ToolCallID = msg.ToolCallID
+		retriable := isRetriableError(ctx, err)
+		b.health.recordFailure(err, retriable)
+		lastErr = fmt.Errorf("backend %s: %w", b.name, err)
+
+		if !retriable {
+			return nil, false, start, lastErr
 		}
-		if msg.Name != "" {
-			openaiMsg.Name = msg.Name
+
+		if result != nil && result.Content != "" {
+			logx.Warn("LLM backend %s failed after partial output, not failing over: %v", b.name, err)
+			return nil, false, start, lastErr
 		}
 
-		openaiMessages = append(openaiMessages, openaiMsg)
+		logx.Warn("LLM backend %s failed, failing over to next backend: %v", b.name, err)
 	}
 
-	// 构建工具定义
-	var openaiTools []openai.Tool
-	if len(tools) > 0 {
-		for _, tool := range tools {
-			openaiTools = append(openaiTools, openai.Tool{
-				Type: openai.ToolTypeFunction,
-				Function: &openai.FunctionDefinition{
-					Name:        tool.Function.Name,
-					Description: tool.Function.Description,
-					Parameters:  tool.Function.Parameters,
-				},
-			})
+	return nil, false, start, lastErr
+}
+
+// backendIndex 返回后端在故障转移链中的序号
+func (c *Client) backendIndex(b *backend) int {
+	for i, candidate := range c.backends {
+		if candidate == b {
+			return i
 		}
 	}
+	return 0
+}
+
+// streamChatWithTools 使用流式 API 进行对话(支持工具调用)
+// 返回: (累积的消息内容, 是否有工具调用, 错误),流中断时返回已累积的部分内容
+func (c *Client) streamChatWithTools(
+	ctx context.Context,
+	openaiClient *OpenAIClient,
+	messages []Message,
+	tools []Tool,
+	responseCh chan<- string,
+) (*StreamResult, bool, error) {
+	// 构建 OpenAI 请求
+	openaiMessages := toOpenAIMessages(messages)
+
+	// 构建工具定义
+	openaiTools := toOpenAITools(tools)
 
 	// 创建流式请求
 	openaiReq := openai.ChatCompletionRequest{
-		Model:    c.config.Model,
+		Model:    openaiClient.config.Model,
 		Messages: openaiMessages,
 		Stream:   true,
+		// 最后一个数据块返回 token 消耗
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
 	}
 
 	if len(openaiTools) > 0 {
@@ -525,7 +565,16 @@ func (c *Client) streamChatWithTools(
 			break
 		}
 		if err != nil {
-			return nil, false, fmt.Errorf("stream error: %w", err)
+			return result, false, fmt.Errorf("stream error: %w", err)
+		}
+
+		// 开启 include_usage 后,token 消耗在结束前的最后一个数据块中返回(choices 为空)
+		if response.Usage != nil {
+			result.Usage = Usage{
+				PromptTokens:     response.Usage.PromptTokens,
+				CompletionTokens: response.Usage.CompletionTokens,
+				TotalTokens:      response.Usage.TotalTokens,
+			}
 		}
 
 		if len(response.Choices) == 0 {
@@ -577,10 +626,9 @@ func (c *Client) streamChatWithTools(
 			}
 		}
 
-		// 检查是否结束
+		// 结束后继续读取,直到收到包含 token 消耗的最后一个数据块
 		if response.Choices[0].FinishReason != "" {
 			logx.Debug("Stream finished, reason: %s", response.Choices[0].FinishReason)
-			break
 		}
 	}
 
@@ -610,6 +658,7 @@ func (c *Client) streamChatWithTools(
 type StreamResult struct {
 	Content   string
 	ToolCalls []ToolCall
+	Usage     Usage
 }
 
 // SetProxy 设置代理
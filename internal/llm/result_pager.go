@@ -0,0 +1,294 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// NextPageToolName 获取分页工具结果后续页的内置工具名称
+	NextPageToolName = "get_tool_result_page"
+	// defaultToolResultMaxTokens 单次工具结果默认的 token 预算
+	defaultToolResultMaxTokens = 4000
+	// pagedResultTTL 分页结果的保留时间
+	pagedResultTTL = 30 * time.Minute
+	// maxPagedResults 最多保留的分页结果数量,超出时淘汰最早的结果
+	maxPagedResults = 200
+)
+
+// pagedResult 被分页的工具结果
+type pagedResult struct {
+	pages     []string
+	createdAt time.Time
+}
+
+// resultPager 按 token 预算截断/分页工具结果,避免大结果撑爆模型上下文
+type resultPager struct {
+	mu        sync.Mutex
+	maxTokens int
+	results   map[string]*pagedResult
+}
+
+// newResultPager 创建分页器,maxTokens <= 0 时使用默认预算
+func newResultPager(maxTokens int) *resultPager {
+	if maxTokens <= 0 {
+		maxTokens = defaultToolResultMaxTokens
+	}
+	return &resultPager{
+		maxTokens: maxTokens,
+		results:   make(map[string]*pagedResult),
+	}
+}
+
+// tool 返回获取后续页的工具定义
+func (p *resultPager) tool() Tool {
+	return Tool{
+		Type: "function",
+		Function: Function{
+			Name:        NextPageToolName,
+			Description: "获取被分页的工具结果的指定页。当工具结果提示已分页时,使用其中的 result_id 调用本工具获取后续数据",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"result_id": map[string]any{
+						"type":        "string",
+						"description": "分页结果 ID",
+					},
+					"page": map[string]any{
+						"type":        "integer",
+						"description": "页码,从 1 开始",
+					},
+				},
+				"required": []string{"result_id", "page"},
+			},
+		},
+	}
+}
+
+// fit 将工具结果转换为放入上下文的内容,超出预算时分页并返回第一页
+// 优先使用结构化内容(JSON),没有时使用文本内容
+func (p *resultPager) fit(toolName string, result *mcp.CallToolResult) string {
+	content, pages := p.paginate(result)
+	if len(pages) <= 1 {
+		return content
+	}
+
+	resultID := p.store(pages)
+	logx.Info("Tool result of %s exceeds token budget %d, split into %d pages, result_id %s",
+		toolName, p.maxTokens, len(pages), resultID)
+	return p.render(resultID, 1, pages)
+}
+
+// page 获取分页结果的指定页(从 1 开始)
+func (p *resultPager) page(resultID string, page int) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.evictLocked()
+
+	result, ok := p.results[resultID]
+	if !ok {
+		return "", fmt.Errorf("result %s not found or expired, please call the original tool again", resultID)
+	}
+	if page < 1 || page > len(result.pages) {
+		return "", fmt.Errorf("page %d out of range, total pages: %d", page, len(result.pages))
+	}
+
+	return p.render(resultID, page, result.pages), nil
+}
+
+// render 生成带分页提示的页面内容
+func (p *resultPager) render(resultID string, page int, pages []string) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("[结果较大,已分页: 第 %d/%d 页, result_id=%s", page, len(pages), resultID))
+	if page < len(pages) {
+		builder.WriteString(fmt.Sprintf("。如需后续数据,请调用 %s(result_id=%q, page=%d)", NextPageToolName, resultID, page+1))
+	}
+	builder.WriteString("]\n")
+	builder.WriteString(pages[page-1])
+	return builder.String()
+}
+
+// paginate 返回完整内容以及按预算切分后的页面
+func (p *resultPager) paginate(result *mcp.CallToolResult) (string, []string) {
+	if result.StructuredContent != nil {
+		data, err := json.Marshal(result.StructuredContent)
+		if err == nil {
+			content := string(data)
+			if estimateTokens(content) <= p.maxTokens {
+				return content, []string{content}
+			}
+			if pages := p.paginateItems(data); len(pages) > 0 {
+				return content, pages
+			}
+			return content, p.paginateText(content)
+		}
+	}
+
+	content := toolResultText(result)
+	if estimateTokens(content) <= p.maxTokens {
+		return content, []string{content}
+	}
+	return content, p.paginateText(content)
+}
+
+// paginateItems 按条目切分包含 items 数组的结构化内容,每页保留其它字段
+func (p *resultPager) paginateItems(data []byte) []string {
+	var structured map[string]json.RawMessage
+	if err := json.Unmarshal(data, &structured); err != nil {
+		return nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(structured["items"], &items); err != nil || len(items) == 0 {
+		return nil
+	}
+
+	var pages []string
+	var current []json.RawMessage
+	build := func(pageItems []json.RawMessage) string {
+		page := make(map[string]any, len(structured))
+		for k, v := range structured {
+			page[k] = v
+		}
+		page["items"] = pageItems
+		out, _ := json.Marshal(page)
+		return string(out)
+	}
+
+	for _, item := range items {
+		candidate := append(current, item)
+		if len(current) > 0 && estimateTokens(build(candidate)) > p.maxTokens {
+			pages = append(pages, build(current))
+			current = []json.RawMessage{item}
+			continue
+		}
+		current = candidate
+	}
+	if len(current) > 0 {
+		pages = append(pages, build(current))
+	}
+
+	// 单个条目超出预算时进一步按文本截断
+	for i, page := range pages {
+		if estimateTokens(page) > p.maxTokens {
+			pages[i] = truncateToTokens(page, p.maxTokens)
+		}
+	}
+
+	return pages
+}
+
+// paginateText 按行切分文本内容,单行超出预算时按字符截断
+func (p *resultPager) paginateText(content string) []string {
+	var pages []string
+	var current strings.Builder
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if current.Len() > 0 && estimateTokens(current.String()+line) > p.maxTokens {
+			pages = append(pages, current.String())
+			current.Reset()
+		}
+		for estimateTokens(line) > p.maxTokens {
+			head := truncateToTokens(line, p.maxTokens)
+			pages = append(pages, head)
+			line = line[len(head):]
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		pages = append(pages, current.String())
+	}
+
+	return pages
+}
+
+// store 保存分页结果并返回 result_id
+func (p *resultPager) store(pages []string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.evictLocked()
+
+	// 超出数量上限时淘汰最早的结果
+	for len(p.results) >= maxPagedResults {
+		var oldestID string
+		var oldest time.Time
+		for id, r := range p.results {
+			if oldestID == "" || r.createdAt.Before(oldest) {
+				oldestID, oldest = id, r.createdAt
+			}
+		}
+		delete(p.results, oldestID)
+	}
+
+	resultID := uuid.New().String()[:8]
+	p.results[resultID] = &pagedResult{
+		pages:     pages,
+		createdAt: time.Now(),
+	}
+
+	return resultID
+}
+
+// evictLocked 清理过期的分页结果(调用方需持有锁)
+func (p *resultPager) evictLocked() {
+	for id, r := range p.results {
+		if time.Since(r.createdAt) > pagedResultTTL {
+			delete(p.results, id)
+		}
+	}
+}
+
+// toolResultText 拼接工具结果中的所有文本内容
+func toolResultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if textContent, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, textContent.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// estimateTokens 粗略估算文本的 token 数: ASCII 字符约 4 个一个 token,其它字符(如中文)约 1 个一个 token
+func estimateTokens(s string) int {
+	ascii, other := 0, 0
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// truncateToTokens 截断文本使其不超过 token 预算(按字符边界截断)
+func truncateToTokens(s string, maxTokens int) string {
+	ascii, other := 0, 0
+	for i, r := range s {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+		if (ascii+3)/4+other > maxTokens {
+			if i == 0 {
+				// 至少保留一个字符,避免死循环
+				_, size := utf8.DecodeRuneInString(s)
+				return s[:size]
+			}
+			return s[:i]
+		}
+	}
+	return s
+}
@@ -0,0 +1,310 @@
+package llm
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/config"
+)
+
+// defaultRouterMaxTools 未配置时每次请求最多发送的工具数量
+const defaultRouterMaxTools = 20
+
+// toolAliases 工具名关键词的中文/常用别名,用于关键词匹配
+var toolAliases = map[string][]string{
+	"ecs":     {"主机", "服务器", "实例", "机器", "云服务器"},
+	"cvm":     {"主机", "服务器", "实例", "机器", "云服务器"},
+	"rds":     {"数据库", "mysql"},
+	"cdb":     {"数据库", "mysql"},
+	"oss":     {"存储桶", "bucket", "对象存储"},
+	"cos":     {"存储桶", "bucket", "对象存储"},
+	"jenkins": {"构建", "发布", "流水线", "job"},
+	"builds":  {"构建记录", "构建历史"},
+	"ip":      {"地址"},
+	// 负载均衡
+	"slb": {"负载均衡"},
+	"clb": {"负载均衡"},
+	// 网络与访问控制
+	"network":  {"网络", "acl", "访问控制", "安全组"},
+	"security": {"安全组", "acl", "防火墙", "端口"},
+	"vpcs":     {"vpc", "专有网络", "私有网络"},
+	"subnets":  {"子网", "交换机", "vswitch"},
+	// 域名解析
+	"dns":     {"解析", "域名"},
+	"records": {"解析记录"},
+	"domain":  {"域名"},
+	"domains": {"域名"},
+	// 弹性公网 IP
+	"eip":          {"弹性ip", "弹性公网", "公网ip"},
+	"eips":         {"弹性ip", "弹性公网", "公网ip"},
+	"unassociated": {"未绑定"},
+	// 证书
+	"certificate":  {"证书", "ssl"},
+	"certificates": {"证书", "ssl"},
+	"expiring":     {"过期", "到期"},
+	// CDN
+	"cdn":   {"加速", "内容分发"},
+	"cache": {"缓存", "刷新", "预热"},
+	// 费用
+	"cost":      {"费用", "成本", "账单", "花费", "开销"},
+	"breakdown": {"明细", "构成"},
+	"top":       {"最贵", "排行"},
+	// 闲置资源
+	"idle": {"闲置", "空闲", "浪费"},
+	// 监控指标
+	"metrics": {"监控", "指标", "cpu", "内存", "使用率"},
+}
+
+// toolProviderAliases 云厂商关键词,用于区分同类工具
+var toolProviderAliases = map[string][]string{
+	"ecs": {"阿里云", "aliyun"},
+	"rds": {"阿里云", "aliyun"},
+	"oss": {"阿里云", "aliyun"},
+	"cvm": {"腾讯云", "tencent"},
+	"cdb": {"腾讯云", "tencent"},
+	"cos": {"腾讯云", "tencent"},
+	"slb": {"阿里云", "aliyun"},
+	"clb": {"腾讯云", "tencent"},
+}
+
+type toolScopeKey struct{}
+
+// ToolScope 工具路由的会话范围
+type ToolScope struct {
+	Bot   string // 机器人: dingtalk, feishu, wecom, openai
+	Group string // 群/会话 ID
+}
+
+// WithToolScope 在 context 中设置会话范围,工具路由据此选择机器人/群的工具集
+func WithToolScope(ctx context.Context, bot, group string) context.Context {
+	return context.WithValue(ctx, toolScopeKey{}, ToolScope{Bot: bot, Group: group})
+}
+
+// toolScopeFrom 从 context 中获取会话范围
+func toolScopeFrom(ctx context.Context) ToolScope {
+	scope, _ := ctx.Value(toolScopeKey{}).(ToolScope)
+	return scope
+}
+
+// ToolAllowed 工具是否在会话范围的工具集中,未启用工具路由或未配置工具集时允许所有工具
+// 斜杠命令、按钮和意图规则直接调用工具时使用,与发送给模型的候选工具保持一致
+func ToolAllowed(ctx context.Context, cfg config.ToolRouterConfig, name string) bool {
+	if !cfg.Enabled {
+		return true
+	}
+	patterns := newToolRouter(cfg).toolSet(toolScopeFrom(ctx))
+	return patterns == nil || matchToolPatterns(name, patterns)
+}
+
+// toolRouter 按请求筛选发送给模型的工具: 机器人/群工具集 + 核心工具 + 关键词匹配
+type toolRouter struct {
+	cfg config.ToolRouterConfig
+}
+
+// newToolRouter 创建工具路由
+func newToolRouter(cfg config.ToolRouterConfig) *toolRouter {
+	if cfg.MaxTools <= 0 {
+		cfg.MaxTools = defaultRouterMaxTools
+	}
+	return &toolRouter{cfg: cfg}
+}
+
+// route 为本次请求选择工具,未启用时返回全部工具
+func (r *toolRouter) route(ctx context.Context, tools []Tool, query string) []Tool {
+	if !r.cfg.Enabled || len(tools) == 0 {
+		return tools
+	}
+
+	scope := toolScopeFrom(ctx)
+
+	// 机器人/群工具集限定候选范围
+	candidates := tools
+	if patterns := r.toolSet(scope); patterns != nil {
+		candidates = filterTools(tools, patterns)
+	}
+
+	selected := make([]Tool, 0, r.cfg.MaxTools+len(r.cfg.CoreTools))
+	picked := make(map[string]bool)
+
+	// 核心工具始终发送,但同样受机器人/群工具集限制
+	for _, tool := range candidates {
+		if matchToolPatterns(tool.Function.Name, r.cfg.CoreTools) {
+			selected = append(selected, tool)
+			picked[tool.Function.Name] = true
+		}
+	}
+
+	// 按关键词得分排序,得分相同时保持注册顺序
+	type scoredTool struct {
+		tool  Tool
+		score int
+	}
+	scored := make([]scoredTool, 0, len(candidates))
+	for _, tool := range candidates {
+		if picked[tool.Function.Name] {
+			continue
+		}
+		scored = append(scored, scoredTool{tool: tool, score: scoreTool(tool, query)})
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	// 有匹配的工具时只发送匹配的工具,完全没有匹配时按顺序补足
+	matched := len(scored) > 0 && scored[0].score > 0
+	count := 0
+	for _, st := range scored {
+		if count >= r.cfg.MaxTools || (matched && st.score == 0) {
+			break
+		}
+		selected = append(selected, st.tool)
+		count++
+	}
+
+	logx.Debug("Tool router selected %d/%d tools, bot %s, group %s", len(selected), len(tools), scope.Bot, scope.Group)
+
+	return selected
+}
+
+// toolSet 返回会话范围对应的工具集,群配置优先于机器人配置,未配置时返回 nil
+func (r *toolRouter) toolSet(scope ToolScope) []string {
+	if scope.Bot == "" {
+		return nil
+	}
+
+	var botTools []string
+	for _, set := range r.cfg.ToolSets {
+		if !strings.EqualFold(set.Bot, scope.Bot) {
+			continue
+		}
+		if set.Group != "" && set.Group == scope.Group {
+			return set.Tools
+		}
+		if set.Group == "" && botTools == nil {
+			botTools = set.Tools
+		}
+	}
+
+	return botTools
+}
+
+// filterTools 按通配符筛选工具
+func filterTools(tools []Tool, patterns []string) []Tool {
+	filtered := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		if matchToolPatterns(tool.Function.Name, patterns) {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered
+}
+
+// matchToolPatterns 工具名是否匹配任一通配符
+func matchToolPatterns(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// scoreTool 计算工具与用户问题的关键词匹配得分
+// 工具名片段及其别名命中得分较高,描述中命中问题里的词得分较低
+func scoreTool(tool Tool, query string) int {
+	query = strings.ToLower(query)
+	if query == "" {
+		return 0
+	}
+
+	score := 0
+	for _, token := range strings.FieldsFunc(strings.ToLower(tool.Function.Name), func(r rune) bool {
+		return r == '_' || r == '-' || r == '.'
+	}) {
+		if len(token) < 2 {
+			continue
+		}
+		if strings.Contains(query, token) {
+			score += 3
+		}
+		if containsAny(query, toolAliases[token]) {
+			score += 2
+		}
+		if containsAny(query, toolProviderAliases[token]) {
+			score += 2
+		}
+	}
+
+	description := strings.ToLower(tool.Function.Description)
+	for _, term := range queryTerms(query) {
+		if strings.Contains(description, term) {
+			score++
+		}
+	}
+
+	return score
+}
+
+// containsAny 文本是否包含任一关键词
+func containsAny(s string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(s, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// queryTerms 将问题拆分为匹配词: 英文/数字按单词(至少 3 个字符),中文按相邻两字
+func queryTerms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	add := func(term string) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	var word []rune
+	var han []rune
+	flush := func() {
+		if len(word) >= 3 {
+			add(string(word))
+		}
+		for i := 0; i+1 < len(han); i++ {
+			add(string(han[i : i+2]))
+		}
+		word, han = word[:0], han[:0]
+	}
+
+	for _, r := range query {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			if len(word) > 0 {
+				if len(word) >= 3 {
+					add(string(word))
+				}
+				word = word[:0]
+			}
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if len(han) > 0 {
+				for i := 0; i+1 < len(han); i++ {
+					add(string(han[i : i+2]))
+				}
+				han = han[:0]
+			}
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return terms
+}
@@ -0,0 +1,120 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eryajf/zenops/internal/config"
+)
+
+func testTools(names ...string) []Tool {
+	tools := make([]Tool, 0, len(names))
+	for _, name := range names {
+		tools = append(tools, Tool{Type: "function", Function: Function{Name: name}})
+	}
+	return tools
+}
+
+func toolNames(tools []Tool) []string {
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Function.Name)
+	}
+	return names
+}
+
+func TestRouteCoreToolsRespectToolSet(t *testing.T) {
+	router := newToolRouter(config.ToolRouterConfig{
+		Enabled:   true,
+		CoreTools: []string{"search_ecs_by_ip", "list_jenkins_jobs"},
+		ToolSets:  []config.ToolSetConfig{{Bot: "dingtalk", Tools: []string{"*_ecs*"}}},
+	})
+	tools := testTools("search_ecs_by_ip", "list_ecs", "list_jenkins_jobs", "rerun_jenkins_build")
+
+	ctx := WithToolScope(context.Background(), "dingtalk", "")
+	got := strings.Join(toolNames(router.route(ctx, tools, "列出 ECS")), ",")
+	if got != "search_ecs_by_ip,list_ecs" {
+		t.Errorf("route() = %s, want search_ecs_by_ip,list_ecs", got)
+	}
+
+	// 未配置工具集的机器人发送全部核心工具
+	ctx = WithToolScope(context.Background(), "feishu", "")
+	got = strings.Join(toolNames(router.route(ctx, tools, "列出 ECS")), ",")
+	if !strings.HasPrefix(got, "search_ecs_by_ip,list_jenkins_jobs,") {
+		t.Errorf("route() = %s, want core tools first", got)
+	}
+}
+
+func TestExecuteToolCallRejectsToolsNotOffered(t *testing.T) {
+	c := &Client{config: &Config{}}
+	call := ToolCall{ID: "call_1", Type: "function"}
+	call.Function.Name = "rerun_jenkins_build"
+	call.Function.Arguments = "{}"
+
+	_, err := c.executeToolCall(context.Background(), testTools("list_jenkins_jobs"), call)
+	if err == nil || !strings.Contains(err.Error(), "not available") {
+		t.Errorf("executeToolCall() error = %v, want tool not available", err)
+	}
+}
+
+func TestToolAllowed(t *testing.T) {
+	cfg := config.ToolRouterConfig{
+		Enabled:  true,
+		ToolSets: []config.ToolSetConfig{{Bot: "dingtalk", Tools: []string{"*_ecs*"}}},
+	}
+	tests := []struct {
+		name string
+		cfg  config.ToolRouterConfig
+		bot  string
+		tool string
+		want bool
+	}{
+		{name: "in tool set", cfg: cfg, bot: "dingtalk", tool: "search_ecs_by_ip", want: true},
+		{name: "outside tool set", cfg: cfg, bot: "dingtalk", tool: "rerun_jenkins_build", want: false},
+		{name: "bot without tool set", cfg: cfg, bot: "feishu", tool: "rerun_jenkins_build", want: true},
+		{name: "router disabled", cfg: config.ToolRouterConfig{ToolSets: cfg.ToolSets}, bot: "dingtalk", tool: "rerun_jenkins_build", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithToolScope(context.Background(), tt.bot, "")
+			if got := ToolAllowed(ctx, tt.cfg, tt.tool); got != tt.want {
+				t.Errorf("ToolAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouteByAliases(t *testing.T) {
+	router := newToolRouter(config.ToolRouterConfig{Enabled: true, MaxTools: 3})
+	tools := testTools("list_ecs", "list_rds", "list_slb", "list_clb", "list_security_groups", "list_vpcs",
+		"list_dns_records", "list_eip", "report_unassociated_eips", "list_expiring_certificates",
+		"refresh_cdn_cache", "get_cost_summary", "report_idle_resources", "get_instance_metrics")
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "阿里云有哪些负载均衡", want: "list_slb"},
+		{query: "腾讯云的负载均衡", want: "list_clb"},
+		{query: "哪些安全组开放了 22 端口", want: "list_security_groups"},
+		{query: "查一下专有网络", want: "list_vpcs"},
+		{query: "这个域名解析到哪里", want: "list_dns_records"},
+		{query: "列出弹性公网 IP", want: "list_eip"},
+		{query: "哪些证书快过期了", want: "list_expiring_certificates"},
+		{query: "刷新一下缓存", want: "refresh_cdn_cache"},
+		{query: "这个月的费用是多少", want: "get_cost_summary"},
+		{query: "有没有闲置的资源", want: "report_idle_resources"},
+		{query: "看下 CPU 使用率监控", want: "get_instance_metrics"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := toolNames(router.route(context.Background(), tools, tt.query))
+			if len(got) == 0 || got[0] != tt.want {
+				t.Errorf("route(%q) = %v, want %s first", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
 	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/metrics"
+	"github.com/eryajf/zenops/internal/tracing"
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,6 +23,7 @@ type Manager struct {
 
 // MCPClient MCP 客户端封装
 type MCPClient struct {
+	Name   string // 注册名称
 	Config *config.MCPServerConfig
 	Client *client.Client
 	Tools  []mcp.Tool
@@ -48,8 +51,10 @@ func (m *Manager) LoadFromConfig(cfg *config.MCPServersConfig) error {
 
 		if err := m.Register(name, serverCfg); err != nil {
 			logx.Error("❌ Failed to register MCP server %s: %v", name, err)
+			metrics.SetExternalMCPUp(name, false)
 			continue
 		}
+		metrics.SetExternalMCPUp(name, true)
 	}
 	return nil
 }
@@ -88,6 +93,7 @@ func (m *Manager) Register(name string, cfg *config.MCPServerConfig) error {
 
 	// 保存客户端
 	m.clients[name] = &MCPClient{
+		Name:   name,
 		Config: cfg,
 		Client: c,
 		Tools:  tools,
@@ -139,7 +145,10 @@ func (m *Manager) createStdioClient(cfg *config.MCPServerConfig) (*client.Client
 // createSSEClient 创建 SSE 客户端
 func (m *Manager) createSSEClient(cfg *config.MCPServerConfig) (*client.Client, error) {
 	// 构建选项
-	opts := []transport.ClientOption{}
+	// 透传链路上下文 (traceparent)
+	opts := []transport.ClientOption{
+		transport.WithHeaderFunc(tracing.Headers),
+	}
 
 	// 添加 Headers
 	if len(cfg.Headers) > 0 {
@@ -160,7 +169,10 @@ func (m *Manager) createSSEClient(cfg *config.MCPServerConfig) (*client.Client,
 // createStreamableHttpClient 创建 Streamable HTTP 客户端
 func (m *Manager) createStreamableHttpClient(cfg *config.MCPServerConfig) (*client.Client, error) {
 	// 构建选项
-	opts := []transport.StreamableHTTPCOption{}
+	// 透传链路上下文 (traceparent)
+	opts := []transport.StreamableHTTPCOption{
+		transport.WithHTTPHeaderFunc(tracing.Headers),
+	}
 
 	// 添加 Headers (注意: streamableHttp 使用 WithHTTPHeaders)
 	if len(cfg.Headers) > 0 {
@@ -237,13 +249,26 @@ func (m *Manager) CallTool(ctx context.Context, serverName, toolName string, arg
 		return nil, err
 	}
 
+	return mcpClient.CallTool(ctx, toolName, args)
+}
+
+// CallTool 调用外部 MCP Server 的工具,记录指标和链路,并将链路上下文透传给 HTTP 类型的 Server
+func (c *MCPClient) CallTool(ctx context.Context, toolName string, args any) (*mcp.CallToolResult, error) {
+	ctx, span := tracing.StartWithKind(ctx, "mcp.external_call", tracing.SpanKindClient,
+		tracing.String("mcp.server", c.Name),
+		tracing.String("mcp.tool", toolName))
+	defer span.End()
+
 	callReq := mcp.CallToolRequest{}
 	callReq.Params.Name = toolName
 	callReq.Params.Arguments = args
 
-	result, err := mcpClient.Client.CallTool(ctx, callReq)
+	result, err := c.Client.CallTool(ctx, callReq)
+	metrics.ObserveExternalMCPCall(c.Name, err)
+	metrics.SetExternalMCPUp(c.Name, err == nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call tool %s on %s: %w", toolName, serverName, err)
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to call tool %s on %s: %w", toolName, c.Name, err)
 	}
 
 	return result, nil
@@ -261,6 +286,7 @@ func (m *Manager) Close(name string) error {
 
 	c.Client.Close()
 	delete(m.clients, name)
+	metrics.DeleteExternalMCP(name)
 
 	logx.Info("Closed MCP client: %s", name)
 	return nil
@@ -273,6 +299,7 @@ func (m *Manager) CloseAll() {
 
 	for name, c := range m.clients {
 		c.Client.Close()
+		metrics.DeleteExternalMCP(name)
 		logx.Info("Closed MCP client: %s", name)
 	}
 	m.clients = make(map[string]*MCPClient)
@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	statusSuccess = "success"
+	statusError   = "error"
+)
+
+// iterationBuckets 工具调用轮数分桶
+var iterationBuckets = []float64{0, 1, 2, 3, 4, 5, 6, 8, 10}
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "zenops_http_requests_total",
+		Help: "HTTP 请求数",
+	}, []string{"method", "route", "code"})
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "zenops_http_request_duration_seconds",
+		Help:    "HTTP 请求耗时(秒)",
+		Buckets: durationBuckets,
+	}, []string{"method", "route"})
+
+	toolCallsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "zenops_tool_calls_total",
+		Help: "MCP 工具调用次数",
+	}, []string{"tool", "status"})
+	toolCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "zenops_tool_call_duration_seconds",
+		Help:    "MCP 工具调用耗时(秒)",
+		Buckets: durationBuckets,
+	}, []string{"tool"})
+
+	cloudAPICallsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "zenops_cloud_api_calls_total",
+		Help: "云厂商 API 调用次数",
+	}, []string{"provider", "account", "region", "api", "status"})
+	cloudAPICallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "zenops_cloud_api_call_duration_seconds",
+		Help:    "云厂商 API 调用耗时(秒)",
+		Buckets: durationBuckets,
+	}, []string{"provider", "api"})
+
+	llmRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "zenops_llm_requests_total",
+		Help: "LLM 请求次数",
+	}, []string{"backend", "mode", "status"})
+	llmRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "zenops_llm_request_duration_seconds",
+		Help:    "LLM 请求耗时(秒)",
+		Buckets: durationBuckets,
+	}, []string{"backend", "mode"})
+	llmTokensTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "zenops_llm_tokens_total",
+		Help: "LLM 消耗的 token 数",
+	}, []string{"backend", "type"})
+	llmToolIterations = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "zenops_llm_tool_iterations",
+		Help:    "单次对话中 LLM 的工具调用轮数",
+		Buckets: iterationBuckets,
+	})
+
+	externalMCPUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zenops_external_mcp_up",
+		Help: "外部 MCP Server 是否可用(1 可用, 0 不可用)",
+	}, []string{"server"})
+	externalMCPCallsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "zenops_external_mcp_calls_total",
+		Help: "外部 MCP Server 工具调用次数",
+	}, []string{"server", "status"})
+
+	botMessagesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "zenops_bot_messages_total",
+		Help: "机器人收到的消息数",
+	}, []string{"platform"})
+)
+
+// status 根据是否失败返回状态标签
+func status(failed bool) string {
+	if failed {
+		return statusError
+	}
+	return statusSuccess
+}
+
+// ObserveHTTPRequest 记录一次 HTTP 请求,route 为路由模板
+func ObserveHTTPRequest(method, route string, code int, duration time.Duration) {
+	httpRequestsTotal.WithLabelValues(method, route, strconv.Itoa(code)).Inc()
+	httpRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// ObserveToolCall 记录一次 MCP 工具调用
+func ObserveToolCall(tool string, failed bool, duration time.Duration) {
+	toolCallsTotal.WithLabelValues(tool, status(failed)).Inc()
+	toolCallDuration.WithLabelValues(tool).Observe(duration.Seconds())
+}
+
+// ObserveCloudAPI 记录一次云厂商 API 调用
+func ObserveCloudAPI(provider, account, region, api string, err error, start time.Time) {
+	cloudAPICallsTotal.WithLabelValues(provider, account, region, api, status(err != nil)).Inc()
+	cloudAPICallDuration.WithLabelValues(provider, api).Observe(time.Since(start).Seconds())
+}
+
+// ObserveLLMRequest 记录一次 LLM 请求,mode 为 stream 或 chat
+func ObserveLLMRequest(backend, mode string, err error, duration time.Duration) {
+	llmRequestsTotal.WithLabelValues(backend, mode, status(err != nil)).Inc()
+	llmRequestDuration.WithLabelValues(backend, mode).Observe(duration.Seconds())
+}
+
+// AddLLMTokens 累加 LLM token 消耗
+func AddLLMTokens(backend string, promptTokens, completionTokens int) {
+	if promptTokens > 0 {
+		llmTokensTotal.WithLabelValues(backend, "prompt").Add(float64(promptTokens))
+	}
+	if completionTokens > 0 {
+		llmTokensTotal.WithLabelValues(backend, "completion").Add(float64(completionTokens))
+	}
+}
+
+// ObserveLLMToolIterations 记录一次对话的工具调用轮数
+func ObserveLLMToolIterations(iterations int) {
+	llmToolIterations.Observe(float64(iterations))
+}
+
+// SetExternalMCPUp 设置外部 MCP Server 的可用状态
+func SetExternalMCPUp(server string, up bool) {
+	value := 0.0
+	if up {
+		value = 1
+	}
+	externalMCPUp.WithLabelValues(server).Set(value)
+}
+
+// DeleteExternalMCP 移除已关闭的外部 MCP Server
+func DeleteExternalMCP(server string) {
+	externalMCPUp.DeleteLabelValues(server)
+}
+
+// ObserveExternalMCPCall 记录一次外部 MCP Server 工具调用
+func ObserveExternalMCPCall(server string, err error) {
+	externalMCPCallsTotal.WithLabelValues(server, status(err != nil)).Inc()
+}
+
+// IncBotMessage 记录机器人收到一条消息
+func IncBotMessage(platform string) {
+	botMessagesTotal.WithLabelValues(platform).Inc()
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// CDNDomain 统一的 CDN 加速域名模型 (阿里云 CDN、腾讯云 CDN)
+type CDNDomain struct {
+	Name         string      `json:"name"`
+	Provider     string      `json:"provider"` // 提供商: aliyun, tencent
+	Account      string      `json:"account,omitempty"`
+	CNAME        string      `json:"cname"`
+	Status       string      `json:"status"`        // 状态: online, offline 及各云的中间状态
+	BusinessType string      `json:"business_type"` // 业务类型: web, download, media 等,保留各云原始取值
+	Area         string      `json:"area"`          // 加速区域: domestic(仅中国内地), overseas, global
+	Origins      []CDNOrigin `json:"origins"`
+	HTTPS        bool        `json:"https"`
+	CertName     string      `json:"cert_name,omitempty"`
+	CertExpireAt *time.Time  `json:"cert_expire_at,omitempty"`
+	CreatedAt    time.Time   `json:"created_at"`
+	ConsoleURL   string      `json:"console_url"` // 控制台跳转地址
+}
+
+// CDNOrigin CDN 源站
+type CDNOrigin struct {
+	Address  string `json:"address"`
+	Type     string `json:"type"`               // 源站类型: ip, domain, oss, cos 等,保留各云原始取值
+	Port     int    `json:"port,omitempty"`     // 回源端口,为 0 时使用默认端口
+	Priority string `json:"priority,omitempty"` // 主备: primary, backup
+}
+
+// CDN 加速域名状态
+const (
+	CDNStatusOnline  = "online"
+	CDNStatusOffline = "offline"
+)
+
+// CDN 源站主备
+const (
+	CDNOriginPrimary = "primary"
+	CDNOriginBackup  = "backup"
+)
+
+// CDNRefreshTask 已提交的 CDN 缓存刷新任务
+type CDNRefreshTask struct {
+	TaskID   string   `json:"task_id"`
+	Provider string   `json:"provider"` // 提供商: aliyun, tencent
+	Account  string   `json:"account,omitempty"`
+	Type     string   `json:"type"` // 刷新类型: url, directory
+	Paths    []string `json:"paths"`
+}
+
+// CDN 缓存刷新类型
+const (
+	CDNRefreshURL       = "url"
+	CDNRefreshDirectory = "directory"
+)
+
+// SplitCDNRefreshPaths 按刷新类型拆分路径,以 / 结尾的为目录,其余为 URL
+// 未带协议的路径补全为 http://,两家云均按域名和路径匹配缓存,与协议无关
+func SplitCDNRefreshPaths(paths []string) (urls, dirs []string) {
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+			path = "http://" + path
+		}
+		if strings.HasSuffix(path, "/") {
+			dirs = append(dirs, path)
+		} else {
+			urls = append(urls, path)
+		}
+	}
+	return urls, dirs
+}
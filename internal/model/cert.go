@@ -0,0 +1,35 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// Certificate 统一的 SSL 证书模型,来源为云证书服务或对主机的主动 TLS 握手
+type Certificate struct {
+	ID         string    `json:"id,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	Provider   string    `json:"provider,omitempty"` // 提供商: aliyun, tencent,主动检查时为空
+	Account    string    `json:"account,omitempty"`
+	Source     string    `json:"source"`         // 来源: cloud, tls
+	Host       string    `json:"host,omitempty"` // 主动检查的主机 (host:port)
+	Domain     string    `json:"domain"`         // 主域名 (CN)
+	SANs       []string  `json:"sans,omitempty"` // 备用域名
+	Issuer     string    `json:"issuer"`         // 颁发机构或证书品牌
+	Status     string    `json:"status"`         // 各云原始状态,主动检查时为 valid 或校验错误
+	NotBefore  time.Time `json:"not_before"`
+	NotAfter   time.Time `json:"not_after"`
+	Products   []string  `json:"products,omitempty"` // 已部署的云产品,如 SLB、CDN、CLB
+	ConsoleURL string    `json:"console_url,omitempty"`
+}
+
+// 证书来源
+const (
+	CertSourceCloud = "cloud"
+	CertSourceTLS   = "tls"
+)
+
+// DaysLeft 距离过期的天数,已过期时为负数
+func (c *Certificate) DaysLeft(now time.Time) int {
+	return int(math.Floor(c.NotAfter.Sub(now).Hours() / 24))
+}
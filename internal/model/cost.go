@@ -0,0 +1,50 @@
+package model
+
+// CostItem 单个实例在一个账期内的费用 (阿里云 BSS 实例账单、腾讯云资源账单)
+type CostItem struct {
+	InstanceID   string            `json:"instance_id"`
+	InstanceName string            `json:"instance_name,omitempty"`
+	ProductCode  string            `json:"product_code"`
+	Product      string            `json:"product"` // 产品名称
+	Region       string            `json:"region"`  // 账单中的地域,保留各云原始取值
+	Provider     string            `json:"provider"`
+	Account      string            `json:"account"`
+	BillingCycle string            `json:"billing_cycle"` // 账期: YYYY-MM
+	Amount       float64           `json:"amount"`        // 应付金额: 优惠后、代金券抵扣前
+	Currency     string            `json:"currency"`
+	Tags         map[string]string `json:"tags,omitempty"`
+}
+
+// CostGroup 按维度汇总的费用
+type CostGroup struct {
+	Key       string  `json:"key"`
+	Amount    float64 `json:"amount"`
+	Instances int     `json:"instances"` // 实例数
+}
+
+// CostBreakdown 一个账期内按维度拆分的费用,Groups 按金额降序排列
+type CostBreakdown struct {
+	BillingCycle string       `json:"billing_cycle"`
+	GroupBy      string       `json:"group_by"` // account, product, region, tag
+	TagKey       string       `json:"tag_key,omitempty"`
+	Currency     string       `json:"currency"`
+	Total        float64      `json:"total"`
+	Groups       []*CostGroup `json:"groups"`
+}
+
+// CostSummary 单个账号本月至今和上月的费用合计
+type CostSummary struct {
+	Provider    string  `json:"provider"`
+	Account     string  `json:"account"`
+	Currency    string  `json:"currency"`
+	MonthToDate float64 `json:"month_to_date"`
+	LastMonth   float64 `json:"last_month"`
+}
+
+// 费用拆分维度
+const (
+	CostGroupAccount = "account"
+	CostGroupProduct = "product"
+	CostGroupRegion  = "region"
+	CostGroupTag     = "tag"
+)
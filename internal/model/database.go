@@ -8,7 +8,7 @@ type Database struct {
 	Name          string            `json:"name"`
 	Provider      string            `json:"provider"`
 	Region        string            `json:"region"`
-	Engine        string            `json:"engine"`         // mysql, postgresql, redis
+	Engine        string            `json:"engine"`         // mysql, postgresql, redis (阿里云 Redis/Tair、腾讯云 Redis)
 	EngineVersion string            `json:"engine_version"`
 	Status        string            `json:"status"`
 	Endpoint      string            `json:"endpoint"`
@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// Domain 云解析托管的域名 (阿里云云解析 DNS、腾讯云 DNSPod)
+type Domain struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Provider    string    `json:"provider"` // 提供商: aliyun, tencent
+	Account     string    `json:"account"`  // 所属账号
+	RecordCount int       `json:"record_count"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DNSRecord 解析记录
+type DNSRecord struct {
+	ID       string `json:"id"`
+	Domain   string `json:"domain"`
+	RR       string `json:"rr"`       // 主机记录,@ 表示主域名
+	FQDN     string `json:"fqdn"`     // 完整域名
+	Type     string `json:"type"`     // A, AAAA, CNAME, MX, TXT 等
+	Value    string `json:"value"`    // 记录值
+	TTL      int    `json:"ttl"`      // 秒
+	Line     string `json:"line"`     // 解析线路
+	Status   string `json:"status"`   // ENABLE, DISABLE
+	Provider string `json:"provider"` // 提供商: aliyun, tencent
+	Account  string `json:"account"`  // 所属账号
+}
+
+// 解析记录状态
+const (
+	DNSRecordEnabled  = "ENABLE"
+	DNSRecordDisabled = "DISABLE"
+)
+
+// 解析目标类型
+const (
+	DNSTargetInstance     = "instance"      // ECS/CVM
+	DNSTargetLoadBalancer = "load_balancer" // SLB/ALB/CLB
+	DNSTargetCDN          = "cdn"
+	DNSTargetOSS          = "oss" // 阿里云 OSS、腾讯云 COS
+	DNSTargetExternal     = "external"
+)
+
+// DNSResolution 域名解析链路及最终指向的云资源
+type DNSResolution struct {
+	Hostname string       `json:"hostname"`
+	Records  []*DNSRecord `json:"records"` // 解析链路上经过的托管记录,按跟随顺序排列
+	Targets  []*DNSTarget `json:"targets"` // 链路终点
+}
+
+// DNSTarget 解析链路终点: A 记录的 IP 或指向托管域名之外的 CNAME
+type DNSTarget struct {
+	Address      string `json:"address"` // IP 或 CNAME 目标
+	Type         string `json:"type"`    // 目标类型: instance, load_balancer, cdn, oss, external
+	Provider     string `json:"provider,omitempty"`
+	Account      string `json:"account,omitempty"`
+	Region       string `json:"region,omitempty"`
+	ResourceID   string `json:"resource_id,omitempty"`
+	ResourceName string `json:"resource_name,omitempty"`
+	ConsoleURL   string `json:"console_url,omitempty"`
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// EIP 统一的公网 IP 模型 (跨云平台),包含弹性公网 IP 和随实例分配的普通公网 IP
+type EIP struct {
+	ID                 string            `json:"id"` // 普通公网 IP 无独立 ID 时为 IP 地址
+	Address            string            `json:"address"`
+	Name               string            `json:"name,omitempty"`
+	Provider           string            `json:"provider"` // 提供商: aliyun, tencent
+	Account            string            `json:"account,omitempty"`
+	Region             string            `json:"region"`
+	Type               string            `json:"type"`                 // 类型: eip, public_ip
+	Status             string            `json:"status"`               // 状态: in_use, available 及各云的中间状态
+	Bandwidth          int               `json:"bandwidth"`            // 带宽峰值 (Mbps)
+	InternetChargeType string            `json:"internet_charge_type"` // 网络计费方式,保留各云原始取值
+	ChargeType         string            `json:"charge_type"`          // 付费类型: PrePaid(包年包月), PostPaid(按量付费)
+	ISP                string            `json:"isp,omitempty"`        // 线路类型: BGP 等
+	InstanceID         string            `json:"instance_id,omitempty"`
+	InstanceType       string            `json:"instance_type,omitempty"` // 绑定资源类型: ecs, cvm, slb, clb, nat, eni, havip 等
+	Locked             bool              `json:"locked"`                  // 是否因欠费或安全原因被锁定
+	CreatedAt          time.Time         `json:"created_at"`
+	ExpiredAt          *time.Time        `json:"expired_at,omitempty"`
+	Tags               map[string]string `json:"tags"`
+	ConsoleURL         string            `json:"console_url"` // 控制台跳转地址
+}
+
+// 公网 IP 类型
+const (
+	EIPTypeEIP      = "eip"
+	EIPTypePublicIP = "public_ip"
+)
+
+// 公网 IP 状态
+const (
+	EIPStatusInUse     = "in_use"
+	EIPStatusAvailable = "available"
+)
+
+// IdleBilling 是否为未绑定任何资源但仍在计费的弹性公网 IP
+// 阿里云和腾讯云对未绑定的 EIP 均持续收取带宽费、IP 保有费或闲置费,欠费锁定的除外
+func (e *EIP) IdleBilling() bool {
+	return e.Type == EIPTypeEIP && e.Status == EIPStatusAvailable && e.InstanceID == "" && !e.Locked
+}
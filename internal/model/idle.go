@@ -0,0 +1,34 @@
+package model
+
+// 闲置资源处理建议
+const (
+	IdleSuggestRelease   = "release"   // 峰值也低于阈值,建议释放
+	IdleSuggestDownscale = "downscale" // 平均负载低但有短时峰值,建议降配
+)
+
+// 闲置资源费用来源
+const (
+	IdleCostBill     = "bill"     // 该实例上月账单
+	IdleCostEstimate = "estimate" // 同账号同规格实例上月账单的平均值
+)
+
+// IdleResource 统计周期内各项监控指标均低于阈值的实例或数据库
+type IdleResource struct {
+	Type        string   `json:"type"` // instance, database
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Provider    string   `json:"provider"`
+	Account     string   `json:"account"`
+	Region      string   `json:"region"`
+	Spec        string   `json:"spec"` // 实例规格或数据库引擎版本
+	Days        int      `json:"days"`
+	CPUAvg      float64  `json:"cpu_avg"`              // 平均 CPU 使用率 (%)
+	CPUMax      float64  `json:"cpu_max"`              // 小时平均 CPU 使用率的最大值 (%)
+	MemoryAvg   *float64 `json:"memory_avg,omitempty"` // 平均内存使用率 (%),无监控数据时为空
+	NetworkKbps float64  `json:"network_kbps"`         // 平均入方向与出方向流量之和 (Kbps)
+	MonthlyCost *float64 `json:"monthly_cost,omitempty"`
+	CostSource  string   `json:"cost_source,omitempty"` // bill, estimate
+	Currency    string   `json:"currency,omitempty"`
+	Suggestion  string   `json:"suggestion"` // release, downscale
+	ConsoleURL  string   `json:"console_url"`
+}
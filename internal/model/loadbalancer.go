@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// LoadBalancer 统一的负载均衡模型 (跨云平台)
+type LoadBalancer struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Provider    string            `json:"provider"`     // 提供商: aliyun, tencent
+	Type        string            `json:"type"`         // 类型: slb, alb, clb
+	Region      string            `json:"region"`       // 区域
+	AddressType string            `json:"address_type"` // 网络类型: internet(公网), intranet(内网)
+	Addresses   []string          `json:"addresses"`    // 服务地址 (VIP)
+	DNSName     string            `json:"dns_name,omitempty"`
+	Status      string            `json:"status"` // 状态
+	VpcID       string            `json:"vpc_id"`
+	Listeners   []*Listener       `json:"listeners,omitempty"`
+	Backends    []*Backend        `json:"backends,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Tags        map[string]string `json:"tags"`
+	Metadata    map[string]any    `json:"metadata"`    // 扩展字段
+	ConsoleURL  string            `json:"console_url"` // 控制台跳转地址
+}
+
+// Listener 负载均衡监听
+type Listener struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Protocol    string `json:"protocol"` // TCP, UDP, HTTP, HTTPS 等
+	Port        int    `json:"port"`
+	BackendPort int    `json:"backend_port,omitempty"` // 后端端口,为 0 时与后端服务器配置一致
+	Status      string `json:"status,omitempty"`
+}
+
+// Backend 负载均衡后端服务器
+type Backend struct {
+	ServerID     string    `json:"server_id"`   // 实例 ID,IP 类型后端为 IP
+	ServerType   string    `json:"server_type"` // 后端类型: ecs, cvm, eni, ip 等
+	IP           string    `json:"ip,omitempty"`
+	Port         int       `json:"port,omitempty"`
+	Weight       int       `json:"weight"`
+	ListenerPort int       `json:"listener_port,omitempty"` // 所属监听端口
+	Health       string    `json:"health"`                  // 健康状态: healthy, unhealthy, unknown
+	Instance     *Instance `json:"instance,omitempty"`      // 关联的云服务器实例
+}
+
+// 后端健康状态
+const (
+	BackendHealthy   = "healthy"
+	BackendUnhealthy = "unhealthy"
+	BackendUnknown   = "unknown"
+)
+
+// LoadBalancerList 负载均衡列表
+type LoadBalancerList struct {
+	Items    []*LoadBalancer `json:"items"`
+	PageInfo *PageInfo       `json:"page_info,omitempty"`
+}
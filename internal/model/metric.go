@@ -0,0 +1,120 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// 监控指标名称,各云的原始指标在 Provider 中映射为统一名称
+const (
+	MetricCPU        = "cpu"         // CPU 使用率 (%)
+	MetricMemory     = "memory"      // 内存使用率 (%),ECS/CVM 需安装监控插件
+	MetricDisk       = "disk"        // 磁盘使用率 (%),仅数据库
+	MetricNetworkIn  = "network_in"  // 入方向流量 (bit/s),实例为公网与内网之和
+	MetricNetworkOut = "network_out" // 出方向流量 (bit/s),实例为公网与内网之和
+)
+
+// 监控指标对应的资源类型
+const (
+	MetricResourceInstance = "instance" // ECS/CVM
+	MetricResourceDatabase = "database" // RDS/CDB
+)
+
+// 监控指标单位
+const (
+	MetricUnitPercent = "%"
+	MetricUnitBPS     = "bit/s"
+)
+
+// MetricPoint 监控数据点,Value 为周期内的平均值
+type MetricPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// MetricSeries 单个指标在一段时间内的数据,Points 按时间升序排列
+type MetricSeries struct {
+	Metric string        `json:"metric"`
+	Unit   string        `json:"unit"`
+	Period int           `json:"period"` // 数据点间隔(秒)
+	Points []MetricPoint `json:"points"`
+}
+
+// Avg 数据点的平均值,无数据时返回 false
+func (s *MetricSeries) Avg() (float64, bool) {
+	if len(s.Points) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, p := range s.Points {
+		sum += p.Value
+	}
+	return sum / float64(len(s.Points)), true
+}
+
+// Max 数据点的最大值,无数据时返回 false
+func (s *MetricSeries) Max() (float64, bool) {
+	if len(s.Points) == 0 {
+		return 0, false
+	}
+	max := s.Points[0].Value
+	for _, p := range s.Points[1:] {
+		if p.Value > max {
+			max = p.Value
+		}
+	}
+	return max, true
+}
+
+// Last 最新的数据点,无数据时返回 false
+func (s *MetricSeries) Last() (MetricPoint, bool) {
+	if len(s.Points) == 0 {
+		return MetricPoint{}, false
+	}
+	return s.Points[len(s.Points)-1], true
+}
+
+// SumMetricPoints 按时间戳合并多组数据点并求和,用于将公网、内网流量合并为总流量
+func SumMetricPoints(groups ...[]MetricPoint) []MetricPoint {
+	sums := make(map[int64]float64)
+	for _, points := range groups {
+		for _, p := range points {
+			sums[p.Timestamp.Unix()] += p.Value
+		}
+	}
+
+	points := make([]MetricPoint, 0, len(sums))
+	for ts, value := range sums {
+		points = append(points, MetricPoint{Timestamp: time.Unix(ts, 0), Value: value})
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Timestamp.Before(points[j].Timestamp)
+	})
+	return points
+}
+
+// MetricSummary 单个指标的当前值、平均值、峰值及降采样后的数据点,无数据时数值为空
+type MetricSummary struct {
+	Metric  string        `json:"metric"`
+	Unit    string        `json:"unit"`
+	Current *float64      `json:"current,omitempty"` // 最新数据点的值
+	Avg     *float64      `json:"avg,omitempty"`
+	Max     *float64      `json:"max,omitempty"` // 按原始粒度计算的峰值
+	Period  int           `json:"period"`        // 降采样后的数据点间隔(秒)
+	Points  []MetricPoint `json:"points"`
+}
+
+// ResourceMetrics 实例或数据库在一段时间内的监控指标
+type ResourceMetrics struct {
+	ResourceType string           `json:"resource_type"` // instance 或 database
+	ResourceID   string           `json:"resource_id"`
+	Name         string           `json:"name"`
+	Provider     string           `json:"provider"`
+	Account      string           `json:"account"`
+	Region       string           `json:"region"`
+	Spec         string           `json:"spec"` // 实例规格或数据库引擎
+	Start        time.Time        `json:"start"`
+	End          time.Time        `json:"end"`
+	Metrics      []*MetricSummary `json:"metrics"`
+	ConsoleURL   string           `json:"console_url"`
+}
@@ -0,0 +1,227 @@
+package model
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VPC 统一的专有网络模型 (跨云平台)
+type VPC struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Provider    string            `json:"provider"` // 提供商: aliyun, tencent
+	Region      string            `json:"region"`   // 区域
+	CIDR        string            `json:"cidr"`     // 网段
+	Status      string            `json:"status"`
+	IsDefault   bool              `json:"is_default"`
+	Description string            `json:"description,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Tags        map[string]string `json:"tags"`
+	ConsoleURL  string            `json:"console_url"` // 控制台跳转地址
+}
+
+// Subnet 统一的子网模型 (阿里云交换机 vSwitch、腾讯云子网)
+type Subnet struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Provider     string            `json:"provider"`
+	Region       string            `json:"region"`
+	Zone         string            `json:"zone"` // 可用区
+	VpcID        string            `json:"vpc_id"`
+	CIDR         string            `json:"cidr"`
+	AvailableIPs int               `json:"available_ips"` // 可用 IP 数
+	Status       string            `json:"status"`
+	IsDefault    bool              `json:"is_default"`
+	Description  string            `json:"description,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	Tags         map[string]string `json:"tags"`
+	ConsoleURL   string            `json:"console_url"`
+}
+
+// SecurityGroup 统一的安全组模型
+type SecurityGroup struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Provider    string               `json:"provider"`
+	Region      string               `json:"region"`
+	VpcID       string               `json:"vpc_id,omitempty"` // 腾讯云安全组不属于 VPC
+	Type        string               `json:"type,omitempty"`   // 阿里云: normal(普通), enterprise(企业级)
+	Description string               `json:"description,omitempty"`
+	Rules       []*SecurityGroupRule `json:"rules,omitempty"` // 列表查询不包含规则
+	CreatedAt   time.Time            `json:"created_at"`
+	Tags        map[string]string    `json:"tags"`
+	ConsoleURL  string               `json:"console_url"`
+}
+
+// SecurityGroupRule 安全组规则
+type SecurityGroupRule struct {
+	SecurityGroupID string `json:"security_group_id"`
+	Direction       string `json:"direction"`               // ingress(入方向), egress(出方向)
+	Policy          string `json:"policy"`                  // accept, drop
+	Protocol        string `json:"protocol"`                // tcp, udp, icmp, all 等
+	PortRange       string `json:"port_range"`              // all, 80, 8000-9000, 80,443
+	CIDR            string `json:"cidr"`                    // 对端网段: 入方向为源地址,出方向为目的地址
+	PeerGroupID     string `json:"peer_group_id,omitempty"` // 对端安全组,授权对象为安全组时使用
+	Priority        int    `json:"priority"`                // 越小越优先
+	Description     string `json:"description,omitempty"`
+}
+
+// 安全组规则方向和策略
+const (
+	DirectionIngress = "ingress"
+	DirectionEgress  = "egress"
+
+	PolicyAccept = "accept"
+	PolicyDrop   = "drop"
+)
+
+// InstanceNetwork 实例的网络上下文及生效的安全组规则
+type InstanceNetwork struct {
+	Instance       *Instance            `json:"instance"`
+	VPC            *VPC                 `json:"vpc,omitempty"`
+	Subnet         *Subnet              `json:"subnet,omitempty"`
+	SecurityGroups []*SecurityGroup     `json:"security_groups"`
+	Ingress        []*SecurityGroupRule `json:"ingress"` // 按匹配顺序排列
+	Egress         []*SecurityGroupRule `json:"egress"`
+	DefaultIngress string               `json:"default_ingress"` // 未命中任何规则时的入方向策略
+	DefaultEgress  string               `json:"default_egress"`
+}
+
+// AccessCheck 端口可达性评估结果
+type AccessCheck struct {
+	Direction string             `json:"direction"`
+	Protocol  string             `json:"protocol"`
+	Port      int                `json:"port"`
+	CIDR      string             `json:"cidr"`
+	Allowed   bool               `json:"allowed"`
+	Rule      *SecurityGroupRule `json:"rule,omitempty"` // 命中的规则,为空表示使用默认策略
+	Reason    string             `json:"reason"`
+}
+
+// SortRules 按匹配顺序排列规则: 优先级越小越先匹配,相同优先级时拒绝规则优先
+func SortRules(rules []*SecurityGroupRule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+		return rules[i].Policy == PolicyDrop && rules[j].Policy != PolicyDrop
+	})
+}
+
+// CheckAccess 评估指定方向、协议和端口对某个网段是否放行
+// 按匹配顺序取第一条命中的规则,授权对象为安全组的规则无法按网段评估,会被跳过
+func (n *InstanceNetwork) CheckAccess(direction, protocol string, port int, cidr string) (*AccessCheck, error) {
+	peer, err := parseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	direction = strings.ToLower(direction)
+	protocol = strings.ToLower(protocol)
+	result := &AccessCheck{Direction: direction, Protocol: protocol, Port: port, CIDR: peer.String()}
+
+	rules, defaultPolicy := n.Ingress, n.DefaultIngress
+	switch direction {
+	case DirectionIngress:
+	case DirectionEgress:
+		rules, defaultPolicy = n.Egress, n.DefaultEgress
+	default:
+		return nil, fmt.Errorf("invalid direction %q, must be ingress or egress", direction)
+	}
+
+	for _, rule := range rules {
+		if !rule.matches(protocol, port, peer) {
+			continue
+		}
+		result.Rule = rule
+		result.Allowed = rule.Policy == PolicyAccept
+		result.Reason = fmt.Sprintf("命中安全组 %s 的规则 (%s %s %s 优先级 %d)",
+			rule.SecurityGroupID, rule.Policy, rule.Protocol, rule.PortRange, rule.Priority)
+		return result, nil
+	}
+
+	result.Allowed = defaultPolicy == PolicyAccept
+	result.Reason = fmt.Sprintf("未命中任何规则,使用默认策略 %s", defaultPolicy)
+	return result, nil
+}
+
+// matches 规则是否覆盖指定协议、端口和对端网段
+func (r *SecurityGroupRule) matches(protocol string, port int, peer *net.IPNet) bool {
+	if r.CIDR == "" {
+		return false
+	}
+	if r.Protocol != "all" && r.Protocol != protocol {
+		return false
+	}
+	if (protocol == "tcp" || protocol == "udp") && !portInRange(port, r.PortRange) {
+		return false
+	}
+
+	ruleNet, err := parseCIDR(r.CIDR)
+	if err != nil {
+		return false
+	}
+	ruleOnes, ruleBits := ruleNet.Mask.Size()
+	peerOnes, peerBits := peer.Mask.Size()
+	return ruleBits == peerBits && ruleOnes <= peerOnes && ruleNet.Contains(peer.IP)
+}
+
+// portInRange 端口是否在规则端口范围内,范围格式: all, 80, 8000-9000, 80,443
+func portInRange(port int, portRange string) bool {
+	if portRange == "" || portRange == "all" {
+		return true
+	}
+	for _, part := range strings.Split(portRange, ",") {
+		part = strings.TrimSpace(part)
+		from, to, found := strings.Cut(part, "-")
+		if !found {
+			to = from
+		}
+		low, err1 := strconv.Atoi(from)
+		high, err2 := strconv.Atoi(to)
+		if err1 == nil && err2 == nil && port >= low && port <= high {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCIDR 解析网段,单个 IP 视为 /32 (IPv6 为 /128)
+func parseCIDR(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP or CIDR %q", s)
+		}
+		if ip.To4() != nil {
+			return &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP or CIDR %q", s)
+	}
+	return ipNet, nil
+}
+
+// CollectRules 汇总所有安全组的规则,按匹配顺序分为入方向和出方向
+func (n *InstanceNetwork) CollectRules() {
+	n.Ingress = make([]*SecurityGroupRule, 0)
+	n.Egress = make([]*SecurityGroupRule, 0)
+	for _, sg := range n.SecurityGroups {
+		for _, rule := range sg.Rules {
+			if rule.Direction == DirectionEgress {
+				n.Egress = append(n.Egress, rule)
+			} else {
+				n.Ingress = append(n.Ingress, rule)
+			}
+		}
+	}
+	SortRules(n.Ingress)
+	SortRules(n.Egress)
+}
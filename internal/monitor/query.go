@@ -0,0 +1,355 @@
+// Package monitor 按实例 ID 或 IP 查询实例、数据库的云监控指标,返回当前值和降采样后的趋势
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/cloudaccount"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+const (
+	// DefaultWindow 未指定时间范围时查询最近 1 小时
+	DefaultWindow = time.Hour
+
+	// minWindow、maxWindow 时间范围的上下限
+	minWindow = 10 * time.Minute
+	maxWindow = 30 * 24 * time.Hour
+
+	// maxPoints 降采样后每个指标最多保留的数据点,与趋势图宽度一致
+	maxPoints = 60
+
+	// maxRawPoints 单次查询的数据点上限,用于选择原始数据粒度
+	maxRawPoints = 1440
+)
+
+// periods 云监控支持的数据点间隔(秒),从细到粗
+var periods = []int{60, 300, 3600}
+
+// metricAliases 指标别名,network 同时查询入方向和出方向流量
+var metricAliases = map[string][]string{
+	model.MetricCPU:        {model.MetricCPU},
+	model.MetricMemory:     {model.MetricMemory},
+	"mem":                  {model.MetricMemory},
+	model.MetricDisk:       {model.MetricDisk},
+	"network":              {model.MetricNetworkIn, model.MetricNetworkOut},
+	"net":                  {model.MetricNetworkIn, model.MetricNetworkOut},
+	model.MetricNetworkIn:  {model.MetricNetworkIn},
+	model.MetricNetworkOut: {model.MetricNetworkOut},
+}
+
+// Querier 在多个账号中查找资源并查询监控指标
+type Querier struct {
+	accounts []*cloudaccount.Account
+}
+
+// NewQuerier 创建监控指标查询器
+func NewQuerier(accounts []*cloudaccount.Account) *Querier {
+	return &Querier{accounts: accounts}
+}
+
+// Load 按 cloud、account 加载账号并创建监控指标查询器
+func Load(cfg *config.Config, cloud, account string) (*Querier, error) {
+	accounts, err := cloudaccount.Load(cfg, cloud, account)
+	if err != nil {
+		return nil, err
+	}
+	return NewQuerier(accounts), nil
+}
+
+// windowUnits 将中英文时间单位统一为 m、h、d,较长的单位放在前面优先匹配
+var windowUnits = strings.NewReplacer(
+	"minutes", "m", "minute", "m", "mins", "m", "min", "m", "分钟", "m",
+	"hours", "h", "hour", "h", "小时", "h",
+	"days", "d", "day", "d", "天", "d",
+	"个", "", " ", "",
+)
+
+// ParseWindow 解析时间范围,支持 30m、6h、7d、6 小时、7 days 等格式,为空时返回 DefaultWindow
+func ParseWindow(value string) (time.Duration, error) {
+	value = windowUnits.Replace(strings.ToLower(strings.TrimSpace(value)))
+	if value == "" {
+		return DefaultWindow, nil
+	}
+
+	var window time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q, use a format like 30m, 6h or 7d", value)
+		}
+		window = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q, use a format like 30m, 6h or 7d", value)
+		}
+		window = d
+	}
+
+	if window < minWindow || window > maxWindow {
+		return 0, fmt.Errorf("window must be between %dm and %dd", int(minWindow.Minutes()), int(maxWindow.Hours()/24))
+	}
+	return window, nil
+}
+
+// ParseMetrics 解析逗号分隔的指标名称并展开别名,为空时返回 nil 表示查询全部指标
+func ParseMetrics(value string) ([]string, error) {
+	var metrics []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		expanded, ok := metricAliases[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported metric %q, must be cpu, memory, disk, network, network_in or network_out", name)
+		}
+		for _, m := range expanded {
+			if !seen[m] {
+				seen[m] = true
+				metrics = append(metrics, m)
+			}
+		}
+	}
+	return metrics, nil
+}
+
+// target 查找到的资源及所属账号
+type target struct {
+	account *cloudaccount.Account
+	metrics *model.ResourceMetrics
+}
+
+// Query 查询资源最近 window 时间内的监控指标
+// resource 为实例 ID、数据库 ID 或实例 IP,resourceType 为空时按 ID 前缀推断,无法推断时依次尝试实例和数据库
+func (q *Querier) Query(ctx context.Context, resource, resourceType string, metrics []string, window time.Duration) (*model.ResourceMetrics, error) {
+	resource = strings.TrimSpace(resource)
+	if resource == "" {
+		return nil, fmt.Errorf("resource is required")
+	}
+	if resourceType != "" && resourceType != model.MetricResourceInstance && resourceType != model.MetricResourceDatabase {
+		return nil, fmt.Errorf("unsupported type %q, must be instance or database", resourceType)
+	}
+	if window <= 0 {
+		window = DefaultWindow
+	}
+
+	t, err := q.find(ctx, resource, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	period := rawPeriod(window)
+	end := time.Now().Truncate(time.Duration(period) * time.Second)
+	start := end.Add(-window)
+
+	result := t.metrics
+	series, err := t.account.Provider.GetMetrics(ctx, &provider.MetricQuery{
+		ResourceType: result.ResourceType,
+		ResourceID:   result.ResourceID,
+		Region:       result.Region,
+		Metrics:      metrics,
+		Start:        start,
+		End:          end,
+		Period:       period,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics of %s: %w", result.ResourceID, err)
+	}
+
+	result.Start, result.End = start, end
+	result.Metrics = make([]*model.MetricSummary, 0, len(series))
+	for _, s := range series {
+		result.Metrics = append(result.Metrics, summarize(s))
+	}
+	return result, nil
+}
+
+// find 在各账号中查找资源,IP 只匹配实例的私网、公网或弹性公网 IP
+func (q *Querier) find(ctx context.Context, resource, resourceType string) (*target, error) {
+	if net.ParseIP(resource) != nil {
+		if resourceType == model.MetricResourceDatabase {
+			return nil, fmt.Errorf("database must be specified by id, not ip")
+		}
+		opts := &provider.QueryOptions{Filters: map[string]string{"ip": resource}}
+		for _, acc := range q.accounts {
+			instances, err := acc.Provider.ListInstances(ctx, opts)
+			if err != nil {
+				logx.Warn("Failed to search instance by IP, ip %s, account %s, error %v", resource, acc.Name, err)
+				continue
+			}
+			if len(instances) > 0 {
+				return instanceTarget(acc, instances[0]), nil
+			}
+		}
+		return nil, fmt.Errorf("no instance found with ip %s", resource)
+	}
+
+	cloud, guessed := guess(resource)
+	if resourceType == "" {
+		resourceType = guessed
+	}
+	types := []string{resourceType}
+	if resourceType == "" {
+		types = []string{model.MetricResourceInstance, model.MetricResourceDatabase}
+	}
+
+	for _, acc := range q.accounts {
+		if cloud != "" && acc.Cloud != cloud {
+			continue
+		}
+		for _, typ := range types {
+			if typ == model.MetricResourceInstance {
+				if inst, err := acc.Provider.GetInstance(ctx, resource); err == nil {
+					return instanceTarget(acc, inst), nil
+				}
+				continue
+			}
+			if db, err := acc.Provider.GetDatabase(ctx, resource); err == nil {
+				return databaseTarget(acc, db), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("resource %s not found in any account", resource)
+}
+
+// guess 按 ID 前缀推断云平台和资源类型: ECS i-、CVM ins-、RDS rm-/pgm-、CDB cdb-
+func guess(id string) (cloud, resourceType string) {
+	switch {
+	case strings.HasPrefix(id, "i-"):
+		return "aliyun", model.MetricResourceInstance
+	case strings.HasPrefix(id, "ins-"):
+		return "tencent", model.MetricResourceInstance
+	case strings.HasPrefix(id, "rm-"), strings.HasPrefix(id, "pgm-"):
+		return "aliyun", model.MetricResourceDatabase
+	case strings.HasPrefix(id, "cdb-"):
+		return "tencent", model.MetricResourceDatabase
+	}
+	return "", ""
+}
+
+// instanceTarget 由实例构造查询目标
+func instanceTarget(acc *cloudaccount.Account, inst *model.Instance) *target {
+	return &target{account: acc, metrics: &model.ResourceMetrics{
+		ResourceType: model.MetricResourceInstance,
+		ResourceID:   inst.ID,
+		Name:         inst.Name,
+		Provider:     acc.Cloud,
+		Account:      acc.Name,
+		Region:       inst.Region,
+		Spec:         inst.InstanceType,
+		ConsoleURL:   inst.ConsoleURL,
+	}}
+}
+
+// databaseTarget 由数据库构造查询目标
+func databaseTarget(acc *cloudaccount.Account, db *model.Database) *target {
+	return &target{account: acc, metrics: &model.ResourceMetrics{
+		ResourceType: model.MetricResourceDatabase,
+		ResourceID:   db.ID,
+		Name:         db.Name,
+		Provider:     acc.Cloud,
+		Account:      acc.Name,
+		Region:       db.Region,
+		Spec:         strings.TrimSpace(db.Engine + " " + db.EngineVersion),
+		ConsoleURL:   db.ConsoleURL,
+	}}
+}
+
+// rawPeriod 选择数据点不超过 maxRawPoints 的最细粒度
+func rawPeriod(window time.Duration) int {
+	for _, p := range periods {
+		if int(window.Seconds())/p <= maxRawPoints {
+			return p
+		}
+	}
+	return periods[len(periods)-1]
+}
+
+// summarize 计算当前值、平均值和峰值,并将数据点降采样到 maxPoints 以内
+func summarize(s *model.MetricSeries) *model.MetricSummary {
+	summary := &model.MetricSummary{Metric: s.Metric, Unit: s.Unit, Period: s.Period, Points: s.Points}
+	if last, ok := s.Last(); ok {
+		summary.Current = &last.Value
+	}
+	if avg, ok := s.Avg(); ok {
+		summary.Avg = &avg
+	}
+	if peak, ok := s.Max(); ok {
+		summary.Max = &peak
+	}
+
+	if len(s.Points) > maxPoints {
+		step := int(math.Ceil(float64(len(s.Points)) / maxPoints))
+		points := make([]model.MetricPoint, 0, maxPoints)
+		for i := 0; i < len(s.Points); i += step {
+			bucket := s.Points[i:min(i+step, len(s.Points))]
+			var sum float64
+			for _, p := range bucket {
+				sum += p.Value
+			}
+			points = append(points, model.MetricPoint{Timestamp: bucket[0].Timestamp, Value: sum / float64(len(bucket))})
+		}
+		summary.Points = points
+		summary.Period = s.Period * step
+	}
+	return summary
+}
+
+// sparkBlocks 趋势图字符,从低到高
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline 将数据点渲染为字符趋势图,百分比指标按 0-100 缩放,其余按最小值到最大值缩放
+func Sparkline(summary *model.MetricSummary) string {
+	if len(summary.Points) == 0 {
+		return ""
+	}
+
+	low, high := 0.0, 100.0
+	if summary.Unit != model.MetricUnitPercent {
+		low, high = summary.Points[0].Value, summary.Points[0].Value
+		for _, p := range summary.Points {
+			low, high = math.Min(low, p.Value), math.Max(high, p.Value)
+		}
+	}
+
+	var sb strings.Builder
+	for _, p := range summary.Points {
+		level := 0
+		if high > low {
+			level = int(math.Round((p.Value - low) / (high - low) * float64(len(sparkBlocks)-1)))
+		}
+		sb.WriteRune(sparkBlocks[max(0, min(level, len(sparkBlocks)-1))])
+	}
+	return sb.String()
+}
+
+// FormatValue 按单位格式化指标值,流量换算为 Kbps、Mbps、Gbps,value 为空时返回 -
+func FormatValue(value *float64, unit string) string {
+	if value == nil {
+		return "-"
+	}
+	v := *value
+	if unit == model.MetricUnitPercent {
+		return fmt.Sprintf("%.1f%%", v)
+	}
+	switch {
+	case v >= 1e9:
+		return fmt.Sprintf("%.2f Gbps", v/1e9)
+	case v >= 1e6:
+		return fmt.Sprintf("%.2f Mbps", v/1e6)
+	default:
+		return fmt.Sprintf("%.1f Kbps", v/1e3)
+	}
+}
@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/certs"
+	"github.com/eryajf/zenops/internal/config"
+)
+
+// RunCertAlert 启动后立即检查一次,之后按配置的间隔检查即将过期的证书并通知到群,阻塞直到 ctx 取消
+func RunCertAlert(ctx context.Context, cfg *config.Config) {
+	alert := cfg.Certificates.Alert
+	if len(alert.Targets) == 0 {
+		logx.Warn("Certificate alert is enabled but no targets configured")
+		return
+	}
+	interval := time.Duration(alert.Interval) * time.Hour
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	notifier := New(cfg, alert.Targets)
+
+	logx.Info("📜 Certificate alert started, days %d, interval %s", alert.Days, interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		checkCertificates(ctx, cfg, notifier)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkCertificates 查询所有账号和主机的证书,存在即将过期的证书时发送通知
+func checkCertificates(ctx context.Context, cfg *config.Config, notifier *Notifier) {
+	days := cfg.Certificates.Alert.Days
+
+	checker, _, err := certs.Load(cfg, "", "", "")
+	if err != nil {
+		logx.Error("Failed to load certificate checker: %v", err)
+		return
+	}
+	expiring, err := checker.Expiring(ctx, days, "")
+	if err != nil {
+		logx.Error("Failed to check certificates: %v", err)
+		return
+	}
+	if len(expiring) == 0 {
+		logx.Info("No certificates expiring within %d days", days)
+		return
+	}
+
+	now := time.Now()
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("以下 %d 个证书将在 %d 天内过期或已过期,请及时续期并重新部署:\n\n", len(expiring), days))
+	for _, cert := range expiring {
+		sb.WriteString("- " + certs.Describe(cert, now) + "\n")
+	}
+	title := fmt.Sprintf("SSL 证书过期提醒 (%d)", len(expiring))
+	_ = notifier.Send(ctx, title, sb.String())
+}
@@ -0,0 +1,153 @@
+// Package notify 向飞书、Slack、Telegram、钉钉、企业微信群发送主动通知
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/feishu"
+	"github.com/eryajf/zenops/internal/slack"
+	"github.com/eryajf/zenops/internal/telegram"
+)
+
+// telegramChunkLength Telegram 单条消息按该长度拆分
+const telegramChunkLength = 3500
+
+// Notifier 按配置的目标列表发送 Markdown 通知
+type Notifier struct {
+	cfg        *config.Config
+	targets    []config.NotifyTarget
+	httpClient *http.Client
+}
+
+// New 创建通知器
+func New(cfg *config.Config, targets []config.NotifyTarget) *Notifier {
+	return &Notifier{
+		cfg:        cfg,
+		targets:    targets,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send 向所有目标发送通知,单个目标失败不影响其他目标,返回合并后的错误
+func (n *Notifier) Send(ctx context.Context, title, content string) error {
+	var errs []error
+	for _, target := range n.targets {
+		if err := n.send(ctx, target, title, content); err != nil {
+			logx.Error("Failed to send notification, bot %s, error %v", target.Bot, err)
+			errs = append(errs, fmt.Errorf("%s: %w", target.Bot, err))
+			continue
+		}
+		logx.Info("Sent notification %q via %s", title, target.Bot)
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) send(ctx context.Context, target config.NotifyTarget, title, content string) error {
+	switch target.Bot {
+	case "feishu":
+		return n.sendFeishu(ctx, target.ChatID, title, content)
+	case "slack":
+		return n.sendSlack(ctx, target.ChatID, title, content)
+	case "telegram":
+		return n.sendTelegram(ctx, target.ChatID, title, content)
+	case "dingtalk":
+		return n.postWebhook(ctx, target.Webhook, map[string]any{
+			"msgtype":  "markdown",
+			"markdown": map[string]string{"title": title, "text": "### " + title + "\n\n" + content},
+		})
+	case "wecom":
+		return n.postWebhook(ctx, target.Webhook, map[string]any{
+			"msgtype":  "markdown",
+			"markdown": map[string]string{"content": "### " + title + "\n\n" + content},
+		})
+	default:
+		return fmt.Errorf("unsupported bot type: %s", target.Bot)
+	}
+}
+
+// sendFeishu 以卡片形式发送,卡片中的 Markdown 可正常渲染
+func (n *Notifier) sendFeishu(ctx context.Context, chatID, title, content string) error {
+	if n.cfg.Feishu.AppID == "" {
+		return fmt.Errorf("feishu app is not configured")
+	}
+	card, err := feishu.ResultCard("**"+title+"**\n\n"+content, nil)
+	if err != nil {
+		return err
+	}
+	client := feishu.NewClient(n.cfg.Feishu.AppID, n.cfg.Feishu.AppSecret)
+	return client.SendInteractiveCard(ctx, "chat_id", chatID, card)
+}
+
+func (n *Notifier) sendSlack(ctx context.Context, channel, title, content string) error {
+	if n.cfg.Slack.BotToken == "" {
+		return fmt.Errorf("slack bot token is not configured")
+	}
+	client := slack.NewClient(n.cfg.Slack.AppToken, n.cfg.Slack.BotToken, n.cfg.Slack.APIURL)
+	text := slack.ToMrkdwn("**" + title + "**\n\n" + content)
+	_, err := client.PostMessage(ctx, channel, "", title, slack.SectionBlocks(text))
+	return err
+}
+
+func (n *Notifier) sendTelegram(ctx context.Context, chatID, title, content string) error {
+	if n.cfg.Telegram.BotToken == "" {
+		return fmt.Errorf("telegram bot token is not configured")
+	}
+	id, err := strconv.ParseInt(chatID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid telegram chat id %q: %w", chatID, err)
+	}
+	client := telegram.NewClient(n.cfg.Telegram.BotToken, n.cfg.Telegram.APIURL)
+	for _, chunk := range telegram.SplitMarkdown("**"+title+"**\n\n"+content, telegramChunkLength) {
+		_, err := client.SendMessage(ctx, id, telegram.ToHTML(chunk), telegram.ParseModeHTML, 0)
+		if telegram.IsParseError(err) {
+			_, err = client.SendMessage(ctx, id, chunk, "", 0)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postWebhook 调用钉钉、企业微信群机器人 Webhook,两者均以 errcode 表示结果
+func (n *Notifier) postWebhook(ctx context.Context, webhook string, payload any) error {
+	if webhook == "" {
+		return fmt.Errorf("webhook is required")
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode webhook response, status %d: %w", resp.StatusCode, err)
+	}
+	if result.ErrCode != 0 {
+		return fmt.Errorf("webhook error: code=%d, msg=%s", result.ErrCode, result.ErrMsg)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package aliyun
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eryajf/zenops/internal/model"
+)
+
+// 费用中心 (BSS) 为全局服务,与区域无关
+
+const (
+	bssEndpoint = "business.aliyuncs.com"
+	bssVersion  = "2017-12-14"
+
+	bssPageSize = 300
+)
+
+type bssInstanceBillItem struct {
+	InstanceID   string  `json:"InstanceID"`
+	NickName     string  `json:"NickName"`
+	ProductCode  string  `json:"ProductCode"`
+	ProductName  string  `json:"ProductName"`
+	Region       string  `json:"Region"`
+	PretaxAmount float64 `json:"PretaxAmount"` // 应付金额
+	Currency     string  `json:"Currency"`
+	Tag          string  `json:"Tag"` // 形如 key:env value:prod; key:team value:ops
+}
+
+type bssDescribeInstanceBillResponse struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+	Success bool   `json:"Success"`
+	Data    struct {
+		BillingCycle string                `json:"BillingCycle"`
+		TotalCount   int                   `json:"TotalCount"`
+		NextToken    string                `json:"NextToken"`
+		Items        []bssInstanceBillItem `json:"Items"`
+	} `json:"Data"`
+}
+
+// ListCostItems 查询账期内的实例账单,同一实例的多条账单 (如不同计费方式) 合并为一条
+func (c *Client) ListCostItems(ctx context.Context, billingCycle string) ([]*model.CostItem, error) {
+	query := map[string]string{
+		"BillingCycle": billingCycle,
+		"MaxResults":   strconv.Itoa(bssPageSize),
+	}
+
+	items := make([]*model.CostItem, 0)
+	index := make(map[string]*model.CostItem)
+	for {
+		var resp bssDescribeInstanceBillResponse
+		if err := c.callRPC(ctx, bssEndpoint, bssVersion, "DescribeInstanceBill", query, &resp); err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			return nil, fmt.Errorf("failed to call DescribeInstanceBill: %s %s", resp.Code, resp.Message)
+		}
+
+		for _, bill := range resp.Data.Items {
+			key := bill.ProductCode + "/" + bill.InstanceID
+			if item, ok := index[key]; ok {
+				item.Amount += bill.PretaxAmount
+				continue
+			}
+			item := c.convertCostItem(&bill, billingCycle)
+			index[key] = item
+			items = append(items, item)
+		}
+
+		if resp.Data.NextToken == "" || len(resp.Data.Items) == 0 {
+			break
+		}
+		query["NextToken"] = resp.Data.NextToken
+	}
+	return items, nil
+}
+
+// convertCostItem 将实例账单转换为统一模型
+func (c *Client) convertCostItem(bill *bssInstanceBillItem, billingCycle string) *model.CostItem {
+	return &model.CostItem{
+		InstanceID:   bill.InstanceID,
+		InstanceName: bill.NickName,
+		ProductCode:  bill.ProductCode,
+		Product:      firstNonEmpty(bill.ProductName, bill.ProductCode),
+		Region:       bill.Region,
+		Provider:     "aliyun",
+		Account:      c.Account,
+		BillingCycle: billingCycle,
+		Amount:       bill.PretaxAmount,
+		Currency:     firstNonEmpty(bill.Currency, "CNY"),
+		Tags:         parseBillTags(bill.Tag),
+	}
+}
+
+// parseBillTags 解析账单中的标签字符串
+func parseBillTags(value string) map[string]string {
+	if value == "" {
+		return nil
+	}
+	tags := make(map[string]string)
+	for _, pair := range strings.Split(value, ";") {
+		pair = strings.TrimSpace(pair)
+		key, val, ok := strings.Cut(pair, " value:")
+		if !ok {
+			continue
+		}
+		key = strings.TrimPrefix(key, "key:")
+		if key != "" {
+			tags[key] = val
+		}
+	}
+	return tags
+}
@@ -0,0 +1,151 @@
+package aliyun
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+)
+
+// 数字证书管理服务 (CAS) 为全局服务,与区域无关
+
+const (
+	casEndpoint = "cas.aliyuncs.com"
+	casVersion  = "2020-04-07"
+
+	casPageSize = 100
+)
+
+type casCertificate struct {
+	CertificateID int64  `json:"CertificateId"`
+	Name          string `json:"Name"`
+	CommonName    string `json:"CommonName"`
+	Sans          string `json:"Sans"` // 逗号分隔
+	Issuer        string `json:"Issuer"`
+	Status        string `json:"Status"`
+	CertStartTime int64  `json:"CertStartTime"` // 毫秒
+	CertEndTime   int64  `json:"CertEndTime"`   // 毫秒
+}
+
+type casListUserCertificateOrderResponse struct {
+	TotalCount           int              `json:"TotalCount"`
+	CertificateOrderList []casCertificate `json:"CertificateOrderList"`
+}
+
+type casListCloudResourcesResponse struct {
+	Total int `json:"Total"`
+	Data  []struct {
+		CertID       int64  `json:"CertId"`
+		CloudProduct string `json:"CloudProduct"`
+	} `json:"Data"`
+}
+
+// ListCertificates 查询上传和签发的证书,keyword 按域名或名称模糊匹配
+func (c *Client) ListCertificates(ctx context.Context, keyword string) ([]*model.Certificate, error) {
+	query := map[string]string{
+		"OrderType": "CERT",
+		"ShowSize":  strconv.Itoa(casPageSize),
+	}
+	if keyword != "" {
+		query["Keyword"] = keyword
+	}
+
+	var certs []*model.Certificate
+	for page, fetched := 1, 0; ; page++ {
+		query["CurrentPage"] = strconv.Itoa(page)
+
+		var resp casListUserCertificateOrderResponse
+		if err := c.callRPC(ctx, casEndpoint, casVersion, "ListUserCertificateOrder", query, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, cert := range resp.CertificateOrderList {
+			certs = append(certs, c.convertCertificate(&cert))
+		}
+
+		fetched += len(resp.CertificateOrderList)
+		if len(resp.CertificateOrderList) < casPageSize || fetched >= resp.TotalCount {
+			break
+		}
+	}
+
+	// 部署信息查询失败不影响证书列表
+	products, err := c.listCertProducts(ctx)
+	if err != nil {
+		logx.Warn("Failed to list Aliyun certificate deployments, account %s, error %v", c.Account, err)
+	}
+	for _, cert := range certs {
+		cert.Products = products[cert.ID]
+	}
+	return certs, nil
+}
+
+// listCertProducts 查询证书已部署的云产品,返回证书 ID -> 产品列表
+func (c *Client) listCertProducts(ctx context.Context) (map[string][]string, error) {
+	query := map[string]string{"ShowSize": strconv.Itoa(casPageSize)}
+
+	seen := make(map[string]map[string]bool)
+	for page, fetched := 1, 0; ; page++ {
+		query["CurrentPage"] = strconv.Itoa(page)
+
+		var resp casListCloudResourcesResponse
+		if err := c.callRPC(ctx, casEndpoint, casVersion, "ListCloudResources", query, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, r := range resp.Data {
+			id := strconv.FormatInt(r.CertID, 10)
+			if seen[id] == nil {
+				seen[id] = make(map[string]bool)
+			}
+			seen[id][r.CloudProduct] = true
+		}
+
+		fetched += len(resp.Data)
+		if len(resp.Data) < casPageSize || fetched >= resp.Total {
+			break
+		}
+	}
+
+	products := make(map[string][]string, len(seen))
+	for id, set := range seen {
+		for product := range set {
+			products[id] = append(products[id], product)
+		}
+		sort.Strings(products[id])
+	}
+	return products, nil
+}
+
+// convertCertificate 将 CAS 证书转换为统一模型
+func (c *Client) convertCertificate(cert *casCertificate) *model.Certificate {
+	result := &model.Certificate{
+		ID:       strconv.FormatInt(cert.CertificateID, 10),
+		Name:     cert.Name,
+		Provider: "aliyun",
+		Account:  c.Account,
+		Source:   model.CertSourceCloud,
+		Domain:   cert.CommonName,
+		Issuer:   cert.Issuer,
+		Status:   cert.Status,
+		ConsoleURL: fmt.Sprintf("https://yundun.console.aliyun.com/?p=cas#/certExtend/detail/%d",
+			cert.CertificateID),
+	}
+	for _, san := range strings.Split(cert.Sans, ",") {
+		if san = strings.TrimSpace(san); san != "" && san != cert.CommonName {
+			result.SANs = append(result.SANs, san)
+		}
+	}
+	if cert.CertStartTime > 0 {
+		result.NotBefore = time.UnixMilli(cert.CertStartTime)
+	}
+	if cert.CertEndTime > 0 {
+		result.NotAfter = time.UnixMilli(cert.CertEndTime)
+	}
+	return result
+}
@@ -0,0 +1,199 @@
+package aliyun
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+)
+
+// CDN 为全局服务,与区域无关
+
+const (
+	cdnEndpoint = "cdn.aliyuncs.com"
+	cdnVersion  = "2018-05-10"
+
+	cdnDomainPageSize = 500
+	cdnCertPageSize   = 1000
+)
+
+type cdnSource struct {
+	Content  string `json:"Content"`
+	Type     string `json:"Type"` // ipaddr, domain, oss, fc_domain
+	Port     int    `json:"Port"`
+	Priority string `json:"Priority"` // 20 主源站, 30 备源站
+}
+
+type cdnDomain struct {
+	DomainName   string `json:"DomainName"`
+	Cname        string `json:"Cname"`
+	DomainStatus string `json:"DomainStatus"` // online, offline, configuring, configure_failed, checking, check_failed, stopping, deleting
+	CdnType      string `json:"CdnType"`      // web, download, video
+	Coverage     string `json:"Coverage"`     // domestic, overseas, global
+	SslProtocol  string `json:"SslProtocol"`  // on, off
+	GmtCreated   string `json:"GmtCreated"`
+	Sources      struct {
+		Source []cdnSource `json:"Source"`
+	} `json:"Sources"`
+}
+
+type cdnDescribeUserDomainsResponse struct {
+	TotalCount int `json:"TotalCount"`
+	Domains    struct {
+		PageData []cdnDomain `json:"PageData"`
+	} `json:"Domains"`
+}
+
+type cdnCertInfo struct {
+	DomainName     string `json:"DomainName"`
+	CertName       string `json:"CertName"`
+	CertExpireTime string `json:"CertExpireTime"`
+}
+
+type cdnDescribeHTTPSDomainListResponse struct {
+	TotalCount int `json:"TotalCount"`
+	CertInfos  struct {
+		CertInfo []cdnCertInfo `json:"CertInfo"`
+	} `json:"CertInfos"`
+}
+
+type cdnRefreshObjectCachesResponse struct {
+	RefreshTaskID string `json:"RefreshTaskId"` // 多个任务时以逗号分隔
+}
+
+// ListCDNDomains 查询 CDN 加速域名,keyword 为空时返回全部,否则按域名模糊匹配
+func (c *Client) ListCDNDomains(ctx context.Context, keyword string) ([]*model.CDNDomain, error) {
+	query := map[string]string{"PageSize": strconv.Itoa(cdnDomainPageSize)}
+	if keyword != "" {
+		query["DomainName"] = keyword
+		query["DomainSearchType"] = "fuzzy_match"
+	}
+
+	domains := make([]*model.CDNDomain, 0)
+	for page, fetched := 1, 0; ; page++ {
+		query["PageNumber"] = strconv.Itoa(page)
+
+		var resp cdnDescribeUserDomainsResponse
+		if err := c.callRPC(ctx, cdnEndpoint, cdnVersion, "DescribeUserDomains", query, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, d := range resp.Domains.PageData {
+			domains = append(domains, c.convertCDNDomain(&d))
+		}
+
+		fetched += len(resp.Domains.PageData)
+		if len(resp.Domains.PageData) < cdnDomainPageSize || fetched >= resp.TotalCount {
+			break
+		}
+	}
+
+	// 证书信息查询失败不影响域名列表
+	certs, err := c.listCDNCerts(ctx, keyword)
+	if err != nil {
+		logx.Warn("Failed to list Aliyun CDN certificates, account %s, error %v", c.Account, err)
+	}
+	for _, d := range domains {
+		if cert, ok := certs[d.Name]; ok {
+			d.CertName = cert.CertName
+			if t, ok := parseCDNTime(cert.CertExpireTime); ok {
+				d.CertExpireAt = &t
+			}
+		}
+	}
+	return domains, nil
+}
+
+// listCDNCerts 查询已开启 HTTPS 的加速域名证书,返回域名 -> 证书
+func (c *Client) listCDNCerts(ctx context.Context, keyword string) (map[string]cdnCertInfo, error) {
+	query := map[string]string{"PageSize": strconv.Itoa(cdnCertPageSize)}
+	if keyword != "" {
+		query["Keyword"] = keyword
+	}
+
+	certs := make(map[string]cdnCertInfo)
+	for page, fetched := 1, 0; ; page++ {
+		query["PageNumber"] = strconv.Itoa(page)
+
+		var resp cdnDescribeHTTPSDomainListResponse
+		if err := c.callRPC(ctx, cdnEndpoint, cdnVersion, "DescribeCdnHttpsDomainList", query, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, cert := range resp.CertInfos.CertInfo {
+			certs[cert.DomainName] = cert
+		}
+
+		fetched += len(resp.CertInfos.CertInfo)
+		if len(resp.CertInfos.CertInfo) < cdnCertPageSize || fetched >= resp.TotalCount {
+			break
+		}
+	}
+	return certs, nil
+}
+
+// RefreshCDNCache 提交缓存刷新任务,refreshType 为 url 或 directory
+func (c *Client) RefreshCDNCache(ctx context.Context, paths []string, refreshType string) (*model.CDNRefreshTask, error) {
+	objectType := "File"
+	if refreshType == model.CDNRefreshDirectory {
+		objectType = "Directory"
+	}
+
+	var resp cdnRefreshObjectCachesResponse
+	if err := c.callRPC(ctx, cdnEndpoint, cdnVersion, "RefreshObjectCaches", map[string]string{
+		"ObjectPath": strings.Join(paths, "\n"),
+		"ObjectType": objectType,
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	logx.Info("Submitted Aliyun CDN refresh, account %s, type %s, paths %d, task %s", c.Account, refreshType, len(paths), resp.RefreshTaskID)
+	return &model.CDNRefreshTask{
+		TaskID:   resp.RefreshTaskID,
+		Provider: "aliyun",
+		Account:  c.Account,
+		Type:     refreshType,
+		Paths:    paths,
+	}, nil
+}
+
+// convertCDNDomain 将 CDN 加速域名转换为统一模型
+func (c *Client) convertCDNDomain(d *cdnDomain) *model.CDNDomain {
+	domain := &model.CDNDomain{
+		Name:         d.DomainName,
+		Provider:     "aliyun",
+		Account:      c.Account,
+		CNAME:        d.Cname,
+		Status:       d.DomainStatus,
+		BusinessType: d.CdnType,
+		Area:         d.Coverage,
+		Origins:      make([]model.CDNOrigin, 0, len(d.Sources.Source)),
+		HTTPS:        d.SslProtocol == "on",
+		ConsoleURL:   fmt.Sprintf("https://cdn.console.aliyun.com/domain/detail/%s/basic", d.DomainName),
+	}
+	for _, s := range d.Sources.Source {
+		origin := model.CDNOrigin{Address: s.Content, Type: s.Type, Port: s.Port, Priority: model.CDNOriginPrimary}
+		if s.Priority == "30" {
+			origin.Priority = model.CDNOriginBackup
+		}
+		domain.Origins = append(domain.Origins, origin)
+	}
+	if t, ok := parseCDNTime(d.GmtCreated); ok {
+		domain.CreatedAt = t
+	}
+	return domain
+}
+
+// parseCDNTime 解析 CDN 接口返回的时间,创建时间为 ISO 8601,证书时间为 UTC 的日期时间
+func parseCDNTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
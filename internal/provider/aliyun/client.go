@@ -1,13 +1,18 @@
 package aliyun
 
 import (
+	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
 	ecs "github.com/alibabacloud-go/ecs-20140526/v4/client"
 	rds "github.com/alibabacloud-go/rds-20140815/v14/client"
 	"github.com/alibabacloud-go/tea/tea"
 	oss "github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/eryajf/zenops/internal/metrics"
+	"github.com/eryajf/zenops/internal/tracing"
 )
 
 // Client 阿里云客户端
@@ -15,9 +20,12 @@ type Client struct {
 	AccessKeyID     string
 	AccessKeySecret string
 	Region          string
+	Account         string // 账号名称,用于指标标签
 	ecsClient       *ecs.Client
 	rdsClient       *rds.Client
 	ossClient       *oss.Client
+	apiClientsMu    sync.Mutex                 // 保护 apiClients,同一客户端可能被多个 goroutine 并发调用
+	apiClients      map[string]*openapi.Client // endpoint -> 通用 OpenAPI 客户端
 }
 
 // NewClient 创建阿里云客户端
@@ -39,6 +47,21 @@ func NewClient(accessKeyID, accessKeySecret, region string) (*Client, error) {
 	return client, nil
 }
 
+// observe 开始一次 API 调用的链路 span,返回的函数在调用结束时记录指标并结束 span
+func (c *Client) observe(ctx context.Context, api string) func(err error) {
+	start := time.Now()
+	_, span := tracing.StartWithKind(ctx, "aliyun."+api, tracing.SpanKindClient,
+		tracing.String("cloud.provider", "aliyun"),
+		tracing.String("cloud.account", c.Account),
+		tracing.String("cloud.region", c.Region))
+
+	return func(err error) {
+		metrics.ObserveCloudAPI("aliyun", c.Account, c.Region, api, err, start)
+		span.RecordError(err)
+		span.End()
+	}
+}
+
 // GetECSClient 获取 ECS 客户端
 func (c *Client) GetECSClient() (*ecs.Client, error) {
 	if c.ecsClient != nil {
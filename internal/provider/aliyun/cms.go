@@ -0,0 +1,148 @@
+package aliyun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eryajf/zenops/internal/model"
+)
+
+// 云监控按区域提供接入点,使用资源所在区域的客户端查询
+
+const (
+	cmsVersion = "2019-01-01"
+
+	cmsPageSize = 1440
+)
+
+// cmsMetric 统一指标对应的云监控指标,多个指标时按时间戳求和
+type cmsMetric struct {
+	names []string
+	unit  string
+}
+
+// cmsNamespaces 资源类型对应的云监控命名空间
+var cmsNamespaces = map[string]string{
+	model.MetricResourceInstance: "acs_ecs_dashboard",
+	model.MetricResourceDatabase: "acs_rds_dashboard",
+}
+
+// cmsMetrics 资源类型支持的指标,按展示顺序排列
+var cmsMetrics = map[string][]struct {
+	metric string
+	cmsMetric
+}{
+	model.MetricResourceInstance: {
+		{model.MetricCPU, cmsMetric{[]string{"CPUUtilization"}, model.MetricUnitPercent}},
+		{model.MetricMemory, cmsMetric{[]string{"memory_usedutilization"}, model.MetricUnitPercent}},
+		{model.MetricNetworkIn, cmsMetric{[]string{"InternetInRate", "IntranetInRate"}, model.MetricUnitBPS}},
+		{model.MetricNetworkOut, cmsMetric{[]string{"InternetOutRate", "IntranetOutRate"}, model.MetricUnitBPS}},
+	},
+	model.MetricResourceDatabase: {
+		{model.MetricCPU, cmsMetric{[]string{"CpuUsage"}, model.MetricUnitPercent}},
+		{model.MetricMemory, cmsMetric{[]string{"MemoryUsage"}, model.MetricUnitPercent}},
+		{model.MetricDisk, cmsMetric{[]string{"DiskUsage"}, model.MetricUnitPercent}},
+		{model.MetricNetworkIn, cmsMetric{[]string{"MySQL_NetworkInNew"}, model.MetricUnitBPS}},
+		{model.MetricNetworkOut, cmsMetric{[]string{"MySQL_NetworkOutNew"}, model.MetricUnitBPS}},
+	},
+}
+
+type cmsDescribeMetricListResponse struct {
+	Code       string `json:"Code"`
+	Message    string `json:"Message"`
+	Success    bool   `json:"Success"`
+	NextToken  string `json:"NextToken"`
+	Datapoints string `json:"Datapoints"` // JSON 数组字符串
+}
+
+type cmsDatapoint struct {
+	Timestamp int64   `json:"timestamp"` // 毫秒
+	Average   float64 `json:"Average"`
+}
+
+// GetMetrics 查询实例或数据库的云监控指标
+func (c *Client) GetMetrics(ctx context.Context, resourceType, resourceID string, metrics []string, start, end time.Time, period int) ([]*model.MetricSeries, error) {
+	supported, ok := cmsMetrics[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported resource type %q, must be instance or database", resourceType)
+	}
+	if len(metrics) == 0 {
+		for _, m := range supported {
+			metrics = append(metrics, m.metric)
+		}
+	}
+
+	series := make([]*model.MetricSeries, 0, len(metrics))
+	for _, name := range metrics {
+		var metric *cmsMetric
+		for i := range supported {
+			if supported[i].metric == name {
+				metric = &supported[i].cmsMetric
+				break
+			}
+		}
+		if metric == nil {
+			return nil, fmt.Errorf("metric %s is not supported for %s", name, resourceType)
+		}
+
+		groups := make([][]model.MetricPoint, 0, len(metric.names))
+		for _, cmsName := range metric.names {
+			points, err := c.describeMetricList(ctx, cmsNamespaces[resourceType], cmsName, resourceID, start, end, period)
+			if err != nil {
+				return nil, err
+			}
+			groups = append(groups, points)
+		}
+		series = append(series, &model.MetricSeries{
+			Metric: name,
+			Unit:   metric.unit,
+			Period: period,
+			Points: model.SumMetricPoints(groups...),
+		})
+	}
+	return series, nil
+}
+
+// describeMetricList 查询单个云监控指标的数据点
+func (c *Client) describeMetricList(ctx context.Context, namespace, metricName, instanceID string, start, end time.Time, period int) ([]model.MetricPoint, error) {
+	dimensions, _ := json.Marshal([]map[string]string{{"instanceId": instanceID}})
+	query := map[string]string{
+		"Namespace":  namespace,
+		"MetricName": metricName,
+		"Dimensions": string(dimensions),
+		"Period":     strconv.Itoa(period),
+		"StartTime":  strconv.FormatInt(start.UnixMilli(), 10),
+		"EndTime":    strconv.FormatInt(end.UnixMilli(), 10),
+		"Length":     strconv.Itoa(cmsPageSize),
+	}
+
+	points := make([]model.MetricPoint, 0)
+	for {
+		var resp cmsDescribeMetricListResponse
+		if err := c.callRPC(ctx, "metrics."+c.Region+".aliyuncs.com", cmsVersion, "DescribeMetricList", query, &resp); err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			return nil, fmt.Errorf("failed to call DescribeMetricList: %s %s", resp.Code, resp.Message)
+		}
+
+		if resp.Datapoints != "" {
+			var datapoints []cmsDatapoint
+			if err := json.Unmarshal([]byte(resp.Datapoints), &datapoints); err != nil {
+				return nil, fmt.Errorf("failed to decode %s datapoints: %w", metricName, err)
+			}
+			for _, d := range datapoints {
+				points = append(points, model.MetricPoint{Timestamp: time.UnixMilli(d.Timestamp), Value: d.Average})
+			}
+		}
+
+		if resp.NextToken == "" {
+			break
+		}
+		query["NextToken"] = resp.NextToken
+	}
+	return points, nil
+}
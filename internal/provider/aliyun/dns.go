@@ -0,0 +1,169 @@
+package aliyun
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eryajf/zenops/internal/model"
+)
+
+// 云解析 DNS (Alidns) 为全局服务,与区域无关
+
+const (
+	alidnsEndpoint = "alidns.aliyuncs.com"
+	alidnsVersion  = "2015-01-09"
+
+	alidnsDomainPageSize = 100
+	alidnsRecordPageSize = 500
+)
+
+type alidnsDomain struct {
+	DomainID        string `json:"DomainId"`
+	DomainName      string `json:"DomainName"`
+	RecordCount     int    `json:"RecordCount"`
+	CreateTimestamp int64  `json:"CreateTimestamp"` // 毫秒
+}
+
+type alidnsDescribeDomainsResponse struct {
+	TotalCount int `json:"TotalCount"`
+	Domains    struct {
+		Domain []alidnsDomain `json:"Domain"`
+	} `json:"Domains"`
+}
+
+type alidnsRecord struct {
+	RecordID   string `json:"RecordId"`
+	DomainName string `json:"DomainName"`
+	RR         string `json:"RR"`
+	Type       string `json:"Type"`
+	Value      string `json:"Value"`
+	TTL        int    `json:"TTL"`
+	Line       string `json:"Line"`
+	Status     string `json:"Status"`
+}
+
+type alidnsDescribeDomainRecordsResponse struct {
+	TotalCount    int `json:"TotalCount"`
+	DomainRecords struct {
+		Record []alidnsRecord `json:"Record"`
+	} `json:"DomainRecords"`
+}
+
+// ListDomains 查询云解析托管的域名,keyword 为空时返回全部
+func (c *Client) ListDomains(ctx context.Context, keyword string) ([]*model.Domain, error) {
+	query := map[string]string{"PageSize": strconv.Itoa(alidnsDomainPageSize)}
+	if keyword != "" {
+		query["KeyWord"] = keyword
+	}
+
+	var domains []*model.Domain
+	for page := 1; ; page++ {
+		query["PageNumber"] = strconv.Itoa(page)
+
+		var resp alidnsDescribeDomainsResponse
+		if err := c.callRPC(ctx, alidnsEndpoint, alidnsVersion, "DescribeDomains", query, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, d := range resp.Domains.Domain {
+			domain := &model.Domain{
+				ID:          d.DomainID,
+				Name:        d.DomainName,
+				Provider:    "aliyun",
+				Account:     c.Account,
+				RecordCount: d.RecordCount,
+				Status:      model.DNSRecordEnabled,
+			}
+			if d.CreateTimestamp > 0 {
+				domain.CreatedAt = time.UnixMilli(d.CreateTimestamp)
+			}
+			domains = append(domains, domain)
+		}
+
+		if len(resp.Domains.Domain) < alidnsDomainPageSize || len(domains) >= resp.TotalCount {
+			break
+		}
+	}
+	return domains, nil
+}
+
+// ListDNSRecords 查询域名的解析记录,支持过滤条件:
+//   - rr: 主机记录,精确匹配
+//   - type: 记录类型
+//   - value: 记录值,精确匹配
+func (c *Client) ListDNSRecords(ctx context.Context, domain string, filters map[string]string) ([]*model.DNSRecord, error) {
+	query := map[string]string{
+		"DomainName": domain,
+		"PageSize":   strconv.Itoa(alidnsRecordPageSize),
+	}
+	// 关键字为模糊匹配,结果在本地精确过滤
+	if rr := filters["rr"]; rr != "" {
+		query["RRKeyWord"] = rr
+	}
+	if recordType := filters["type"]; recordType != "" {
+		query["TypeKeyWord"] = strings.ToUpper(recordType)
+	}
+	if value := filters["value"]; value != "" {
+		query["ValueKeyWord"] = value
+	}
+
+	var records []*model.DNSRecord
+	for page, fetched := 1, 0; ; page++ {
+		query["PageNumber"] = strconv.Itoa(page)
+
+		var resp alidnsDescribeDomainRecordsResponse
+		if err := c.callRPC(ctx, alidnsEndpoint, alidnsVersion, "DescribeDomainRecords", query, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, r := range resp.DomainRecords.Record {
+			record := &model.DNSRecord{
+				ID:       r.RecordID,
+				Domain:   domain,
+				RR:       r.RR,
+				FQDN:     recordFQDN(r.RR, domain),
+				Type:     r.Type,
+				Value:    r.Value,
+				TTL:      r.TTL,
+				Line:     r.Line,
+				Status:   r.Status,
+				Provider: "aliyun",
+				Account:  c.Account,
+			}
+			if matchDNSRecord(record, filters) {
+				records = append(records, record)
+			}
+		}
+
+		fetched += len(resp.DomainRecords.Record)
+		if len(resp.DomainRecords.Record) < alidnsRecordPageSize || fetched >= resp.TotalCount {
+			break
+		}
+	}
+	return records, nil
+}
+
+// recordFQDN 拼接完整域名
+func recordFQDN(rr, domain string) string {
+	if rr == "" || rr == "@" {
+		return domain
+	}
+	return rr + "." + domain
+}
+
+// matchDNSRecord 按 rr、type、value 精确过滤解析记录
+func matchDNSRecord(record *model.DNSRecord, filters map[string]string) bool {
+	if rr := filters["rr"]; rr != "" && !strings.EqualFold(record.RR, rr) {
+		return false
+	}
+	if recordType := filters["type"]; recordType != "" && !strings.EqualFold(record.Type, recordType) {
+		return false
+	}
+	if value := filters["value"]; value != "" &&
+		!strings.EqualFold(strings.TrimSuffix(record.Value, "."), strings.TrimSuffix(value, ".")) {
+		return false
+	}
+	return true
+}
@@ -130,7 +130,9 @@ func (c *Client) QueryECSInstances(ctx context.Context, params *ECSQueryParams)
 	logx.Debug("Querying Aliyun ECS instances with enhanced params, region %s, page_size %d, page_num %d",
 		c.Region, params.PageSize, params.PageNum)
 
+	done := c.observe(ctx, "DescribeInstances")
 	response, err := ecsClient.DescribeInstances(request)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe instances: %w", err)
 	}
@@ -170,6 +172,23 @@ func (c *Client) ListECSInstances(ctx context.Context, pageSize, pageNum int, fi
 		params.InstanceChargeType = chargeType
 	}
 
+	// 按 IP 查询时依次匹配私网 IP、公网 IP、EIP
+	if ip := filters["ip"]; ip != "" {
+		for _, apply := range []func(*ECSQueryParams){
+			func(p *ECSQueryParams) { p.PrivateIPAddresses = []string{ip} },
+			func(p *ECSQueryParams) { p.PublicIPAddresses = []string{ip} },
+			func(p *ECSQueryParams) { p.EipAddresses = []string{ip} },
+		} {
+			query := *params
+			apply(&query)
+			instances, err := c.QueryECSInstances(ctx, &query)
+			if err != nil || len(instances) > 0 {
+				return instances, err
+			}
+		}
+		return []*model.Instance{}, nil
+	}
+
 	return c.QueryECSInstances(ctx, params)
 }
 
@@ -317,6 +336,19 @@ func convertECSToInstance(inst *ecs.DescribeInstancesResponseBodyInstancesInstan
 		}
 	}
 
+	// 解析 VPC 和安全组
+	if inst.VpcAttributes != nil {
+		instance.VpcID = tea.StringValue(inst.VpcAttributes.VpcId)
+		instance.SubnetID = tea.StringValue(inst.VpcAttributes.VSwitchId)
+	}
+	if inst.SecurityGroupIds != nil {
+		for _, sgID := range inst.SecurityGroupIds.SecurityGroupId {
+			if sgID != nil {
+				instance.SecurityGroupIDs = append(instance.SecurityGroupIDs, tea.StringValue(sgID))
+			}
+		}
+	}
+
 	// 解析公网 IP
 	if inst.PublicIpAddress != nil && inst.PublicIpAddress.IpAddress != nil {
 		instance.PublicIP = make([]string, 0, len(inst.PublicIpAddress.IpAddress))
@@ -330,6 +362,7 @@ func convertECSToInstance(inst *ecs.DescribeInstancesResponseBodyInstancesInstan
 	// 解析 EIP
 	if inst.EipAddress != nil && inst.EipAddress.IpAddress != nil {
 		instance.PublicIP = append(instance.PublicIP, tea.StringValue(inst.EipAddress.IpAddress))
+		instance.Metadata["eip_address"] = tea.StringValue(inst.EipAddress.IpAddress)
 	}
 
 	// 解析标签
@@ -0,0 +1,260 @@
+package aliyun
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+)
+
+// 公网 IP: 弹性公网 IP (EIP) 通过 VPC OpenAPI 查询,ECS 实例的固定公网 IP 从实例列表中提取
+
+const (
+	vpcVersion = "2016-04-28"
+
+	eipPageSize = 100
+)
+
+func (c *Client) vpcEndpoint() string {
+	return fmt.Sprintf("vpc.%s.aliyuncs.com", c.Region)
+}
+
+type vpcEipAddress struct {
+	AllocationID       string `json:"AllocationId"`
+	IPAddress          string `json:"IpAddress"`
+	Name               string `json:"Name"`
+	Status             string `json:"Status"` // Associating, Unassociating, InUse, Available, Releasing
+	InstanceID         string `json:"InstanceId"`
+	InstanceType       string `json:"InstanceType"` // EcsInstance, SlbInstance, Nat, HaVip, NetworkInterface
+	Bandwidth          string `json:"Bandwidth"`
+	InternetChargeType string `json:"InternetChargeType"` // PayByBandwidth, PayByTraffic
+	ChargeType         string `json:"ChargeType"`         // PostPaid, PrePaid
+	ISP                string `json:"ISP"`
+	AllocationTime     string `json:"AllocationTime"`
+	ExpiredTime        string `json:"ExpiredTime"`
+	BusinessStatus     string `json:"BusinessStatus"` // Normal, FinancialLocked
+	OperationLocks     struct {
+		LockReason []struct {
+			LockReason string `json:"LockReason"`
+		} `json:"LockReason"`
+	} `json:"OperationLocks"`
+	Tags struct {
+		Tag []struct {
+			Key   string `json:"Key"`
+			Value string `json:"Value"`
+		} `json:"Tag"`
+	} `json:"Tags"`
+}
+
+type vpcDescribeEipAddressesResponse struct {
+	TotalCount   int `json:"TotalCount"`
+	EipAddresses struct {
+		EipAddress []vpcEipAddress `json:"EipAddress"`
+	} `json:"EipAddresses"`
+}
+
+// eipInstanceTypes 阿里云绑定资源类型到统一取值的映射
+var eipInstanceTypes = map[string]string{
+	"EcsInstance":      "ecs",
+	"SlbInstance":      "slb",
+	"Nat":              "nat",
+	"HaVip":            "havip",
+	"NetworkInterface": "eni",
+}
+
+// ListEIPs 列出当前区域的公网 IP,支持过滤条件:
+//   - address: IP 地址,精确匹配
+//   - status: in_use 或 available
+//   - instance_id: 绑定的实例 ID
+//   - type: eip 或 public_ip,为空时查询全部
+func (c *Client) ListEIPs(ctx context.Context, filters map[string]string) ([]*model.EIP, error) {
+	eipType := strings.ToLower(filters["type"])
+
+	result := make([]*model.EIP, 0)
+	if eipType == "" || eipType == model.EIPTypeEIP {
+		eips, err := c.listEipAddresses(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, eips...)
+	}
+
+	// 固定公网 IP 始终绑定在实例上
+	if (eipType == "" || eipType == model.EIPTypePublicIP) && filters["status"] != model.EIPStatusAvailable {
+		ips, err := c.listECSPublicIPs(ctx, filters)
+		if err != nil {
+			if eipType == model.EIPTypePublicIP {
+				return nil, err
+			}
+			logx.Warn("Failed to list Aliyun ECS public IPs, region %s, error %v", c.Region, err)
+		}
+		result = append(result, ips...)
+	}
+
+	logx.Debug("Listed Aliyun public IPs, count %d, region %s", len(result), c.Region)
+	return result, nil
+}
+
+// listEipAddresses 查询弹性公网 IP 的全部分页
+func (c *Client) listEipAddresses(ctx context.Context, filters map[string]string) ([]*model.EIP, error) {
+	query := map[string]string{
+		"RegionId": c.Region,
+		"PageSize": strconv.Itoa(eipPageSize),
+	}
+	if address := filters["address"]; address != "" {
+		query["EipAddress"] = address
+	}
+	if instanceID := filters["instance_id"]; instanceID != "" {
+		query["AssociatedInstanceId"] = instanceID
+	}
+	switch filters["status"] {
+	case model.EIPStatusInUse:
+		query["Status"] = "InUse"
+	case model.EIPStatusAvailable:
+		query["Status"] = "Available"
+	}
+
+	result := make([]*model.EIP, 0)
+	for page := 1; ; page++ {
+		query["PageNumber"] = strconv.Itoa(page)
+
+		var resp vpcDescribeEipAddressesResponse
+		if err := c.callRPC(ctx, c.vpcEndpoint(), vpcVersion, "DescribeEipAddresses", query, &resp); err != nil {
+			return nil, err
+		}
+
+		for i := range resp.EipAddresses.EipAddress {
+			result = append(result, c.convertEip(&resp.EipAddresses.EipAddress[i]))
+		}
+
+		if len(resp.EipAddresses.EipAddress) < eipPageSize || len(result) >= resp.TotalCount {
+			break
+		}
+	}
+	return result, nil
+}
+
+// listECSPublicIPs 从 ECS 实例中提取固定公网 IP (不含已绑定的 EIP)
+func (c *Client) listECSPublicIPs(ctx context.Context, filters map[string]string) ([]*model.EIP, error) {
+	address := filters["address"]
+	params := &ECSQueryParams{PageSize: eipPageSize}
+	if address != "" {
+		params.PublicIPAddresses = []string{address}
+	}
+	if instanceID := filters["instance_id"]; instanceID != "" {
+		params.InstanceIDs = []string{instanceID}
+	}
+
+	result := make([]*model.EIP, 0)
+	for page := 1; ; page++ {
+		params.PageNum = page
+		instances, err := c.QueryECSInstances(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, inst := range instances {
+			eipAddress, _ := inst.Metadata["eip_address"].(string)
+			for _, ip := range inst.PublicIP {
+				if ip == "" || ip == eipAddress || (address != "" && ip != address) {
+					continue
+				}
+				result = append(result, convertECSPublicIP(inst, ip, c.Account))
+			}
+		}
+
+		if len(instances) < eipPageSize {
+			break
+		}
+	}
+	return result, nil
+}
+
+// convertEip 将 DescribeEipAddresses 返回的 EIP 转换为统一模型
+func (c *Client) convertEip(e *vpcEipAddress) *model.EIP {
+	eip := &model.EIP{
+		ID:                 e.AllocationID,
+		Address:            e.IPAddress,
+		Name:               e.Name,
+		Provider:           "aliyun",
+		Account:            c.Account,
+		Region:             c.Region,
+		Type:               model.EIPTypeEIP,
+		InternetChargeType: e.InternetChargeType,
+		ChargeType:         e.ChargeType,
+		ISP:                e.ISP,
+		InstanceID:         e.InstanceID,
+		InstanceType:       eipInstanceTypes[e.InstanceType],
+		Locked:             e.BusinessStatus == "FinancialLocked" || len(e.OperationLocks.LockReason) > 0,
+		Tags:               make(map[string]string),
+		ConsoleURL: fmt.Sprintf("https://vpc.console.aliyun.com/eip/%s/eips/%s",
+			c.Region, e.AllocationID),
+	}
+	if eip.InstanceType == "" {
+		eip.InstanceType = strings.ToLower(e.InstanceType)
+	}
+
+	switch e.Status {
+	case "InUse":
+		eip.Status = model.EIPStatusInUse
+	case "Available":
+		eip.Status = model.EIPStatusAvailable
+	default:
+		eip.Status = strings.ToLower(e.Status)
+	}
+
+	if bandwidth, err := strconv.Atoi(e.Bandwidth); err == nil {
+		eip.Bandwidth = bandwidth
+	}
+	if t, ok := parseEipTime(e.AllocationTime); ok {
+		eip.CreatedAt = t
+	}
+	if t, ok := parseEipTime(e.ExpiredTime); ok {
+		eip.ExpiredAt = &t
+	}
+
+	for _, tag := range e.Tags.Tag {
+		eip.Tags[tag.Key] = tag.Value
+	}
+	return eip
+}
+
+// convertECSPublicIP 将 ECS 实例的固定公网 IP 转换为统一模型,带宽与计费方式随实例
+func convertECSPublicIP(inst *model.Instance, ip, account string) *model.EIP {
+	eip := &model.EIP{
+		ID:           ip,
+		Address:      ip,
+		Name:         inst.Name,
+		Provider:     "aliyun",
+		Account:      account,
+		Region:       inst.Region,
+		Type:         model.EIPTypePublicIP,
+		Status:       model.EIPStatusInUse,
+		InstanceID:   inst.ID,
+		InstanceType: "ecs",
+		CreatedAt:    inst.CreatedAt,
+		ExpiredAt:    inst.ExpiredAt,
+		Tags:         inst.Tags,
+		ConsoleURL:   inst.ConsoleURL,
+	}
+	if bandwidth, ok := inst.Metadata["internet_max_bandwidth_out"].(int32); ok {
+		eip.Bandwidth = int(bandwidth)
+	}
+	eip.InternetChargeType, _ = inst.Metadata["internet_charge_type"].(string)
+	eip.ChargeType, _ = inst.Metadata["instance_charge_type"].(string)
+	return eip
+}
+
+// parseEipTime 解析 EIP 时间,分配时间精确到秒,到期时间精确到分钟
+func parseEipTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,185 @@
+package aliyun
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+)
+
+// 云数据库 Redis / Tair (KVStore),实例数量通常不多,列表接口一次查询全部分页后在本地过滤
+
+const (
+	kvstoreEndpoint = "r-kvstore.aliyuncs.com"
+	kvstoreVersion  = "2015-01-01"
+
+	kvstorePageSize = 50
+)
+
+type kvstoreInstance struct {
+	InstanceID       string `json:"InstanceId"`
+	InstanceName     string `json:"InstanceName"`
+	InstanceType     string `json:"InstanceType"` // Redis, Memcache
+	EditionType      string `json:"EditionType"`  // Community: 社区版, Enterprise: Tair
+	EngineVersion    string `json:"EngineVersion"`
+	InstanceStatus   string `json:"InstanceStatus"`
+	ConnectionDomain string `json:"ConnectionDomain"`
+	PrivateIP        string `json:"PrivateIp"`
+	Port             int    `json:"Port"`
+	CreateTime       string `json:"CreateTime"`
+	Tags             struct {
+		Tag []struct {
+			Key   string `json:"Key"`
+			Value string `json:"Value"`
+		} `json:"Tag"`
+	} `json:"Tags"`
+}
+
+type kvstoreDescribeInstancesResponse struct {
+	TotalCount int `json:"TotalCount"`
+	Instances  struct {
+		KVStoreInstance []kvstoreInstance `json:"KVStoreInstance"`
+	} `json:"Instances"`
+}
+
+// ListRedisInstances 查询 Redis/Tair 实例列表,支持过滤条件:
+//   - name: 名称,模糊匹配
+//   - address: 连接地址,支持域名、私网 IP 及 host:port
+//
+// pageSize > 0 时按 pageNum 返回对应分页
+func (c *Client) ListRedisInstances(ctx context.Context, pageSize, pageNum int, filters map[string]string) ([]*model.Database, error) {
+	instances, err := c.describeRedisInstances(ctx, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(filters["name"])
+	address := filters["address"]
+
+	databases := make([]*model.Database, 0, len(instances))
+	for i := range instances {
+		inst := &instances[i]
+		if inst.InstanceType == "Memcache" {
+			continue
+		}
+		if name != "" && !strings.Contains(strings.ToLower(inst.InstanceName), name) {
+			continue
+		}
+		if address != "" && !inst.matchAddress(address) {
+			continue
+		}
+		databases = append(databases, convertKVStoreToDatabase(inst, c.Region))
+	}
+
+	logx.Debug("Listed Aliyun Redis instances, count %d, region %s", len(databases), c.Region)
+	return pageDatabases(databases, pageSize, pageNum), nil
+}
+
+// GetRedisInstance 获取 Redis/Tair 实例详情
+func (c *Client) GetRedisInstance(ctx context.Context, instanceID string) (*model.Database, error) {
+	instances, err := c.describeRedisInstances(ctx, map[string]string{"InstanceIds": instanceID})
+	if err != nil {
+		return nil, err
+	}
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("redis instance %s not found", instanceID)
+	}
+	return convertKVStoreToDatabase(&instances[0], c.Region), nil
+}
+
+// describeRedisInstances 调用 DescribeInstances 并查询全部分页
+func (c *Client) describeRedisInstances(ctx context.Context, query map[string]string) ([]kvstoreInstance, error) {
+	query["RegionId"] = c.Region
+	query["PageSize"] = strconv.Itoa(kvstorePageSize)
+
+	var result []kvstoreInstance
+	for page := 1; ; page++ {
+		query["PageNumber"] = strconv.Itoa(page)
+
+		var resp kvstoreDescribeInstancesResponse
+		if err := c.callRPC(ctx, kvstoreEndpoint, kvstoreVersion, "DescribeInstances", query, &resp); err != nil {
+			return nil, err
+		}
+
+		result = append(result, resp.Instances.KVStoreInstance...)
+		if len(resp.Instances.KVStoreInstance) < kvstorePageSize || len(result) >= resp.TotalCount {
+			break
+		}
+	}
+	return result, nil
+}
+
+// matchAddress 连接地址是否属于该实例
+// 公网地址、读写分离地址等均以实例 ID 开头,例如 r-bp1xxx-pd.redis.rds.aliyuncs.com
+func (inst *kvstoreInstance) matchAddress(address string) bool {
+	host := strings.ToLower(address)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	id := strings.ToLower(inst.InstanceID)
+	return host == strings.ToLower(inst.ConnectionDomain) ||
+		host == inst.PrivateIP ||
+		host == id ||
+		strings.HasPrefix(host, id+".") ||
+		strings.HasPrefix(host, id+"-")
+}
+
+// convertKVStoreToDatabase 将 Redis/Tair 实例转换为统一的数据库模型
+func convertKVStoreToDatabase(inst *kvstoreInstance, region string) *model.Database {
+	database := &model.Database{
+		ID:            inst.InstanceID,
+		Name:          inst.InstanceName,
+		Provider:      "aliyun",
+		Region:        region,
+		Engine:        "redis",
+		EngineVersion: inst.EngineVersion,
+		Status:        inst.InstanceStatus,
+		Endpoint:      inst.ConnectionDomain,
+		Port:          inst.Port,
+		Tags:          make(map[string]string),
+	}
+
+	if inst.EditionType == "Enterprise" {
+		database.EngineVersion = "Tair " + inst.EngineVersion
+	}
+	if database.Port == 0 {
+		database.Port = 6379
+	}
+	if t, err := time.Parse(time.RFC3339, inst.CreateTime); err == nil {
+		database.CreatedAt = t
+	}
+	for _, tag := range inst.Tags.Tag {
+		database.Tags[tag.Key] = tag.Value
+	}
+
+	// 如果名称为空,使用 ID 作为名称
+	if database.Name == "" {
+		database.Name = database.ID
+	}
+
+	database.ConsoleURL = fmt.Sprintf("https://kvstore.console.aliyun.com/Redis/instance/%s/%s/info",
+		region, database.ID)
+
+	return database
+}
+
+// pageDatabases 按分页参数截取结果,pageSize <= 0 时返回全部
+func pageDatabases(databases []*model.Database, pageSize, pageNum int) []*model.Database {
+	if pageSize <= 0 {
+		return databases
+	}
+	if pageNum <= 0 {
+		pageNum = 1
+	}
+	start := (pageNum - 1) * pageSize
+	if start >= len(databases) {
+		return []*model.Database{}
+	}
+	end := min(start+pageSize, len(databases))
+	return databases[start:end]
+}
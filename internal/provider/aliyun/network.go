@@ -0,0 +1,408 @@
+package aliyun
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	ecs "github.com/alibabacloud-go/ecs-20140526/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+	"github.com/eryajf/zenops/internal/model"
+)
+
+// 专有网络: VPC、交换机 (vSwitch)、安全组及其规则
+// 这几类资源在 ECS OpenAPI 中均有查询接口,复用 ECS 客户端
+
+const networkPageSize = 50
+
+// ListVPCs 列出当前区域的 VPC
+func (c *Client) ListVPCs(ctx context.Context) ([]*model.VPC, error) {
+	ecsClient, err := c.GetECSClient()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.VPC, 0)
+	for page := int32(1); ; page++ {
+		request := &ecs.DescribeVpcsRequest{
+			RegionId:   tea.String(c.Region),
+			PageNumber: tea.Int32(page),
+			PageSize:   tea.Int32(networkPageSize),
+		}
+
+		done := c.observe(ctx, "DescribeVpcs")
+		response, err := ecsClient.DescribeVpcs(request)
+		done(err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe vpcs: %w", err)
+		}
+		if response.Body == nil || response.Body.Vpcs == nil {
+			break
+		}
+
+		for _, vpc := range response.Body.Vpcs.Vpc {
+			result = append(result, c.convertVPC(vpc))
+		}
+		if len(response.Body.Vpcs.Vpc) < networkPageSize ||
+			len(result) >= int(tea.Int32Value(response.Body.TotalCount)) {
+			break
+		}
+	}
+
+	logx.Debug("Listed Aliyun VPCs, count %d, region %s", len(result), c.Region)
+	return result, nil
+}
+
+// ListVSwitches 列出当前区域的交换机,vpcID 为空时查询全部
+func (c *Client) ListVSwitches(ctx context.Context, vpcID string) ([]*model.Subnet, error) {
+	ecsClient, err := c.GetECSClient()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Subnet, 0)
+	for page := int32(1); ; page++ {
+		request := &ecs.DescribeVSwitchesRequest{
+			RegionId:   tea.String(c.Region),
+			PageNumber: tea.Int32(page),
+			PageSize:   tea.Int32(networkPageSize),
+		}
+		if vpcID != "" {
+			request.VpcId = tea.String(vpcID)
+		}
+
+		done := c.observe(ctx, "DescribeVSwitches")
+		response, err := ecsClient.DescribeVSwitches(request)
+		done(err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe vswitches: %w", err)
+		}
+		if response.Body == nil || response.Body.VSwitches == nil {
+			break
+		}
+
+		for _, vsw := range response.Body.VSwitches.VSwitch {
+			result = append(result, c.convertVSwitch(vsw))
+		}
+		if len(response.Body.VSwitches.VSwitch) < networkPageSize ||
+			len(result) >= int(tea.Int32Value(response.Body.TotalCount)) {
+			break
+		}
+	}
+
+	logx.Debug("Listed Aliyun vSwitches, count %d, region %s", len(result), c.Region)
+	return result, nil
+}
+
+// ListSecurityGroups 列出当前区域的安全组 (不含规则),vpcID 为空时查询全部
+func (c *Client) ListSecurityGroups(ctx context.Context, vpcID string) ([]*model.SecurityGroup, error) {
+	request := &ecs.DescribeSecurityGroupsRequest{}
+	if vpcID != "" {
+		request.VpcId = tea.String(vpcID)
+	}
+	return c.describeSecurityGroups(ctx, request)
+}
+
+// GetSecurityGroup 获取安全组详情,包含入方向和出方向规则
+func (c *Client) GetSecurityGroup(ctx context.Context, sgID string) (*model.SecurityGroup, error) {
+	groups, err := c.describeSecurityGroups(ctx, &ecs.DescribeSecurityGroupsRequest{
+		SecurityGroupId: tea.String(sgID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("security group %s not found in region %s", sgID, c.Region)
+	}
+
+	sg := groups[0]
+	sg.Rules, err = c.describeSecurityGroupRules(ctx, sgID)
+	if err != nil {
+		return nil, err
+	}
+	return sg, nil
+}
+
+// GetInstanceNetwork 获取 ECS 实例的 VPC、交换机、安全组及生效的规则
+func (c *Client) GetInstanceNetwork(ctx context.Context, instanceID string) (*model.InstanceNetwork, error) {
+	instance, err := c.GetECSInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	network := &model.InstanceNetwork{
+		Instance:       instance,
+		SecurityGroups: make([]*model.SecurityGroup, 0, len(instance.SecurityGroupIDs)),
+		DefaultIngress: model.PolicyDrop,
+		DefaultEgress:  model.PolicyAccept,
+	}
+
+	if instance.VpcID != "" {
+		network.VPC, err = c.getVPC(ctx, instance.VpcID)
+		if err != nil {
+			logx.Warn("Failed to get Aliyun VPC, vpc_id %s, error %v", instance.VpcID, err)
+		}
+	}
+	if instance.SubnetID != "" {
+		network.Subnet, err = c.getVSwitch(ctx, instance.SubnetID)
+		if err != nil {
+			logx.Warn("Failed to get Aliyun vSwitch, vswitch_id %s, error %v", instance.SubnetID, err)
+		}
+	}
+
+	for _, sgID := range instance.SecurityGroupIDs {
+		sg, err := c.GetSecurityGroup(ctx, sgID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get security group %s: %w", sgID, err)
+		}
+		// 企业级安全组未命中规则时出方向默认拒绝
+		if sg.Type == "enterprise" {
+			network.DefaultEgress = model.PolicyDrop
+		}
+		network.SecurityGroups = append(network.SecurityGroups, sg)
+	}
+
+	network.CollectRules()
+	return network, nil
+}
+
+// getVPC 按 ID 获取 VPC
+func (c *Client) getVPC(ctx context.Context, vpcID string) (*model.VPC, error) {
+	ecsClient, err := c.GetECSClient()
+	if err != nil {
+		return nil, err
+	}
+
+	done := c.observe(ctx, "DescribeVpcs")
+	response, err := ecsClient.DescribeVpcs(&ecs.DescribeVpcsRequest{
+		RegionId: tea.String(c.Region),
+		VpcId:    tea.String(vpcID),
+	})
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe vpcs: %w", err)
+	}
+	if response.Body == nil || response.Body.Vpcs == nil || len(response.Body.Vpcs.Vpc) == 0 {
+		return nil, fmt.Errorf("vpc %s not found in region %s", vpcID, c.Region)
+	}
+	return c.convertVPC(response.Body.Vpcs.Vpc[0]), nil
+}
+
+// getVSwitch 按 ID 获取交换机
+func (c *Client) getVSwitch(ctx context.Context, vswitchID string) (*model.Subnet, error) {
+	ecsClient, err := c.GetECSClient()
+	if err != nil {
+		return nil, err
+	}
+
+	done := c.observe(ctx, "DescribeVSwitches")
+	response, err := ecsClient.DescribeVSwitches(&ecs.DescribeVSwitchesRequest{
+		RegionId:  tea.String(c.Region),
+		VSwitchId: tea.String(vswitchID),
+	})
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe vswitches: %w", err)
+	}
+	if response.Body == nil || response.Body.VSwitches == nil || len(response.Body.VSwitches.VSwitch) == 0 {
+		return nil, fmt.Errorf("vswitch %s not found in region %s", vswitchID, c.Region)
+	}
+	return c.convertVSwitch(response.Body.VSwitches.VSwitch[0]), nil
+}
+
+// describeSecurityGroups 按 NextToken 分页查询安全组
+func (c *Client) describeSecurityGroups(ctx context.Context, request *ecs.DescribeSecurityGroupsRequest) ([]*model.SecurityGroup, error) {
+	ecsClient, err := c.GetECSClient()
+	if err != nil {
+		return nil, err
+	}
+
+	request.RegionId = tea.String(c.Region)
+	request.MaxResults = tea.Int32(networkPageSize)
+
+	result := make([]*model.SecurityGroup, 0)
+	for {
+		done := c.observe(ctx, "DescribeSecurityGroups")
+		response, err := ecsClient.DescribeSecurityGroups(request)
+		done(err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe security groups: %w", err)
+		}
+		if response.Body == nil || response.Body.SecurityGroups == nil {
+			break
+		}
+
+		for _, sg := range response.Body.SecurityGroups.SecurityGroup {
+			result = append(result, c.convertSecurityGroup(sg))
+		}
+
+		nextToken := tea.StringValue(response.Body.NextToken)
+		if nextToken == "" {
+			break
+		}
+		request.NextToken = tea.String(nextToken)
+	}
+
+	return result, nil
+}
+
+// describeSecurityGroupRules 查询安全组的全部规则
+func (c *Client) describeSecurityGroupRules(ctx context.Context, sgID string) ([]*model.SecurityGroupRule, error) {
+	ecsClient, err := c.GetECSClient()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &ecs.DescribeSecurityGroupAttributeRequest{
+		RegionId:        tea.String(c.Region),
+		SecurityGroupId: tea.String(sgID),
+		Direction:       tea.String("all"),
+		MaxResults:      tea.Int32(1000),
+	}
+
+	rules := make([]*model.SecurityGroupRule, 0)
+	for {
+		done := c.observe(ctx, "DescribeSecurityGroupAttribute")
+		response, err := ecsClient.DescribeSecurityGroupAttribute(request)
+		done(err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe security group attribute: %w", err)
+		}
+		if response.Body == nil || response.Body.Permissions == nil {
+			break
+		}
+
+		for _, perm := range response.Body.Permissions.Permission {
+			rules = append(rules, convertPermission(sgID, perm))
+		}
+
+		nextToken := tea.StringValue(response.Body.NextToken)
+		if nextToken == "" {
+			break
+		}
+		request.NextToken = tea.String(nextToken)
+	}
+
+	model.SortRules(rules)
+	return rules, nil
+}
+
+// convertVPC 将阿里云 VPC 转换为统一模型
+func (c *Client) convertVPC(vpc *ecs.DescribeVpcsResponseBodyVpcsVpc) *model.VPC {
+	result := &model.VPC{
+		ID:          tea.StringValue(vpc.VpcId),
+		Name:        tea.StringValue(vpc.VpcName),
+		Provider:    "aliyun",
+		Region:      c.Region,
+		CIDR:        tea.StringValue(vpc.CidrBlock),
+		Status:      tea.StringValue(vpc.Status),
+		IsDefault:   tea.BoolValue(vpc.IsDefault),
+		Description: tea.StringValue(vpc.Description),
+		Tags:        make(map[string]string),
+	}
+	if t, err := time.Parse(time.RFC3339, tea.StringValue(vpc.CreationTime)); err == nil {
+		result.CreatedAt = t
+	}
+	result.ConsoleURL = fmt.Sprintf("https://vpc.console.aliyun.com/vpc/%s/vpcs/%s", c.Region, result.ID)
+	return result
+}
+
+// convertVSwitch 将阿里云交换机转换为统一的子网模型
+func (c *Client) convertVSwitch(vsw *ecs.DescribeVSwitchesResponseBodyVSwitchesVSwitch) *model.Subnet {
+	result := &model.Subnet{
+		ID:           tea.StringValue(vsw.VSwitchId),
+		Name:         tea.StringValue(vsw.VSwitchName),
+		Provider:     "aliyun",
+		Region:       c.Region,
+		Zone:         tea.StringValue(vsw.ZoneId),
+		VpcID:        tea.StringValue(vsw.VpcId),
+		CIDR:         tea.StringValue(vsw.CidrBlock),
+		AvailableIPs: int(tea.Int64Value(vsw.AvailableIpAddressCount)),
+		Status:       tea.StringValue(vsw.Status),
+		IsDefault:    tea.BoolValue(vsw.IsDefault),
+		Description:  tea.StringValue(vsw.Description),
+		Tags:         make(map[string]string),
+	}
+	if t, err := time.Parse(time.RFC3339, tea.StringValue(vsw.CreationTime)); err == nil {
+		result.CreatedAt = t
+	}
+	result.ConsoleURL = fmt.Sprintf("https://vpc.console.aliyun.com/vpc/%s/switches/%s", c.Region, result.ID)
+	return result
+}
+
+// convertSecurityGroup 将阿里云安全组转换为统一模型
+func (c *Client) convertSecurityGroup(sg *ecs.DescribeSecurityGroupsResponseBodySecurityGroupsSecurityGroup) *model.SecurityGroup {
+	result := &model.SecurityGroup{
+		ID:          tea.StringValue(sg.SecurityGroupId),
+		Name:        tea.StringValue(sg.SecurityGroupName),
+		Provider:    "aliyun",
+		Region:      c.Region,
+		VpcID:       tea.StringValue(sg.VpcId),
+		Type:        tea.StringValue(sg.SecurityGroupType),
+		Description: tea.StringValue(sg.Description),
+		Tags:        make(map[string]string),
+	}
+	if t, err := time.Parse(time.RFC3339, tea.StringValue(sg.CreationTime)); err == nil {
+		result.CreatedAt = t
+	}
+	if sg.Tags != nil {
+		for _, tag := range sg.Tags.Tag {
+			if tag != nil {
+				result.Tags[tea.StringValue(tag.TagKey)] = tea.StringValue(tag.TagValue)
+			}
+		}
+	}
+	result.ConsoleURL = fmt.Sprintf("https://ecs.console.aliyun.com/securityGroupDetail/region/%s/groupId/%s/detail",
+		c.Region, result.ID)
+	return result
+}
+
+// convertPermission 将阿里云安全组规则转换为统一模型
+func convertPermission(sgID string, perm *ecs.DescribeSecurityGroupAttributeResponseBodyPermissionsPermission) *model.SecurityGroupRule {
+	rule := &model.SecurityGroupRule{
+		SecurityGroupID: sgID,
+		Direction:       strings.ToLower(tea.StringValue(perm.Direction)),
+		Policy:          strings.ToLower(tea.StringValue(perm.Policy)),
+		Protocol:        strings.ToLower(tea.StringValue(perm.IpProtocol)),
+		PortRange:       aliyunPortRange(tea.StringValue(perm.PortRange)),
+		Description:     tea.StringValue(perm.Description),
+	}
+	rule.Priority, _ = strconv.Atoi(tea.StringValue(perm.Priority))
+
+	if rule.Direction == model.DirectionEgress {
+		rule.CIDR = firstNonEmpty(tea.StringValue(perm.DestCidrIp), tea.StringValue(perm.Ipv6DestCidrIp))
+		rule.PeerGroupID = firstNonEmpty(tea.StringValue(perm.DestGroupId), tea.StringValue(perm.DestPrefixListId))
+	} else {
+		rule.CIDR = firstNonEmpty(tea.StringValue(perm.SourceCidrIp), tea.StringValue(perm.Ipv6SourceCidrIp))
+		rule.PeerGroupID = firstNonEmpty(tea.StringValue(perm.SourceGroupId), tea.StringValue(perm.SourcePrefixListId))
+	}
+	return rule
+}
+
+// aliyunPortRange 将阿里云端口范围 (如 80/80、1/65535、-1/-1) 转换为统一格式
+func aliyunPortRange(portRange string) string {
+	from, to, found := strings.Cut(portRange, "/")
+	if !found || from == "-1" {
+		return "all"
+	}
+	if from == to {
+		return from
+	}
+	if from == "1" && to == "65535" {
+		return "all"
+	}
+	return from + "-" + to
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
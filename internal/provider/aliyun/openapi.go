@@ -0,0 +1,81 @@
+package aliyun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// 部分产品 (SLB、ALB 等) 未引入独立 SDK,通过通用 OpenAPI 调用 RPC 风格接口
+
+// getAPIClient 获取指定 endpoint 的通用 OpenAPI 客户端,可并发调用
+func (c *Client) getAPIClient(endpoint string) (*openapi.Client, error) {
+	c.apiClientsMu.Lock()
+	defer c.apiClientsMu.Unlock()
+
+	if client, ok := c.apiClients[endpoint]; ok {
+		return client, nil
+	}
+
+	config := &openapi.Config{
+		AccessKeyId:     tea.String(c.AccessKeyID),
+		AccessKeySecret: tea.String(c.AccessKeySecret),
+		Endpoint:        tea.String(endpoint),
+	}
+
+	client, err := openapi.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OpenAPI client for %s: %w", endpoint, err)
+	}
+
+	if c.apiClients == nil {
+		c.apiClients = make(map[string]*openapi.Client)
+	}
+	c.apiClients[endpoint] = client
+	return client, nil
+}
+
+// callRPC 调用 RPC 风格接口,并将响应 Body 解析到 result
+func (c *Client) callRPC(ctx context.Context, endpoint, version, action string, query map[string]string, result any) error {
+	client, err := c.getAPIClient(endpoint)
+	if err != nil {
+		return err
+	}
+
+	params := &openapi.Params{
+		Action:      tea.String(action),
+		Version:     tea.String(version),
+		Protocol:    tea.String("HTTPS"),
+		Pathname:    tea.String("/"),
+		Method:      tea.String("POST"),
+		AuthType:    tea.String("AK"),
+		Style:       tea.String("RPC"),
+		ReqBodyType: tea.String("formData"),
+		BodyType:    tea.String("json"),
+	}
+
+	request := &openapi.OpenApiRequest{Query: make(map[string]*string, len(query))}
+	for k, v := range query {
+		request.Query[k] = tea.String(v)
+	}
+
+	done := c.observe(ctx, action)
+	response, err := client.CallApi(params, request, &dara.RuntimeOptions{})
+	done(err)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", action, err)
+	}
+
+	body, err := json.Marshal(response["body"])
+	if err != nil {
+		return fmt.Errorf("failed to encode %s response: %w", action, err)
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", action, err)
+	}
+	return nil
+}
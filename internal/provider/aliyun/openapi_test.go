@@ -0,0 +1,36 @@
+package aliyun
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestGetAPIClientConcurrent(t *testing.T) {
+	c, err := NewClient("ak", "sk", "cn-hangzhou")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			endpoint := fmt.Sprintf("service%d.cn-hangzhou.aliyuncs.com", i%4)
+			if _, err := c.getAPIClient(endpoint); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(c.apiClients) != 4 {
+		t.Fatalf("expected 4 cached clients, got %d", len(c.apiClients))
+	}
+	first, _ := c.getAPIClient("service0.cn-hangzhou.aliyuncs.com")
+	second, _ := c.getAPIClient("service0.cn-hangzhou.aliyuncs.com")
+	if first != second {
+		t.Fatal("expected the cached client to be reused")
+	}
+}
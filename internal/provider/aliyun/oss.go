@@ -31,7 +31,9 @@ func (c *Client) ListOSSBuckets(ctx context.Context, pageSize, pageNum int, filt
 				tempOptions = append(tempOptions, oss.Marker(markerValue))
 			}
 
+			done := c.observe(ctx, "ListBuckets")
 			response, err := ossClient.ListBuckets(tempOptions...)
+			done(err)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list buckets: %w", err)
 			}
@@ -69,7 +71,9 @@ func (c *Client) ListOSSBuckets(ctx context.Context, pageSize, pageNum int, filt
 	logx.Debug("Querying Aliyun OSS buckets, page_size %d, page_num %d, marker %s",
 		pageSize, pageNum, markerValue)
 
+	done := c.observe(ctx, "ListBuckets")
 	response, err := ossClient.ListBuckets(options...)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list buckets: %w", err)
 	}
@@ -97,7 +101,9 @@ func (c *Client) GetOSSBucket(ctx context.Context, bucketName string) (*model.OS
 	logx.Debug("Querying Aliyun OSS bucket info, bucket_name %s", bucketName)
 
 	// 获取 bucket 信息
+	done := c.observe(ctx, "GetBucketInfo")
 	result, err := ossClient.GetBucketInfo(bucketName)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get bucket info: %w", err)
 	}
@@ -3,6 +3,8 @@ package aliyun
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
 	"github.com/eryajf/zenops/internal/model"
@@ -41,6 +43,9 @@ func (p *AliyunProvider) Initialize(config map[string]any) error {
 		return fmt.Errorf("access_key_secret is required")
 	}
 
+	// 账号名称(可选)
+	account, _ := config["account"].(string)
+
 	// 获取区域列表
 	regions, ok := config["regions"].([]any)
 	if !ok || len(regions) == 0 {
@@ -59,6 +64,7 @@ func (p *AliyunProvider) Initialize(config map[string]any) error {
 			logx.Warn("%s", "Failed to create client for region "+region+": "+err.Error())
 			continue
 		}
+		client.Account = account
 
 		p.clients[region] = client
 		logx.Info("%s", "Initialized Aliyun client for region "+region)
@@ -117,41 +123,32 @@ func (p *AliyunProvider) GetInstance(ctx context.Context, instanceID string) (*m
 	return nil, fmt.Errorf("instance %s not found in any region", instanceID)
 }
 
-// ListDatabases 列出数据库实例
+// ListDatabases 列出 RDS 数据库实例
+// Filters 中 engine 为 redis 时改为查询 Redis/Tair,name、address 用于过滤 Redis
 func (p *AliyunProvider) ListDatabases(ctx context.Context, opts *provider.QueryOptions) ([]*model.Database, error) {
 	if opts == nil {
 		opts = &provider.QueryOptions{}
 	}
 
-	// 如果指定了区域,只查询该区域
-	if opts.Region != "" {
-		client, ok := p.clients[opts.Region]
-		if !ok {
-			return nil, fmt.Errorf("region %s not configured", opts.Region)
-		}
-
-		return client.ListRDSInstances(ctx, opts.PageSize, opts.PageNum, opts.Filters)
-	}
-
-	// 否则查询所有区域
-	allDatabases := make([]*model.Database, 0)
-	for region, client := range p.clients {
-		databases, err := client.ListRDSInstances(ctx, opts.PageSize, opts.PageNum, opts.Filters)
-		if err != nil {
-			logx.Warn("Failed to query databases in region %s: %v", region, err)
-			continue
-		}
-		allDatabases = append(allDatabases, databases...)
-	}
-
-	return allDatabases, nil
+	return collectRegions(p, opts, "databases", func(client *Client) ([]*model.Database, error) {
+		return client.listDatabases(ctx, opts)
+	})
 }
 
 // GetDatabase 获取数据库详情
 func (p *AliyunProvider) GetDatabase(ctx context.Context, dbID string) (*model.Database, error) {
 	// 尝试在所有区域查找数据库
 	for region, client := range p.clients {
-		database, err := client.GetRDSInstance(ctx, dbID)
+		var (
+			database *model.Database
+			err      error
+		)
+		// Redis/Tair 实例 ID 以 r- 开头
+		if strings.HasPrefix(dbID, "r-") {
+			database, err = client.GetRedisInstance(ctx, dbID)
+		} else {
+			database, err = client.GetRDSInstance(ctx, dbID)
+		}
 		if err == nil {
 			return database, nil
 		}
@@ -185,6 +182,281 @@ func (p *AliyunProvider) GetOSSBucket(ctx context.Context, bucketName string) (*
 	return nil, fmt.Errorf("no clients available")
 }
 
+// ListLoadBalancers 列出负载均衡 (SLB/ALB)
+func (p *AliyunProvider) ListLoadBalancers(ctx context.Context, opts *provider.QueryOptions) ([]*model.LoadBalancer, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+
+	// 如果指定了区域,只查询该区域
+	if opts.Region != "" {
+		client, ok := p.clients[opts.Region]
+		if !ok {
+			return nil, fmt.Errorf("region %s not configured", opts.Region)
+		}
+
+		return client.ListLoadBalancers(ctx, opts.Filters)
+	}
+
+	// 否则查询所有区域
+	allLoadBalancers := make([]*model.LoadBalancer, 0)
+	for region, client := range p.clients {
+		lbs, err := client.ListLoadBalancers(ctx, opts.Filters)
+		if err != nil {
+			logx.Warn("Failed to query load balancers in region %s: %v", region, err)
+			continue
+		}
+		allLoadBalancers = append(allLoadBalancers, lbs...)
+	}
+
+	return allLoadBalancers, nil
+}
+
+// GetLoadBalancer 获取负载均衡详情
+func (p *AliyunProvider) GetLoadBalancer(ctx context.Context, lbID string) (*model.LoadBalancer, error) {
+	// 尝试在所有区域查找负载均衡
+	for region, client := range p.clients {
+		lb, err := client.GetLoadBalancer(ctx, lbID)
+		if err == nil {
+			return lb, nil
+		}
+		logx.Debug("Load balancer not found in region, lb_id %s, region %s", lbID, region)
+	}
+
+	return nil, fmt.Errorf("load balancer %s not found in any region", lbID)
+}
+
+// ListVPCs 列出 VPC
+func (p *AliyunProvider) ListVPCs(ctx context.Context, opts *provider.QueryOptions) ([]*model.VPC, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	return collectRegions(p, opts, "VPCs", func(client *Client) ([]*model.VPC, error) {
+		return client.ListVPCs(ctx)
+	})
+}
+
+// ListSubnets 列出交换机
+func (p *AliyunProvider) ListSubnets(ctx context.Context, opts *provider.QueryOptions) ([]*model.Subnet, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	return collectRegions(p, opts, "vSwitches", func(client *Client) ([]*model.Subnet, error) {
+		return client.ListVSwitches(ctx, opts.Filters["vpc_id"])
+	})
+}
+
+// ListSecurityGroups 列出安全组
+func (p *AliyunProvider) ListSecurityGroups(ctx context.Context, opts *provider.QueryOptions) ([]*model.SecurityGroup, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	return collectRegions(p, opts, "security groups", func(client *Client) ([]*model.SecurityGroup, error) {
+		return client.ListSecurityGroups(ctx, opts.Filters["vpc_id"])
+	})
+}
+
+// GetSecurityGroup 获取安全组详情
+func (p *AliyunProvider) GetSecurityGroup(ctx context.Context, sgID string) (*model.SecurityGroup, error) {
+	// 尝试在所有区域查找安全组
+	for region, client := range p.clients {
+		sg, err := client.GetSecurityGroup(ctx, sgID)
+		if err == nil {
+			return sg, nil
+		}
+		logx.Debug("Security group not found in region, sg_id %s, region %s", sgID, region)
+	}
+
+	return nil, fmt.Errorf("security group %s not found in any region", sgID)
+}
+
+// GetInstanceNetwork 获取 ECS 实例的网络上下文
+func (p *AliyunProvider) GetInstanceNetwork(ctx context.Context, instanceID string) (*model.InstanceNetwork, error) {
+	instance, err := p.GetInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := p.clients[instance.Region]
+	if !ok {
+		return nil, fmt.Errorf("region %s not configured", instance.Region)
+	}
+	return client.GetInstanceNetwork(ctx, instanceID)
+}
+
+// ListDomains 列出云解析托管的域名
+func (p *AliyunProvider) ListDomains(ctx context.Context, opts *provider.QueryOptions) ([]*model.Domain, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListDomains(ctx, opts.Filters["keyword"])
+}
+
+// ListDNSRecords 列出域名的解析记录
+func (p *AliyunProvider) ListDNSRecords(ctx context.Context, opts *provider.QueryOptions) ([]*model.DNSRecord, error) {
+	if opts == nil || opts.Filters["domain"] == "" {
+		return nil, fmt.Errorf("domain filter is required")
+	}
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListDNSRecords(ctx, opts.Filters["domain"], opts.Filters)
+}
+
+// ListEIPs 列出弹性公网 IP 及 ECS 固定公网 IP
+func (p *AliyunProvider) ListEIPs(ctx context.Context, opts *provider.QueryOptions) ([]*model.EIP, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	return collectRegions(p, opts, "public IPs", func(client *Client) ([]*model.EIP, error) {
+		return client.ListEIPs(ctx, opts.Filters)
+	})
+}
+
+// ListCertificates 列出数字证书管理服务中的证书
+func (p *AliyunProvider) ListCertificates(ctx context.Context, opts *provider.QueryOptions) ([]*model.Certificate, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListCertificates(ctx, opts.Filters["keyword"])
+}
+
+// ListCDNDomains 列出 CDN 加速域名
+func (p *AliyunProvider) ListCDNDomains(ctx context.Context, opts *provider.QueryOptions) ([]*model.CDNDomain, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListCDNDomains(ctx, opts.Filters["keyword"])
+}
+
+// RefreshCDNCache 按 URL 和目录分别提交缓存刷新任务
+func (p *AliyunProvider) RefreshCDNCache(ctx context.Context, paths []string) ([]*model.CDNRefreshTask, error) {
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+
+	urls, dirs := model.SplitCDNRefreshPaths(paths)
+	if len(urls) == 0 && len(dirs) == 0 {
+		return nil, fmt.Errorf("no paths to refresh")
+	}
+
+	var tasks []*model.CDNRefreshTask
+	for _, batch := range []struct {
+		refreshType string
+		paths       []string
+	}{
+		{model.CDNRefreshURL, urls},
+		{model.CDNRefreshDirectory, dirs},
+	} {
+		if len(batch.paths) == 0 {
+			continue
+		}
+		task, err := client.RefreshCDNCache(ctx, batch.paths, batch.refreshType)
+		if err != nil {
+			return tasks, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// ListCostItems 列出一个账期内按实例汇总的费用
+func (p *AliyunProvider) ListCostItems(ctx context.Context, opts *provider.QueryOptions) ([]*model.CostItem, error) {
+	if opts == nil || opts.Filters["billing_cycle"] == "" {
+		return nil, fmt.Errorf("billing_cycle filter is required")
+	}
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListCostItems(ctx, opts.Filters["billing_cycle"])
+}
+
+// GetMetrics 查询 ECS 或 RDS 实例的云监控指标,未指定区域时先查找实例所在区域
+func (p *AliyunProvider) GetMetrics(ctx context.Context, query *provider.MetricQuery) ([]*model.MetricSeries, error) {
+	if query == nil || query.ResourceID == "" {
+		return nil, fmt.Errorf("resource id is required")
+	}
+	if _, ok := cmsNamespaces[query.ResourceType]; !ok {
+		return nil, fmt.Errorf("unsupported resource type %q, must be instance or database", query.ResourceType)
+	}
+	if query.ResourceType == model.MetricResourceDatabase && strings.HasPrefix(query.ResourceID, "r-") {
+		return nil, fmt.Errorf("metrics for Redis instance %s are not supported", query.ResourceID)
+	}
+
+	region := query.Region
+	if region == "" {
+		if query.ResourceType == model.MetricResourceInstance {
+			instance, err := p.GetInstance(ctx, query.ResourceID)
+			if err != nil {
+				return nil, err
+			}
+			region = instance.Region
+		} else {
+			database, err := p.GetDatabase(ctx, query.ResourceID)
+			if err != nil {
+				return nil, err
+			}
+			region = database.Region
+		}
+	}
+
+	client, ok := p.clients[region]
+	if !ok {
+		return nil, fmt.Errorf("region %s not configured", region)
+	}
+	return client.GetMetrics(ctx, query.ResourceType, query.ResourceID, query.Metrics, query.Start, query.End, query.Period)
+}
+
+// globalClient 返回用于调用全局服务的客户端,按区域排序取第一个以保证结果稳定
+func (p *AliyunProvider) globalClient() (*Client, error) {
+	regions := make([]string, 0, len(p.clients))
+	for region := range p.clients {
+		regions = append(regions, region)
+	}
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("no clients initialized")
+	}
+	sort.Strings(regions)
+	return p.clients[regions[0]], nil
+}
+
+// collectRegions 在指定区域或所有区域执行查询并合并结果,单个区域失败时记录告警并跳过
+func collectRegions[T any](p *AliyunProvider, opts *provider.QueryOptions, resource string, query func(*Client) ([]T, error)) ([]T, error) {
+	if opts.Region != "" {
+		client, ok := p.clients[opts.Region]
+		if !ok {
+			return nil, fmt.Errorf("region %s not configured", opts.Region)
+		}
+		return query(client)
+	}
+
+	result := make([]T, 0)
+	for region, client := range p.clients {
+		items, err := query(client)
+		if err != nil {
+			logx.Warn("Failed to query %s in region %s: %v", resource, region, err)
+			continue
+		}
+		result = append(result, items...)
+	}
+	return result, nil
+}
+
 // HealthCheck 健康检查
 func (p *AliyunProvider) HealthCheck(ctx context.Context) error {
 	if len(p.clients) == 0 {
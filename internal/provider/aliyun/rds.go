@@ -3,14 +3,24 @@ package aliyun
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
 	rds "github.com/alibabacloud-go/rds-20140815/v14/client"
 	"github.com/alibabacloud-go/tea/tea"
 	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
 )
 
+// listDatabases 查询当前区域的数据库实例,engine 为 redis 时查询 Redis/Tair,否则查询 RDS
+func (c *Client) listDatabases(ctx context.Context, opts *provider.QueryOptions) ([]*model.Database, error) {
+	if strings.EqualFold(opts.Filters["engine"], "redis") {
+		return c.ListRedisInstances(ctx, opts.PageSize, opts.PageNum, opts.Filters)
+	}
+	return c.ListRDSInstances(ctx, opts.PageSize, opts.PageNum, opts.Filters)
+}
+
 // ListRDSInstances 查询 RDS 实例列表
 func (c *Client) ListRDSInstances(ctx context.Context, pageSize, pageNum int, filters map[string]string) ([]*model.Database, error) {
 	rdsClient, err := c.GetRDSClient()
@@ -44,7 +54,9 @@ func (c *Client) ListRDSInstances(ctx context.Context, pageSize, pageNum int, fi
 		pageSize,
 		pageNum)
 
+	done := c.observe(ctx, "DescribeDBInstances")
 	response, err := rdsClient.DescribeDBInstances(request)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe RDS instances: %w", err)
 	}
@@ -78,7 +90,9 @@ func (c *Client) GetRDSInstance(ctx context.Context, instanceID string) (*model.
 
 	logx.Debug("Querying Aliyun RDS instance, instance_id %s, region %s", instanceID, c.Region)
 
+	done := c.observe(ctx, "DescribeDBInstances")
 	response, err := rdsClient.DescribeDBInstances(request)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe RDS instance: %w", err)
 	}
@@ -0,0 +1,675 @@
+package aliyun
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+)
+
+// 负载均衡: 传统型负载均衡 SLB (CLB) 与应用型负载均衡 ALB
+// 负载均衡数量通常不多,列表接口一次查询全部分页
+
+const (
+	slbVersion = "2014-05-15"
+	albVersion = "2020-06-16"
+
+	lbPageSize = 100
+)
+
+func (c *Client) slbEndpoint() string {
+	return fmt.Sprintf("slb.%s.aliyuncs.com", c.Region)
+}
+
+func (c *Client) albEndpoint() string {
+	return fmt.Sprintf("alb.%s.aliyuncs.com", c.Region)
+}
+
+// ListLoadBalancers 列出负载均衡,支持过滤条件:
+//   - type: slb 或 alb,为空时查询全部
+//   - address: 服务地址 (VIP)
+//   - name: 名称,模糊匹配
+func (c *Client) ListLoadBalancers(ctx context.Context, filters map[string]string) ([]*model.LoadBalancer, error) {
+	lbType := strings.ToLower(filters["type"])
+	address := filters["address"]
+	name := filters["name"]
+
+	result := make([]*model.LoadBalancer, 0)
+
+	if lbType == "" || lbType == "slb" {
+		lbs, err := c.listSLBs(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, lbs...)
+	}
+
+	if lbType == "" || lbType == "alb" {
+		lbs, err := c.listALBs(ctx)
+		if err != nil {
+			// 未开通 ALB 的账号会返回错误,不影响 SLB 结果
+			if lbType == "alb" {
+				return nil, err
+			}
+			logx.Warn("Failed to list Aliyun ALB, region %s, error %v", c.Region, err)
+		}
+		for _, lb := range lbs {
+			// ALB 列表不返回地址,按地址查询时需要补充详情
+			if address != "" {
+				if err := c.fillALBAddresses(ctx, lb); err != nil {
+					logx.Warn("Failed to get Aliyun ALB addresses, id %s, error %v", lb.ID, err)
+					continue
+				}
+				if !slices.Contains(lb.Addresses, address) {
+					continue
+				}
+			}
+			result = append(result, lb)
+		}
+	}
+
+	if name != "" {
+		filtered := make([]*model.LoadBalancer, 0, len(result))
+		for _, lb := range result {
+			if strings.Contains(strings.ToLower(lb.Name), strings.ToLower(name)) {
+				filtered = append(filtered, lb)
+			}
+		}
+		result = filtered
+	}
+
+	logx.Info("Successfully queried Aliyun load balancers, count %d, region %s", len(result), c.Region)
+	return result, nil
+}
+
+// GetLoadBalancer 获取负载均衡详情,包含监听、后端服务器及健康状态
+func (c *Client) GetLoadBalancer(ctx context.Context, lbID string) (*model.LoadBalancer, error) {
+	var (
+		lb  *model.LoadBalancer
+		err error
+	)
+	if strings.HasPrefix(lbID, "alb-") {
+		lb, err = c.getALB(ctx, lbID)
+	} else {
+		lb, err = c.getSLB(ctx, lbID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.linkBackendInstances(ctx, lb)
+	return lb, nil
+}
+
+// ==================== SLB ====================
+
+type slbTags struct {
+	Tag []struct {
+		TagKey   string `json:"TagKey"`
+		TagValue string `json:"TagValue"`
+	} `json:"Tag"`
+}
+
+type slbLoadBalancer struct {
+	LoadBalancerID     string  `json:"LoadBalancerId"`
+	LoadBalancerName   string  `json:"LoadBalancerName"`
+	LoadBalancerStatus string  `json:"LoadBalancerStatus"`
+	Address            string  `json:"Address"`
+	AddressType        string  `json:"AddressType"`
+	VpcID              string  `json:"VpcId"`
+	NetworkType        string  `json:"NetworkType"`
+	LoadBalancerSpec   string  `json:"LoadBalancerSpec"`
+	PayType            string  `json:"PayType"`
+	CreateTimeStamp    int64   `json:"CreateTimeStamp"`
+	Tags               slbTags `json:"Tags"`
+}
+
+type slbDescribeLoadBalancersResponse struct {
+	TotalCount    int `json:"TotalCount"`
+	LoadBalancers struct {
+		LoadBalancer []slbLoadBalancer `json:"LoadBalancer"`
+	} `json:"LoadBalancers"`
+}
+
+type slbDescribeLoadBalancerAttributeResponse struct {
+	slbLoadBalancer
+	ListenerPortsAndProtocol struct {
+		ListenerPortAndProtocol []struct {
+			ListenerPort     int    `json:"ListenerPort"`
+			ListenerProtocol string `json:"ListenerProtocol"`
+			Description      string `json:"Description"`
+		} `json:"ListenerPortAndProtocol"`
+	} `json:"ListenerPortsAndProtocol"`
+	BackendServers struct {
+		BackendServer []struct {
+			ServerID string `json:"ServerId"`
+			ServerIP string `json:"ServerIp"`
+			Type     string `json:"Type"`
+			Weight   int    `json:"Weight"`
+		} `json:"BackendServer"`
+	} `json:"BackendServers"`
+}
+
+type slbDescribeHealthStatusResponse struct {
+	BackendServers struct {
+		BackendServer []struct {
+			ServerID           string `json:"ServerId"`
+			ServerIP           string `json:"ServerIp"`
+			Port               int    `json:"Port"`
+			ListenerPort       int    `json:"ListenerPort"`
+			Protocol           string `json:"Protocol"`
+			ServerHealthStatus string `json:"ServerHealthStatus"`
+		} `json:"BackendServer"`
+	} `json:"BackendServers"`
+}
+
+// listSLBs 查询 SLB 列表,address 不为空时按服务地址过滤
+func (c *Client) listSLBs(ctx context.Context, address string) ([]*model.LoadBalancer, error) {
+	result := make([]*model.LoadBalancer, 0)
+	for page := 1; ; page++ {
+		query := map[string]string{
+			"RegionId":   c.Region,
+			"PageSize":   strconv.Itoa(lbPageSize),
+			"PageNumber": strconv.Itoa(page),
+		}
+		if address != "" {
+			query["Address"] = address
+		}
+
+		var resp slbDescribeLoadBalancersResponse
+		if err := c.callRPC(ctx, c.slbEndpoint(), slbVersion, "DescribeLoadBalancers", query, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, lb := range resp.LoadBalancers.LoadBalancer {
+			result = append(result, convertSLB(&lb, c.Region))
+		}
+
+		if len(resp.LoadBalancers.LoadBalancer) < lbPageSize || len(result) >= resp.TotalCount {
+			break
+		}
+	}
+	return result, nil
+}
+
+// getSLB 查询 SLB 详情
+func (c *Client) getSLB(ctx context.Context, lbID string) (*model.LoadBalancer, error) {
+	query := map[string]string{
+		"RegionId":       c.Region,
+		"LoadBalancerId": lbID,
+	}
+
+	var attr slbDescribeLoadBalancerAttributeResponse
+	if err := c.callRPC(ctx, c.slbEndpoint(), slbVersion, "DescribeLoadBalancerAttribute", query, &attr); err != nil {
+		return nil, err
+	}
+	if attr.LoadBalancerID == "" {
+		return nil, fmt.Errorf("load balancer %s not found", lbID)
+	}
+
+	lb := convertSLB(&attr.slbLoadBalancer, c.Region)
+	for _, l := range attr.ListenerPortsAndProtocol.ListenerPortAndProtocol {
+		lb.Listeners = append(lb.Listeners, &model.Listener{
+			Name:     l.Description,
+			Protocol: strings.ToUpper(l.ListenerProtocol),
+			Port:     l.ListenerPort,
+		})
+	}
+
+	weights := make(map[string]int)
+	types := make(map[string]string)
+	for _, s := range attr.BackendServers.BackendServer {
+		weights[s.ServerID] = s.Weight
+		types[s.ServerID] = s.Type
+	}
+
+	// 健康状态按监听端口返回每个后端,查询失败时只返回默认服务器组
+	var health slbDescribeHealthStatusResponse
+	if err := c.callRPC(ctx, c.slbEndpoint(), slbVersion, "DescribeHealthStatus", query, &health); err != nil {
+		logx.Warn("Failed to describe Aliyun SLB health status, id %s, error %v", lbID, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, s := range health.BackendServers.BackendServer {
+		seen[s.ServerID] = true
+		lb.Backends = append(lb.Backends, &model.Backend{
+			ServerID:     s.ServerID,
+			ServerType:   serverType(types[s.ServerID], "ecs"),
+			IP:           s.ServerIP,
+			Port:         s.Port,
+			Weight:       weights[s.ServerID],
+			ListenerPort: s.ListenerPort,
+			Health:       slbHealth(s.ServerHealthStatus),
+		})
+	}
+	for _, s := range attr.BackendServers.BackendServer {
+		if seen[s.ServerID] {
+			continue
+		}
+		lb.Backends = append(lb.Backends, &model.Backend{
+			ServerID:   s.ServerID,
+			ServerType: serverType(s.Type, "ecs"),
+			IP:         s.ServerIP,
+			Weight:     s.Weight,
+			Health:     model.BackendUnknown,
+		})
+	}
+
+	return lb, nil
+}
+
+// convertSLB 将 SLB 实例转换为统一的负载均衡模型
+func convertSLB(lb *slbLoadBalancer, region string) *model.LoadBalancer {
+	result := &model.LoadBalancer{
+		ID:          lb.LoadBalancerID,
+		Name:        lb.LoadBalancerName,
+		Provider:    "aliyun",
+		Type:        "slb",
+		Region:      region,
+		AddressType: lb.AddressType,
+		Status:      lb.LoadBalancerStatus,
+		VpcID:       lb.VpcID,
+		Tags:        make(map[string]string),
+		Metadata:    make(map[string]any),
+	}
+	if lb.Address != "" {
+		result.Addresses = []string{lb.Address}
+	}
+	if lb.CreateTimeStamp > 0 {
+		result.CreatedAt = time.UnixMilli(lb.CreateTimeStamp)
+	}
+	for _, tag := range lb.Tags.Tag {
+		result.Tags[tag.TagKey] = tag.TagValue
+	}
+
+	result.Metadata["network_type"] = lb.NetworkType
+	result.Metadata["spec"] = lb.LoadBalancerSpec
+	result.Metadata["pay_type"] = lb.PayType
+
+	result.ConsoleURL = fmt.Sprintf("https://slb.console.aliyun.com/slb/%s/slbs/%s", region, lb.LoadBalancerID)
+	return result
+}
+
+// slbHealth 转换 SLB 后端健康状态: normal, abnormal, unavailable
+func slbHealth(status string) string {
+	switch status {
+	case "normal":
+		return model.BackendHealthy
+	case "abnormal":
+		return model.BackendUnhealthy
+	default:
+		return model.BackendUnknown
+	}
+}
+
+// ==================== ALB ====================
+
+type albLoadBalancer struct {
+	LoadBalancerID      string `json:"LoadBalancerId"`
+	LoadBalancerName    string `json:"LoadBalancerName"`
+	LoadBalancerStatus  string `json:"LoadBalancerStatus"`
+	LoadBalancerEdition string `json:"LoadBalancerEdition"`
+	AddressType         string `json:"AddressType"`
+	DNSName             string `json:"DNSName"`
+	VpcID               string `json:"VpcId"`
+	CreateTime          string `json:"CreateTime"`
+	Tags                []struct {
+		Key   string `json:"Key"`
+		Value string `json:"Value"`
+	} `json:"Tags"`
+}
+
+type albListLoadBalancersResponse struct {
+	NextToken     string            `json:"NextToken"`
+	LoadBalancers []albLoadBalancer `json:"LoadBalancers"`
+}
+
+type albGetLoadBalancerAttributeResponse struct {
+	albLoadBalancer
+	ZoneMappings []struct {
+		ZoneID                string `json:"ZoneId"`
+		LoadBalancerAddresses []struct {
+			Address         string `json:"Address"`
+			IntranetAddress string `json:"IntranetAddress"`
+		} `json:"LoadBalancerAddresses"`
+	} `json:"ZoneMappings"`
+}
+
+type albListener struct {
+	ListenerID          string `json:"ListenerId"`
+	ListenerProtocol    string `json:"ListenerProtocol"`
+	ListenerPort        int    `json:"ListenerPort"`
+	ListenerStatus      string `json:"ListenerStatus"`
+	ListenerDescription string `json:"ListenerDescription"`
+	DefaultActions      []struct {
+		ForwardGroupConfig struct {
+			ServerGroupTuples []struct {
+				ServerGroupID string `json:"ServerGroupId"`
+			} `json:"ServerGroupTuples"`
+		} `json:"ForwardGroupConfig"`
+	} `json:"DefaultActions"`
+}
+
+type albListListenersResponse struct {
+	NextToken string        `json:"NextToken"`
+	Listeners []albListener `json:"Listeners"`
+}
+
+type albServer struct {
+	ServerID   string `json:"ServerId"`
+	ServerIP   string `json:"ServerIp"`
+	ServerType string `json:"ServerType"`
+	Port       int    `json:"Port"`
+	Weight     int    `json:"Weight"`
+}
+
+type albListServerGroupServersResponse struct {
+	NextToken string      `json:"NextToken"`
+	Servers   []albServer `json:"Servers"`
+}
+
+type albGetListenerHealthStatusResponse struct {
+	ListenerHealthStatus []struct {
+		ServerGroupInfos []struct {
+			ServerGroupID    string `json:"ServerGroupId"`
+			NonNormalServers []struct {
+				ServerID string `json:"ServerId"`
+				ServerIP string `json:"ServerIp"`
+				Port     int    `json:"Port"`
+				Status   string `json:"Status"`
+			} `json:"NonNormalServers"`
+		} `json:"ServerGroupInfos"`
+	} `json:"ListenerHealthStatus"`
+}
+
+// listALBs 查询 ALB 列表
+func (c *Client) listALBs(ctx context.Context) ([]*model.LoadBalancer, error) {
+	result := make([]*model.LoadBalancer, 0)
+	nextToken := ""
+	for {
+		query := map[string]string{"MaxResults": strconv.Itoa(lbPageSize)}
+		if nextToken != "" {
+			query["NextToken"] = nextToken
+		}
+
+		var resp albListLoadBalancersResponse
+		if err := c.callRPC(ctx, c.albEndpoint(), albVersion, "ListLoadBalancers", query, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, lb := range resp.LoadBalancers {
+			result = append(result, convertALB(&lb, c.Region))
+		}
+
+		if resp.NextToken == "" {
+			break
+		}
+		nextToken = resp.NextToken
+	}
+	return result, nil
+}
+
+// getALBAttribute 查询 ALB 属性
+func (c *Client) getALBAttribute(ctx context.Context, lbID string) (*albGetLoadBalancerAttributeResponse, error) {
+	var attr albGetLoadBalancerAttributeResponse
+	query := map[string]string{"LoadBalancerId": lbID}
+	if err := c.callRPC(ctx, c.albEndpoint(), albVersion, "GetLoadBalancerAttribute", query, &attr); err != nil {
+		return nil, err
+	}
+	if attr.LoadBalancerID == "" {
+		return nil, fmt.Errorf("load balancer %s not found", lbID)
+	}
+	return &attr, nil
+}
+
+// fillALBAddresses 补充 ALB 各可用区的服务地址
+func (c *Client) fillALBAddresses(ctx context.Context, lb *model.LoadBalancer) error {
+	attr, err := c.getALBAttribute(ctx, lb.ID)
+	if err != nil {
+		return err
+	}
+	lb.Addresses = albAddresses(attr)
+	return nil
+}
+
+// getALB 查询 ALB 详情
+func (c *Client) getALB(ctx context.Context, lbID string) (*model.LoadBalancer, error) {
+	attr, err := c.getALBAttribute(ctx, lbID)
+	if err != nil {
+		return nil, err
+	}
+
+	lb := convertALB(&attr.albLoadBalancer, c.Region)
+	lb.Addresses = albAddresses(attr)
+
+	listeners, err := c.listALBListeners(ctx, lbID)
+	if err != nil {
+		logx.Warn("Failed to list Aliyun ALB listeners, id %s, error %v", lbID, err)
+		return lb, nil
+	}
+
+	groupServers := make(map[string][]albServer)
+	for _, l := range listeners {
+		lb.Listeners = append(lb.Listeners, &model.Listener{
+			ID:       l.ListenerID,
+			Name:     l.ListenerDescription,
+			Protocol: strings.ToUpper(l.ListenerProtocol),
+			Port:     l.ListenerPort,
+			Status:   l.ListenerStatus,
+		})
+
+		unhealthy := c.albNonNormalServers(ctx, l.ListenerID)
+
+		for _, action := range l.DefaultActions {
+			for _, tuple := range action.ForwardGroupConfig.ServerGroupTuples {
+				servers, ok := groupServers[tuple.ServerGroupID]
+				if !ok {
+					servers, err = c.listALBServerGroupServers(ctx, tuple.ServerGroupID)
+					if err != nil {
+						logx.Warn("Failed to list Aliyun ALB server group servers, id %s, error %v", tuple.ServerGroupID, err)
+					}
+					groupServers[tuple.ServerGroupID] = servers
+				}
+
+				for _, s := range servers {
+					health := model.BackendHealthy
+					if unhealthy == nil {
+						health = model.BackendUnknown
+					} else if status, ok := unhealthy[albServerKey(s.ServerID, s.Port)]; ok {
+						health = albHealth(status)
+					}
+					lb.Backends = append(lb.Backends, &model.Backend{
+						ServerID:     s.ServerID,
+						ServerType:   serverType(s.ServerType, "ecs"),
+						IP:           s.ServerIP,
+						Port:         s.Port,
+						Weight:       s.Weight,
+						ListenerPort: l.ListenerPort,
+						Health:       health,
+					})
+				}
+			}
+		}
+	}
+
+	return lb, nil
+}
+
+// listALBListeners 查询 ALB 的监听列表
+func (c *Client) listALBListeners(ctx context.Context, lbID string) ([]albListener, error) {
+	result := make([]albListener, 0)
+	nextToken := ""
+	for {
+		query := map[string]string{
+			"LoadBalancerIds.1": lbID,
+			"MaxResults":        strconv.Itoa(lbPageSize),
+		}
+		if nextToken != "" {
+			query["NextToken"] = nextToken
+		}
+
+		var resp albListListenersResponse
+		if err := c.callRPC(ctx, c.albEndpoint(), albVersion, "ListListeners", query, &resp); err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Listeners...)
+
+		if resp.NextToken == "" {
+			break
+		}
+		nextToken = resp.NextToken
+	}
+	return result, nil
+}
+
+// listALBServerGroupServers 查询 ALB 服务器组中的后端服务器
+func (c *Client) listALBServerGroupServers(ctx context.Context, groupID string) ([]albServer, error) {
+	result := make([]albServer, 0)
+	nextToken := ""
+	for {
+		query := map[string]string{
+			"ServerGroupId": groupID,
+			"MaxResults":    strconv.Itoa(lbPageSize),
+		}
+		if nextToken != "" {
+			query["NextToken"] = nextToken
+		}
+
+		var resp albListServerGroupServersResponse
+		if err := c.callRPC(ctx, c.albEndpoint(), albVersion, "ListServerGroupServers", query, &resp); err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Servers...)
+
+		if resp.NextToken == "" {
+			break
+		}
+		nextToken = resp.NextToken
+	}
+	return result, nil
+}
+
+// albNonNormalServers 查询监听下健康检查异常的后端,返回 nil 表示健康状态未知
+func (c *Client) albNonNormalServers(ctx context.Context, listenerID string) map[string]string {
+	var resp albGetListenerHealthStatusResponse
+	query := map[string]string{"ListenerId": listenerID}
+	if err := c.callRPC(ctx, c.albEndpoint(), albVersion, "GetListenerHealthStatus", query, &resp); err != nil {
+		logx.Warn("Failed to get Aliyun ALB listener health status, id %s, error %v", listenerID, err)
+		return nil
+	}
+
+	result := make(map[string]string)
+	for _, status := range resp.ListenerHealthStatus {
+		for _, group := range status.ServerGroupInfos {
+			for _, s := range group.NonNormalServers {
+				result[albServerKey(s.ServerID, s.Port)] = s.Status
+			}
+		}
+	}
+	return result
+}
+
+// convertALB 将 ALB 实例转换为统一的负载均衡模型
+func convertALB(lb *albLoadBalancer, region string) *model.LoadBalancer {
+	result := &model.LoadBalancer{
+		ID:          lb.LoadBalancerID,
+		Name:        lb.LoadBalancerName,
+		Provider:    "aliyun",
+		Type:        "alb",
+		Region:      region,
+		AddressType: strings.ToLower(lb.AddressType),
+		DNSName:     lb.DNSName,
+		Status:      lb.LoadBalancerStatus,
+		VpcID:       lb.VpcID,
+		Tags:        make(map[string]string),
+		Metadata:    make(map[string]any),
+	}
+	if t, err := time.Parse(time.RFC3339, lb.CreateTime); err == nil {
+		result.CreatedAt = t
+	}
+	for _, tag := range lb.Tags {
+		result.Tags[tag.Key] = tag.Value
+	}
+
+	result.Metadata["edition"] = lb.LoadBalancerEdition
+
+	result.ConsoleURL = fmt.Sprintf("https://slb.console.aliyun.com/alb/%s/albs/%s", region, lb.LoadBalancerID)
+	return result
+}
+
+// albAddresses 汇总 ALB 各可用区的公网和内网地址
+func albAddresses(attr *albGetLoadBalancerAttributeResponse) []string {
+	addresses := make([]string, 0)
+	for _, zone := range attr.ZoneMappings {
+		for _, addr := range zone.LoadBalancerAddresses {
+			for _, ip := range []string{addr.Address, addr.IntranetAddress} {
+				if ip != "" && !slices.Contains(addresses, ip) {
+					addresses = append(addresses, ip)
+				}
+			}
+		}
+	}
+	return addresses
+}
+
+// albHealth 转换 ALB 异常后端状态: Unhealthy, Unused, Unavailable
+func albHealth(status string) string {
+	if status == "Unhealthy" {
+		return model.BackendUnhealthy
+	}
+	return model.BackendUnknown
+}
+
+func albServerKey(serverID string, port int) string {
+	return fmt.Sprintf("%s:%d", serverID, port)
+}
+
+// ==================== 后端关联 ====================
+
+// linkBackendInstances 将 ECS 类型的后端关联到实例详情,查询失败时只记录日志
+func (c *Client) linkBackendInstances(ctx context.Context, lb *model.LoadBalancer) {
+	ids := make([]string, 0)
+	for _, b := range lb.Backends {
+		if b.ServerType == "ecs" && !slices.Contains(ids, b.ServerID) {
+			ids = append(ids, b.ServerID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	instances := make(map[string]*model.Instance)
+	for start := 0; start < len(ids); start += lbPageSize {
+		end := min(start+lbPageSize, len(ids))
+		items, err := c.QueryECSInstances(ctx, &ECSQueryParams{
+			InstanceIDs: ids[start:end],
+			PageSize:    lbPageSize,
+		})
+		if err != nil {
+			logx.Warn("Failed to query backend instances of load balancer %s: %v", lb.ID, err)
+			return
+		}
+		for _, inst := range items {
+			instances[inst.ID] = inst
+		}
+	}
+
+	for _, b := range lb.Backends {
+		if inst, ok := instances[b.ServerID]; ok {
+			b.Instance = inst
+		}
+	}
+}
+
+// serverType 统一后端类型为小写,为空时使用默认类型
+func serverType(t, def string) string {
+	if t == "" {
+		return def
+	}
+	return strings.ToLower(t)
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/eryajf/zenops/internal/model"
 )
@@ -14,7 +15,7 @@ type Provider interface {
 	// Initialize 初始化提供商客户端
 	Initialize(config map[string]any) error
 
-	// ListInstances 列出所有实例 (ECS/CVM/EC2)
+	// ListInstances 列出所有实例 (ECS/CVM/EC2),Filters 支持 ip(私网、公网或弹性公网 IP)
 	ListInstances(ctx context.Context, opts *QueryOptions) ([]*model.Instance, error)
 
 	// GetInstance 获取单个实例详情
@@ -32,6 +33,51 @@ type Provider interface {
 	// GetOSSBucket 获取对象存储桶详情
 	GetOSSBucket(ctx context.Context, bucketName string) (*model.OSSBucket, error)
 
+	// ListLoadBalancers 列出负载均衡 (SLB/ALB/CLB),Filters 支持 address(VIP)、name、type
+	ListLoadBalancers(ctx context.Context, opts *QueryOptions) ([]*model.LoadBalancer, error)
+
+	// GetLoadBalancer 获取负载均衡详情,包含监听、后端服务器及健康状态
+	GetLoadBalancer(ctx context.Context, lbID string) (*model.LoadBalancer, error)
+
+	// ListVPCs 列出专有网络 VPC
+	ListVPCs(ctx context.Context, opts *QueryOptions) ([]*model.VPC, error)
+
+	// ListSubnets 列出子网 (阿里云交换机),Filters 支持 vpc_id
+	ListSubnets(ctx context.Context, opts *QueryOptions) ([]*model.Subnet, error)
+
+	// ListSecurityGroups 列出安全组 (不含规则),Filters 支持 vpc_id (仅阿里云)
+	ListSecurityGroups(ctx context.Context, opts *QueryOptions) ([]*model.SecurityGroup, error)
+
+	// GetSecurityGroup 获取安全组详情,包含入方向和出方向规则
+	GetSecurityGroup(ctx context.Context, sgID string) (*model.SecurityGroup, error)
+
+	// GetInstanceNetwork 获取实例的 VPC、子网、安全组及按匹配顺序排列的生效规则
+	GetInstanceNetwork(ctx context.Context, instanceID string) (*model.InstanceNetwork, error)
+
+	// ListDomains 列出云解析托管的域名,Filters 支持 keyword
+	ListDomains(ctx context.Context, opts *QueryOptions) ([]*model.Domain, error)
+
+	// ListDNSRecords 列出域名的解析记录,Filters 中 domain 必填,支持 rr、type、value(精确匹配)
+	ListDNSRecords(ctx context.Context, opts *QueryOptions) ([]*model.DNSRecord, error)
+
+	// ListEIPs 列出弹性公网 IP 及实例的普通公网 IP,Filters 支持 address、status、instance_id、type
+	ListEIPs(ctx context.Context, opts *QueryOptions) ([]*model.EIP, error)
+
+	// ListCertificates 列出证书服务中的 SSL 证书,包含已部署的云产品,Filters 支持 keyword
+	ListCertificates(ctx context.Context, opts *QueryOptions) ([]*model.Certificate, error)
+
+	// ListCDNDomains 列出 CDN 加速域名,包含源站和 HTTPS 配置,Filters 支持 keyword
+	ListCDNDomains(ctx context.Context, opts *QueryOptions) ([]*model.CDNDomain, error)
+
+	// RefreshCDNCache 提交 CDN 缓存刷新任务,以 / 结尾的路径按目录刷新,其余按 URL 刷新
+	RefreshCDNCache(ctx context.Context, paths []string) ([]*model.CDNRefreshTask, error)
+
+	// ListCostItems 列出一个账期内按实例汇总的费用,Filters 中 billing_cycle(YYYY-MM) 必填
+	ListCostItems(ctx context.Context, opts *QueryOptions) ([]*model.CostItem, error)
+
+	// GetMetrics 查询实例或数据库的云监控指标,无数据的指标返回空序列
+	GetMetrics(ctx context.Context, query *MetricQuery) ([]*model.MetricSeries, error)
+
 	// HealthCheck 健康检查
 	HealthCheck(ctx context.Context) error
 }
@@ -53,6 +99,12 @@ type CICDProvider interface {
 	// GetJobBuilds 获取任务的构建历史
 	GetJobBuilds(ctx context.Context, jobName string, limit int) ([]*model.Build, error)
 
+	// GetBuildLog 获取构建的控制台日志,buildNumber <= 0 时获取最后一次构建
+	GetBuildLog(ctx context.Context, jobName string, buildNumber int) (*model.Build, string, error)
+
+	// RerunBuild 使用原构建参数重新触发构建,返回队列 ID
+	RerunBuild(ctx context.Context, jobName string, buildNumber int) (int64, error)
+
 	// HealthCheck 健康检查
 	HealthCheck(ctx context.Context) error
 }
@@ -65,3 +117,14 @@ type QueryOptions struct {
 	Filters  map[string]string // 过滤条件
 	Tags     map[string]string // 标签过滤
 }
+
+// MetricQuery 监控指标查询条件
+type MetricQuery struct {
+	ResourceType string    // instance 或 database
+	ResourceID   string    // 实例 ID
+	Region       string    // 资源所在区域,为空时按资源 ID 查找
+	Metrics      []string  // 指标名称,为空时查询该资源类型支持的全部指标
+	Start        time.Time // 开始时间
+	End          time.Time // 结束时间
+	Period       int       // 数据点间隔(秒): 60、300、3600 或 86400,单次查询不超过 1440 个数据点
+}
@@ -117,6 +117,67 @@ func (p *JenkinsProvider) GetLastBuild(ctx context.Context, jobName string) (*mo
 	return convertBuildToModel(build, jobName), nil
 }
 
+// getBuildOrLast 获取指定构建,buildNumber <= 0 时获取最后一次构建
+func (p *JenkinsProvider) getBuildOrLast(ctx context.Context, jobName string, buildNumber int) (*gojenkins.Job, *gojenkins.Build, error) {
+	if err := p.client.Connect(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	job, err := p.client.GetJenkins().GetJob(ctx, jobName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get job '%s': %w", jobName, err)
+	}
+
+	var build *gojenkins.Build
+	if buildNumber > 0 {
+		build, err = job.GetBuild(ctx, int64(buildNumber))
+	} else {
+		build, err = job.GetLastBuild(ctx)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get build #%d: %w", buildNumber, err)
+	}
+	if build == nil {
+		return nil, nil, fmt.Errorf("no builds found for job '%s'", jobName)
+	}
+
+	return job, build, nil
+}
+
+// GetBuildLog 获取构建的控制台日志,buildNumber <= 0 时获取最后一次构建
+func (p *JenkinsProvider) GetBuildLog(ctx context.Context, jobName string, buildNumber int) (*model.Build, string, error) {
+	_, build, err := p.getBuildOrLast(ctx, jobName, buildNumber)
+	if err != nil {
+		return nil, "", err
+	}
+
+	logx.Info("Fetched build log, job %s, build %d", jobName, build.Raw.Number)
+
+	return convertBuildToModel(build, jobName), build.GetConsoleOutput(ctx), nil
+}
+
+// RerunBuild 使用指定构建的参数重新触发构建,buildNumber <= 0 时使用最后一次构建,返回队列 ID
+func (p *JenkinsProvider) RerunBuild(ctx context.Context, jobName string, buildNumber int) (int64, error) {
+	job, build, err := p.getBuildOrLast(ctx, jobName, buildNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	params := make(map[string]string)
+	for _, param := range build.GetParameters() {
+		params[param.Name] = param.Value
+	}
+
+	queueID, err := job.InvokeSimple(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to trigger job '%s': %w", jobName, err)
+	}
+
+	logx.Info("Triggered rerun of build, job %s, build %d, queue_id %d", jobName, build.Raw.Number, queueID)
+
+	return queueID, nil
+}
+
 // convertBuildToModel 将 Jenkins Build 转换为统一的 Build 模型
 func convertBuildToModel(build *gojenkins.Build, jobName string) *model.Build {
 	modelBuild := &model.Build{
@@ -0,0 +1,110 @@
+package tencent
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+// 费用中心为全局服务,与区域无关
+
+const (
+	billingService = "billing"
+	billingVersion = "2018-07-09"
+
+	billingPageSize = 1000
+)
+
+type billingResourceSummary struct {
+	ResourceID       string `json:"ResourceId"`
+	InstanceName     string `json:"InstanceName"`
+	BusinessCode     string `json:"BusinessCode"`
+	BusinessCodeName string `json:"BusinessCodeName"`
+	RegionID         string `json:"RegionId"`
+	RegionName       string `json:"RegionName"`
+	RealTotalCost    string `json:"RealTotalCost"` // 优惠后总价
+	Tags             []struct {
+		TagKey   string `json:"TagKey"`
+		TagValue string `json:"TagValue"`
+	} `json:"Tags"`
+}
+
+type billingDescribeResourceSummaryResponse struct {
+	Total              int                      `json:"Total"`
+	ResourceSummarySet []billingResourceSummary `json:"ResourceSummarySet"`
+}
+
+// ListCostItems 查询账期内按资源汇总的账单,同一资源的多条账单合并为一条
+func (p *TencentProvider) ListCostItems(ctx context.Context, opts *provider.QueryOptions) ([]*model.CostItem, error) {
+	if opts == nil || opts.Filters["billing_cycle"] == "" {
+		return nil, fmt.Errorf("billing_cycle filter is required")
+	}
+	billingCycle := opts.Filters["billing_cycle"]
+
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]any{
+		"Month":         billingCycle,
+		"Limit":         billingPageSize,
+		"PeriodType":    "byUsedTime",
+		"NeedRecordNum": 1,
+	}
+
+	items := make([]*model.CostItem, 0)
+	index := make(map[string]*model.CostItem)
+	for offset := 0; ; offset += billingPageSize {
+		params["Offset"] = offset
+
+		var resp billingDescribeResourceSummaryResponse
+		if err := client.callAPI(ctx, billingService, billingVersion, "DescribeBillResourceSummary", params, &resp); err != nil {
+			return nil, err
+		}
+
+		for i := range resp.ResourceSummarySet {
+			summary := &resp.ResourceSummarySet[i]
+			amount, _ := strconv.ParseFloat(summary.RealTotalCost, 64)
+			key := summary.BusinessCode + "/" + summary.ResourceID
+			if item, ok := index[key]; ok {
+				item.Amount += amount
+				continue
+			}
+			item := client.convertCostItem(summary, billingCycle, amount)
+			index[key] = item
+			items = append(items, item)
+		}
+
+		if len(resp.ResourceSummarySet) < billingPageSize || offset+len(resp.ResourceSummarySet) >= resp.Total {
+			break
+		}
+	}
+	return items, nil
+}
+
+// convertCostItem 将资源账单转换为统一模型,腾讯云账单金额单位为人民币元
+func (c *Client) convertCostItem(s *billingResourceSummary, billingCycle string, amount float64) *model.CostItem {
+	item := &model.CostItem{
+		InstanceID:   s.ResourceID,
+		InstanceName: s.InstanceName,
+		ProductCode:  s.BusinessCode,
+		Product:      firstNonEmpty(s.BusinessCodeName, s.BusinessCode),
+		Region:       firstNonEmpty(s.RegionName, s.RegionID),
+		Provider:     "tencent",
+		Account:      c.Account,
+		BillingCycle: billingCycle,
+		Amount:       amount,
+		Currency:     "CNY",
+	}
+	if len(s.Tags) > 0 {
+		item.Tags = make(map[string]string, len(s.Tags))
+		for _, tag := range s.Tags {
+			item.Tags[tag.TagKey] = tag.TagValue
+		}
+	}
+	return item
+}
@@ -65,7 +65,9 @@ func (p *TencentProvider) listCDBInstancesInRegion(ctx context.Context, client *
 		request.Offset = &offset
 	}
 
+	done := client.observe(ctx, "DescribeDBInstances")
 	response, err := cdbClient.DescribeDBInstances(request)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe database instances: %w", err)
 	}
@@ -93,7 +95,9 @@ func (p *TencentProvider) GetCDBInstance(ctx context.Context, instanceID string)
 		request := cdb.NewDescribeDBInstancesRequest()
 		request.InstanceIds = []*string{&instanceID}
 
+		done := client.observe(ctx, "DescribeDBInstances")
 		response, err := cdbClient.DescribeDBInstances(request)
+		done(err)
 		if err != nil {
 			logx.Warn("Failed to describe database, instance_id %s, region %s, error %v", instanceID, region, err)
 			continue
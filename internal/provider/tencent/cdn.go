@@ -0,0 +1,193 @@
+package tencent
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+// CDN 为全局服务,与区域无关
+
+const (
+	cdnService = "cdn"
+	cdnVersion = "2018-06-06"
+
+	cdnPageSize = 1000
+)
+
+// cdnTimeZone 域名创建时间和证书过期时间为北京时间
+var cdnTimeZone = time.FixedZone("CST", 8*3600)
+
+// cdnAreas 加速区域到统一取值的映射
+var cdnAreas = map[string]string{
+	"mainland": "domestic",
+	"overseas": "overseas",
+	"global":   "global",
+}
+
+type cdnDomain struct {
+	ResourceID  string `json:"ResourceId"`
+	Domain      string `json:"Domain"`
+	Cname       string `json:"Cname"`
+	Status      string `json:"Status"`      // online, offline, processing, rejected
+	ServiceType string `json:"ServiceType"` // web, download, media, hybrid, dynamic
+	Area        string `json:"Area"`        // mainland, overseas, global
+	CreateTime  string `json:"CreateTime"`
+	Origin      struct {
+		Origins          []string `json:"Origins"` // host、host:port 或 host:port:weight
+		OriginType       string   `json:"OriginType"`
+		BackupOrigins    []string `json:"BackupOrigins"`
+		BackupOriginType string   `json:"BackupOriginType"`
+	} `json:"Origin"`
+	HTTPS *struct {
+		Switch   string `json:"Switch"` // on, off
+		CertInfo *struct {
+			CertName   string `json:"CertName"`
+			ExpireTime string `json:"ExpireTime"`
+		} `json:"CertInfo"`
+	} `json:"Https"`
+}
+
+type cdnDescribeDomainsConfigResponse struct {
+	TotalNumber int         `json:"TotalNumber"`
+	Domains     []cdnDomain `json:"Domains"`
+}
+
+type cdnPurgeResponse struct {
+	TaskID string `json:"TaskId"`
+}
+
+// ListCDNDomains 列出 CDN 加速域名,Filters 支持 keyword(域名模糊匹配)
+func (p *TencentProvider) ListCDNDomains(ctx context.Context, opts *provider.QueryOptions) ([]*model.CDNDomain, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]any{"Limit": cdnPageSize}
+	if keyword := opts.Filters["keyword"]; keyword != "" {
+		params["Filters"] = []map[string]any{{"Name": "domain", "Value": []string{keyword}, "Fuzzy": true}}
+	}
+
+	domains := make([]*model.CDNDomain, 0)
+	for offset := 0; ; offset += cdnPageSize {
+		params["Offset"] = offset
+
+		var resp cdnDescribeDomainsConfigResponse
+		if err := client.callAPI(ctx, cdnService, cdnVersion, "DescribeDomainsConfig", params, &resp); err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Domains {
+			domains = append(domains, client.convertCDNDomain(&resp.Domains[i]))
+		}
+
+		if len(resp.Domains) < cdnPageSize || offset+len(resp.Domains) >= resp.TotalNumber {
+			break
+		}
+	}
+	return domains, nil
+}
+
+// RefreshCDNCache 按 URL 和目录分别提交缓存刷新任务,目录刷新全部资源
+func (p *TencentProvider) RefreshCDNCache(ctx context.Context, paths []string) ([]*model.CDNRefreshTask, error) {
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+
+	urls, dirs := model.SplitCDNRefreshPaths(paths)
+	if len(urls) == 0 && len(dirs) == 0 {
+		return nil, fmt.Errorf("no paths to refresh")
+	}
+
+	var tasks []*model.CDNRefreshTask
+	if len(urls) > 0 {
+		var resp cdnPurgeResponse
+		if err := client.callAPI(ctx, cdnService, cdnVersion, "PurgeUrlsCache", map[string]any{
+			"Urls": urls,
+		}, &resp); err != nil {
+			return tasks, err
+		}
+		tasks = append(tasks, client.refreshTask(resp.TaskID, model.CDNRefreshURL, urls))
+	}
+	if len(dirs) > 0 {
+		var resp cdnPurgeResponse
+		if err := client.callAPI(ctx, cdnService, cdnVersion, "PurgePathCache", map[string]any{
+			"Paths":     dirs,
+			"FlushType": "delete",
+		}, &resp); err != nil {
+			return tasks, err
+		}
+		tasks = append(tasks, client.refreshTask(resp.TaskID, model.CDNRefreshDirectory, dirs))
+	}
+	return tasks, nil
+}
+
+// refreshTask 记录已提交的刷新任务
+func (c *Client) refreshTask(taskID, refreshType string, paths []string) *model.CDNRefreshTask {
+	logx.Info("Submitted Tencent CDN refresh, account %s, type %s, paths %d, task %s", c.Account, refreshType, len(paths), taskID)
+	return &model.CDNRefreshTask{
+		TaskID:   taskID,
+		Provider: "tencent",
+		Account:  c.Account,
+		Type:     refreshType,
+		Paths:    paths,
+	}
+}
+
+// convertCDNDomain 将 CDN 加速域名转换为统一模型
+func (c *Client) convertCDNDomain(d *cdnDomain) *model.CDNDomain {
+	domain := &model.CDNDomain{
+		Name:         d.Domain,
+		Provider:     "tencent",
+		Account:      c.Account,
+		CNAME:        d.Cname,
+		Status:       d.Status,
+		BusinessType: d.ServiceType,
+		Area:         firstNonEmpty(cdnAreas[d.Area], d.Area),
+		Origins:      make([]model.CDNOrigin, 0, len(d.Origin.Origins)+len(d.Origin.BackupOrigins)),
+		ConsoleURL:   "https://console.cloud.tencent.com/cdn/domains/" + d.ResourceID,
+	}
+	for _, o := range d.Origin.Origins {
+		domain.Origins = append(domain.Origins, parseCDNOrigin(o, d.Origin.OriginType, model.CDNOriginPrimary))
+	}
+	for _, o := range d.Origin.BackupOrigins {
+		domain.Origins = append(domain.Origins, parseCDNOrigin(o, d.Origin.BackupOriginType, model.CDNOriginBackup))
+	}
+	if d.HTTPS != nil && d.HTTPS.Switch == "on" {
+		domain.HTTPS = true
+		if cert := d.HTTPS.CertInfo; cert != nil {
+			domain.CertName = cert.CertName
+			if t, err := time.ParseInLocation("2006-01-02 15:04:05", cert.ExpireTime, cdnTimeZone); err == nil {
+				domain.CertExpireAt = &t
+			}
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", d.CreateTime, cdnTimeZone); err == nil {
+		domain.CreatedAt = t
+	}
+	return domain
+}
+
+// parseCDNOrigin 解析源站地址,IPv6 地址原样保留
+func parseCDNOrigin(value, originType, priority string) model.CDNOrigin {
+	origin := model.CDNOrigin{Address: value, Type: originType, Priority: priority}
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return origin
+	}
+	if port, err := strconv.Atoi(parts[1]); err == nil {
+		origin.Address, origin.Port = parts[0], port
+	}
+	return origin
+}
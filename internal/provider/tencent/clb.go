@@ -0,0 +1,341 @@
+package tencent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// 负载均衡 CLB,负载均衡数量通常不多,列表接口一次查询全部分页
+
+const (
+	clbService = "clb"
+	clbVersion = "2018-03-17"
+
+	clbPageSize = 100
+)
+
+type clbLoadBalancer struct {
+	LoadBalancerID   string   `json:"LoadBalancerId"`
+	LoadBalancerName string   `json:"LoadBalancerName"`
+	LoadBalancerType string   `json:"LoadBalancerType"` // OPEN: 公网, INTERNAL: 内网
+	Forward          int      `json:"Forward"`          // 1: 负载均衡, 0: 传统型负载均衡
+	Domain           string   `json:"Domain"`
+	LoadBalancerVips []string `json:"LoadBalancerVips"`
+	Status           int      `json:"Status"` // 0: 创建中, 1: 正常运行
+	CreateTime       string   `json:"CreateTime"`
+	VpcID            string   `json:"VpcId"`
+	ChargeType       string   `json:"ChargeType"`
+	AddressIPVersion string   `json:"AddressIPVersion"`
+	Tags             []struct {
+		TagKey   string `json:"TagKey"`
+		TagValue string `json:"TagValue"`
+	} `json:"Tags"`
+}
+
+type clbDescribeLoadBalancersResponse struct {
+	TotalCount      int               `json:"TotalCount"`
+	LoadBalancerSet []clbLoadBalancer `json:"LoadBalancerSet"`
+}
+
+type clbTarget struct {
+	Type               string   `json:"Type"` // CVM, ENI
+	InstanceID         string   `json:"InstanceId"`
+	Port               int      `json:"Port"`
+	Weight             int      `json:"Weight"`
+	PrivateIPAddresses []string `json:"PrivateIpAddresses"`
+	EniIP              string   `json:"EniIp"`
+}
+
+type clbDescribeTargetsResponse struct {
+	Listeners []struct {
+		ListenerID   string      `json:"ListenerId"`
+		Protocol     string      `json:"Protocol"`
+		Port         int         `json:"Port"`
+		ListenerName string      `json:"ListenerName"`
+		Targets      []clbTarget `json:"Targets"`
+		Rules        []struct {
+			Targets []clbTarget `json:"Targets"`
+		} `json:"Rules"`
+	} `json:"Listeners"`
+}
+
+type clbDescribeTargetHealthResponse struct {
+	LoadBalancers []struct {
+		Listeners []struct {
+			ListenerID string `json:"ListenerId"`
+			Rules      []struct {
+				Targets []struct {
+					IP           string `json:"IP"`
+					Port         int    `json:"Port"`
+					HealthStatus bool   `json:"HealthStatus"`
+				} `json:"Targets"`
+			} `json:"Rules"`
+		} `json:"Listeners"`
+	} `json:"LoadBalancers"`
+}
+
+// ListCLBLoadBalancers 列出 CLB 负载均衡,Filters 支持 address(VIP)、name
+func (p *TencentProvider) ListCLBLoadBalancers(ctx context.Context, opts *provider.QueryOptions) ([]*model.LoadBalancer, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+
+	// 如果指定了区域,只查询该区域
+	if opts.Region != "" {
+		client, exists := p.clients[opts.Region]
+		if !exists {
+			return nil, fmt.Errorf("region %s not configured", opts.Region)
+		}
+		return client.listCLBs(ctx, opts.Filters)
+	}
+
+	// 查询所有区域
+	var allLoadBalancers []*model.LoadBalancer
+	for region, client := range p.clients {
+		lbs, err := client.listCLBs(ctx, opts.Filters)
+		if err != nil {
+			logx.Warn("Failed to query CLB in region %s, error %v", region, err)
+			continue
+		}
+		allLoadBalancers = append(allLoadBalancers, lbs...)
+	}
+
+	return allLoadBalancers, nil
+}
+
+// GetCLBLoadBalancer 获取 CLB 详情,包含监听、后端服务器及健康状态
+func (p *TencentProvider) GetCLBLoadBalancer(ctx context.Context, lbID string) (*model.LoadBalancer, error) {
+	// 遍历所有区域查找负载均衡
+	for region, client := range p.clients {
+		lbs, err := client.describeCLBs(ctx, map[string]any{"LoadBalancerIds": []string{lbID}})
+		if err != nil {
+			logx.Warn("Failed to describe CLB, region %s, error %v", region, err)
+			continue
+		}
+		if len(lbs) == 0 {
+			continue
+		}
+
+		lb := lbs[0]
+		if err := client.fillCLBBackends(ctx, lb); err != nil {
+			logx.Warn("Failed to describe CLB targets, id %s, error %v", lbID, err)
+		}
+		client.linkBackendInstances(ctx, lb)
+		return lb, nil
+	}
+
+	return nil, fmt.Errorf("load balancer %s not found in any region", lbID)
+}
+
+// listCLBs 查询单个区域的 CLB 列表
+func (c *Client) listCLBs(ctx context.Context, filters map[string]string) ([]*model.LoadBalancer, error) {
+	params := map[string]any{}
+	if address := filters["address"]; address != "" {
+		params["LoadBalancerVips"] = []string{address}
+	}
+	if name := filters["name"]; name != "" {
+		params["LoadBalancerName"] = name
+	}
+	return c.describeCLBs(ctx, params)
+}
+
+// describeCLBs 调用 DescribeLoadBalancers 并查询全部分页
+func (c *Client) describeCLBs(ctx context.Context, params map[string]any) ([]*model.LoadBalancer, error) {
+	var result []*model.LoadBalancer
+	for offset := 0; ; offset += clbPageSize {
+		params["Offset"] = offset
+		params["Limit"] = clbPageSize
+
+		var resp clbDescribeLoadBalancersResponse
+		if err := c.callAPI(ctx, clbService, clbVersion, "DescribeLoadBalancers", params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, lb := range resp.LoadBalancerSet {
+			result = append(result, convertCLB(&lb, c.Region))
+		}
+
+		if len(resp.LoadBalancerSet) < clbPageSize || len(result) >= resp.TotalCount {
+			break
+		}
+	}
+	return result, nil
+}
+
+// fillCLBBackends 查询 CLB 的监听、后端服务器及健康状态
+func (c *Client) fillCLBBackends(ctx context.Context, lb *model.LoadBalancer) error {
+	var targets clbDescribeTargetsResponse
+	if err := c.callAPI(ctx, clbService, clbVersion, "DescribeTargets", map[string]any{"LoadBalancerId": lb.ID}, &targets); err != nil {
+		return err
+	}
+
+	// 健康状态按 监听ID/IP:端口 索引,查询失败时健康状态为未知
+	health := make(map[string]string)
+	var healthResp clbDescribeTargetHealthResponse
+	if err := c.callAPI(ctx, clbService, clbVersion, "DescribeTargetHealth", map[string]any{"LoadBalancerIds": []string{lb.ID}}, &healthResp); err != nil {
+		logx.Warn("Failed to describe CLB target health, id %s, error %v", lb.ID, err)
+	}
+	for _, h := range healthResp.LoadBalancers {
+		for _, l := range h.Listeners {
+			for _, rule := range l.Rules {
+				for _, t := range rule.Targets {
+					status := model.BackendUnhealthy
+					if t.HealthStatus {
+						status = model.BackendHealthy
+					}
+					health[clbTargetKey(l.ListenerID, t.IP, t.Port)] = status
+				}
+			}
+		}
+	}
+
+	for _, l := range targets.Listeners {
+		lb.Listeners = append(lb.Listeners, &model.Listener{
+			ID:       l.ListenerID,
+			Name:     l.ListenerName,
+			Protocol: l.Protocol,
+			Port:     l.Port,
+		})
+
+		// 四层监听直接绑定后端,七层监听的后端绑定在转发规则上
+		listenerTargets := slices.Clone(l.Targets)
+		for _, rule := range l.Rules {
+			listenerTargets = append(listenerTargets, rule.Targets...)
+		}
+
+		for _, t := range listenerTargets {
+			ip := t.EniIP
+			if len(t.PrivateIPAddresses) > 0 {
+				ip = t.PrivateIPAddresses[0]
+			}
+			serverID := t.InstanceID
+			if serverID == "" {
+				serverID = ip
+			}
+
+			status, ok := health[clbTargetKey(l.ListenerID, ip, t.Port)]
+			if !ok {
+				status = model.BackendUnknown
+			}
+
+			lb.Backends = append(lb.Backends, &model.Backend{
+				ServerID:     serverID,
+				ServerType:   strings.ToLower(t.Type),
+				IP:           ip,
+				Port:         t.Port,
+				Weight:       t.Weight,
+				ListenerPort: l.Port,
+				Health:       status,
+			})
+		}
+	}
+
+	return nil
+}
+
+// linkBackendInstances 将 CVM 类型的后端关联到实例详情,查询失败时只记录日志
+func (c *Client) linkBackendInstances(ctx context.Context, lb *model.LoadBalancer) {
+	var ids []string
+	for _, b := range lb.Backends {
+		if b.ServerType == "cvm" && !slices.Contains(ids, b.ServerID) {
+			ids = append(ids, b.ServerID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	cvmClient, err := c.GetCVMClient()
+	if err != nil {
+		logx.Warn("Failed to get CVM client, region %s, error %v", c.Region, err)
+		return
+	}
+
+	instances := make(map[string]*model.Instance)
+	for start := 0; start < len(ids); start += clbPageSize {
+		end := min(start+clbPageSize, len(ids))
+
+		request := cvm.NewDescribeInstancesRequest()
+		for _, id := range ids[start:end] {
+			request.InstanceIds = append(request.InstanceIds, &id)
+		}
+		limit := int64(clbPageSize)
+		request.Limit = &limit
+
+		done := c.observe(ctx, "DescribeInstances")
+		response, err := cvmClient.DescribeInstances(request)
+		done(err)
+		if err != nil {
+			logx.Warn("Failed to query backend instances of load balancer %s, error %v", lb.ID, err)
+			return
+		}
+
+		for _, inst := range response.Response.InstanceSet {
+			instance := convertCVMToInstance(inst, c.Region)
+			instances[instance.ID] = instance
+		}
+	}
+
+	for _, b := range lb.Backends {
+		if inst, ok := instances[b.ServerID]; ok {
+			b.Instance = inst
+		}
+	}
+}
+
+// convertCLB 将 CLB 实例转换为统一的负载均衡模型
+func convertCLB(lb *clbLoadBalancer, region string) *model.LoadBalancer {
+	result := &model.LoadBalancer{
+		ID:        lb.LoadBalancerID,
+		Name:      lb.LoadBalancerName,
+		Provider:  "tencent",
+		Type:      "clb",
+		Region:    region,
+		Addresses: lb.LoadBalancerVips,
+		DNSName:   lb.Domain,
+		VpcID:     lb.VpcID,
+		Tags:      make(map[string]string),
+		Metadata:  make(map[string]any),
+	}
+
+	switch lb.LoadBalancerType {
+	case "OPEN":
+		result.AddressType = "internet"
+	case "INTERNAL":
+		result.AddressType = "intranet"
+	}
+
+	switch lb.Status {
+	case 0:
+		result.Status = "creating"
+	case 1:
+		result.Status = "active"
+	}
+
+	if t, err := time.Parse("2006-01-02 15:04:05", lb.CreateTime); err == nil {
+		result.CreatedAt = t
+	}
+	for _, tag := range lb.Tags {
+		result.Tags[tag.TagKey] = tag.TagValue
+	}
+
+	result.Metadata["forward"] = lb.Forward
+	result.Metadata["charge_type"] = lb.ChargeType
+	result.Metadata["address_ip_version"] = lb.AddressIPVersion
+
+	result.ConsoleURL = fmt.Sprintf("https://console.cloud.tencent.com/clb/detail?rid=%d&id=%s",
+		tencentRegionIDMap[region], lb.LoadBalancerID)
+	return result
+}
+
+func clbTargetKey(listenerID, ip string, port int) string {
+	return fmt.Sprintf("%s/%s:%d", listenerID, ip, port)
+}
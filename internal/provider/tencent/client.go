@@ -1,11 +1,15 @@
 package tencent
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
+	"github.com/eryajf/zenops/internal/metrics"
+	"github.com/eryajf/zenops/internal/tracing"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
 	cdb "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cdb/v20170320"
@@ -15,12 +19,15 @@ import (
 
 // Client 腾讯云客户端
 type Client struct {
-	SecretID  string
-	SecretKey string
-	Region    string
-	cvmClient *cvm.Client
-	cdbClient *cdb.Client
-	cosClient *cos.Client
+	SecretID     string
+	SecretKey    string
+	Region       string
+	Account      string // 账号名称,用于指标标签
+	cvmClient    *cvm.Client
+	cdbClient    *cdb.Client
+	cosClient    *cos.Client
+	apiClientsMu sync.Mutex                // 保护 apiClients,同一客户端可能被多个 goroutine 并发调用
+	apiClients   map[string]*common.Client // service -> 通用请求客户端
 }
 
 // NewClient 创建腾讯云客户端
@@ -32,6 +39,21 @@ func NewClient(secretID, secretKey, region string) *Client {
 	}
 }
 
+// observe 开始一次 API 调用的链路 span,返回的函数在调用结束时记录指标并结束 span
+func (c *Client) observe(ctx context.Context, api string) func(err error) {
+	start := time.Now()
+	_, span := tracing.StartWithKind(ctx, "tencent."+api, tracing.SpanKindClient,
+		tracing.String("cloud.provider", "tencent"),
+		tracing.String("cloud.account", c.Account),
+		tracing.String("cloud.region", c.Region))
+
+	return func(err error) {
+		metrics.ObserveCloudAPI("tencent", c.Account, c.Region, api, err, start)
+		span.RecordError(err)
+		span.End()
+	}
+}
+
 // GetCVMClient 获取 CVM 客户端
 func (c *Client) GetCVMClient() (*cvm.Client, error) {
 	if c.cvmClient != nil {
@@ -0,0 +1,62 @@
+package tencent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
+)
+
+// 部分产品 (CLB 等) 未引入独立 SDK,通过通用请求调用 API 3.0 接口
+
+// getCommonClient 获取指定服务的通用请求客户端,可并发调用
+func (c *Client) getCommonClient(service string) *common.Client {
+	c.apiClientsMu.Lock()
+	defer c.apiClientsMu.Unlock()
+
+	if client, ok := c.apiClients[service]; ok {
+		return client
+	}
+
+	credential := common.NewCredential(c.SecretID, c.SecretKey)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = service + ".tencentcloudapi.com"
+
+	client := common.NewCommonClient(credential, c.Region, cpf)
+
+	if c.apiClients == nil {
+		c.apiClients = make(map[string]*common.Client)
+	}
+	c.apiClients[service] = client
+	return client
+}
+
+// callAPI 调用 API 3.0 接口,并将响应中的 Response 字段解析到 result
+func (c *Client) callAPI(ctx context.Context, service, version, action string, params map[string]any, result any) error {
+	request := tchttp.NewCommonRequest(service, version, action)
+	if err := request.SetActionParameters(params); err != nil {
+		return fmt.Errorf("failed to set %s parameters: %w", action, err)
+	}
+	response := tchttp.NewCommonResponse()
+
+	done := c.observe(ctx, action)
+	err := c.getCommonClient(service).Send(request, response)
+	done(err)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", action, err)
+	}
+
+	var body struct {
+		Response json.RawMessage `json:"Response"`
+	}
+	if err := json.Unmarshal(response.GetBody(), &body); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", action, err)
+	}
+	if err := json.Unmarshal(body.Response, result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", action, err)
+	}
+	return nil
+}
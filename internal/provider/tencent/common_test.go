@@ -0,0 +1,28 @@
+package tencent
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestGetCommonClientConcurrent(t *testing.T) {
+	c := NewClient("id", "key", "ap-guangzhou")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.getCommonClient(fmt.Sprintf("service%d", i%4))
+		}()
+	}
+	wg.Wait()
+
+	if len(c.apiClients) != 4 {
+		t.Fatalf("expected 4 cached clients, got %d", len(c.apiClients))
+	}
+	if c.getCommonClient("service0") != c.getCommonClient("service0") {
+		t.Fatal("expected the cached client to be reused")
+	}
+}
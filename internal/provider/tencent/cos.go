@@ -16,7 +16,9 @@ func (c *Client) ListCOSBuckets(ctx context.Context, pageSize, pageNum int, filt
 	logx.Debug("Querying Tencent COS buckets")
 
 	// COS SDK 的 GetService 方法列出所有 buckets
+	done := c.observe(ctx, "GetService")
 	result, _, err := cosClient.Service.Get(ctx)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list buckets: %w", err)
 	}
@@ -81,13 +83,17 @@ func (c *Client) GetCOSBucket(ctx context.Context, bucketName string) (*model.OS
 	bucketClient := c.GetCOSBucketClient(bucketName)
 
 	// 获取 bucket ACL
+	done := c.observe(ctx, "GetBucketACL")
 	aclResult, _, err := bucketClient.Bucket.GetACL(ctx)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get bucket ACL: %w", err)
 	}
 
 	// 获取 bucket location
+	done = c.observe(ctx, "GetBucketLocation")
 	locationResult, _, err := bucketClient.Bucket.GetLocation(ctx)
+	done(err)
 	if err != nil {
 		logx.Warn("Failed to get bucket location: %v", err)
 	}
@@ -8,6 +8,7 @@ import (
 	"cnb.cool/zhiqiangwang/pkg/logx"
 	"github.com/eryajf/zenops/internal/model"
 	"github.com/eryajf/zenops/internal/provider"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
 )
 
@@ -88,14 +89,32 @@ func (p *TencentProvider) listCVMInstancesInRegion(ctx context.Context, client *
 		request.Offset = &offset
 	}
 
-	response, err := cvmClient.DescribeInstances(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to describe instances: %w", err)
+	// 按 IP 查询时依次匹配私网 IP、公网 IP (含 EIP)
+	filterNames := []string{""}
+	ip := opts.Filters["ip"]
+	if ip != "" {
+		filterNames = []string{"private-ip-address", "public-ip-address"}
 	}
 
 	var instances []*model.Instance
-	for _, inst := range response.Response.InstanceSet {
-		instances = append(instances, convertCVMToInstance(inst, client.Region))
+	for _, name := range filterNames {
+		if name != "" {
+			request.Filters = []*cvm.Filter{{Name: common.StringPtr(name), Values: common.StringPtrs([]string{ip})}}
+		}
+
+		done := client.observe(ctx, "DescribeInstances")
+		response, err := cvmClient.DescribeInstances(request)
+		done(err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe instances: %w", err)
+		}
+
+		for _, inst := range response.Response.InstanceSet {
+			instances = append(instances, convertCVMToInstance(inst, client.Region))
+		}
+		if len(instances) > 0 {
+			break
+		}
 	}
 
 	return instances, nil
@@ -116,7 +135,9 @@ func (p *TencentProvider) GetCVMInstance(ctx context.Context, instanceID string)
 		request := cvm.NewDescribeInstancesRequest()
 		request.InstanceIds = []*string{&instanceID}
 
+		done := client.observe(ctx, "DescribeInstances")
 		response, err := cvmClient.DescribeInstances(request)
+		done(err)
 		if err != nil {
 			logx.Warn("Failed to describe instance, region %s, error %v", region, err)
 			continue
@@ -201,13 +222,22 @@ func convertCVMToInstance(inst *cvm.Instance, region string) *model.Instance {
 	// VPC 信息
 	if inst.VirtualPrivateCloud != nil {
 		if inst.VirtualPrivateCloud.VpcId != nil {
+			instance.VpcID = *inst.VirtualPrivateCloud.VpcId
 			instance.Metadata["vpc_id"] = *inst.VirtualPrivateCloud.VpcId
 		}
 		if inst.VirtualPrivateCloud.SubnetId != nil {
+			instance.SubnetID = *inst.VirtualPrivateCloud.SubnetId
 			instance.Metadata["subnet_id"] = *inst.VirtualPrivateCloud.SubnetId
 		}
 	}
 
+	// 安全组
+	for _, sgID := range inst.SecurityGroupIds {
+		if sgID != nil {
+			instance.SecurityGroupIDs = append(instance.SecurityGroupIDs, *sgID)
+		}
+	}
+
 	// 计费模式
 	if inst.InstanceChargeType != nil {
 		instance.Metadata["charge_type"] = *inst.InstanceChargeType
@@ -0,0 +1,197 @@
+package tencent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	sdkerrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+)
+
+// DNSPod 为全局服务,与区域无关
+
+const (
+	dnspodService = "dnspod"
+	dnspodVersion = "2021-03-23"
+
+	dnspodPageSize = 3000
+)
+
+type dnspodDomain struct {
+	DomainID    int    `json:"DomainId"`
+	Name        string `json:"Name"`
+	Status      string `json:"Status"` // ENABLE, PAUSE, SPAM
+	RecordCount int    `json:"RecordCount"`
+	CreatedOn   string `json:"CreatedOn"`
+}
+
+type dnspodDescribeDomainListResponse struct {
+	DomainCountInfo struct {
+		AllTotal int `json:"AllTotal"`
+	} `json:"DomainCountInfo"`
+	DomainList []dnspodDomain `json:"DomainList"`
+}
+
+type dnspodRecord struct {
+	RecordID uint64 `json:"RecordId"`
+	Name     string `json:"Name"` // 主机记录
+	Type     string `json:"Type"`
+	Value    string `json:"Value"`
+	TTL      int    `json:"TTL"`
+	Line     string `json:"Line"`
+	Status   string `json:"Status"`
+}
+
+type dnspodDescribeRecordListResponse struct {
+	RecordCountInfo struct {
+		TotalCount int `json:"TotalCount"`
+	} `json:"RecordCountInfo"`
+	RecordList []dnspodRecord `json:"RecordList"`
+}
+
+// ListDomains 列出 DNSPod 托管的域名,Filters 支持 keyword
+func (p *TencentProvider) ListDomains(ctx context.Context, opts *provider.QueryOptions) ([]*model.Domain, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]any{"Limit": dnspodPageSize}
+	if keyword := opts.Filters["keyword"]; keyword != "" {
+		params["Keyword"] = keyword
+	}
+
+	var domains []*model.Domain
+	for offset := 0; ; offset += dnspodPageSize {
+		params["Offset"] = offset
+
+		var resp dnspodDescribeDomainListResponse
+		if err := client.callAPI(ctx, dnspodService, dnspodVersion, "DescribeDomainList", params, &resp); err != nil {
+			if isDNSPodNoData(err) {
+				break
+			}
+			return nil, err
+		}
+
+		for _, d := range resp.DomainList {
+			domain := &model.Domain{
+				ID:          strconv.Itoa(d.DomainID),
+				Name:        d.Name,
+				Provider:    "tencent",
+				Account:     client.Account,
+				RecordCount: d.RecordCount,
+				Status:      d.Status,
+			}
+			if t, err := time.Parse("2006-01-02 15:04:05", d.CreatedOn); err == nil {
+				domain.CreatedAt = t
+			}
+			domains = append(domains, domain)
+		}
+
+		if len(resp.DomainList) < dnspodPageSize || len(domains) >= resp.DomainCountInfo.AllTotal {
+			break
+		}
+	}
+	return domains, nil
+}
+
+// ListDNSRecords 列出域名的解析记录,Filters 中 domain 必填,支持 rr、type、value(精确匹配)
+func (p *TencentProvider) ListDNSRecords(ctx context.Context, opts *provider.QueryOptions) ([]*model.DNSRecord, error) {
+	if opts == nil || opts.Filters["domain"] == "" {
+		return nil, fmt.Errorf("domain filter is required")
+	}
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+
+	domain := opts.Filters["domain"]
+	params := map[string]any{
+		"Domain": domain,
+		"Limit":  dnspodPageSize,
+	}
+	if rr := opts.Filters["rr"]; rr != "" {
+		params["Subdomain"] = rr
+	}
+	if recordType := opts.Filters["type"]; recordType != "" {
+		params["RecordType"] = strings.ToUpper(recordType)
+	}
+	// Keyword 同时模糊匹配主机记录和记录值,结果在本地精确过滤
+	if value := opts.Filters["value"]; value != "" {
+		params["Keyword"] = value
+	}
+
+	var records []*model.DNSRecord
+	for offset := 0; ; offset += dnspodPageSize {
+		params["Offset"] = offset
+
+		var resp dnspodDescribeRecordListResponse
+		if err := client.callAPI(ctx, dnspodService, dnspodVersion, "DescribeRecordList", params, &resp); err != nil {
+			if isDNSPodNoData(err) {
+				break
+			}
+			return nil, err
+		}
+
+		for _, r := range resp.RecordList {
+			record := &model.DNSRecord{
+				ID:       strconv.FormatUint(r.RecordID, 10),
+				Domain:   domain,
+				RR:       r.Name,
+				FQDN:     recordFQDN(r.Name, domain),
+				Type:     r.Type,
+				Value:    r.Value,
+				TTL:      r.TTL,
+				Line:     r.Line,
+				Status:   r.Status,
+				Provider: "tencent",
+				Account:  client.Account,
+			}
+			if matchDNSRecord(record, opts.Filters) {
+				records = append(records, record)
+			}
+		}
+
+		if len(resp.RecordList) < dnspodPageSize || offset+len(resp.RecordList) >= resp.RecordCountInfo.TotalCount {
+			break
+		}
+	}
+	return records, nil
+}
+
+// isDNSPodNoData 列表为空时 DNSPod 返回 ResourceNotFound.NoDataOfXxx 错误
+func isDNSPodNoData(err error) bool {
+	var sdkErr *sdkerrors.TencentCloudSDKError
+	return errors.As(err, &sdkErr) && strings.HasPrefix(sdkErr.GetCode(), "ResourceNotFound.NoData")
+}
+
+// recordFQDN 拼接完整域名
+func recordFQDN(rr, domain string) string {
+	if rr == "" || rr == "@" {
+		return domain
+	}
+	return rr + "." + domain
+}
+
+// matchDNSRecord 按 rr、type、value 精确过滤解析记录
+func matchDNSRecord(record *model.DNSRecord, filters map[string]string) bool {
+	if rr := filters["rr"]; rr != "" && !strings.EqualFold(record.RR, rr) {
+		return false
+	}
+	if recordType := filters["type"]; recordType != "" && !strings.EqualFold(record.Type, recordType) {
+		return false
+	}
+	if value := filters["value"]; value != "" &&
+		!strings.EqualFold(strings.TrimSuffix(record.Value, "."), strings.TrimSuffix(value, ".")) {
+		return false
+	}
+	return true
+}
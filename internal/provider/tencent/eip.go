@@ -0,0 +1,145 @@
+package tencent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+// 公网 IP: 弹性公网 IP 与 CVM 普通公网 IP 均通过私有网络 DescribeAddresses 查询
+
+// eipAddressTypes DescribeAddresses 默认只返回 EIP,需显式指定全部地址类型
+var eipAddressTypes = []string{"WAN_IP", "EIP", "AnycastEIP", "HighQualityEIP", "AntiDDoSEIP"}
+
+type vpcAddress struct {
+	AddressID               string   `json:"AddressId"`
+	AddressName             string   `json:"AddressName"`
+	AddressIP               string   `json:"AddressIp"`
+	AddressStatus           string   `json:"AddressStatus"` // CREATING, BINDING, BIND, UNBINDING, UNBIND, OFFLINING, BIND_ENI
+	AddressType             string   `json:"AddressType"`   // WanIP, EIP, AnycastEIP, HighQualityEIP, AntiDDoSEIP
+	InstanceID              string   `json:"InstanceId"`
+	InstanceType            string   `json:"InstanceType"` // CVM, NAT, HAVIP, ENI, CLB
+	NetworkInterfaceID      string   `json:"NetworkInterfaceId"`
+	Bandwidth               int      `json:"Bandwidth"`
+	InternetChargeType      string   `json:"InternetChargeType"` // BANDWIDTH_PREPAID_BY_MONTH, TRAFFIC_POSTPAID_BY_HOUR 等
+	InternetServiceProvider string   `json:"InternetServiceProvider"`
+	IsArrears               bool     `json:"IsArrears"`
+	IsBlocked               bool     `json:"IsBlocked"`
+	CreatedTime             string   `json:"CreatedTime"`
+	DeadlineDate            string   `json:"DeadlineDate"`
+	TagSet                  []vpcTag `json:"TagSet"`
+}
+
+type vpcDescribeAddressesResponse struct {
+	TotalCount int          `json:"TotalCount"`
+	AddressSet []vpcAddress `json:"AddressSet"`
+}
+
+// ListEIPs 列出弹性公网 IP 及普通公网 IP,Filters 支持 address、status、instance_id、type
+func (p *TencentProvider) ListEIPs(ctx context.Context, opts *provider.QueryOptions) ([]*model.EIP, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	return collectRegions(p, opts, "public IPs", func(client *Client) ([]*model.EIP, error) {
+		return client.describeAddresses(ctx, opts.Filters)
+	})
+}
+
+// describeAddresses 调用 DescribeAddresses 并查询全部分页
+func (c *Client) describeAddresses(ctx context.Context, filters map[string]string) ([]*model.EIP, error) {
+	addressTypes := eipAddressTypes
+	switch strings.ToLower(filters["type"]) {
+	case model.EIPTypeEIP:
+		addressTypes = eipAddressTypes[1:]
+	case model.EIPTypePublicIP:
+		addressTypes = eipAddressTypes[:1]
+	}
+
+	apiFilters := []map[string]any{{"Name": "address-type", "Values": addressTypes}}
+	if address := filters["address"]; address != "" {
+		apiFilters = append(apiFilters, map[string]any{"Name": "address-ip", "Values": []string{address}})
+	}
+	if instanceID := filters["instance_id"]; instanceID != "" {
+		apiFilters = append(apiFilters, map[string]any{"Name": "instance-id", "Values": []string{instanceID}})
+	}
+	switch filters["status"] {
+	case model.EIPStatusInUse:
+		apiFilters = append(apiFilters, map[string]any{"Name": "address-status", "Values": []string{"BIND", "BIND_ENI"}})
+	case model.EIPStatusAvailable:
+		apiFilters = append(apiFilters, map[string]any{"Name": "address-status", "Values": []string{"UNBIND"}})
+	}
+	params := map[string]any{"Filters": apiFilters}
+
+	result := make([]*model.EIP, 0)
+	for offset := 0; ; offset += vpcPageSize {
+		params["Offset"] = offset
+		params["Limit"] = vpcPageSize
+
+		var resp vpcDescribeAddressesResponse
+		if err := c.callAPI(ctx, vpcService, vpcVersion, "DescribeAddresses", params, &resp); err != nil {
+			return nil, err
+		}
+
+		for i := range resp.AddressSet {
+			result = append(result, c.convertAddress(&resp.AddressSet[i]))
+		}
+
+		if len(resp.AddressSet) < vpcPageSize || len(result) >= resp.TotalCount {
+			break
+		}
+	}
+
+	logx.Debug("Listed Tencent public IPs, count %d, region %s", len(result), c.Region)
+	return result, nil
+}
+
+// convertAddress 将 DescribeAddresses 返回的地址转换为统一模型
+func (c *Client) convertAddress(a *vpcAddress) *model.EIP {
+	eip := &model.EIP{
+		ID:                 a.AddressID,
+		Address:            a.AddressIP,
+		Name:               a.AddressName,
+		Provider:           "tencent",
+		Account:            c.Account,
+		Region:             c.Region,
+		Type:               model.EIPTypeEIP,
+		Bandwidth:          a.Bandwidth,
+		InternetChargeType: a.InternetChargeType,
+		ChargeType:         "PostPaid",
+		ISP:                a.InternetServiceProvider,
+		InstanceID:         firstNonEmpty(a.InstanceID, a.NetworkInterfaceID),
+		InstanceType:       strings.ToLower(a.InstanceType),
+		Locked:             a.IsArrears || a.IsBlocked,
+		Tags:               convertVPCTags(a.TagSet),
+		ConsoleURL: fmt.Sprintf("https://console.cloud.tencent.com/cvm/eip?rid=%d",
+			tencentRegionID(c.Region)),
+	}
+	if a.AddressType == "WanIP" {
+		eip.Type = model.EIPTypePublicIP
+	}
+	if strings.Contains(a.InternetChargeType, "PREPAID") {
+		eip.ChargeType = "PrePaid"
+	}
+
+	switch a.AddressStatus {
+	case "BIND", "BIND_ENI":
+		eip.Status = model.EIPStatusInUse
+	case "UNBIND":
+		eip.Status = model.EIPStatusAvailable
+	default:
+		eip.Status = strings.ToLower(a.AddressStatus)
+	}
+
+	if t, err := time.Parse("2006-01-02T15:04:05Z", a.CreatedTime); err == nil {
+		eip.CreatedAt = t
+	}
+	if t, err := time.Parse("2006-01-02 15:04:05", a.DeadlineDate); err == nil {
+		eip.ExpiredAt = &t
+	}
+	return eip
+}
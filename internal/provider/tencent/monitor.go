@@ -0,0 +1,162 @@
+package tencent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+// 云监控为区域服务,使用资源所在区域的客户端查询
+
+const (
+	monitorService = "monitor"
+	monitorVersion = "2018-07-24"
+)
+
+// monitorMetric 统一指标对应的云监控指标,多个指标时按时间戳求和,scale 将原始单位换算为统一单位
+type monitorMetric struct {
+	names []string
+	unit  string
+	scale float64
+}
+
+// monitorNamespaces 资源类型对应的云监控命名空间
+var monitorNamespaces = map[string]string{
+	model.MetricResourceInstance: "QCE/CVM",
+	model.MetricResourceDatabase: "QCE/CDB",
+}
+
+// monitorMetrics 资源类型支持的指标,按展示顺序排列
+var monitorMetrics = map[string][]struct {
+	metric string
+	monitorMetric
+}{
+	model.MetricResourceInstance: {
+		{model.MetricCPU, monitorMetric{[]string{"CpuUsage"}, model.MetricUnitPercent, 1}},
+		{model.MetricMemory, monitorMetric{[]string{"MemUsage"}, model.MetricUnitPercent, 1}},
+		// 流量单位为 Mbps
+		{model.MetricNetworkIn, monitorMetric{[]string{"WanIntraffic", "LanIntraffic"}, model.MetricUnitBPS, 1e6}},
+		{model.MetricNetworkOut, monitorMetric{[]string{"WanOuttraffic", "LanOuttraffic"}, model.MetricUnitBPS, 1e6}},
+	},
+	model.MetricResourceDatabase: {
+		{model.MetricCPU, monitorMetric{[]string{"CpuUseRate"}, model.MetricUnitPercent, 1}},
+		{model.MetricMemory, monitorMetric{[]string{"MemoryUseRate"}, model.MetricUnitPercent, 1}},
+		{model.MetricDisk, monitorMetric{[]string{"VolumeRate"}, model.MetricUnitPercent, 1}},
+		// 流量单位为 Byte/s
+		{model.MetricNetworkIn, monitorMetric{[]string{"BytesReceived"}, model.MetricUnitBPS, 8}},
+		{model.MetricNetworkOut, monitorMetric{[]string{"BytesSent"}, model.MetricUnitBPS, 8}},
+	},
+}
+
+type monitorGetMonitorDataResponse struct {
+	DataPoints []struct {
+		Timestamps []float64 `json:"Timestamps"` // 秒
+		Values     []float64 `json:"Values"`
+	} `json:"DataPoints"`
+}
+
+// GetMetrics 查询 CVM 或 CDB 实例的云监控指标,未指定区域时先查找实例所在区域
+func (p *TencentProvider) GetMetrics(ctx context.Context, query *provider.MetricQuery) ([]*model.MetricSeries, error) {
+	if query == nil || query.ResourceID == "" {
+		return nil, fmt.Errorf("resource id is required")
+	}
+	supported, ok := monitorMetrics[query.ResourceType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported resource type %q, must be instance or database", query.ResourceType)
+	}
+	if query.ResourceType == model.MetricResourceDatabase && strings.HasPrefix(query.ResourceID, "crs-") {
+		return nil, fmt.Errorf("metrics for Redis instance %s are not supported", query.ResourceID)
+	}
+
+	region := query.Region
+	if region == "" {
+		if query.ResourceType == model.MetricResourceInstance {
+			instance, err := p.GetInstance(ctx, query.ResourceID)
+			if err != nil {
+				return nil, err
+			}
+			region = instance.Region
+		} else {
+			database, err := p.GetDatabase(ctx, query.ResourceID)
+			if err != nil {
+				return nil, err
+			}
+			region = database.Region
+		}
+	}
+	client, ok := p.clients[region]
+	if !ok {
+		return nil, fmt.Errorf("region %s not configured", region)
+	}
+
+	metrics := query.Metrics
+	if len(metrics) == 0 {
+		for _, m := range supported {
+			metrics = append(metrics, m.metric)
+		}
+	}
+
+	series := make([]*model.MetricSeries, 0, len(metrics))
+	for _, name := range metrics {
+		var metric *monitorMetric
+		for i := range supported {
+			if supported[i].metric == name {
+				metric = &supported[i].monitorMetric
+				break
+			}
+		}
+		if metric == nil {
+			return nil, fmt.Errorf("metric %s is not supported for %s", name, query.ResourceType)
+		}
+
+		groups := make([][]model.MetricPoint, 0, len(metric.names))
+		for _, monitorName := range metric.names {
+			points, err := client.getMonitorData(ctx, monitorNamespaces[query.ResourceType], monitorName, query.ResourceID, query.Start, query.End, query.Period)
+			if err != nil {
+				return nil, err
+			}
+			for i := range points {
+				points[i].Value *= metric.scale
+			}
+			groups = append(groups, points)
+		}
+		series = append(series, &model.MetricSeries{
+			Metric: name,
+			Unit:   metric.unit,
+			Period: query.Period,
+			Points: model.SumMetricPoints(groups...),
+		})
+	}
+	return series, nil
+}
+
+// getMonitorData 查询单个云监控指标的数据点
+func (c *Client) getMonitorData(ctx context.Context, namespace, metricName, instanceID string, start, end time.Time, period int) ([]model.MetricPoint, error) {
+	var resp monitorGetMonitorDataResponse
+	if err := c.callAPI(ctx, monitorService, monitorVersion, "GetMonitorData", map[string]any{
+		"Namespace":  namespace,
+		"MetricName": metricName,
+		"Period":     period,
+		"StartTime":  start.Format(time.RFC3339),
+		"EndTime":    end.Format(time.RFC3339),
+		"Instances": []map[string]any{{
+			"Dimensions": []map[string]string{{"Name": "InstanceId", "Value": instanceID}},
+		}},
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	points := make([]model.MetricPoint, 0)
+	for _, d := range resp.DataPoints {
+		for i, ts := range d.Timestamps {
+			if i < len(d.Values) {
+				points = append(points, model.MetricPoint{Timestamp: time.Unix(int64(ts), 0), Value: d.Values[i]})
+			}
+		}
+	}
+	return points, nil
+}
@@ -3,6 +3,7 @@ package tencent
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
 	"github.com/eryajf/zenops/internal/model"
@@ -52,6 +53,9 @@ func (p *TencentProvider) Initialize(config map[string]any) error {
 	p.secretID = secretID
 	p.secretKey = secretKey
 
+	// 账号名称(可选)
+	account, _ := config["account"].(string)
+
 	// 初始化每个区域的客户端
 	for _, r := range regions {
 		region, ok := r.(string)
@@ -60,7 +64,9 @@ func (p *TencentProvider) Initialize(config map[string]any) error {
 		}
 
 		p.regions = append(p.regions, region)
-		p.clients[region] = NewClient(secretID, secretKey, region)
+		client := NewClient(secretID, secretKey, region)
+		client.Account = account
+		p.clients[region] = client
 
 		logx.Debug("%s", "Initialized Tencent client for region "+region)
 	}
@@ -80,13 +86,23 @@ func (p *TencentProvider) GetInstance(ctx context.Context, instanceID string) (*
 	return p.GetCVMInstance(ctx, instanceID)
 }
 
-// ListDatabases 列出数据库
+// ListDatabases 列出 CDB 数据库
+// Filters 中 engine 为 redis 时改为查询 Redis,name、address 用于过滤 Redis
 func (p *TencentProvider) ListDatabases(ctx context.Context, opts *provider.QueryOptions) ([]*model.Database, error) {
-	return p.ListCDBInstances(ctx, opts)
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	return collectRegions(p, opts, "databases", func(client *Client) ([]*model.Database, error) {
+		return p.listDatabasesInRegion(ctx, client, opts)
+	})
 }
 
 // GetDatabase 获取数据库详情
 func (p *TencentProvider) GetDatabase(ctx context.Context, dbID string) (*model.Database, error) {
+	// Redis 实例 ID 以 crs- 开头
+	if strings.HasPrefix(dbID, "crs-") {
+		return p.GetRedisInstance(ctx, dbID)
+	}
 	return p.GetCDBInstance(ctx, dbID)
 }
 
@@ -114,6 +130,16 @@ func (p *TencentProvider) GetOSSBucket(ctx context.Context, bucketName string) (
 	return nil, fmt.Errorf("no clients available")
 }
 
+// ListLoadBalancers 列出负载均衡
+func (p *TencentProvider) ListLoadBalancers(ctx context.Context, opts *provider.QueryOptions) ([]*model.LoadBalancer, error) {
+	return p.ListCLBLoadBalancers(ctx, opts)
+}
+
+// GetLoadBalancer 获取负载均衡详情
+func (p *TencentProvider) GetLoadBalancer(ctx context.Context, lbID string) (*model.LoadBalancer, error) {
+	return p.GetCLBLoadBalancer(ctx, lbID)
+}
+
 // HealthCheck 健康检查
 func (p *TencentProvider) HealthCheck(ctx context.Context) error {
 	if len(p.clients) == 0 {
@@ -0,0 +1,195 @@
+package tencent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+// 云数据库 Redis,实例数量通常不多,列表接口一次查询全部分页后在本地过滤
+
+const (
+	redisService = "redis"
+	redisVersion = "2018-04-12"
+
+	redisPageSize = 100
+)
+
+type redisInstance struct {
+	InstanceID          string `json:"InstanceId"`
+	InstanceName        string `json:"InstanceName"`
+	Status              int    `json:"Status"`
+	WanIP               string `json:"WanIp"` // 内网 VIP
+	Port                int    `json:"Port"`
+	WanAddress          string `json:"WanAddress"` // 外网地址,host:port
+	CurrentRedisVersion string `json:"CurrentRedisVersion"`
+	Createtime          string `json:"Createtime"`
+	InstanceTags        []struct {
+		TagKey   string `json:"TagKey"`
+		TagValue string `json:"TagValue"`
+	} `json:"InstanceTags"`
+}
+
+type redisDescribeInstancesResponse struct {
+	TotalCount  int             `json:"TotalCount"`
+	InstanceSet []redisInstance `json:"InstanceSet"`
+}
+
+// redisStatusMap 实例状态
+var redisStatusMap = map[int]string{
+	0:  "Initializing",
+	1:  "Processing",
+	2:  "Running",
+	-2: "Isolated",
+	-3: "Deleting",
+}
+
+// ListRedisInstances 列出 Redis 实例,Filters 支持 name(模糊匹配)、address(连接地址)
+func (p *TencentProvider) ListRedisInstances(ctx context.Context, opts *provider.QueryOptions) ([]*model.Database, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	return collectRegions(p, opts, "Redis", func(client *Client) ([]*model.Database, error) {
+		return client.listRedis(ctx, opts)
+	})
+}
+
+// listDatabasesInRegion 查询单个区域的数据库实例,engine 为 redis 时查询 Redis,否则查询 CDB
+func (p *TencentProvider) listDatabasesInRegion(ctx context.Context, client *Client, opts *provider.QueryOptions) ([]*model.Database, error) {
+	if strings.EqualFold(opts.Filters["engine"], "redis") {
+		return client.listRedis(ctx, opts)
+	}
+	return p.listCDBInstancesInRegion(ctx, client, opts)
+}
+
+// GetRedisInstance 获取 Redis 实例详情
+func (p *TencentProvider) GetRedisInstance(ctx context.Context, instanceID string) (*model.Database, error) {
+	// 遍历所有区域查找实例
+	for region, client := range p.clients {
+		instances, err := client.describeRedis(ctx, map[string]any{"InstanceId": instanceID})
+		if err != nil {
+			logx.Warn("Failed to describe Redis, instance_id %s, region %s, error %v", instanceID, region, err)
+			continue
+		}
+		if len(instances) > 0 {
+			return convertRedisToDatabase(&instances[0], region), nil
+		}
+	}
+
+	return nil, fmt.Errorf("redis instance %s not found in any region", instanceID)
+}
+
+// listRedis 查询单个区域的 Redis 实例并按名称、连接地址过滤,PageSize > 0 时按 PageNum 返回对应分页
+func (c *Client) listRedis(ctx context.Context, opts *provider.QueryOptions) ([]*model.Database, error) {
+	instances, err := c.describeRedis(ctx, map[string]any{})
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(opts.Filters["name"])
+	address := opts.Filters["address"]
+
+	var databases []*model.Database
+	for i := range instances {
+		inst := &instances[i]
+		if name != "" && !strings.Contains(strings.ToLower(inst.InstanceName), name) {
+			continue
+		}
+		if address != "" && !inst.matchAddress(address) {
+			continue
+		}
+		databases = append(databases, convertRedisToDatabase(inst, c.Region))
+	}
+
+	logx.Debug("Listed Tencent Redis instances, count %d, region %s", len(databases), c.Region)
+	return pageDatabases(databases, opts.PageSize, opts.PageNum), nil
+}
+
+// describeRedis 调用 DescribeInstances 并查询全部分页
+func (c *Client) describeRedis(ctx context.Context, params map[string]any) ([]redisInstance, error) {
+	var result []redisInstance
+	for offset := 0; ; offset += redisPageSize {
+		params["Offset"] = offset
+		params["Limit"] = redisPageSize
+
+		var resp redisDescribeInstancesResponse
+		if err := c.callAPI(ctx, redisService, redisVersion, "DescribeInstances", params, &resp); err != nil {
+			return nil, err
+		}
+
+		result = append(result, resp.InstanceSet...)
+		if len(resp.InstanceSet) < redisPageSize || len(result) >= resp.TotalCount {
+			break
+		}
+	}
+	return result, nil
+}
+
+// matchAddress 连接地址是否属于该实例,支持内网 VIP、外网域名及 host:port
+func (inst *redisInstance) matchAddress(address string) bool {
+	host := strings.ToLower(address)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	wanHost := strings.ToLower(inst.WanAddress)
+	if h, _, err := net.SplitHostPort(wanHost); err == nil {
+		wanHost = h
+	}
+	return host == inst.WanIP || (wanHost != "" && host == wanHost) || host == strings.ToLower(inst.InstanceID)
+}
+
+// convertRedisToDatabase 将腾讯云 Redis 实例转换为统一的 Database 模型
+func convertRedisToDatabase(inst *redisInstance, region string) *model.Database {
+	database := &model.Database{
+		ID:            inst.InstanceID,
+		Name:          inst.InstanceName,
+		Provider:      "tencent",
+		Region:        region,
+		Engine:        "redis",
+		EngineVersion: inst.CurrentRedisVersion,
+		Status:        redisStatusMap[inst.Status],
+		Endpoint:      inst.WanIP,
+		Port:          inst.Port,
+		Tags:          make(map[string]string),
+	}
+
+	if database.Status == "" {
+		database.Status = fmt.Sprintf("%d", inst.Status)
+	}
+	if t, err := time.Parse("2006-01-02 15:04:05", inst.Createtime); err == nil {
+		database.CreatedAt = t
+	}
+	for _, tag := range inst.InstanceTags {
+		database.Tags[tag.TagKey] = tag.TagValue
+	}
+	if database.Name == "" {
+		database.Name = database.ID
+	}
+
+	database.ConsoleURL = fmt.Sprintf("https://console.cloud.tencent.com/redis/instance/m?rid=%d&insid=%s",
+		tencentRegionID(region), database.ID)
+
+	return database
+}
+
+// pageDatabases 按分页参数截取结果,pageSize <= 0 时返回全部
+func pageDatabases(databases []*model.Database, pageSize, pageNum int) []*model.Database {
+	if pageSize <= 0 {
+		return databases
+	}
+	if pageNum <= 0 {
+		pageNum = 1
+	}
+	start := (pageNum - 1) * pageSize
+	if start >= len(databases) {
+		return []*model.Database{}
+	}
+	end := min(start+pageSize, len(databases))
+	return databases[start:end]
+}
@@ -0,0 +1,118 @@
+package tencent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+// SSL 证书服务为全局服务,与区域无关
+
+const (
+	sslService = "ssl"
+	sslVersion = "2019-12-05"
+
+	sslPageSize = 1000
+)
+
+// sslTimeZone 证书有效期为北京时间
+var sslTimeZone = time.FixedZone("CST", 8*3600)
+
+// sslStatusNames 证书状态
+var sslStatusNames = map[int]string{
+	0:  "审核中",
+	1:  "已通过",
+	2:  "审核失败",
+	3:  "已过期",
+	4:  "已添加 DNS 记录",
+	5:  "企业证书待提交",
+	6:  "订单取消中",
+	7:  "已取消",
+	8:  "已提交资料待上传确认函",
+	9:  "证书吊销中",
+	10: "已吊销",
+	11: "重颁发中",
+	12: "待上传吊销确认函",
+	13: "免费证书待提交资料",
+}
+
+type sslCertificate struct {
+	CertificateID  string   `json:"CertificateId"`
+	Alias          string   `json:"Alias"`
+	Domain         string   `json:"Domain"`
+	ProductZhName  string   `json:"ProductZhName"`
+	Status         int      `json:"Status"`
+	CertBeginTime  string   `json:"CertBeginTime"`
+	CertEndTime    string   `json:"CertEndTime"`
+	SubjectAltName []string `json:"SubjectAltName"`
+	BoundResource  []string `json:"BoundResource"`
+}
+
+type sslDescribeCertificatesResponse struct {
+	TotalCount   int              `json:"TotalCount"`
+	Certificates []sslCertificate `json:"Certificates"`
+}
+
+// ListCertificates 列出 SSL 证书服务中的服务端证书,Filters 支持 keyword(证书 ID、备注名或域名)
+func (p *TencentProvider) ListCertificates(ctx context.Context, opts *provider.QueryOptions) ([]*model.Certificate, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	client, err := p.globalClient()
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]any{
+		"Limit":           sslPageSize,
+		"CertificateType": "SVR",
+	}
+	if keyword := opts.Filters["keyword"]; keyword != "" {
+		params["SearchKey"] = keyword
+	}
+
+	var certs []*model.Certificate
+	for offset := 0; ; offset += sslPageSize {
+		params["Offset"] = offset
+
+		var resp sslDescribeCertificatesResponse
+		if err := client.callAPI(ctx, sslService, sslVersion, "DescribeCertificates", params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, c := range resp.Certificates {
+			cert := &model.Certificate{
+				ID:         c.CertificateID,
+				Name:       c.Alias,
+				Provider:   "tencent",
+				Account:    client.Account,
+				Source:     model.CertSourceCloud,
+				Domain:     c.Domain,
+				Issuer:     c.ProductZhName,
+				Status:     firstNonEmpty(sslStatusNames[c.Status], fmt.Sprintf("%d", c.Status)),
+				Products:   c.BoundResource,
+				ConsoleURL: "https://console.cloud.tencent.com/ssl/detail/" + c.CertificateID,
+			}
+			for _, san := range c.SubjectAltName {
+				if san != c.Domain {
+					cert.SANs = append(cert.SANs, san)
+				}
+			}
+			if t, err := time.ParseInLocation("2006-01-02 15:04:05", c.CertBeginTime, sslTimeZone); err == nil {
+				cert.NotBefore = t
+			}
+			if t, err := time.ParseInLocation("2006-01-02 15:04:05", c.CertEndTime, sslTimeZone); err == nil {
+				cert.NotAfter = t
+			}
+			certs = append(certs, cert)
+		}
+
+		if len(resp.Certificates) < sslPageSize || offset+len(resp.Certificates) >= resp.TotalCount {
+			break
+		}
+	}
+	return certs, nil
+}
@@ -0,0 +1,448 @@
+package tencent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+)
+
+// 私有网络: VPC、子网、安全组及其规则
+// 腾讯云安全组不属于 VPC,多个安全组按绑定顺序依次匹配,未命中任何规则时拒绝
+
+const (
+	vpcService = "vpc"
+	vpcVersion = "2017-03-12"
+
+	vpcPageSize = 100
+)
+
+type vpcTag struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"`
+}
+
+type vpcDescribeVpcsResponse struct {
+	TotalCount int `json:"TotalCount"`
+	VpcSet     []struct {
+		VpcID       string   `json:"VpcId"`
+		VpcName     string   `json:"VpcName"`
+		CidrBlock   string   `json:"CidrBlock"`
+		IsDefault   bool     `json:"IsDefault"`
+		CreatedTime string   `json:"CreatedTime"`
+		TagSet      []vpcTag `json:"TagSet"`
+	} `json:"VpcSet"`
+}
+
+type vpcDescribeSubnetsResponse struct {
+	TotalCount int `json:"TotalCount"`
+	SubnetSet  []struct {
+		SubnetID                string   `json:"SubnetId"`
+		SubnetName              string   `json:"SubnetName"`
+		VpcID                   string   `json:"VpcId"`
+		CidrBlock               string   `json:"CidrBlock"`
+		Zone                    string   `json:"Zone"`
+		IsDefault               bool     `json:"IsDefault"`
+		AvailableIPAddressCount int      `json:"AvailableIpAddressCount"`
+		CreatedTime             string   `json:"CreatedTime"`
+		TagSet                  []vpcTag `json:"TagSet"`
+	} `json:"SubnetSet"`
+}
+
+type vpcDescribeSecurityGroupsResponse struct {
+	TotalCount       int `json:"TotalCount"`
+	SecurityGroupSet []struct {
+		SecurityGroupID   string   `json:"SecurityGroupId"`
+		SecurityGroupName string   `json:"SecurityGroupName"`
+		SecurityGroupDesc string   `json:"SecurityGroupDesc"`
+		CreatedTime       string   `json:"CreatedTime"`
+		TagSet            []vpcTag `json:"TagSet"`
+	} `json:"SecurityGroupSet"`
+}
+
+type vpcSecurityGroupPolicy struct {
+	PolicyIndex       int    `json:"PolicyIndex"`
+	Protocol          string `json:"Protocol"`
+	Port              string `json:"Port"`
+	CidrBlock         string `json:"CidrBlock"`
+	Ipv6CidrBlock     string `json:"Ipv6CidrBlock"`
+	SecurityGroupID   string `json:"SecurityGroupId"`
+	Action            string `json:"Action"` // ACCEPT, DROP
+	PolicyDescription string `json:"PolicyDescription"`
+	ServiceTemplate   struct {
+		ServiceID      string `json:"ServiceId"`
+		ServiceGroupID string `json:"ServiceGroupId"`
+	} `json:"ServiceTemplate"`
+	AddressTemplate struct {
+		AddressID      string `json:"AddressId"`
+		AddressGroupID string `json:"AddressGroupId"`
+	} `json:"AddressTemplate"`
+}
+
+type vpcDescribeSecurityGroupPoliciesResponse struct {
+	SecurityGroupPolicySet struct {
+		Ingress []vpcSecurityGroupPolicy `json:"Ingress"`
+		Egress  []vpcSecurityGroupPolicy `json:"Egress"`
+	} `json:"SecurityGroupPolicySet"`
+}
+
+// ListVPCs 列出 VPC
+func (p *TencentProvider) ListVPCs(ctx context.Context, opts *provider.QueryOptions) ([]*model.VPC, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	return collectRegions(p, opts, "VPC", func(client *Client) ([]*model.VPC, error) {
+		return client.describeVpcs(ctx, map[string]any{})
+	})
+}
+
+// ListSubnets 列出子网,Filters 支持 vpc_id
+func (p *TencentProvider) ListSubnets(ctx context.Context, opts *provider.QueryOptions) ([]*model.Subnet, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	return collectRegions(p, opts, "subnets", func(client *Client) ([]*model.Subnet, error) {
+		params := map[string]any{}
+		if vpcID := opts.Filters["vpc_id"]; vpcID != "" {
+			params["Filters"] = []map[string]any{{"Name": "vpc-id", "Values": []string{vpcID}}}
+		}
+		return client.describeSubnets(ctx, params)
+	})
+}
+
+// ListSecurityGroups 列出安全组 (不含规则)
+func (p *TencentProvider) ListSecurityGroups(ctx context.Context, opts *provider.QueryOptions) ([]*model.SecurityGroup, error) {
+	if opts == nil {
+		opts = &provider.QueryOptions{}
+	}
+	return collectRegions(p, opts, "security groups", func(client *Client) ([]*model.SecurityGroup, error) {
+		return client.describeSecurityGroups(ctx, map[string]any{})
+	})
+}
+
+// GetSecurityGroup 获取安全组详情,包含入方向和出方向规则
+func (p *TencentProvider) GetSecurityGroup(ctx context.Context, sgID string) (*model.SecurityGroup, error) {
+	// 遍历所有区域查找安全组
+	for region, client := range p.clients {
+		groups, err := client.describeSecurityGroups(ctx, map[string]any{"SecurityGroupIds": []string{sgID}})
+		if err != nil {
+			logx.Debug("Security group not found in region, sg_id %s, region %s, error %v", sgID, region, err)
+			continue
+		}
+		if len(groups) == 0 {
+			continue
+		}
+
+		sg := groups[0]
+		if sg.Rules, err = client.describeSecurityGroupPolicies(ctx, sgID); err != nil {
+			return nil, err
+		}
+		return sg, nil
+	}
+
+	return nil, fmt.Errorf("security group %s not found in any region", sgID)
+}
+
+// GetInstanceNetwork 获取 CVM 实例的 VPC、子网、安全组及生效的规则
+func (p *TencentProvider) GetInstanceNetwork(ctx context.Context, instanceID string) (*model.InstanceNetwork, error) {
+	instance, err := p.GetCVMInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := p.clients[instance.Region]
+	if !ok {
+		return nil, fmt.Errorf("region %s not configured", instance.Region)
+	}
+
+	network := &model.InstanceNetwork{
+		Instance:       instance,
+		SecurityGroups: make([]*model.SecurityGroup, 0, len(instance.SecurityGroupIDs)),
+		DefaultIngress: model.PolicyDrop,
+		DefaultEgress:  model.PolicyDrop,
+	}
+
+	if instance.VpcID != "" {
+		vpcs, err := client.describeVpcs(ctx, map[string]any{"VpcIds": []string{instance.VpcID}})
+		if err != nil {
+			logx.Warn("Failed to describe Tencent VPC, vpc_id %s, error %v", instance.VpcID, err)
+		} else if len(vpcs) > 0 {
+			network.VPC = vpcs[0]
+		}
+	}
+	if instance.SubnetID != "" {
+		subnets, err := client.describeSubnets(ctx, map[string]any{"SubnetIds": []string{instance.SubnetID}})
+		if err != nil {
+			logx.Warn("Failed to describe Tencent subnet, subnet_id %s, error %v", instance.SubnetID, err)
+		} else if len(subnets) > 0 {
+			network.Subnet = subnets[0]
+		}
+	}
+
+	if len(instance.SecurityGroupIDs) > 0 {
+		groups, err := client.describeSecurityGroups(ctx, map[string]any{"SecurityGroupIds": instance.SecurityGroupIDs})
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]*model.SecurityGroup, len(groups))
+		for _, sg := range groups {
+			byID[sg.ID] = sg
+		}
+
+		// 按绑定顺序匹配: 将组内的规则序号转换为跨安全组的全局优先级
+		offsets := map[string]int{model.DirectionIngress: 0, model.DirectionEgress: 0}
+		for _, sgID := range instance.SecurityGroupIDs {
+			sg, ok := byID[sgID]
+			if !ok {
+				logx.Warn("Security group bound to instance not found, sg_id %s, instance_id %s", sgID, instanceID)
+				continue
+			}
+			if sg.Rules, err = client.describeSecurityGroupPolicies(ctx, sgID); err != nil {
+				return nil, err
+			}
+
+			counts := map[string]int{}
+			for _, rule := range sg.Rules {
+				rule.Priority += offsets[rule.Direction]
+				counts[rule.Direction]++
+			}
+			for direction, count := range counts {
+				offsets[direction] += count
+			}
+			network.SecurityGroups = append(network.SecurityGroups, sg)
+		}
+	}
+
+	network.CollectRules()
+	return network, nil
+}
+
+// describeVpcs 调用 DescribeVpcs 并查询全部分页
+func (c *Client) describeVpcs(ctx context.Context, params map[string]any) ([]*model.VPC, error) {
+	var result []*model.VPC
+	for offset := 0; ; offset += vpcPageSize {
+		// 私有网络接口的分页参数为字符串类型
+		params["Offset"] = strconv.Itoa(offset)
+		params["Limit"] = strconv.Itoa(vpcPageSize)
+
+		var resp vpcDescribeVpcsResponse
+		if err := c.callAPI(ctx, vpcService, vpcVersion, "DescribeVpcs", params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, v := range resp.VpcSet {
+			vpc := &model.VPC{
+				ID:        v.VpcID,
+				Name:      v.VpcName,
+				Provider:  "tencent",
+				Region:    c.Region,
+				CIDR:      v.CidrBlock,
+				Status:    "Available",
+				IsDefault: v.IsDefault,
+				Tags:      convertVPCTags(v.TagSet),
+			}
+			if t, err := time.Parse("2006-01-02 15:04:05", v.CreatedTime); err == nil {
+				vpc.CreatedAt = t
+			}
+			vpc.ConsoleURL = fmt.Sprintf("https://console.cloud.tencent.com/vpc/vpc/detail?rid=%d&id=%s",
+				tencentRegionID(c.Region), vpc.ID)
+			result = append(result, vpc)
+		}
+
+		if len(resp.VpcSet) < vpcPageSize || len(result) >= resp.TotalCount {
+			break
+		}
+	}
+	return result, nil
+}
+
+// describeSubnets 调用 DescribeSubnets 并查询全部分页
+func (c *Client) describeSubnets(ctx context.Context, params map[string]any) ([]*model.Subnet, error) {
+	var result []*model.Subnet
+	for offset := 0; ; offset += vpcPageSize {
+		params["Offset"] = strconv.Itoa(offset)
+		params["Limit"] = strconv.Itoa(vpcPageSize)
+
+		var resp vpcDescribeSubnetsResponse
+		if err := c.callAPI(ctx, vpcService, vpcVersion, "DescribeSubnets", params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, s := range resp.SubnetSet {
+			subnet := &model.Subnet{
+				ID:           s.SubnetID,
+				Name:         s.SubnetName,
+				Provider:     "tencent",
+				Region:       c.Region,
+				Zone:         s.Zone,
+				VpcID:        s.VpcID,
+				CIDR:         s.CidrBlock,
+				AvailableIPs: s.AvailableIPAddressCount,
+				Status:       "Available",
+				IsDefault:    s.IsDefault,
+				Tags:         convertVPCTags(s.TagSet),
+			}
+			if t, err := time.Parse("2006-01-02 15:04:05", s.CreatedTime); err == nil {
+				subnet.CreatedAt = t
+			}
+			subnet.ConsoleURL = fmt.Sprintf("https://console.cloud.tencent.com/vpc/subnet/detail?rid=%d&id=%s",
+				tencentRegionID(c.Region), subnet.ID)
+			result = append(result, subnet)
+		}
+
+		if len(resp.SubnetSet) < vpcPageSize || len(result) >= resp.TotalCount {
+			break
+		}
+	}
+	return result, nil
+}
+
+// describeSecurityGroups 调用 DescribeSecurityGroups 并查询全部分页
+func (c *Client) describeSecurityGroups(ctx context.Context, params map[string]any) ([]*model.SecurityGroup, error) {
+	var result []*model.SecurityGroup
+	for offset := 0; ; offset += vpcPageSize {
+		params["Offset"] = strconv.Itoa(offset)
+		params["Limit"] = strconv.Itoa(vpcPageSize)
+
+		var resp vpcDescribeSecurityGroupsResponse
+		if err := c.callAPI(ctx, vpcService, vpcVersion, "DescribeSecurityGroups", params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, g := range resp.SecurityGroupSet {
+			sg := &model.SecurityGroup{
+				ID:          g.SecurityGroupID,
+				Name:        g.SecurityGroupName,
+				Provider:    "tencent",
+				Region:      c.Region,
+				Description: g.SecurityGroupDesc,
+				Tags:        convertVPCTags(g.TagSet),
+			}
+			if t, err := time.Parse("2006-01-02 15:04:05", g.CreatedTime); err == nil {
+				sg.CreatedAt = t
+			}
+			sg.ConsoleURL = fmt.Sprintf("https://console.cloud.tencent.com/vpc/security-group/detail/%s?rid=%d",
+				sg.ID, tencentRegionID(c.Region))
+			result = append(result, sg)
+		}
+
+		if len(resp.SecurityGroupSet) < vpcPageSize || len(result) >= resp.TotalCount {
+			break
+		}
+	}
+	return result, nil
+}
+
+// describeSecurityGroupPolicies 查询安全组规则,优先级为组内的规则序号
+func (c *Client) describeSecurityGroupPolicies(ctx context.Context, sgID string) ([]*model.SecurityGroupRule, error) {
+	var resp vpcDescribeSecurityGroupPoliciesResponse
+	if err := c.callAPI(ctx, vpcService, vpcVersion, "DescribeSecurityGroupPolicies", map[string]any{"SecurityGroupId": sgID}, &resp); err != nil {
+		return nil, err
+	}
+
+	policies := resp.SecurityGroupPolicySet
+	rules := make([]*model.SecurityGroupRule, 0, len(policies.Ingress)+len(policies.Egress))
+	for _, policy := range policies.Ingress {
+		rules = append(rules, convertSecurityGroupPolicy(sgID, model.DirectionIngress, &policy))
+	}
+	for _, policy := range policies.Egress {
+		rules = append(rules, convertSecurityGroupPolicy(sgID, model.DirectionEgress, &policy))
+	}
+
+	model.SortRules(rules)
+	return rules, nil
+}
+
+// convertSecurityGroupPolicy 将腾讯云安全组规则转换为统一模型
+// 引用参数模板的规则无法在本地展开,端口和对端以模板 ID 表示,评估时不会命中
+func convertSecurityGroupPolicy(sgID, direction string, policy *vpcSecurityGroupPolicy) *model.SecurityGroupRule {
+	rule := &model.SecurityGroupRule{
+		SecurityGroupID: sgID,
+		Direction:       direction,
+		Policy:          strings.ToLower(policy.Action),
+		Protocol:        strings.ToLower(policy.Protocol),
+		PortRange:       strings.ToLower(policy.Port),
+		CIDR:            policy.CidrBlock,
+		PeerGroupID:     policy.SecurityGroupID,
+		Priority:        policy.PolicyIndex,
+		Description:     policy.PolicyDescription,
+	}
+	if rule.CIDR == "" {
+		rule.CIDR = policy.Ipv6CidrBlock
+	}
+	if rule.PeerGroupID == "" {
+		rule.PeerGroupID = firstNonEmpty(policy.AddressTemplate.AddressID, policy.AddressTemplate.AddressGroupID)
+	}
+	if service := firstNonEmpty(policy.ServiceTemplate.ServiceID, policy.ServiceTemplate.ServiceGroupID); service != "" {
+		rule.PortRange = service
+	}
+	return rule
+}
+
+// convertVPCTags 转换私有网络资源的标签
+func convertVPCTags(tags []vpcTag) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		result[tag.Key] = tag.Value
+	}
+	return result
+}
+
+// tencentRegionID 获取控制台跳转使用的区域编号,找不到时使用默认值1(北京)
+func tencentRegionID(region string) int {
+	if regionID, ok := tencentRegionIDMap[region]; ok {
+		return regionID
+	}
+	return 1
+}
+
+// globalClient 返回用于调用全局服务的客户端,按区域排序取第一个以保证结果稳定
+func (p *TencentProvider) globalClient() (*Client, error) {
+	regions := make([]string, 0, len(p.clients))
+	for region := range p.clients {
+		regions = append(regions, region)
+	}
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("no clients initialized")
+	}
+	sort.Strings(regions)
+	return p.clients[regions[0]], nil
+}
+
+// collectRegions 在指定区域或所有区域执行查询并合并结果,单个区域失败时记录告警并跳过
+func collectRegions[T any](p *TencentProvider, opts *provider.QueryOptions, resource string, query func(*Client) ([]T, error)) ([]T, error) {
+	if opts.Region != "" {
+		client, exists := p.clients[opts.Region]
+		if !exists {
+			return nil, fmt.Errorf("region %s not configured", opts.Region)
+		}
+		return query(client)
+	}
+
+	var result []T
+	for region, client := range p.clients {
+		items, err := query(client)
+		if err != nil {
+			logx.Warn("Failed to query %s in region %s, error %v", resource, region, err)
+			continue
+		}
+		result = append(result, items...)
+	}
+	return result, nil
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eryajf/zenops/internal/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// auditMiddleware 记录 REST 资源查询接口的调用,工具名为 "方法 路由"
+func (s *HTTPGinServer) auditMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		arguments := make(map[string]any, len(c.Request.URL.Query()))
+		for key, values := range c.Request.URL.Query() {
+			if len(values) == 1 {
+				arguments[key] = values[0]
+			} else {
+				arguments[key] = values
+			}
+		}
+
+		entry := audit.Entry{
+			Time:       start,
+			Platform:   "http",
+			UserName:   c.ClientIP(),
+			Tool:       c.Request.Method + " " + c.FullPath(),
+			Arguments:  audit.RedactArguments(arguments),
+			DurationMS: time.Since(start).Milliseconds(),
+			Status:     audit.StatusSuccess,
+		}
+		if status := c.Writer.Status(); status >= http.StatusBadRequest {
+			entry.Status = audit.StatusError
+			entry.Error = fmt.Sprintf("HTTP %d", status)
+			if len(c.Errors) > 0 {
+				entry.Error = c.Errors.String()
+			}
+		}
+
+		audit.Record(entry)
+	}
+}
+
+// ==================== 审计日志 ====================
+
+func (s *HTTPGinServer) handleAuditQuery(c *gin.Context) {
+	filter := audit.Filter{
+		Platform:     c.Query("platform"),
+		User:         c.Query("user"),
+		Conversation: c.Query("conversation"),
+		Tool:         c.Query("tool"),
+		Status:       c.Query("status"),
+	}
+
+	if since := c.Query("since"); since != "" {
+		t, err := parseAuditTime(since)
+		if err != nil {
+			s.error(c, http.StatusBadRequest, fmt.Sprintf("invalid since: %v", err))
+			return
+		}
+		filter.Since = t
+	}
+	if until := c.Query("until"); until != "" {
+		t, err := parseAuditTime(until)
+		if err != nil {
+			s.error(c, http.StatusBadRequest, fmt.Sprintf("invalid until: %v", err))
+			return
+		}
+		filter.Until = t
+	}
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			s.error(c, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		filter.Limit = n
+	}
+
+	entries, err := audit.Query(s.config.Audit.Path, s.config.Audit.MaxBackups, filter)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("failed to query audit log: %v", err))
+		return
+	}
+
+	s.success(c, gin.H{
+		"total":   len(entries),
+		"entries": entries,
+	})
+}
+
+// parseAuditTime 解析时间参数,支持 RFC3339 或相对时长(如 24h,表示 24 小时前)
+func parseAuditTime(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/audit"
+	"github.com/eryajf/zenops/internal/llm"
+	"github.com/eryajf/zenops/internal/metrics"
+	"github.com/eryajf/zenops/internal/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// defaultChatModelID OpenAI 兼容接口对外暴露的模型 ID
+const defaultChatModelID = "zenops"
+
+// chatCompletionRequest OpenAI 兼容的对话请求(只解析 ZenOps 需要的字段)
+type chatCompletionRequest struct {
+	Model    string                  `json:"model"`
+	Messages []chatCompletionMessage `json:"messages"`
+	Stream   bool                    `json:"stream"`
+}
+
+// chatCompletionMessage OpenAI 兼容的消息,content 可以是字符串或多段内容数组
+type chatCompletionMessage struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+}
+
+// text 提取消息中的文本内容,多段内容时拼接所有 text 段
+func (m chatCompletionMessage) text() string {
+	var s string
+	if err := json.Unmarshal(m.Content, &s); err == nil {
+		return s
+	}
+
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(m.Content, &parts); err != nil {
+		return ""
+	}
+
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part.Type == "text" && part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// openAIError 返回 OpenAI 格式的错误响应
+func (s *HTTPGinServer) openAIError(c *gin.Context, code int, errType, message string) {
+	c.JSON(code, gin.H{
+		"error": gin.H{
+			"message": message,
+			"type":    errType,
+		},
+	})
+}
+
+// ==================== OpenAI 兼容 API ====================
+
+func (s *HTTPGinServer) handleOpenAIModels(c *gin.Context) {
+	c.JSON(http.StatusOK, openai.ModelsList{
+		Models: []openai.Model{
+			{
+				ID:      defaultChatModelID,
+				Object:  "model",
+				OwnedBy: "zenops",
+			},
+		},
+	})
+}
+
+func (s *HTTPGinServer) handleChatCompletions(c *gin.Context) {
+	if s.llmClient == nil {
+		s.openAIError(c, http.StatusServiceUnavailable, "server_error", "LLM is not enabled")
+		return
+	}
+
+	var req chatCompletionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.openAIError(c, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	// 只保留对话内容,工具调用由 ZenOps 在服务端完成
+	messages := make([]llm.Message, 0, len(req.Messages))
+	for _, msg := range req.Messages {
+		if msg.Role != "system" && msg.Role != "user" && msg.Role != "assistant" {
+			continue
+		}
+		messages = append(messages, llm.Message{
+			Role:    msg.Role,
+			Content: msg.text(),
+		})
+	}
+	if len(messages) == 0 {
+		s.openAIError(c, http.StatusBadRequest, "invalid_request_error", "messages is required")
+		return
+	}
+
+	model := req.Model
+	if model == "" {
+		model = defaultChatModelID
+	}
+
+	// 工具调用循环可能远超服务器的写超时,取消本次请求的写超时
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		logx.Warn("Failed to clear write deadline for chat completion: %v", err)
+	}
+
+	logx.Info("Chat completion request, model %s, messages %d, stream %v", model, len(messages), req.Stream)
+
+	// 延续调用方的链路 (traceparent)
+	ctx := tracing.Extract(c.Request.Context(), c.Request.Header)
+	ctx, span := tracing.StartWithKind(ctx, "openai.ChatCompletions", tracing.SpanKindServer,
+		tracing.String("bot.platform", "openai"),
+		tracing.Bool("openai.stream", req.Stream))
+	defer span.End()
+
+	// 按机器人选择工具集
+	ctx = llm.WithToolScope(ctx, "openai", "")
+	ctx = audit.WithCaller(ctx, audit.Caller{Platform: "openai", UserName: c.ClientIP()})
+	metrics.IncBotMessage("openai")
+
+	if req.Stream {
+		s.streamChatCompletion(ctx, c, model, messages)
+		return
+	}
+
+	answer, err := s.llmClient.Chat(ctx, messages)
+	if err != nil {
+		span.RecordError(err)
+		logx.Error("Chat completion failed: %v", err)
+		s.openAIError(c, http.StatusBadGateway, "server_error", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, openai.ChatCompletionResponse{
+		ID:      "chatcmpl-" + uuid.New().String(),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   model,
+		Choices: []openai.ChatCompletionChoice{
+			{
+				Index: 0,
+				Message: openai.ChatCompletionMessage{
+					Role:    openai.ChatMessageRoleAssistant,
+					Content: answer,
+				},
+				FinishReason: openai.FinishReasonStop,
+			},
+		},
+	})
+}
+
+// streamChatCompletion 以 OpenAI SSE 格式流式输出回答
+// 输出第一段内容前失败时返回与非流式相同的错误响应,输出过程中失败时发送 error 事件并结束
+func (s *HTTPGinServer) streamChatCompletion(ctx context.Context, c *gin.Context, model string, messages []llm.Message) {
+	contentCh, errCh, err := s.llmClient.ChatStream(ctx, messages)
+	if err != nil {
+		s.openAIError(c, http.StatusBadGateway, "server_error", err.Error())
+		return
+	}
+
+	// 等待第一段内容,确定对话是否成功后再写入响应头
+	first, ok := <-contentCh
+	if !ok {
+		if err := <-errCh; err != nil {
+			logx.Error("Chat completion failed: %v", err)
+			s.openAIError(c, http.StatusBadGateway, "server_error", err.Error())
+			return
+		}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	id := "chatcmpl-" + uuid.New().String()
+	created := time.Now().Unix()
+
+	writeEvent := func(event any) {
+		data, err := json.Marshal(event)
+		if err != nil {
+			logx.Error("Failed to marshal chat completion event: %v", err)
+			return
+		}
+		_, _ = fmt.Fprintf(c.Writer, "data: %s\n\n", data)
+		c.Writer.Flush()
+	}
+
+	writeChunk := func(delta openai.ChatCompletionStreamChoiceDelta, finishReason openai.FinishReason) {
+		writeEvent(openai.ChatCompletionStreamResponse{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   model,
+			Choices: []openai.ChatCompletionStreamChoice{
+				{
+					Index:        0,
+					Delta:        delta,
+					FinishReason: finishReason,
+				},
+			},
+		})
+	}
+
+	writeChunk(openai.ChatCompletionStreamChoiceDelta{Role: openai.ChatMessageRoleAssistant}, "")
+
+	if ok {
+		writeChunk(openai.ChatCompletionStreamChoiceDelta{Content: first}, "")
+		for content := range contentCh {
+			writeChunk(openai.ChatCompletionStreamChoiceDelta{Content: content}, "")
+		}
+	}
+
+	// 客户端已断开,无需再输出结束标记
+	if ctx.Err() != nil {
+		logx.Warn("Chat completion client disconnected: %v", ctx.Err())
+		return
+	}
+
+	// 已输出部分内容后失败,按 OpenAI 的格式发送 error 事件
+	if err := <-errCh; err != nil {
+		logx.Error("Chat completion failed after partial output: %v", err)
+		writeEvent(gin.H{
+			"error": gin.H{
+				"message": err.Error(),
+				"type":    "server_error",
+			},
+		})
+		return
+	}
+
+	writeChunk(openai.ChatCompletionStreamChoiceDelta{}, openai.FinishReasonStop)
+	_, _ = fmt.Fprint(c.Writer, "data: [DONE]\n\n")
+	c.Writer.Flush()
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/bot"
+	"github.com/open-dingtalk/dingtalk-stream-sdk-go/card"
+)
+
+// 卡片按钮: 卡片模板中的按钮通过 buttons 变量(JSON 数组)循环渲染,
+// 点击后回传 action_id 和 value 参数,经 Stream 连接回调到这里,执行对应的动作后原地更新卡片
+
+const (
+	// cardKeyContent 卡片模板中的 Markdown 内容变量
+	cardKeyContent = "content"
+	// cardKeyButtons 卡片模板中的按钮列表变量
+	cardKeyButtons = "buttons"
+)
+
+// cardButton 卡片模板 buttons 变量的元素
+type cardButton struct {
+	Text     string `json:"text"`
+	ActionID string `json:"action_id"`
+	Value    string `json:"value"`
+	Danger   bool   `json:"danger"`
+}
+
+// cardButtonsParam 将按钮序列化为卡片变量,没有按钮时为空数组(隐藏按钮区域)
+func cardButtonsParam(buttons []bot.Button) string {
+	items := make([]cardButton, 0, len(buttons))
+	for _, b := range buttons {
+		items = append(items, cardButton{Text: b.Text, ActionID: b.ActionID, Value: b.Value, Danger: b.Danger})
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
+
+// onCardCallback 处理卡片按钮回调
+func (h *DingTalkStreamHandler) onCardCallback(ctx context.Context, request *card.CardRequest) (*card.CardResponse, error) {
+	actionID := request.GetActionString("action_id")
+	if actionID == "" {
+		logx.Debug("Ignoring DingTalk card callback without action_id, track_id %s", request.OutTrackId)
+		return &card.CardResponse{}, nil
+	}
+
+	logx.Info("Received card callback from %s, track_id %s, action %s", request.UserId, request.OutTrackId, actionID)
+
+	action := &bot.Action{
+		Message: bot.Message{
+			ConversationID: request.SpaceId,
+			UserID:         request.UserId,
+		},
+		ActionID: actionID,
+		Value:    request.GetActionString("value"),
+	}
+
+	// 工具调用可能较慢,回调先返回,执行完成后通过 UpdateCard 原地更新卡片
+	go func() {
+		updater := &cardUpdater{cardClient: h.cardClient, trackID: request.OutTrackId}
+		if err := h.core.HandleAction(context.WithoutCancel(ctx), action, updater); err != nil {
+			logx.Error("Failed to handle DingTalk card action: %v", err)
+		}
+	}()
+
+	return &card.CardResponse{}, nil
+}
+
+// SupportsActions 配置了卡片模板时才能展示按钮
+func (r *dingTalkReplier) SupportsActions() bool {
+	return r.handler.useCard()
+}
+
+// ReplyWithActions 发送带按钮的卡片,未配置卡片模板时降级为文本回复(不含按钮)
+func (r *dingTalkReplier) ReplyWithActions(ctx context.Context, content string, buttons []bot.Button) error {
+	if !r.handler.useCard() {
+		return r.handler.sendTextReply(r.data, content)
+	}
+
+	trackID := r.handler.generateTrackID(r.data.MsgId)
+	params := map[string]string{cardKeyButtons: cardButtonsParam(buttons)}
+	if err := r.handler.cardClient.CreateAndDeliverCardWithParams(ctx, trackID, r.data.ConversationId, r.data.ConversationType, r.data.SenderStaffId, params); err != nil {
+		logx.Error("Failed to create card, fallback to text: %v", err)
+		return r.handler.sendTextReply(r.data, content)
+	}
+
+	return r.handler.cardClient.StreamingUpdate(trackID, content, true)
+}
+
+// cardUpdater 按钮回调的回复适配器,原地更新按钮所在的卡片
+type cardUpdater struct {
+	cardClient *DingTalkStreamClient
+	trackID    string
+}
+
+// Reply 更新卡片内容并移除按钮
+func (u *cardUpdater) Reply(_ context.Context, content string) error {
+	return u.update(content, nil)
+}
+
+// SupportsActions 按钮回调来自卡片,始终可以展示按钮
+func (u *cardUpdater) SupportsActions() bool {
+	return true
+}
+
+// ReplyWithActions 更新卡片内容和按钮
+func (u *cardUpdater) ReplyWithActions(_ context.Context, content string, buttons []bot.Button) error {
+	return u.update(content, buttons)
+}
+
+// StartStream 在原卡片上流式更新
+func (u *cardUpdater) StartStream(_ context.Context, question string) (bot.Stream, error) {
+	stream := &cardUpdateStream{updater: u, header: fmt.Sprintf("**%s**\n\n", question)}
+	if err := u.update(stream.header+"🤖 正在思考...", nil); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// update 更新卡片的内容和按钮变量
+func (u *cardUpdater) update(content string, buttons []bot.Button) error {
+	return u.cardClient.UpdateCard(u.trackID, map[string]string{
+		cardKeyContent: content,
+		cardKeyButtons: cardButtonsParam(buttons),
+	})
+}
+
+// cardUpdateStream 通过 UpdateCard 实现的流式回复
+type cardUpdateStream struct {
+	updater *cardUpdater
+	header  string // 卡片顶部显示的问题
+}
+
+// Update 更新卡片内容
+func (s *cardUpdateStream) Update(_ context.Context, content string) error {
+	return s.updater.update(s.header+content, nil)
+}
+
+// Finish 发送最终内容
+func (s *cardUpdateStream) Finish(_ context.Context, content string) error {
+	return s.updater.update(s.header+content, nil)
+}
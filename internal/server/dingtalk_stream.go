@@ -112,11 +112,22 @@ func (c *DingTalkStreamClient) CreateAndDeliverCard(ctx context.Context, trackID
 		SenderStaffID:    senderStaffID,
 	}
 
-	return c.createAndDeliverCardInternal(ctx, trackID, msg)
+	return c.createAndDeliverCardInternal(ctx, trackID, msg, nil)
+}
+
+// CreateAndDeliverCardWithParams 创建并投递 AI 卡片,同时设置卡片变量(如按钮)
+func (c *DingTalkStreamClient) CreateAndDeliverCardWithParams(ctx context.Context, trackID, conversationID, conversationType, senderStaffID string, params map[string]string) error {
+	msg := &DingTalkMessage{
+		ConversationID:   conversationID,
+		ConversationType: conversationType,
+		SenderStaffID:    senderStaffID,
+	}
+
+	return c.createAndDeliverCardInternal(ctx, trackID, msg, params)
 }
 
 // createAndDeliverCardInternal 内部创建卡片方法
-func (c *DingTalkStreamClient) createAndDeliverCardInternal(ctx context.Context, trackID string, msg *DingTalkMessage) error {
+func (c *DingTalkStreamClient) createAndDeliverCardInternal(ctx context.Context, trackID string, msg *DingTalkMessage, params map[string]string) error {
 	accessToken, err := c.GetAccessToken()
 	if err != nil {
 		return fmt.Errorf("failed to get access token: %w", err)
@@ -128,6 +139,9 @@ func (c *DingTalkStreamClient) createAndDeliverCardInternal(ctx context.Context,
 	cardDataCardParamMap := map[string]*string{
 		"content": tea.String(""), // 初始内容为空
 	}
+	for key, value := range params {
+		cardDataCardParamMap[key] = tea.String(value)
+	}
 
 	cardData := &dingtalkcard_1_0.CreateAndDeliverRequestCardData{
 		CardParamMap: cardDataCardParamMap,
@@ -207,6 +221,43 @@ func (c *DingTalkStreamClient) StreamingUpdate(trackID, content string, isFinali
 	return nil
 }
 
+// UpdateCard 按 key 更新卡片变量(用于按钮回调后原地更新卡片)
+func (c *DingTalkStreamClient) UpdateCard(trackID string, params map[string]string) error {
+	accessToken, err := c.GetAccessToken()
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	headers := &dingtalkcard_1_0.UpdateCardHeaders{
+		XAcsDingtalkAccessToken: tea.String(accessToken),
+	}
+
+	cardParamMap := make(map[string]*string, len(params))
+	for key, value := range params {
+		cardParamMap[key] = tea.String(value)
+	}
+
+	request := &dingtalkcard_1_0.UpdateCardRequest{
+		OutTrackId: tea.String(trackID),
+		CardData: &dingtalkcard_1_0.UpdateCardRequestCardData{
+			CardParamMap: cardParamMap,
+		},
+		CardUpdateOptions: &dingtalkcard_1_0.UpdateCardRequestCardUpdateOptions{
+			UpdateCardDataByKey: tea.Bool(true), // 只更新传入的变量
+		},
+		UserIdType: tea.Int32(1),
+	}
+
+	_, err = c.cardClient.UpdateCardWithOptions(request, headers, &util.RuntimeOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update card: %w", err)
+	}
+
+	logx.Debug("Updated card, track_id %s, keys %d", trackID, len(params))
+
+	return nil
+}
+
 // StreamResponse 流式响应(定时更新)
 func (c *DingTalkStreamClient) StreamResponse(ctx context.Context, trackID string, contentCh <-chan string, question string) {
 	fullContent := fmt.Sprintf("**%s**\n\n", question)
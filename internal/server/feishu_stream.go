@@ -10,6 +10,7 @@ import (
 	"github.com/eryajf/zenops/internal/feishu"
 	"github.com/eryajf/zenops/internal/imcp"
 	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher"
+	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher/callback"
 	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
 	larkws "github.com/larksuite/oapi-sdk-go/v3/ws"
 )
@@ -63,6 +64,9 @@ func (s *FeishuStreamServer) Start() error {
 	eventHandler := dispatcher.NewEventDispatcher("", "").
 		OnP2MessageReceiveV1(func(ctx context.Context, event *larkim.P2MessageReceiveV1) error {
 			return s.handleMessage(ctx, event)
+		}).
+		OnP2CardActionTrigger(func(ctx context.Context, event *callback.CardActionTriggerEvent) (*callback.CardActionTriggerResponse, error) {
+			return s.handler.HandleCardAction(ctx, event)
 		})
 
 	// 创建 WebSocket 客户端
@@ -2,13 +2,17 @@ package server
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
 	"github.com/eryajf/zenops/internal/config"
 	"github.com/eryajf/zenops/internal/imcp"
+	"github.com/eryajf/zenops/internal/llm"
+	"github.com/eryajf/zenops/internal/metrics"
 	"github.com/eryajf/zenops/internal/model"
 	"github.com/eryajf/zenops/internal/provider"
 	aliyunprovider "github.com/eryajf/zenops/internal/provider/aliyun"
@@ -23,6 +27,7 @@ type HTTPGinServer struct {
 	server        *http.Server
 	mcpServer     *imcp.MCPServer
 	wecomHandler  *wecom.MessageHandler
+	llmClient     *llm.Client
 }
 
 // NewHTTPGinServer 创建基于 Gin 的 HTTP 服务器
@@ -55,6 +60,14 @@ func NewHTTPGinServer(cfg *config.Config) *HTTPGinServer {
 func (s *HTTPGinServer) SetMCPServer(mcpServer *imcp.MCPServer) {
 	s.mcpServer = mcpServer
 
+	// 如果启用了 LLM,初始化 OpenAI 兼容接口使用的客户端
+	if s.config.LLM.Enabled {
+		llmConfig := llm.NewConfig(s.config.LLM)
+		llmConfig.ExcludeTools = imcp.ConfirmTools() // 变更操作需通过命令或按钮确认后执行
+		s.llmClient = llm.NewClient(llmConfig, mcpServer)
+		logx.Info("LLM client initialized for OpenAI compatible API, model %s", s.llmClient.Model())
+	}
+
 	// 如果启用了企业微信,初始化消息处理器
 	if s.config.Wecom.Enabled {
 		handler, err := wecom.NewMessageHandler(s.config, mcpServer)
@@ -77,6 +90,11 @@ func (s *HTTPGinServer) registerMiddlewares() {
 
 	// CORS 中间件(如果需要)
 	s.engine.Use(s.corsMiddleware())
+
+	// Prometheus 指标中间件
+	if s.config.Metrics.Enabled {
+		s.engine.Use(s.metricsMiddleware())
+	}
 }
 
 // loggingMiddleware 自定义日志中间件
@@ -115,6 +133,35 @@ func (s *HTTPGinServer) corsMiddleware() gin.HandlerFunc {
 	}
 }
 
+// authMiddleware Token 认证中间件,未启用认证时直接放行
+// 支持 Authorization: Bearer <token> 请求头
+func (s *HTTPGinServer) authMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !s.config.Auth.Enabled {
+			c.Next()
+			return
+		}
+
+		token := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+		if token != "" {
+			for _, allowed := range s.config.Auth.Tokens {
+				if allowed != "" && subtle.ConstantTimeCompare([]byte(token), []byte(allowed)) == 1 {
+					c.Next()
+					return
+				}
+			}
+		}
+
+		logx.Warn("Unauthorized request, path %s, remote_addr %s", c.Request.URL.Path, c.ClientIP())
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": gin.H{
+				"message": "invalid or missing API token",
+				"type":    "invalid_request_error",
+			},
+		})
+	}
+}
+
 // registerRoutes 注册路由
 func (s *HTTPGinServer) registerRoutes() {
 	// 企业微信机器人回调路由(不在 v1 组内)
@@ -123,14 +170,39 @@ func (s *HTTPGinServer) registerRoutes() {
 		s.engine.POST("/api/wecom/callback", s.handleWecomMessage)
 	}
 
+	// OpenAI 兼容接口(供 Open WebUI、LobeChat 等对话界面接入),使用 auth.tokens 认证
+	if s.config.LLM.Enabled {
+		if !s.config.Auth.Enabled {
+			logx.Warn("OpenAI compatible API is enabled without auth, consider enabling auth.tokens")
+		}
+
+		openaiAPI := s.engine.Group("/v1", s.authMiddleware())
+		{
+			openaiAPI.GET("/models", s.handleOpenAIModels)
+			openaiAPI.POST("/chat/completions", s.handleChatCompletions)
+		}
+	}
+
+	// Prometheus 指标
+	if s.config.Metrics.Enabled {
+		s.engine.GET(s.config.Metrics.Path, gin.WrapH(metrics.Handler()))
+	}
+
 	// API v1 路由组
 	v1 := s.engine.Group("/api/v1")
 	{
 		// 健康检查
 		v1.GET("/health", s.handleHealth)
 
+		// 审计日志查询,记录中包含用户和调用参数,只在启用认证时开放
+		if s.config.Audit.Enabled && s.config.Auth.Enabled {
+			v1.GET("/audit", s.authMiddleware(), s.handleAuditQuery)
+		} else if s.config.Audit.Enabled {
+			logx.Warn("Audit query endpoint /api/v1/audit is disabled because auth is not enabled")
+		}
+
 		// 阿里云路由
-		aliyun := v1.Group("/aliyun")
+		aliyun := v1.Group("/aliyun", s.auditMiddleware())
 		{
 			// ECS
 			aliyun.GET("/ecs/list", s.handleAliyunECSList)
@@ -141,13 +213,36 @@ func (s *HTTPGinServer) registerRoutes() {
 			aliyun.GET("/rds/list", s.handleAliyunRDSList)
 			aliyun.GET("/rds/search", s.handleAliyunRDSSearch)
 
+			// Redis/Tair
+			aliyun.GET("/redis/list", s.handleRedisList("aliyun"))
+			aliyun.GET("/redis/search", s.handleRedisSearch("aliyun"))
+
+			// 公网 IP
+			aliyun.GET("/eip/list", s.handleEIPList("aliyun"))
+			aliyun.GET("/eip/unassociated", s.handleEIPUnassociated("aliyun"))
+
+			// CDN
+			aliyun.GET("/cdn/list", s.handleCDNList("aliyun"))
+
 			// OSS
 			aliyun.GET("/oss/list", s.handleAliyunOSSList)
 			aliyun.GET("/oss/get", s.handleAliyunOSSGet)
+
+			// SLB/ALB
+			aliyun.GET("/slb/list", s.handleLoadBalancerList("aliyun"))
+			aliyun.GET("/slb/search", s.handleLoadBalancerSearch("aliyun"))
+			aliyun.GET("/slb/get", s.handleLoadBalancerGet("aliyun"))
+
+			// VPC/交换机/安全组
+			aliyun.GET("/vpc/list", s.handleVPCList("aliyun"))
+			aliyun.GET("/vswitch/list", s.handleSubnetList("aliyun"))
+			aliyun.GET("/sg/list", s.handleSecurityGroupList("aliyun"))
+			aliyun.GET("/sg/get", s.handleSecurityGroupGet("aliyun"))
+			aliyun.GET("/ecs/network", s.handleInstanceNetwork("aliyun"))
 		}
 
 		// 腾讯云路由
-		tencent := v1.Group("/tencent")
+		tencent := v1.Group("/tencent", s.auditMiddleware())
 		{
 			// CVM
 			tencent.GET("/cvm/list", s.handleTencentCVMList)
@@ -158,13 +253,73 @@ func (s *HTTPGinServer) registerRoutes() {
 			tencent.GET("/cdb/list", s.handleTencentCDBList)
 			tencent.GET("/cdb/search", s.handleTencentCDBSearch)
 
+			// Redis
+			tencent.GET("/redis/list", s.handleRedisList("tencent"))
+			tencent.GET("/redis/search", s.handleRedisSearch("tencent"))
+
+			// 公网 IP
+			tencent.GET("/eip/list", s.handleEIPList("tencent"))
+			tencent.GET("/eip/unassociated", s.handleEIPUnassociated("tencent"))
+
+			// CDN
+			tencent.GET("/cdn/list", s.handleCDNList("tencent"))
+
 			// COS
 			tencent.GET("/cos/list", s.handleTencentCOSList)
 			tencent.GET("/cos/get", s.handleTencentCOSGet)
+
+			// CLB
+			tencent.GET("/clb/list", s.handleLoadBalancerList("tencent"))
+			tencent.GET("/clb/search", s.handleLoadBalancerSearch("tencent"))
+			tencent.GET("/clb/get", s.handleLoadBalancerGet("tencent"))
+
+			// VPC/子网/安全组
+			tencent.GET("/vpc/list", s.handleVPCList("tencent"))
+			tencent.GET("/subnet/list", s.handleSubnetList("tencent"))
+			tencent.GET("/sg/list", s.handleSecurityGroupList("tencent"))
+			tencent.GET("/sg/get", s.handleSecurityGroupGet("tencent"))
+			tencent.GET("/cvm/network", s.handleInstanceNetwork("tencent"))
+		}
+
+		// DNS 路由 (跨云、跨账号)
+		dnsGroup := v1.Group("/dns", s.auditMiddleware())
+		{
+			dnsGroup.GET("/domains", s.handleDNSDomainList)
+			dnsGroup.GET("/records", s.handleDNSRecordList)
+			dnsGroup.GET("/resolve", s.handleDNSResolve)
+			dnsGroup.GET("/reverse", s.handleDNSReverse)
+		}
+
+		// 证书路由 (跨云、跨账号)
+		certGroup := v1.Group("/certs", s.auditMiddleware())
+		{
+			certGroup.GET("/list", s.handleCertList)
+			certGroup.GET("/expiring", s.handleCertExpiring)
+			certGroup.GET("/check", s.handleCertCheck)
+		}
+
+		// 费用路由 (跨云、跨账号)
+		costGroup := v1.Group("/cost", s.auditMiddleware())
+		{
+			costGroup.GET("/summary", s.handleCostSummary)
+			costGroup.GET("/breakdown", s.handleCostBreakdown)
+			costGroup.GET("/top", s.handleCostTop)
+		}
+
+		// 闲置资源路由 (跨云、跨账号)
+		idleGroup := v1.Group("/idle", s.auditMiddleware())
+		{
+			idleGroup.GET("/report", s.handleIdleReport)
+		}
+
+		// 监控指标路由 (跨云、跨账号查找资源)
+		metricsGroup := v1.Group("/metrics", s.auditMiddleware())
+		{
+			metricsGroup.GET("/:resource", s.handleResourceMetrics)
 		}
 
 		// Jenkins 路由
-		jenkins := v1.Group("/jenkins")
+		jenkins := v1.Group("/jenkins", s.auditMiddleware())
 		{
 			jenkins.GET("/job/list", s.handleJenkinsJobList)
 			jenkins.GET("/job/get", s.handleJenkinsJobGet)
@@ -223,9 +378,28 @@ func (s *HTTPGinServer) error(c *gin.Context, code int, message string) {
 // ==================== 健康检查 ====================
 
 func (s *HTTPGinServer) handleHealth(c *gin.Context) {
-	s.success(c, gin.H{
+	data := gin.H{
 		"status": "healthy",
-	})
+	}
+
+	// LLM 后端健康状态
+	if s.config.LLM.Enabled {
+		backends := llm.BackendsHealth()
+		healthyCount := 0
+		for _, b := range backends {
+			if b.Healthy {
+				healthyCount++
+			}
+		}
+		if len(backends) > 0 && healthyCount == 0 {
+			data["status"] = "degraded"
+		}
+		data["llm"] = gin.H{
+			"backends": backends,
+		}
+	}
+
+	s.success(c, data)
 }
 
 // ==================== 阿里云 ECS API ====================
@@ -247,6 +421,7 @@ func (s *HTTPGinServer) handleAliyunECSList(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":           aliyunConfig.Name,
 		"access_key_id":     aliyunConfig.AK,
 		"access_key_secret": aliyunConfig.SK,
 		"regions":           interfaceSlice(aliyunConfig.Regions),
@@ -312,6 +487,7 @@ func (s *HTTPGinServer) handleAliyunECSSearch(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":           aliyunConfig.Name,
 		"access_key_id":     aliyunConfig.AK,
 		"access_key_secret": aliyunConfig.SK,
 		"regions":           interfaceSlice(aliyunConfig.Regions),
@@ -398,6 +574,7 @@ func (s *HTTPGinServer) handleAliyunECSGet(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":           aliyunConfig.Name,
 		"access_key_id":     aliyunConfig.AK,
 		"access_key_secret": aliyunConfig.SK,
 		"regions":           interfaceSlice(aliyunConfig.Regions),
@@ -439,6 +616,7 @@ func (s *HTTPGinServer) handleAliyunRDSList(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":           aliyunConfig.Name,
 		"access_key_id":     aliyunConfig.AK,
 		"access_key_secret": aliyunConfig.SK,
 		"regions":           interfaceSlice(aliyunConfig.Regions),
@@ -504,6 +682,7 @@ func (s *HTTPGinServer) handleAliyunRDSSearch(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":           aliyunConfig.Name,
 		"access_key_id":     aliyunConfig.AK,
 		"access_key_secret": aliyunConfig.SK,
 		"regions":           interfaceSlice(aliyunConfig.Regions),
@@ -665,6 +844,7 @@ func (s *HTTPGinServer) handleTencentCVMList(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":    tencentConfig.Name,
 		"secret_id":  tencentConfig.AK,
 		"secret_key": tencentConfig.SK,
 		"regions":    interfaceSlice(tencentConfig.Regions),
@@ -730,6 +910,7 @@ func (s *HTTPGinServer) handleTencentCVMSearch(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":    tencentConfig.Name,
 		"secret_id":  tencentConfig.AK,
 		"secret_key": tencentConfig.SK,
 		"regions":    interfaceSlice(tencentConfig.Regions),
@@ -816,6 +997,7 @@ func (s *HTTPGinServer) handleTencentCVMGet(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":    tencentConfig.Name,
 		"secret_id":  tencentConfig.AK,
 		"secret_key": tencentConfig.SK,
 		"regions":    interfaceSlice(tencentConfig.Regions),
@@ -857,6 +1039,7 @@ func (s *HTTPGinServer) handleTencentCDBList(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":    tencentConfig.Name,
 		"secret_id":  tencentConfig.AK,
 		"secret_key": tencentConfig.SK,
 		"regions":    interfaceSlice(tencentConfig.Regions),
@@ -922,6 +1105,7 @@ func (s *HTTPGinServer) handleTencentCDBSearch(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":    tencentConfig.Name,
 		"secret_id":  tencentConfig.AK,
 		"secret_key": tencentConfig.SK,
 		"regions":    interfaceSlice(tencentConfig.Regions),
@@ -990,6 +1174,7 @@ func (s *HTTPGinServer) handleTencentCOSList(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":    tencentConfig.Name,
 		"secret_id":  tencentConfig.AK,
 		"secret_key": tencentConfig.SK,
 		"regions":    interfaceSlice(tencentConfig.Regions),
@@ -1053,6 +1238,7 @@ func (s *HTTPGinServer) handleTencentCOSGet(c *gin.Context) {
 	}
 
 	providerConfig := map[string]any{
+		"account":    tencentConfig.Name,
 		"secret_id":  tencentConfig.AK,
 		"secret_key": tencentConfig.SK,
 		"regions":    interfaceSlice(tencentConfig.Regions),
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== CDN API ====================
+// 阿里云与腾讯云共用处理逻辑,刷新缓存只通过 refresh_cdn_cache 工具提供
+
+// handleCDNList 列出 CDN 加速域名,支持 keyword 参数
+func (s *HTTPGinServer) handleCDNList(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, account, code, err := s.cloudProvider(cloud, c.Query("account"))
+		if err != nil {
+			s.error(c, code, err.Error())
+			return
+		}
+
+		domains, err := p.ListCDNDomains(c.Request.Context(), &provider.QueryOptions{
+			Filters: map[string]string{"keyword": c.Query("keyword")},
+		})
+		if err != nil {
+			s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list CDN domains: %v", err))
+			return
+		}
+
+		s.success(c, gin.H{
+			"total":   len(domains),
+			"domains": domains,
+			"account": account,
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eryajf/zenops/internal/certs"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== 证书 API ====================
+// 阿里云数字证书管理服务、腾讯云 SSL 证书及 certificates.hosts 中主机的线上证书
+// 支持 cloud、account、source (cloud/tls) 参数缩小范围
+
+// handleCertList 列出证书,支持 keyword 参数
+func (s *HTTPGinServer) handleCertList(c *gin.Context) {
+	checker, source, ok := s.certChecker(c)
+	if !ok {
+		return
+	}
+
+	list, err := checker.List(c.Request.Context(), c.Query("keyword"), source)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list certificates: %v", err))
+		return
+	}
+
+	s.success(c, gin.H{
+		"total":        len(list),
+		"certificates": list,
+	})
+}
+
+// handleCertExpiring 列出 days 天内过期或已过期的证书,days 默认 30
+func (s *HTTPGinServer) handleCertExpiring(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days <= 0 {
+		s.error(c, http.StatusBadRequest, "days must be a positive integer")
+		return
+	}
+
+	checker, source, ok := s.certChecker(c)
+	if !ok {
+		return
+	}
+
+	list, err := checker.Expiring(c.Request.Context(), days, source)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list certificates: %v", err))
+		return
+	}
+
+	s.success(c, gin.H{
+		"days":         days,
+		"total":        len(list),
+		"certificates": list,
+	})
+}
+
+// handleCertCheck 与 host 进行 TLS 握手并返回线上证书
+func (s *HTTPGinServer) handleCertCheck(c *gin.Context) {
+	host := c.Query("host")
+	if host == "" {
+		s.error(c, http.StatusBadRequest, "host parameter is required")
+		return
+	}
+
+	cert, err := certs.Probe(c.Request.Context(), host, time.Duration(s.config.Certificates.Timeout)*time.Second)
+	if err != nil {
+		s.error(c, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	s.success(c, cert)
+}
+
+// certChecker 按查询参数创建证书检查器,失败时直接返回错误响应
+func (s *HTTPGinServer) certChecker(c *gin.Context) (*certs.Checker, string, bool) {
+	checker, source, err := certs.Load(s.config, c.Query("cloud"), c.Query("account"), c.Query("source"))
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err.Error())
+		return nil, "", false
+	}
+	return checker, source, true
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eryajf/zenops/internal/cost"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== 费用 API ====================
+// 阿里云费用中心、腾讯云费用中心账单,默认汇总所有启用的账号
+// 支持 cloud、account 参数缩小范围,period 为 current、last 或 YYYY-MM
+
+// handleCostSummary 返回各账号本月至今和上月的费用
+func (s *HTTPGinServer) handleCostSummary(c *gin.Context) {
+	reporter, ok := s.costReporter(c)
+	if !ok {
+		return
+	}
+
+	summaries, err := reporter.Summary(c.Request.Context(), time.Now())
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to query cost: %v", err))
+		return
+	}
+
+	s.success(c, gin.H{
+		"total":     len(summaries),
+		"summaries": summaries,
+	})
+}
+
+// handleCostBreakdown 按 group_by (account/product/region/tag) 拆分费用,按标签拆分时 tag_key 必填
+func (s *HTTPGinServer) handleCostBreakdown(c *gin.Context) {
+	cycle, err := cost.Cycle(c.Query("period"), time.Now())
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	reporter, ok := s.costReporter(c)
+	if !ok {
+		return
+	}
+
+	items, err := reporter.Items(c.Request.Context(), cycle)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to query cost: %v", err))
+		return
+	}
+	breakdown, err := cost.Breakdown(items, cycle, c.Query("group_by"), c.Query("tag_key"))
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.success(c, breakdown)
+}
+
+// handleCostTop 返回费用最高的实例,top 默认 10
+func (s *HTTPGinServer) handleCostTop(c *gin.Context) {
+	top, err := strconv.Atoi(c.DefaultQuery("top", "10"))
+	if err != nil || top <= 0 {
+		s.error(c, http.StatusBadRequest, "top must be a positive integer")
+		return
+	}
+	cycle, err := cost.Cycle(c.Query("period"), time.Now())
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	reporter, ok := s.costReporter(c)
+	if !ok {
+		return
+	}
+
+	items, err := reporter.Items(c.Request.Context(), cycle)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to query cost: %v", err))
+		return
+	}
+	list := cost.Top(items, top)
+
+	s.success(c, gin.H{
+		"billing_cycle": cycle,
+		"total":         len(list),
+		"instances":     list,
+	})
+}
+
+// costReporter 按查询参数创建账单查询器,失败时直接返回错误响应
+func (s *HTTPGinServer) costReporter(c *gin.Context) (*cost.Reporter, bool) {
+	reporter, err := cost.Load(s.config, c.Query("cloud"), c.Query("account"))
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return reporter, true
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/eryajf/zenops/internal/cloudaccount"
+	"github.com/eryajf/zenops/internal/dns"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== DNS API ====================
+// 阿里云云解析 DNS 与腾讯云 DNSPod,默认查询所有启用的账号,可通过 cloud、account 参数缩小范围
+
+// handleDNSDomainList 列出托管域名,支持 keyword 参数
+func (s *HTTPGinServer) handleDNSDomainList(c *gin.Context) {
+	resolver, ok := s.dnsResolver(c)
+	if !ok {
+		return
+	}
+
+	domains, err := resolver.ListDomains(c.Request.Context(), c.Query("keyword"))
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list domains: %v", err))
+		return
+	}
+
+	s.success(c, gin.H{
+		"total":   len(domains),
+		"domains": domains,
+	})
+}
+
+// handleDNSRecordList 列出域名解析记录,domain 必填,支持 rr、type 参数
+func (s *HTTPGinServer) handleDNSRecordList(c *gin.Context) {
+	domain := c.Query("domain")
+	if domain == "" {
+		s.error(c, http.StatusBadRequest, "domain parameter is required")
+		return
+	}
+
+	resolver, ok := s.dnsResolver(c)
+	if !ok {
+		return
+	}
+
+	records, err := resolver.ListRecords(c.Request.Context(), domain, map[string]string{
+		"rr":   c.Query("rr"),
+		"type": c.Query("type"),
+	})
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list DNS records: %v", err))
+		return
+	}
+
+	s.success(c, gin.H{
+		"total":   len(records),
+		"records": records,
+	})
+}
+
+// handleDNSResolve 沿 A/CNAME 记录将主机名解析到云资源
+func (s *HTTPGinServer) handleDNSResolve(c *gin.Context) {
+	hostname := c.Query("hostname")
+	if hostname == "" {
+		s.error(c, http.StatusBadRequest, "hostname parameter is required")
+		return
+	}
+
+	resolver, ok := s.dnsResolver(c)
+	if !ok {
+		return
+	}
+
+	resolution, err := resolver.Resolve(c.Request.Context(), hostname)
+	if err != nil {
+		s.error(c, http.StatusNotFound, fmt.Sprintf("Failed to resolve %s: %v", hostname, err))
+		return
+	}
+
+	s.success(c, resolution)
+}
+
+// handleDNSReverse 按记录值 (通常为 IP) 反查解析记录
+func (s *HTTPGinServer) handleDNSReverse(c *gin.Context) {
+	value := c.Query("ip")
+	if value == "" {
+		s.error(c, http.StatusBadRequest, "ip parameter is required")
+		return
+	}
+
+	resolver, ok := s.dnsResolver(c)
+	if !ok {
+		return
+	}
+
+	records, err := resolver.ReverseLookup(c.Request.Context(), value)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to search DNS records: %v", err))
+		return
+	}
+
+	s.success(c, gin.H{
+		"total":   len(records),
+		"records": records,
+	})
+}
+
+// dnsResolver 按 cloud、account 参数创建 DNS 解析器,失败时直接返回错误响应
+func (s *HTTPGinServer) dnsResolver(c *gin.Context) (*dns.Resolver, bool) {
+	accounts, err := cloudaccount.Load(s.config, c.Query("cloud"), c.Query("account"))
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return dns.NewResolver(accounts), true
+}
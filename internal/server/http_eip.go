@@ -0,0 +1,78 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== 公网 IP API ====================
+// 阿里云与腾讯云共用处理逻辑,包含弹性公网 IP 和实例的普通公网 IP
+
+// handleEIPList 列出公网 IP,支持 region、status(in_use/available)、type(eip/public_ip)、address 参数
+func (s *HTTPGinServer) handleEIPList(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eips, account, ok := s.queryEIPs(c, cloud, map[string]string{
+			"status":  c.Query("status"),
+			"type":    c.Query("type"),
+			"address": c.Query("address"),
+		})
+		if !ok {
+			return
+		}
+
+		s.success(c, gin.H{
+			"total":   len(eips),
+			"eips":    eips,
+			"account": account,
+		})
+	}
+}
+
+// handleEIPUnassociated 列出未绑定但仍在计费的弹性公网 IP,支持 region 参数
+func (s *HTTPGinServer) handleEIPUnassociated(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eips, account, ok := s.queryEIPs(c, cloud, map[string]string{
+			"type":   model.EIPTypeEIP,
+			"status": model.EIPStatusAvailable,
+		})
+		if !ok {
+			return
+		}
+
+		idle := make([]*model.EIP, 0, len(eips))
+		for _, eip := range eips {
+			if eip.IdleBilling() {
+				idle = append(idle, eip)
+			}
+		}
+
+		s.success(c, gin.H{
+			"total":   len(idle),
+			"eips":    idle,
+			"account": account,
+		})
+	}
+}
+
+// queryEIPs 按过滤条件查询公网 IP,出错时已写入响应
+func (s *HTTPGinServer) queryEIPs(c *gin.Context, cloud string, filters map[string]string) ([]*model.EIP, string, bool) {
+	p, account, code, err := s.cloudProvider(cloud, c.Query("account"))
+	if err != nil {
+		s.error(c, code, err.Error())
+		return nil, "", false
+	}
+
+	eips, err := p.ListEIPs(c.Request.Context(), &provider.QueryOptions{
+		Region:  c.Query("region"),
+		Filters: filters,
+	})
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list public IPs: %v", err))
+		return nil, "", false
+	}
+	return eips, account, true
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/eryajf/zenops/internal/idle"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== 闲置资源 API ====================
+
+// handleIdleReport 检测闲置的实例和数据库,支持 cloud、account、type (instance/database)、days 参数
+func (s *HTTPGinServer) handleIdleReport(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "0"))
+	if err != nil || days < 0 {
+		s.error(c, http.StatusBadRequest, "days must be a positive integer")
+		return
+	}
+	if days == 0 {
+		days = s.config.Idle.Days
+	}
+
+	detector, err := idle.Load(s.config, c.Query("cloud"), c.Query("account"))
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := detector.Detect(c.Request.Context(), c.Query("type"), days)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to detect idle resources: %v", err))
+		return
+	}
+
+	s.success(c, gin.H{
+		"days":      days,
+		"total":     len(list),
+		"resources": list,
+	})
+}
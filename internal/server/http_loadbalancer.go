@@ -0,0 +1,186 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== 负载均衡 API ====================
+// 阿里云 SLB/ALB 与腾讯云 CLB 共用处理逻辑,详情中的后端服务器关联云服务器实例
+
+// newAliyunProvider 创建并初始化阿里云 Provider
+func newAliyunProvider(cfg *config.ProviderConfig) (provider.Provider, error) {
+	p, err := provider.GetProvider("aliyun")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider: %w", err)
+	}
+
+	providerConfig := map[string]any{
+		"account":           cfg.Name,
+		"access_key_id":     cfg.AK,
+		"access_key_secret": cfg.SK,
+		"regions":           interfaceSlice(cfg.Regions),
+	}
+	if err := p.Initialize(providerConfig); err != nil {
+		return nil, fmt.Errorf("failed to initialize provider: %w", err)
+	}
+	return p, nil
+}
+
+// newTencentProvider 创建并初始化腾讯云 Provider
+func newTencentProvider(cfg *config.ProviderConfig) (provider.Provider, error) {
+	p, err := provider.GetProvider("tencent")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider: %w", err)
+	}
+
+	providerConfig := map[string]any{
+		"account":    cfg.Name,
+		"secret_id":  cfg.AK,
+		"secret_key": cfg.SK,
+		"regions":    interfaceSlice(cfg.Regions),
+	}
+	if err := p.Initialize(providerConfig); err != nil {
+		return nil, fmt.Errorf("failed to initialize provider: %w", err)
+	}
+	return p, nil
+}
+
+// cloudProvider 按云平台和账号获取 Provider
+func (s *HTTPGinServer) cloudProvider(cloud, accountName string) (provider.Provider, string, int, error) {
+	var (
+		cfg *config.ProviderConfig
+		err error
+	)
+	if cloud == "aliyun" {
+		cfg, err = getAliyunConfigByName(s.config, accountName)
+	} else {
+		cfg, err = getTencentConfigByName(s.config, accountName)
+	}
+	if err != nil {
+		return nil, "", http.StatusBadRequest, err
+	}
+
+	var p provider.Provider
+	if cloud == "aliyun" {
+		p, err = newAliyunProvider(cfg)
+	} else {
+		p, err = newTencentProvider(cfg)
+	}
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+	return p, cfg.Name, 0, nil
+}
+
+// handleLoadBalancerList 列出负载均衡,支持 region、type(仅阿里云: slb, alb)、name 参数
+func (s *HTTPGinServer) handleLoadBalancerList(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, account, code, err := s.cloudProvider(cloud, c.Query("account"))
+		if err != nil {
+			s.error(c, code, err.Error())
+			return
+		}
+
+		opts := &provider.QueryOptions{
+			Region: c.Query("region"),
+			Filters: map[string]string{
+				"type": c.Query("type"),
+				"name": c.Query("name"),
+			},
+		}
+
+		lbs, err := p.ListLoadBalancers(c.Request.Context(), opts)
+		if err != nil {
+			s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list load balancers: %v", err))
+			return
+		}
+
+		s.success(c, gin.H{
+			"total":          len(lbs),
+			"load_balancers": lbs,
+			"account":        account,
+		})
+	}
+}
+
+// handleLoadBalancerSearch 按服务地址 (VIP) 搜索负载均衡,返回包含后端的详情
+func (s *HTTPGinServer) handleLoadBalancerSearch(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := c.Query("ip")
+		if ip == "" {
+			s.error(c, http.StatusBadRequest, "ip parameter is required")
+			return
+		}
+
+		p, account, code, err := s.cloudProvider(cloud, c.Query("account"))
+		if err != nil {
+			s.error(c, code, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		lbs, err := p.ListLoadBalancers(ctx, &provider.QueryOptions{
+			Filters: map[string]string{"address": ip},
+		})
+		if err != nil {
+			s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list load balancers: %v", err))
+			return
+		}
+
+		if len(lbs) == 0 {
+			s.error(c, http.StatusNotFound, "No matching load balancers found")
+			return
+		}
+
+		matched := make([]*model.LoadBalancer, 0, len(lbs))
+		for _, lb := range lbs {
+			detail, err := p.GetLoadBalancer(ctx, lb.ID)
+			if err != nil {
+				logx.Warn("Failed to get load balancer detail, id %s, error %v", lb.ID, err)
+				detail = lb
+			}
+			matched = append(matched, detail)
+		}
+
+		s.success(c, gin.H{
+			"total":          len(matched),
+			"load_balancers": matched,
+			"account":        account,
+		})
+	}
+}
+
+// handleLoadBalancerGet 获取负载均衡详情
+func (s *HTTPGinServer) handleLoadBalancerGet(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lbID := c.Query("lb_id")
+		if lbID == "" {
+			s.error(c, http.StatusBadRequest, "lb_id parameter is required")
+			return
+		}
+
+		p, account, code, err := s.cloudProvider(cloud, c.Query("account"))
+		if err != nil {
+			s.error(c, code, err.Error())
+			return
+		}
+
+		lb, err := p.GetLoadBalancer(c.Request.Context(), lbID)
+		if err != nil {
+			s.error(c, http.StatusNotFound, fmt.Sprintf("Load balancer not found: %v", err))
+			return
+		}
+
+		s.success(c, gin.H{
+			"load_balancer": lb,
+			"account":       account,
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/eryajf/zenops/internal/monitor"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== 监控指标 API ====================
+
+// handleResourceMetrics 查询实例或数据库的监控指标,resource 为实例 ID、数据库 ID 或实例 IP
+// 支持 cloud、account、type (instance/database)、metrics (逗号分隔)、window (如 1h、7d) 参数
+func (s *HTTPGinServer) handleResourceMetrics(c *gin.Context) {
+	window, err := monitor.ParseWindow(c.Query("window"))
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	metrics, err := monitor.ParseMetrics(c.Query("metrics"))
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	querier, err := monitor.Load(s.config, c.Query("cloud"), c.Query("account"))
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := querier.Query(c.Request.Context(), c.Param("resource"), c.Query("type"), metrics, window)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to get metrics: %v", err))
+		return
+	}
+
+	s.success(c, result)
+}
@@ -0,0 +1,171 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/eryajf/zenops/internal/model"
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== 网络 API ====================
+// VPC、子网 (阿里云交换机)、安全组及实例生效规则,阿里云与腾讯云共用处理逻辑
+
+// handleVPCList 列出 VPC,支持 region 参数
+func (s *HTTPGinServer) handleVPCList(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, account, code, err := s.cloudProvider(cloud, c.Query("account"))
+		if err != nil {
+			s.error(c, code, err.Error())
+			return
+		}
+
+		vpcs, err := p.ListVPCs(c.Request.Context(), &provider.QueryOptions{Region: c.Query("region")})
+		if err != nil {
+			s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list VPCs: %v", err))
+			return
+		}
+
+		s.success(c, gin.H{
+			"total":   len(vpcs),
+			"vpcs":    vpcs,
+			"account": account,
+		})
+	}
+}
+
+// handleSubnetList 列出子网,支持 region、vpc_id 参数
+func (s *HTTPGinServer) handleSubnetList(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, account, code, err := s.cloudProvider(cloud, c.Query("account"))
+		if err != nil {
+			s.error(c, code, err.Error())
+			return
+		}
+
+		subnets, err := p.ListSubnets(c.Request.Context(), &provider.QueryOptions{
+			Region:  c.Query("region"),
+			Filters: map[string]string{"vpc_id": c.Query("vpc_id")},
+		})
+		if err != nil {
+			s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list subnets: %v", err))
+			return
+		}
+
+		s.success(c, gin.H{
+			"total":   len(subnets),
+			"subnets": subnets,
+			"account": account,
+		})
+	}
+}
+
+// handleSecurityGroupList 列出安全组,支持 region、vpc_id(仅阿里云) 参数
+func (s *HTTPGinServer) handleSecurityGroupList(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, account, code, err := s.cloudProvider(cloud, c.Query("account"))
+		if err != nil {
+			s.error(c, code, err.Error())
+			return
+		}
+
+		groups, err := p.ListSecurityGroups(c.Request.Context(), &provider.QueryOptions{
+			Region:  c.Query("region"),
+			Filters: map[string]string{"vpc_id": c.Query("vpc_id")},
+		})
+		if err != nil {
+			s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list security groups: %v", err))
+			return
+		}
+
+		s.success(c, gin.H{
+			"total":           len(groups),
+			"security_groups": groups,
+			"account":         account,
+		})
+	}
+}
+
+// handleSecurityGroupGet 获取安全组详情及规则
+func (s *HTTPGinServer) handleSecurityGroupGet(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sgID := c.Query("sg_id")
+		if sgID == "" {
+			s.error(c, http.StatusBadRequest, "sg_id parameter is required")
+			return
+		}
+
+		p, account, code, err := s.cloudProvider(cloud, c.Query("account"))
+		if err != nil {
+			s.error(c, code, err.Error())
+			return
+		}
+
+		sg, err := p.GetSecurityGroup(c.Request.Context(), sgID)
+		if err != nil {
+			s.error(c, http.StatusNotFound, fmt.Sprintf("Security group not found: %v", err))
+			return
+		}
+
+		s.success(c, gin.H{
+			"security_group": sg,
+			"account":        account,
+		})
+	}
+}
+
+// handleInstanceNetwork 获取实例网络及生效的安全组规则
+// 指定 port 时评估可达性,可选 protocol(默认 tcp)、cidr(默认 0.0.0.0/0)、direction(默认 ingress)
+func (s *HTTPGinServer) handleInstanceNetwork(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		instanceID := c.Query("instance_id")
+		if instanceID == "" {
+			s.error(c, http.StatusBadRequest, "instance_id parameter is required")
+			return
+		}
+
+		var port int
+		if portStr := c.Query("port"); portStr != "" {
+			var err error
+			if port, err = strconv.Atoi(portStr); err != nil || port <= 0 || port > 65535 {
+				s.error(c, http.StatusBadRequest, "invalid port parameter")
+				return
+			}
+		}
+
+		p, account, code, err := s.cloudProvider(cloud, c.Query("account"))
+		if err != nil {
+			s.error(c, code, err.Error())
+			return
+		}
+
+		network, err := p.GetInstanceNetwork(c.Request.Context(), instanceID)
+		if err != nil {
+			s.error(c, http.StatusNotFound, fmt.Sprintf("Failed to get instance network: %v", err))
+			return
+		}
+
+		data := gin.H{
+			"network": network,
+			"account": account,
+		}
+
+		if port > 0 {
+			check, err := network.CheckAccess(
+				c.DefaultQuery("direction", model.DirectionIngress),
+				c.DefaultQuery("protocol", "tcp"),
+				port,
+				c.DefaultQuery("cidr", "0.0.0.0/0"),
+			)
+			if err != nil {
+				s.error(c, http.StatusBadRequest, err.Error())
+				return
+			}
+			data["check"] = check
+		}
+
+		s.success(c, data)
+	}
+}
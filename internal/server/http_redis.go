@@ -0,0 +1,58 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/eryajf/zenops/internal/provider"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== Redis API ====================
+// 阿里云 Redis/Tair 与腾讯云 Redis 共用处理逻辑,实例通过 ListDatabases 的 engine=redis 过滤返回
+
+// handleRedisList 列出 Redis 实例,支持 region 参数
+func (s *HTTPGinServer) handleRedisList(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		s.queryRedis(c, cloud, c.Query("region"), map[string]string{})
+	}
+}
+
+// handleRedisSearch 搜索 Redis 实例,支持 name(模糊匹配)、address(域名、IP 或 host:port) 参数
+func (s *HTTPGinServer) handleRedisSearch(cloud string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Query("name")
+		address := c.Query("address")
+		if name == "" && address == "" {
+			s.error(c, http.StatusBadRequest, "Either 'name' or 'address' parameter is required")
+			return
+		}
+
+		s.queryRedis(c, cloud, "", map[string]string{"name": name, "address": address})
+	}
+}
+
+// queryRedis 按过滤条件查询 Redis 实例
+func (s *HTTPGinServer) queryRedis(c *gin.Context, cloud, region string, filters map[string]string) {
+	p, account, code, err := s.cloudProvider(cloud, c.Query("account"))
+	if err != nil {
+		s.error(c, code, err.Error())
+		return
+	}
+
+	filters["engine"] = "redis"
+	databases, err := p.ListDatabases(c.Request.Context(), &provider.QueryOptions{
+		Region:  region,
+		Filters: filters,
+	})
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to list Redis instances: %v", err))
+		return
+	}
+
+	s.success(c, gin.H{
+		"total":     len(databases),
+		"databases": databases,
+		"account":   account,
+	})
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"time"
+
+	"github.com/eryajf/zenops/internal/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// metricsMiddleware 按路由模板统计请求数和耗时,未匹配的路由统一记为 unmatched 避免标签膨胀
+func (s *HTTPGinServer) metricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
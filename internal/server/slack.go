@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/imcp"
+	"github.com/eryajf/zenops/internal/slack"
+)
+
+// SlackSocketServer Slack Socket Mode 服务
+type SlackSocketServer struct {
+	config  *config.Config
+	handler *slack.MessageHandler
+	msgMap  sync.Map // 消息去重
+}
+
+// NewSlackSocketServer 创建 Slack Socket Mode 服务
+func NewSlackSocketServer(cfg *config.Config, mcpServer *imcp.MCPServer) (*SlackSocketServer, error) {
+	if !cfg.Slack.Enabled {
+		logx.Info("Slack is disabled, skipping initialization")
+		return nil, nil
+	}
+
+	handler, err := slack.NewMessageHandler(cfg, mcpServer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SlackSocketServer{
+		config:  cfg,
+		handler: handler,
+	}, nil
+}
+
+// Start 启动 Slack Socket Mode 服务,阻塞直到 ctx 取消
+func (s *SlackSocketServer) Start(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	logx.Info("Starting Slack Socket Mode server...")
+
+	// 校验 Bot Token
+	if _, err := s.handler.Client().AuthTest(ctx); err != nil {
+		return err
+	}
+
+	// 启动消息清理协程
+	go s.startMessageCleanup(ctx)
+
+	socketClient := slack.NewSocketModeClient(s.handler.Client(), slack.SocketHandler{
+		OnEvent:       s.handleEvent,
+		OnInteraction: s.handleInteraction,
+	})
+
+	err := socketClient.Run(ctx)
+	logx.Info("Slack Socket Mode server stopped")
+	return err
+}
+
+// handleEvent 处理事件
+func (s *SlackSocketServer) handleEvent(ctx context.Context, eventID string, event *slack.Event) {
+	if !slack.ShouldHandle(event) {
+		return
+	}
+
+	// 消息去重: 同一条消息可能同时触发 app_mention 和 message 事件,Slack 也可能重发
+	key := event.Channel + ":" + event.TS
+	if _, exists := s.msgMap.LoadOrStore(key, time.Now().Unix()); exists {
+		logx.Debug("Duplicate Slack message ignored: event %s, key %s", eventID, key)
+		return
+	}
+
+	logx.Info("Processing Slack message: event %s, type %s, channel_type %s",
+		eventID, event.Type, event.ChannelType)
+
+	// 异步处理消息,避免阻塞事件循环
+	go func() {
+		if err := s.handler.HandleMessage(ctx, event); err != nil {
+			logx.Error("Failed to handle Slack message: %v", err)
+		}
+	}()
+}
+
+// handleInteraction 处理按钮点击
+func (s *SlackSocketServer) handleInteraction(ctx context.Context, callback *slack.InteractionCallback) {
+	go func() {
+		if err := s.handler.HandleInteraction(ctx, callback); err != nil {
+			logx.Error("Failed to handle Slack interaction: %v", err)
+		}
+	}()
+}
+
+// startMessageCleanup 启动消息清理协程
+func (s *SlackSocketServer) startMessageCleanup(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// 清理 5 分钟前的消息记录
+			now := time.Now().Unix()
+			s.msgMap.Range(func(key, value any) bool {
+				if now-value.(int64) > 5*60 {
+					s.msgMap.Delete(key)
+				}
+				return true
+			})
+		}
+	}
+}
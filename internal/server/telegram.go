@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/imcp"
+	"github.com/eryajf/zenops/internal/telegram"
+)
+
+// TelegramPollingServer Telegram 长轮询服务
+type TelegramPollingServer struct {
+	config  *config.Config
+	handler *telegram.MessageHandler
+}
+
+// NewTelegramPollingServer 创建 Telegram 长轮询服务
+func NewTelegramPollingServer(cfg *config.Config, mcpServer *imcp.MCPServer) (*TelegramPollingServer, error) {
+	if !cfg.Telegram.Enabled {
+		logx.Info("Telegram is disabled, skipping initialization")
+		return nil, nil
+	}
+
+	handler, err := telegram.NewMessageHandler(cfg, mcpServer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TelegramPollingServer{
+		config:  cfg,
+		handler: handler,
+	}, nil
+}
+
+// Start 启动长轮询,阻塞直到 ctx 取消
+func (s *TelegramPollingServer) Start(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	logx.Info("Starting Telegram polling server...")
+
+	// 校验 Token 并获取机器人用户名
+	bot, err := s.handler.Client().GetMe(ctx)
+	if err != nil {
+		return err
+	}
+	s.handler.SetBotUsername(bot.Username)
+	logx.Info("Telegram bot authenticated, username %s", bot.Username)
+
+	timeout := s.config.Telegram.PollTimeout
+	if timeout <= 0 {
+		timeout = 30
+	}
+
+	var offset int64
+	delay := time.Second
+	for {
+		updates, err := s.handler.Client().GetUpdates(ctx, offset, timeout)
+		if ctx.Err() != nil {
+			logx.Info("Telegram polling server stopped")
+			return nil
+		}
+		if err != nil {
+			logx.Warn("Failed to get Telegram updates: %v, retrying in %s", err, delay)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, 30*time.Second)
+			continue
+		}
+		delay = time.Second
+
+		for _, update := range updates {
+			// 确认已处理的更新,下次只拉取新的更新
+			offset = update.UpdateID + 1
+			s.handleUpdate(ctx, update)
+		}
+	}
+}
+
+// handleUpdate 处理单个更新
+func (s *TelegramPollingServer) handleUpdate(ctx context.Context, update telegram.Update) {
+	msg := update.Message
+	if msg == nil || !s.handler.ShouldHandle(msg) {
+		return
+	}
+
+	logx.Info("Processing Telegram message: update %d, chat %d, chat_type %s",
+		update.UpdateID, msg.Chat.ID, msg.Chat.Type)
+
+	// 异步处理消息,避免阻塞轮询
+	go func() {
+		if err := s.handler.HandleMessage(ctx, msg); err != nil {
+			logx.Error("Failed to handle Telegram message: %v", err)
+		}
+	}()
+}
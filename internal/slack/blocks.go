@@ -0,0 +1,146 @@
+package slack
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+)
+
+const (
+	// maxSectionText section 块文本长度上限
+	maxSectionText = 3000
+	// maxBlocks 单条消息的块数量上限
+	maxBlocks = 50
+	// maxButtonValue 按钮 value 长度上限
+	maxButtonValue = 2000
+)
+
+// Block Slack Block Kit 块
+type Block map[string]any
+
+// Button 按钮
+type Button struct {
+	ActionID string
+	Text     string
+	Value    string
+	Style    string // primary, danger,为空时使用默认样式
+}
+
+// SectionBlocks 将 mrkdwn 文本按长度上限拆分为多个 section 块
+func SectionBlocks(text string) []Block {
+	var blocks []Block
+	for _, chunk := range splitText(text, maxSectionText) {
+		blocks = append(blocks, Block{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": chunk},
+		})
+	}
+	return blocks
+}
+
+// ActionsBlock 创建按钮组,value 超过长度上限的按钮不展示(截断后的 JSON 无法解析),没有按钮时返回 nil
+func ActionsBlock(blockID string, buttons ...Button) Block {
+	elements := make([]any, 0, len(buttons))
+	for _, b := range buttons {
+		if len(b.Value) > maxButtonValue {
+			logx.Warn("Omitting Slack button %s, value length %d exceeds %d", b.ActionID, len(b.Value), maxButtonValue)
+			continue
+		}
+		element := map[string]any{
+			"type":      "button",
+			"action_id": b.ActionID,
+			"text":      map[string]any{"type": "plain_text", "text": b.Text},
+			"value":     b.Value,
+		}
+		if b.Style != "" {
+			element["style"] = b.Style
+		}
+		elements = append(elements, element)
+	}
+	if len(elements) == 0 {
+		return nil
+	}
+	return Block{
+		"type":     "actions",
+		"block_id": blockID,
+		"elements": elements,
+	}
+}
+
+// limitBlocks 限制块数量,超出部分丢弃并保留末尾的块(如按钮),nil 块忽略
+func limitBlocks(blocks []Block, tail ...Block) []Block {
+	tail = slices.DeleteFunc(tail, func(b Block) bool { return b == nil })
+	if len(blocks)+len(tail) > maxBlocks {
+		blocks = blocks[:maxBlocks-len(tail)]
+	}
+	return append(blocks, tail...)
+}
+
+// splitText 按行拆分文本,每段不超过 limit 个字节
+func splitText(text string, limit int) []string {
+	if text == "" {
+		return nil
+	}
+
+	var chunks []string
+	var current strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		for len(line) > limit {
+			// 单行超长时按字符边界截断
+			cut := limit
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			if current.Len() > 0 {
+				chunks = append(chunks, current.String())
+				current.Reset()
+			}
+			chunks = append(chunks, line[:cut])
+			line = line[cut:]
+		}
+		if current.Len()+len(line) > limit {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// truncate 按字节截断字符串,保证不截断多字节字符
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
+
+var (
+	headingPattern = regexp.MustCompile(`(?m)^#{1,6}\s+(.+)$`)
+	boldPattern    = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	linkPattern    = regexp.MustCompile(`\[([^\]]+)\]\((https?://[^)\s]+)\)`)
+	mentionPattern = regexp.MustCompile(`<@[A-Z0-9]+>`)
+)
+
+// ToMrkdwn 将 LLM 输出的 Markdown 转换为 Slack mrkdwn
+func ToMrkdwn(markdown string) string {
+	text := headingPattern.ReplaceAllString(markdown, "**$1**")
+	text = boldPattern.ReplaceAllString(text, "*$1*")
+	text = linkPattern.ReplaceAllString(text, "<$2|$1>")
+	return text
+}
+
+// stripMentions 去掉消息中的 @ 提及
+func stripMentions(text string) string {
+	return strings.TrimSpace(mentionPattern.ReplaceAllString(text, ""))
+}
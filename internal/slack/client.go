@@ -0,0 +1,165 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+)
+
+// defaultAPIURL Slack Web API 默认地址
+const defaultAPIURL = "https://slack.com/api/"
+
+// Client Slack Web API 客户端
+type Client struct {
+	appToken   string
+	botToken   string
+	apiURL     string
+	httpClient *http.Client
+}
+
+// NewClient 创建 Slack 客户端,apiURL 为空时使用官方地址
+func NewClient(appToken, botToken, apiURL string) *Client {
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+	if !strings.HasSuffix(apiURL, "/") {
+		apiURL += "/"
+	}
+
+	logx.Info("Slack client created, api_url %s", apiURL)
+
+	return &Client{
+		appToken:   appToken,
+		botToken:   botToken,
+		apiURL:     apiURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// apiResponse Web API 通用响应
+type apiResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+// call 调用 Web API,body 为 nil 时发送空请求体
+func (c *Client) call(ctx context.Context, method, token string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s request: %w", method, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+method, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", method, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to call %s: status=%d, body=%s", method, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var result apiResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", method, err)
+	}
+	if !result.OK {
+		return fmt.Errorf("failed to call %s: %s", method, result.Error)
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to parse %s response: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// OpenConnection 获取 Socket Mode 的 WebSocket 地址(使用应用级 Token)
+func (c *Client) OpenConnection(ctx context.Context) (string, error) {
+	var resp struct {
+		URL string `json:"url"`
+	}
+	if err := c.call(ctx, "apps.connections.open", c.appToken, nil, &resp); err != nil {
+		return "", err
+	}
+	if resp.URL == "" {
+		return "", fmt.Errorf("apps.connections.open returned empty url")
+	}
+	return resp.URL, nil
+}
+
+// AuthTest 校验 Bot Token,返回机器人的用户 ID
+func (c *Client) AuthTest(ctx context.Context) (string, error) {
+	var resp struct {
+		UserID string `json:"user_id"`
+		Team   string `json:"team"`
+	}
+	if err := c.call(ctx, "auth.test", c.botToken, nil, &resp); err != nil {
+		return "", err
+	}
+	logx.Info("Slack bot authenticated, team %s, user_id %s", resp.Team, resp.UserID)
+	return resp.UserID, nil
+}
+
+// PostMessage 发送消息,threadTS 不为空时回复到对应话题,返回消息的 ts
+func (c *Client) PostMessage(ctx context.Context, channel, threadTS, text string, blocks []Block) (string, error) {
+	req := map[string]any{
+		"channel": channel,
+		"text":    text,
+	}
+	if threadTS != "" {
+		req["thread_ts"] = threadTS
+	}
+	if len(blocks) > 0 {
+		req["blocks"] = blocks
+	}
+
+	var resp struct {
+		TS string `json:"ts"`
+	}
+	if err := c.call(ctx, "chat.postMessage", c.botToken, req, &resp); err != nil {
+		return "", err
+	}
+
+	logx.Debug("Sent message to %s, ts %s", channel, resp.TS)
+	return resp.TS, nil
+}
+
+// UpdateMessage 更新已发送的消息,用于流式输出
+func (c *Client) UpdateMessage(ctx context.Context, channel, ts, text string, blocks []Block) error {
+	req := map[string]any{
+		"channel": channel,
+		"ts":      ts,
+		"text":    text,
+	}
+	if blocks != nil {
+		req["blocks"] = blocks
+	}
+
+	return c.call(ctx, "chat.update", c.botToken, req, nil)
+}
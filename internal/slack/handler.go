@@ -0,0 +1,229 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/bot"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/imcp"
+)
+
+// updateInterval 流式更新消息的间隔,chat.update 有频率限制,不宜过快
+const updateInterval = time.Second
+
+// MessageHandler Slack 消息处理器
+type MessageHandler struct {
+	client    *Client
+	config    *config.Config
+	mcpServer *imcp.MCPServer
+	core      *bot.Core
+}
+
+// NewMessageHandler 创建消息处理器
+func NewMessageHandler(cfg *config.Config, mcpServer *imcp.MCPServer) (*MessageHandler, error) {
+	if cfg.Slack.AppToken == "" || cfg.Slack.BotToken == "" {
+		return nil, fmt.Errorf("slack app_token and bot_token are required")
+	}
+
+	client := NewClient(cfg.Slack.AppToken, cfg.Slack.BotToken, cfg.Slack.APIURL)
+
+	core := bot.NewCore(cfg, mcpServer, bot.Options{
+		Platform:       "slack",
+		DisplayName:    "Slack",
+		UpdateInterval: updateInterval,
+	})
+
+	return &MessageHandler{
+		client:    client,
+		config:    cfg,
+		mcpServer: mcpServer,
+		core:      core,
+	}, nil
+}
+
+// Client 返回 Slack 客户端
+func (h *MessageHandler) Client() *Client {
+	return h.client
+}
+
+// ShouldHandle 判断事件是否需要处理: 频道中 @机器人 或私聊消息,忽略机器人自己发出的和编辑/删除等子类型消息
+func ShouldHandle(event *Event) bool {
+	if event.BotID != "" || event.Subtype != "" || event.User == "" {
+		return false
+	}
+	switch event.Type {
+	case "app_mention":
+		return true
+	case "message":
+		return event.ChannelType == "im"
+	}
+	return false
+}
+
+// HandleMessage 处理 app_mention 和私聊消息
+func (h *MessageHandler) HandleMessage(ctx context.Context, event *Event) error {
+	msg := &bot.Message{
+		ConversationID: event.Channel,
+		UserID:         event.User,
+		Text:           stripMentions(event.Text),
+	}
+
+	return h.core.HandleMessage(ctx, msg, &replier{
+		client:   h.client,
+		channel:  event.Channel,
+		threadTS: replyThread(event),
+	})
+}
+
+// HandleInteraction 处理按钮点击,结果原地更新按钮所在的消息
+func (h *MessageHandler) HandleInteraction(ctx context.Context, callback *InteractionCallback) error {
+	if callback.Type != "block_actions" || len(callback.Actions) == 0 {
+		return nil
+	}
+
+	// 回复到按钮所在消息的话题中
+	threadTS := callback.Message.ThreadTS
+	if threadTS == "" {
+		threadTS = callback.Message.TS
+	}
+
+	action := &bot.Action{
+		Message: bot.Message{
+			ConversationID: callback.Channel.ID,
+			UserID:         callback.User.ID,
+			UserName:       callback.User.Username,
+		},
+		ActionID: coreActionID(callback.Actions[0].ActionID),
+		Value:    callback.Actions[0].Value,
+	}
+
+	return h.core.HandleAction(ctx, action, &messageUpdater{
+		replier: replier{client: h.client, channel: callback.Channel.ID, threadTS: threadTS},
+		ts:      callback.Message.TS,
+	})
+}
+
+// replyThread 频道消息回复到话题中,私聊直接回复(已在话题中时保持在话题内)
+func replyThread(event *Event) string {
+	if event.ThreadTS != "" {
+		return event.ThreadTS
+	}
+	if event.ChannelType == "im" {
+		return ""
+	}
+	return event.TS
+}
+
+// replier Slack 回复适配器
+type replier struct {
+	client   *Client
+	channel  string
+	threadTS string
+}
+
+// Reply 发送消息
+func (r *replier) Reply(ctx context.Context, content string) error {
+	_, err := r.client.PostMessage(ctx, r.channel, r.threadTS, fallbackText(content),
+		limitBlocks(SectionBlocks(ToMrkdwn(content))))
+	return err
+}
+
+// SupportsActions Block Kit 消息始终支持按钮
+func (r *replier) SupportsActions() bool {
+	return true
+}
+
+// ReplyWithActions 发送带按钮的消息
+func (r *replier) ReplyWithActions(ctx context.Context, content string, buttons []bot.Button) error {
+	_, err := r.client.PostMessage(ctx, r.channel, r.threadTS, fallbackText(content), actionBlocks(content, buttons))
+	return err
+}
+
+// messageUpdater 按钮回调的回复适配器: 结果原地更新按钮所在的消息,流式回复(重新生成)发送新消息
+type messageUpdater struct {
+	replier
+	ts string
+}
+
+// Reply 原地更新消息,不带按钮
+func (u *messageUpdater) Reply(ctx context.Context, content string) error {
+	return u.ReplyWithActions(ctx, content, nil)
+}
+
+// ReplyWithActions 原地更新消息
+func (u *messageUpdater) ReplyWithActions(ctx context.Context, content string, buttons []bot.Button) error {
+	return u.client.UpdateMessage(ctx, u.channel, u.ts, fallbackText(content), actionBlocks(content, buttons))
+}
+
+// actionBlocks 消息内容及按钮组
+func actionBlocks(content string, buttons []bot.Button) []Block {
+	blocks := SectionBlocks(ToMrkdwn(content))
+	if len(buttons) == 0 {
+		return limitBlocks(blocks)
+	}
+
+	elements := make([]Button, 0, len(buttons))
+	for i, b := range buttons {
+		button := Button{ActionID: fmt.Sprintf("%s%s%d", b.ActionID, actionIDSeparator, i), Text: b.Text, Value: b.Value}
+		if b.Danger {
+			button.Style = "danger"
+		}
+		elements = append(elements, button)
+	}
+	return limitBlocks(blocks, ActionsBlock("zenops_actions", elements...))
+}
+
+// actionIDSeparator 同一按钮组内 action_id 不能重复,按钮的 action_id 附加序号
+const actionIDSeparator = "#"
+
+// coreActionID 去掉 action_id 的序号
+func coreActionID(actionID string) string {
+	id, _, _ := strings.Cut(actionID, actionIDSeparator)
+	return id
+}
+
+// StartStream 发送占位消息,后续通过 chat.update 逐步更新
+func (r *replier) StartStream(ctx context.Context, question string) (bot.Stream, error) {
+	ts, err := r.client.PostMessage(ctx, r.channel, r.threadTS, "正在思考中...",
+		SectionBlocks("_正在思考中..._"))
+	if err != nil {
+		logx.Error("Failed to post placeholder message: %v", err)
+		return nil, err
+	}
+
+	return &messageStream{client: r.client, channel: r.channel, ts: ts, question: question}, nil
+}
+
+// messageStream 通过编辑消息实现的流式回复
+type messageStream struct {
+	client   *Client
+	channel  string
+	ts       string
+	question string
+}
+
+// Update 更新消息内容
+func (s *messageStream) Update(ctx context.Context, content string) error {
+	return s.client.UpdateMessage(ctx, s.channel, s.ts, fallbackText(content),
+		limitBlocks(SectionBlocks(ToMrkdwn(content))))
+}
+
+// Finish 发送最终内容并附加操作按钮
+func (s *messageStream) Finish(ctx context.Context, content string) error {
+	blocks := limitBlocks(SectionBlocks(ToMrkdwn(content)),
+		ActionsBlock("zenops_actions",
+			Button{ActionID: bot.ActionRegenerate, Text: "重新生成", Value: s.question, Style: "primary"},
+			Button{ActionID: bot.ActionHelp, Text: "帮助"},
+		),
+	)
+	return s.client.UpdateMessage(ctx, s.channel, s.ts, fallbackText(content), blocks)
+}
+
+// fallbackText 通知和无法显示 blocks 时使用的纯文本
+func fallbackText(content string) string {
+	return truncate(strings.TrimSpace(content), maxSectionText)
+}
@@ -0,0 +1,255 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/eryajf/zenops/internal/bot"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/imcp"
+)
+
+// apiCall 模拟服务收到的 Web API 请求
+type apiCall struct {
+	method string
+	body   map[string]any
+}
+
+// fakeSlack 模拟 Slack Web API,记录收到的请求
+type fakeSlack struct {
+	mu    sync.Mutex
+	calls []apiCall
+}
+
+func (f *fakeSlack) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	f.mu.Lock()
+	f.calls = append(f.calls, apiCall{method: strings.TrimPrefix(r.URL.Path, "/api/"), body: body})
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"ok":true,"ts":"1700000000.000200"}`))
+}
+
+// take 返回并清空已记录的请求
+func (f *fakeSlack) take() []apiCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := f.calls
+	f.calls = nil
+	return calls
+}
+
+func newTestHandler(t *testing.T) (*MessageHandler, *fakeSlack) {
+	t.Helper()
+
+	fake := &fakeSlack{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{}
+	cfg.Slack = config.SlackConfig{Enabled: true, AppToken: "xapp-test", BotToken: "xoxb-test", APIURL: server.URL + "/api/"}
+	cfg.CDN.AllowRefresh = true
+
+	h, err := NewMessageHandler(cfg, imcp.NewMCPServer(cfg))
+	if err != nil {
+		t.Fatalf("NewMessageHandler() error = %v", err)
+	}
+	return h, fake
+}
+
+// blockText 拼接消息中 section 块的文本
+func blockText(body map[string]any) string {
+	var sb strings.Builder
+	blocks, _ := body["blocks"].([]any)
+	for _, b := range blocks {
+		block, _ := b.(map[string]any)
+		if text, ok := block["text"].(map[string]any); ok {
+			sb.WriteString(text["text"].(string))
+		}
+	}
+	return sb.String()
+}
+
+// buttons 返回消息中 actions 块的按钮
+func buttons(body map[string]any) []map[string]any {
+	var result []map[string]any
+	blocks, _ := body["blocks"].([]any)
+	for _, b := range blocks {
+		block, _ := b.(map[string]any)
+		if block["type"] != "actions" {
+			continue
+		}
+		for _, e := range block["elements"].([]any) {
+			result = append(result, e.(map[string]any))
+		}
+	}
+	return result
+}
+
+// click 模拟用户点击 ts 消息中的按钮
+func click(t *testing.T, h *MessageHandler, user, ts string, button map[string]any) {
+	t.Helper()
+
+	payload, _ := json.Marshal(map[string]any{
+		"type":    "block_actions",
+		"user":    map[string]any{"id": user},
+		"channel": map[string]any{"id": "C1"},
+		"message": map[string]any{"ts": ts},
+		"actions": []any{map[string]any{"action_id": button["action_id"], "block_id": "zenops_actions", "value": button["value"]}},
+	})
+	var callback InteractionCallback
+	if err := json.Unmarshal(payload, &callback); err != nil {
+		t.Fatalf("failed to parse callback: %v", err)
+	}
+
+	if err := h.HandleInteraction(context.Background(), &callback); err != nil {
+		t.Fatalf("HandleInteraction() error = %v", err)
+	}
+}
+
+func TestHandleMessage(t *testing.T) {
+	h, fake := newTestHandler(t)
+
+	event := &Event{Type: "app_mention", User: "U1", Channel: "C1", TS: "1700000000.000100", Text: "<@UBOT> /whoami"}
+	if err := h.HandleMessage(context.Background(), event); err != nil {
+		t.Fatalf("HandleMessage() error = %v", err)
+	}
+
+	calls := fake.take()
+	if len(calls) != 1 || calls[0].method != "chat.postMessage" {
+		t.Fatalf("calls = %+v, want one chat.postMessage", calls)
+	}
+	body := calls[0].body
+	if body["channel"] != "C1" || body["thread_ts"] != event.TS {
+		t.Errorf("channel = %v, thread_ts = %v, want C1 in thread %s", body["channel"], body["thread_ts"], event.TS)
+	}
+	if !strings.Contains(blockText(body), "U1") {
+		t.Errorf("reply %q does not mention the user", blockText(body))
+	}
+}
+
+func TestConfirmFlow(t *testing.T) {
+	h, fake := newTestHandler(t)
+
+	event := &Event{Type: "message", ChannelType: "im", User: "U1", Channel: "C1", TS: "1700000000.000100",
+		Text: "/purge https://static.example.com/app.js"}
+	if err := h.HandleMessage(context.Background(), event); err != nil {
+		t.Fatalf("HandleMessage() error = %v", err)
+	}
+
+	// 变更操作先发送确认按钮
+	calls := fake.take()
+	if len(calls) != 1 || calls[0].method != "chat.postMessage" {
+		t.Fatalf("calls = %+v, want one chat.postMessage", calls)
+	}
+	if text := blockText(calls[0].body); !strings.Contains(text, "refresh_cdn_cache") {
+		t.Errorf("confirmation %q does not name the tool", text)
+	}
+	confirm := buttons(calls[0].body)
+	if len(confirm) != 2 {
+		t.Fatalf("buttons = %+v, want confirm and cancel", confirm)
+	}
+	if confirm[0]["action_id"] == confirm[1]["action_id"] {
+		t.Errorf("duplicate action_id %v", confirm[0]["action_id"])
+	}
+	if confirm[0]["style"] != "danger" {
+		t.Errorf("confirm style = %v, want danger", confirm[0]["style"])
+	}
+
+	// 按钮只携带 token,其他用户点击时拒绝并保留确认按钮
+	for _, b := range confirm {
+		if strings.Contains(b["value"].(string), "refresh_cdn_cache") {
+			t.Errorf("button value %v carries the tool call", b["value"])
+		}
+	}
+	click(t, h, "U2", "1700000000.000200", confirm[0])
+	calls = fake.take()
+	if len(calls) != 1 || calls[0].method != "chat.update" {
+		t.Fatalf("calls = %+v, want chat.update of the confirmation", calls)
+	}
+	if text := blockText(calls[0].body); !strings.Contains(text, "只有发起者") || len(buttons(calls[0].body)) != 2 {
+		t.Errorf("reply to other user = %q, buttons %+v", text, buttons(calls[0].body))
+	}
+
+	// 发起者确认后执行工具,结果原地更新确认消息(未配置云账号,工具返回错误)
+	click(t, h, "U1", "1700000000.000200", confirm[0])
+	calls = fake.take()
+	if len(calls) != 1 || calls[0].method != "chat.update" || calls[0].body["ts"] != "1700000000.000200" {
+		t.Fatalf("calls = %+v, want chat.update with the tool result", calls)
+	}
+	if text := blockText(calls[0].body); !strings.Contains(text, "refresh_cdn_cache") || strings.Contains(text, "确认执行") {
+		t.Errorf("tool result = %q", text)
+	}
+	if len(buttons(calls[0].body)) != 0 {
+		t.Errorf("tool result has buttons %+v", buttons(calls[0].body))
+	}
+
+	// token 只能使用一次
+	click(t, h, "U1", "1700000000.000200", confirm[1])
+	calls = fake.take()
+	if len(calls) != 1 || !strings.Contains(blockText(calls[0].body), "未找到待确认的操作") {
+		t.Errorf("calls = %+v, want expired confirmation", calls)
+	}
+}
+
+func TestCoreActionID(t *testing.T) {
+	for _, id := range []string{bot.ActionRunTool, bot.ActionPage, bot.ActionConfirm, bot.ActionRegenerate} {
+		if got := coreActionID(id + actionIDSeparator + "3"); got != id {
+			t.Errorf("coreActionID(%q) = %q", id+"#3", got)
+		}
+		if got := coreActionID(id); got != id {
+			t.Errorf("coreActionID(%q) = %q", id, got)
+		}
+	}
+}
+
+func TestActionBlocksOmitOversizedValues(t *testing.T) {
+	long := `{"tool":"get_ecs","args":{"instance_id":"` + strings.Repeat("i", maxButtonValue) + `"}}`
+	tests := []struct {
+		name    string
+		buttons []bot.Button
+		want    []string
+	}{
+		{
+			name: "keeps valid buttons",
+			buttons: []bot.Button{
+				{Text: "查看详情", ActionID: bot.ActionRunTool, Value: long},
+				{Text: "下一页", ActionID: bot.ActionPage, Value: "abc:2"},
+			},
+			want: []string{"abc:2"},
+		},
+		{
+			name:    "no valid buttons",
+			buttons: []bot.Button{{Text: "查看详情", ActionID: bot.ActionRunTool, Value: long}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := map[string]any{}
+			raw, _ := json.Marshal(map[string]any{"blocks": actionBlocks("结果", tt.buttons)})
+			_ = json.Unmarshal(raw, &body)
+
+			var got []string
+			for _, b := range buttons(body) {
+				got = append(got, b["value"].(string))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("button values = %q, want %q", got, tt.want)
+			}
+			for _, b := range body["blocks"].([]any) {
+				if b.(map[string]any)["type"] == "actions" && len(got) == 0 {
+					t.Error("empty actions block")
+				}
+			}
+		})
+	}
+}
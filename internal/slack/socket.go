@@ -0,0 +1,228 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// maxReconnectDelay 重连最大等待时间
+	maxReconnectDelay = 30 * time.Second
+	// pingInterval WebSocket 心跳间隔
+	pingInterval = 30 * time.Second
+)
+
+// envelope Socket Mode 消息信封
+type envelope struct {
+	EnvelopeID   string          `json:"envelope_id"`
+	Type         string          `json:"type"` // hello, events_api, interactive, slash_commands, disconnect
+	Payload      json.RawMessage `json:"payload"`
+	RetryAttempt int             `json:"retry_attempt"`
+	Reason       string          `json:"reason"` // disconnect 原因
+}
+
+// eventsAPIPayload events_api 类型的消息体
+type eventsAPIPayload struct {
+	EventID string `json:"event_id"`
+	Event   Event  `json:"event"`
+}
+
+// Event Events API 事件(app_mention、message)
+type Event struct {
+	Type        string `json:"type"`
+	Subtype     string `json:"subtype"`
+	User        string `json:"user"`
+	BotID       string `json:"bot_id"`
+	Text        string `json:"text"`
+	Channel     string `json:"channel"`
+	ChannelType string `json:"channel_type"` // im 为私聊
+	TS          string `json:"ts"`
+	ThreadTS    string `json:"thread_ts"`
+}
+
+// InteractionCallback 交互回调(按钮点击)
+type InteractionCallback struct {
+	Type string `json:"type"` // block_actions
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Channel struct {
+		ID string `json:"id"`
+	} `json:"channel"`
+	Message struct {
+		TS       string `json:"ts"`
+		ThreadTS string `json:"thread_ts"`
+	} `json:"message"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		BlockID  string `json:"block_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+}
+
+// SocketHandler Socket Mode 事件回调,在读取协程中同步调用,耗时操作需自行异步处理
+type SocketHandler struct {
+	OnEvent       func(ctx context.Context, eventID string, event *Event)
+	OnInteraction func(ctx context.Context, callback *InteractionCallback)
+}
+
+// SocketModeClient Socket Mode 客户端,断线后自动重连
+type SocketModeClient struct {
+	client  *Client
+	handler SocketHandler
+
+	writeMu sync.Mutex
+}
+
+// NewSocketModeClient 创建 Socket Mode 客户端
+func NewSocketModeClient(client *Client, handler SocketHandler) *SocketModeClient {
+	return &SocketModeClient{
+		client:  client,
+		handler: handler,
+	}
+}
+
+// Run 建立连接并处理事件,直到 ctx 取消
+func (s *SocketModeClient) Run(ctx context.Context) error {
+	delay := time.Second
+	for {
+		connected, err := s.connectOnce(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if connected {
+			delay = time.Second
+		}
+		if err != nil {
+			logx.Warn("Slack Socket Mode connection lost: %v, reconnecting in %s", err, delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// connectOnce 建立一次连接并持续读取,连接断开时返回;connected 表示是否收到过 hello
+func (s *SocketModeClient) connectOnce(ctx context.Context) (connected bool, err error) {
+	url, err := s.client.OpenConnection(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to dial socket mode url: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	// ctx 取消时关闭连接,使读取立即返回
+	done := make(chan struct{})
+	defer close(done)
+	go s.keepalive(ctx, conn, done)
+
+	for {
+		var env envelope
+		if err := conn.ReadJSON(&env); err != nil {
+			return connected, fmt.Errorf("failed to read envelope: %w", err)
+		}
+
+		// 先确认再处理,避免 Slack 因超时重发
+		if env.EnvelopeID != "" {
+			if err := s.ack(conn, env.EnvelopeID); err != nil {
+				return connected, err
+			}
+		}
+
+		switch env.Type {
+		case "hello":
+			connected = true
+			logx.Info("Slack Socket Mode connected")
+		case "disconnect":
+			logx.Info("Slack Socket Mode disconnect requested, reason %s", env.Reason)
+			return connected, nil
+		case "events_api":
+			s.dispatchEvent(ctx, env)
+		case "interactive":
+			s.dispatchInteraction(ctx, env)
+		default:
+			logx.Debug("Ignoring Slack envelope type %s", env.Type)
+		}
+	}
+}
+
+// keepalive 定时发送 ping,并在 ctx 取消时关闭连接
+func (s *SocketModeClient) keepalive(ctx context.Context, conn *websocket.Conn, done <-chan struct{}) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			_ = conn.Close()
+			return
+		case <-ticker.C:
+			s.writeMu.Lock()
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second))
+			s.writeMu.Unlock()
+			if err != nil {
+				logx.Debug("Slack Socket Mode ping failed: %v", err)
+			}
+		}
+	}
+}
+
+// ack 确认收到信封
+func (s *SocketModeClient) ack(conn *websocket.Conn, envelopeID string) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := conn.WriteJSON(map[string]string{"envelope_id": envelopeID}); err != nil {
+		return fmt.Errorf("failed to ack envelope: %w", err)
+	}
+	return nil
+}
+
+// dispatchEvent 分发 Events API 事件
+func (s *SocketModeClient) dispatchEvent(ctx context.Context, env envelope) {
+	if s.handler.OnEvent == nil {
+		return
+	}
+
+	var payload eventsAPIPayload
+	if err := json.Unmarshal(env.Payload, &payload); err != nil {
+		logx.Warn("Failed to parse Slack event payload: %v", err)
+		return
+	}
+	s.handler.OnEvent(ctx, payload.EventID, &payload.Event)
+}
+
+// dispatchInteraction 分发交互回调
+func (s *SocketModeClient) dispatchInteraction(ctx context.Context, env envelope) {
+	if s.handler.OnInteraction == nil {
+		return
+	}
+
+	var callback InteractionCallback
+	if err := json.Unmarshal(env.Payload, &callback); err != nil {
+		logx.Warn("Failed to parse Slack interaction payload: %v", err)
+		return
+	}
+	s.handler.OnInteraction(ctx, &callback)
+}
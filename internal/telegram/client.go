@@ -0,0 +1,223 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+)
+
+// defaultAPIURL Telegram Bot API 默认地址
+const defaultAPIURL = "https://api.telegram.org"
+
+// ParseModeHTML HTML 格式消息
+const ParseModeHTML = "HTML"
+
+// Client Telegram Bot API 客户端
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient 创建 Telegram 客户端,apiURL 为空时使用官方地址
+func NewClient(botToken, apiURL string) *Client {
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+
+	logx.Info("Telegram client created, api_url %s", apiURL)
+
+	return &Client{
+		baseURL: strings.TrimRight(apiURL, "/") + "/bot" + botToken + "/",
+		// 长轮询会阻塞到超时,单次请求超时由 ctx 控制
+		httpClient: &http.Client{},
+	}
+}
+
+// User Telegram 用户
+type User struct {
+	ID        int64  `json:"id"`
+	IsBot     bool   `json:"is_bot"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+}
+
+// Chat Telegram 会话
+type Chat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"` // private, group, supergroup, channel
+}
+
+// Message Telegram 消息
+type Message struct {
+	MessageID      int64    `json:"message_id"`
+	From           *User    `json:"from"`
+	Chat           Chat     `json:"chat"`
+	Text           string   `json:"text"`
+	ReplyToMessage *Message `json:"reply_to_message"`
+}
+
+// Update getUpdates 返回的更新
+type Update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *Message `json:"message"`
+}
+
+// apiResponse Bot API 通用响应
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+}
+
+// APIError Bot API 返回的错误
+type APIError struct {
+	Method      string
+	Code        int
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("failed to call %s: code=%d, description=%s", e.Method, e.Code, e.Description)
+}
+
+// call 调用 Bot API
+func (c *Client) call(ctx context.Context, method string, params any, out any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", method, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+method, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// 错误信息中的 URL 包含 Token,不直接返回
+		return fmt.Errorf("failed to call %s: %w", method, unwrapURLError(err))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", method, err)
+	}
+
+	var result apiResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to parse %s response: status=%d", method, resp.StatusCode)
+	}
+	if !result.OK {
+		return &APIError{Method: method, Code: result.ErrorCode, Description: result.Description}
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(result.Result, out); err != nil {
+			return fmt.Errorf("failed to parse %s result: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// GetMe 获取机器人信息,用于校验 Token
+func (c *Client) GetMe(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.call(ctx, "getMe", map[string]any{}, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUpdates 长轮询获取消息更新
+func (c *Client) GetUpdates(ctx context.Context, offset int64, timeout int) ([]Update, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout+10)*time.Second)
+	defer cancel()
+
+	var updates []Update
+	err := c.call(ctx, "getUpdates", map[string]any{
+		"offset":          offset,
+		"timeout":         timeout,
+		"allowed_updates": []string{"message"},
+	}, &updates)
+	return updates, err
+}
+
+// SendMessage 发送消息,parseMode 为空时发送纯文本,返回消息 ID
+func (c *Client) SendMessage(ctx context.Context, chatID int64, text, parseMode string, replyTo int64) (int64, error) {
+	params := map[string]any{
+		"chat_id": chatID,
+		"text":    text,
+	}
+	if parseMode != "" {
+		params["parse_mode"] = parseMode
+	}
+	if replyTo != 0 {
+		params["reply_parameters"] = map[string]any{
+			"message_id":                  replyTo,
+			"allow_sending_without_reply": true,
+		}
+	}
+
+	var msg Message
+	if err := c.call(ctx, "sendMessage", params, &msg); err != nil {
+		return 0, err
+	}
+
+	logx.Debug("Sent message to chat %d, message_id %d", chatID, msg.MessageID)
+	return msg.MessageID, nil
+}
+
+// EditMessageText 编辑已发送的消息,用于流式输出
+func (c *Client) EditMessageText(ctx context.Context, chatID, messageID int64, text, parseMode string) error {
+	params := map[string]any{
+		"chat_id":    chatID,
+		"message_id": messageID,
+		"text":       text,
+	}
+	if parseMode != "" {
+		params["parse_mode"] = parseMode
+	}
+
+	err := c.call(ctx, "editMessageText", params, nil)
+	if IsNotModified(err) {
+		return nil
+	}
+	return err
+}
+
+// IsNotModified 内容未变化时 Telegram 会返回错误,可忽略
+func IsNotModified(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && strings.Contains(apiErr.Description, "message is not modified")
+}
+
+// IsParseError 消息格式解析失败
+func IsParseError(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && strings.Contains(apiErr.Description, "can't parse entities")
+}
+
+// IsTooLong 消息超过长度上限
+func IsTooLong(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && strings.Contains(apiErr.Description, "message is too long")
+}
+
+// unwrapURLError 去掉 *url.Error 中包含 Token 的 URL
+func unwrapURLError(err error) error {
+	type unwrapper interface{ Unwrap() error }
+	if u, ok := err.(unwrapper); ok && u.Unwrap() != nil {
+		return u.Unwrap()
+	}
+	return err
+}
@@ -0,0 +1,292 @@
+package telegram
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Telegram 只支持有限的 HTML 标签且不支持表格,这里将 LLM 输出的 Markdown 转换为 Telegram HTML:
+// 表格渲染为等宽对齐的 <pre> 块,代码块渲染为 <pre><code>,其余按行转换行内格式
+
+// maxMessageLength 单条消息长度上限(字符)
+const maxMessageLength = 4096
+
+// chunkLength 拆分 Markdown 时每段的长度,为 HTML 转义和标签预留余量
+const chunkLength = 3500
+
+// minChunkLength 重新拆分的最小长度,仍然超长的段降级为纯文本发送
+const minChunkLength = 500
+
+var (
+	headingPattern    = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+	inlineCodePattern = regexp.MustCompile("`([^`]+)`")
+	boldPattern       = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	linkPattern       = regexp.MustCompile(`\[([^\]]+)\]\((https?://[^)\s]+)\)`)
+	separatorPattern  = regexp.MustCompile(`^:?-{2,}:?$`)
+)
+
+// ToHTML 将 Markdown 转换为 Telegram HTML,未闭合的代码块会自动闭合
+func ToHTML(markdown string) string {
+	var b strings.Builder
+	var table []string
+	inFence := false
+
+	flushTable := func() {
+		if len(table) > 0 {
+			b.WriteString(renderTable(table))
+			table = nil
+		}
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			flushTable()
+			if inFence {
+				b.WriteString("</code></pre>\n")
+			} else {
+				lang := strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+				if lang != "" {
+					b.WriteString(`<pre><code class="language-` + html.EscapeString(lang) + `">`)
+				} else {
+					b.WriteString("<pre><code>")
+				}
+			}
+			inFence = !inFence
+			continue
+		}
+
+		if inFence {
+			b.WriteString(html.EscapeString(line) + "\n")
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "|") {
+			table = append(table, trimmed)
+			continue
+		}
+		flushTable()
+
+		b.WriteString(formatLine(line) + "\n")
+	}
+
+	flushTable()
+	if inFence {
+		b.WriteString("</code></pre>")
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// formatLine 转换单行的行内格式: 标题、加粗、行内代码、链接
+func formatLine(line string) string {
+	if m := headingPattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+		return "<b>" + html.EscapeString(strings.ReplaceAll(m[1], "**", "")) + "</b>"
+	}
+
+	// 先提取行内代码,避免代码中的内容被当作格式处理
+	var codes []string
+	line = inlineCodePattern.ReplaceAllStringFunc(line, func(s string) string {
+		codes = append(codes, s[1:len(s)-1])
+		return "\x00" + strconv.Itoa(len(codes)-1) + "\x00"
+	})
+
+	text := html.EscapeString(line)
+	text = boldPattern.ReplaceAllString(text, "<b>$1</b>")
+	text = linkPattern.ReplaceAllString(text, `<a href="$2">$1</a>`)
+
+	for i, code := range codes {
+		text = strings.Replace(text, "\x00"+strconv.Itoa(i)+"\x00", "<code>"+html.EscapeString(code)+"</code>", 1)
+	}
+	return text
+}
+
+// renderTable 将 Markdown 表格渲染为列对齐的 <pre> 块
+func renderTable(lines []string) string {
+	var rows [][]string
+	for _, line := range lines {
+		cells := splitRow(line)
+		if isSeparatorRow(cells) {
+			continue
+		}
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+
+	// 计算每列的显示宽度
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := displayWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("<pre>")
+	for r, row := range rows {
+		var line strings.Builder
+		for i, cell := range row {
+			if i > 0 {
+				line.WriteString("  ")
+			}
+			line.WriteString(cell)
+			if i < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", widths[i]-displayWidth(cell)))
+			}
+		}
+		b.WriteString(html.EscapeString(strings.TrimRight(line.String(), " ")) + "\n")
+
+		// 表头下方加分隔线
+		if r == 0 && len(rows) > 1 {
+			total := 0
+			for i, w := range widths {
+				if i > 0 {
+					total += 2
+				}
+				total += w
+			}
+			b.WriteString(strings.Repeat("-", total) + "\n")
+		}
+	}
+	b.WriteString("</pre>\n")
+	return b.String()
+}
+
+// splitRow 拆分表格行,去掉首尾的竖线和单元格中的加粗标记
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+
+	parts := strings.Split(line, "|")
+	cells := make([]string, 0, len(parts))
+	for _, part := range parts {
+		cell := strings.TrimSpace(part)
+		cell = strings.ReplaceAll(cell, "**", "")
+		cell = strings.ReplaceAll(cell, "`", "")
+		cells = append(cells, cell)
+	}
+	return cells
+}
+
+// isSeparatorRow 是否为表头分隔行(|---|:---:|)
+func isSeparatorRow(cells []string) bool {
+	for _, cell := range cells {
+		if !separatorPattern.MatchString(cell) {
+			return false
+		}
+	}
+	return len(cells) > 0
+}
+
+// displayWidth 计算等宽字体下的显示宽度,中日韩字符和全角符号占两列
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Han, r), unicode.Is(unicode.Hangul, r),
+			unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r),
+			r >= 0x3000 && r <= 0x303F, // 中文标点
+			r >= 0xFF00 && r <= 0xFF60, // 全角字符
+			r >= 0x1F300:               // emoji
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// SplitMarkdown 按行将 Markdown 拆分为多段,跨段的代码块会在段尾闭合、段首重新打开
+func SplitMarkdown(markdown string, limit int) []string {
+	if utf8.RuneCountInString(markdown) <= limit {
+		return []string{markdown}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	currentLen := 0
+	fence := "" // 当前所在代码块的起始行,不在代码块中时为空
+
+	for _, line := range strings.Split(markdown, "\n") {
+		lineLen := utf8.RuneCountInString(line) + 1
+		if currentLen > 0 && currentLen+lineLen > limit {
+			if fence != "" {
+				current.WriteString("```\n")
+			}
+			chunks = append(chunks, strings.TrimRight(current.String(), "\n"))
+			current.Reset()
+			currentLen = 0
+			if fence != "" {
+				current.WriteString(fence + "\n")
+				currentLen += utf8.RuneCountInString(fence) + 1
+			}
+		}
+
+		// 单行超长时强制截断
+		for utf8.RuneCountInString(line) > limit {
+			runes := []rune(line)
+			chunks = append(chunks, string(runes[:limit]))
+			line = string(runes[limit:])
+			lineLen = len(runes) - limit + 1
+		}
+
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") {
+			if fence == "" {
+				fence = trimmed
+			} else {
+				fence = ""
+			}
+		}
+
+		current.WriteString(line + "\n")
+		currentLen += lineLen
+	}
+
+	if currentLen > 0 {
+		chunks = append(chunks, strings.TrimRight(current.String(), "\n"))
+	}
+	return chunks
+}
+
+// chunk 拆分后的一段消息,Markdown 原文用于降级为纯文本
+type chunk struct {
+	markdown string
+	html     string
+}
+
+// fitsHTML HTML 是否未超过单条消息上限
+func (c chunk) fitsHTML() bool {
+	return utf8.RuneCountInString(c.html) <= maxMessageLength
+}
+
+// renderChunks 拆分 Markdown 并转换为 HTML
+// 转义、标签和表格对齐会使 HTML 变长,超过单条消息上限的段按更小的长度重新拆分
+func renderChunks(markdown string) []chunk {
+	return renderChunksWithin(markdown, chunkLength)
+}
+
+func renderChunksWithin(markdown string, limit int) []chunk {
+	var chunks []chunk
+	for _, part := range SplitMarkdown(markdown, limit) {
+		c := chunk{markdown: part, html: ToHTML(part)}
+		if !c.fitsHTML() && limit > minChunkLength {
+			chunks = append(chunks, renderChunksWithin(part, limit/2)...)
+			continue
+		}
+		chunks = append(chunks, c)
+	}
+	return chunks
+}
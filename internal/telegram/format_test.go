@@ -0,0 +1,120 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "escape",
+			markdown: "a < b && c > d",
+			want:     "a &lt; b &amp;&amp; c &gt; d",
+		},
+		{
+			name:     "inline format",
+			markdown: "## 实例\n**状态** `<running>` [控制台](https://example.com/a?b=1)",
+			want:     "<b>实例</b>\n<b>状态</b> <code>&lt;running&gt;</code> <a href=\"https://example.com/a?b=1\">控制台</a>",
+		},
+		{
+			name:     "code block",
+			markdown: "```go\nif a < b {\n**x**\n```",
+			want:     "<pre><code class=\"language-go\">if a &lt; b {\n**x**\n</code></pre>",
+		},
+		{
+			name:     "unclosed code block",
+			markdown: "```\nline",
+			want:     "<pre><code>line\n</code></pre>",
+		},
+		{
+			name:     "table",
+			markdown: "| 名称 | IP |\n|---|---|\n| **web-1** | 10.0.0.1 |\n| db | `10.0.0.20` |",
+			want:     "<pre>名称   IP\n----------------\nweb-1  10.0.0.1\ndb     10.0.0.20\n</pre>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToHTML(tt.markdown); got != tt.want {
+				t.Errorf("ToHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitMarkdown(t *testing.T) {
+	code := "```sh\n" + strings.Repeat("echo line\n", 6) + "```"
+	tests := []struct {
+		name     string
+		markdown string
+		limit    int
+		want     []string
+	}{
+		{
+			name:     "short",
+			markdown: "hello\nworld",
+			limit:    20,
+			want:     []string{"hello\nworld"},
+		},
+		{
+			name:     "by line",
+			markdown: "aaaa\nbbbb\ncccc",
+			limit:    10,
+			want:     []string{"aaaa\nbbbb", "cccc"},
+		},
+		{
+			name:     "long line",
+			markdown: strings.Repeat("x", 12),
+			limit:    5,
+			want:     []string{"xxxxx", "xxxxx", "xx"},
+		},
+		{
+			name:     "code fence across chunks",
+			markdown: "结果:\n" + code,
+			limit:    40,
+			want: []string{
+				"结果:\n```sh\necho line\necho line\necho line\n```",
+				"```sh\necho line\necho line\necho line\n```",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitMarkdown(tt.markdown, tt.limit)
+			if strings.Join(got, "\n---\n") != strings.Join(tt.want, "\n---\n") {
+				t.Errorf("SplitMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderChunks(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+	}{
+		{name: "escaped text", markdown: strings.Repeat("a<b&c>d ", 440)},
+		{name: "padded table", markdown: "| 名称 | IP | 状态 |\n|---|---|---|\n" + strings.Repeat("| web-server-production-0001 | 10.0.0.1 | 运行中 |\n| db | 10.0.0.2 | 运行中 |\n", 80)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := SplitMarkdown(tt.markdown, chunkLength)[0]
+			if n := utf8.RuneCountInString(ToHTML(first)); n <= maxMessageLength {
+				t.Fatalf("first chunk renders to %d runes, want more than %d", n, maxMessageLength)
+			}
+			for i, c := range renderChunks(tt.markdown) {
+				if !c.fitsHTML() {
+					t.Errorf("chunk %d renders to %d runes", i, utf8.RuneCountInString(c.html))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/bot"
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/imcp"
+)
+
+// updateInterval 流式编辑消息的间隔,Telegram 对同一会话的编辑频率有限制
+const updateInterval = time.Second
+
+// MessageHandler Telegram 消息处理器
+type MessageHandler struct {
+	client       *Client
+	config       *config.Config
+	mcpServer    *imcp.MCPServer
+	core         *bot.Core
+	allowedChats map[int64]bool
+	botUsername  string
+}
+
+// NewMessageHandler 创建消息处理器
+func NewMessageHandler(cfg *config.Config, mcpServer *imcp.MCPServer) (*MessageHandler, error) {
+	if cfg.Telegram.BotToken == "" {
+		return nil, fmt.Errorf("telegram bot_token is required")
+	}
+
+	client := NewClient(cfg.Telegram.BotToken, cfg.Telegram.APIURL)
+
+	allowedChats := make(map[int64]bool, len(cfg.Telegram.AllowedChatIDs))
+	for _, id := range cfg.Telegram.AllowedChatIDs {
+		allowedChats[id] = true
+	}
+	if len(allowedChats) == 0 {
+		logx.Warn("Telegram allowed_chat_ids is empty, all chats will be rejected")
+	}
+
+	core := bot.NewCore(cfg, mcpServer, bot.Options{
+		Platform:       "telegram",
+		DisplayName:    "Telegram",
+		UpdateInterval: updateInterval,
+	})
+
+	return &MessageHandler{
+		client:       client,
+		config:       cfg,
+		mcpServer:    mcpServer,
+		core:         core,
+		allowedChats: allowedChats,
+	}, nil
+}
+
+// Client 返回 Telegram 客户端
+func (h *MessageHandler) Client() *Client {
+	return h.client
+}
+
+// SetBotUsername 设置机器人用户名,用于去掉群聊消息中的 @机器人
+func (h *MessageHandler) SetBotUsername(username string) {
+	h.botUsername = username
+}
+
+// ShouldHandle 判断消息是否需要处理: 私聊消息,或群聊中 @机器人、命令和回复机器人的消息
+func (h *MessageHandler) ShouldHandle(msg *Message) bool {
+	if msg.Text == "" || (msg.From != nil && msg.From.IsBot) {
+		return false
+	}
+	if msg.Chat.Type == "private" {
+		return true
+	}
+	if strings.HasPrefix(msg.Text, "/") {
+		return true
+	}
+	if h.botUsername != "" && strings.Contains(msg.Text, "@"+h.botUsername) {
+		return true
+	}
+	reply := msg.ReplyToMessage
+	return reply != nil && reply.From != nil && reply.From.IsBot && reply.From.Username == h.botUsername
+}
+
+// HandleMessage 处理文本消息
+func (h *MessageHandler) HandleMessage(ctx context.Context, msg *Message) error {
+	// 会话白名单校验,未授权时返回会话 ID 便于管理员配置
+	if !h.allowedChats[msg.Chat.ID] {
+		logx.Warn("Rejected Telegram message from unauthorized chat %d", msg.Chat.ID)
+		_, err := h.client.SendMessage(ctx, msg.Chat.ID,
+			fmt.Sprintf("当前会话未授权使用 ZenOps 机器人,请联系管理员将 chat_id %d 加入 telegram.allowed_chat_ids。", msg.Chat.ID),
+			"", msg.MessageID)
+		return err
+	}
+
+	botMsg := &bot.Message{
+		ConversationID: strconv.FormatInt(msg.Chat.ID, 10),
+		Text:           h.cleanText(msg.Text),
+	}
+	if msg.From != nil {
+		botMsg.UserID = strconv.FormatInt(msg.From.ID, 10)
+		botMsg.UserName = msg.From.Username
+	}
+
+	return h.core.HandleMessage(ctx, botMsg, &replier{client: h.client, chatID: msg.Chat.ID, replyTo: msg.MessageID})
+}
+
+// cleanText 去掉 @机器人 和命令后缀(/help@bot)
+func (h *MessageHandler) cleanText(text string) string {
+	if h.botUsername != "" {
+		text = strings.ReplaceAll(text, "@"+h.botUsername, "")
+	}
+	return strings.TrimSpace(text)
+}
+
+// replier Telegram 回复适配器
+type replier struct {
+	client  *Client
+	chatID  int64
+	replyTo int64 // 回复的消息 ID
+}
+
+// Reply 发送消息,超长时拆分为多条
+func (r *replier) Reply(ctx context.Context, content string) error {
+	for i, c := range renderChunks(content) {
+		replyTo := r.replyTo
+		if i > 0 {
+			replyTo = 0
+		}
+		if _, err := sendMessage(ctx, r.client, r.chatID, c, replyTo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StartStream 发送占位消息,后续通过 editMessageText 逐步更新
+func (r *replier) StartStream(ctx context.Context, _ string) (bot.Stream, error) {
+	messageID, err := r.client.SendMessage(ctx, r.chatID, "<i>正在思考中...</i>", ParseModeHTML, r.replyTo)
+	if err != nil {
+		logx.Error("Failed to send placeholder message: %v", err)
+		return nil, err
+	}
+	return &messageStream{client: r.client, chatID: r.chatID, messageID: messageID}, nil
+}
+
+// messageStream 通过编辑消息实现的流式回复
+type messageStream struct {
+	client    *Client
+	chatID    int64
+	messageID int64
+}
+
+// Update 编辑消息,超长时只显示第一段
+func (s *messageStream) Update(ctx context.Context, content string) error {
+	return editMessage(ctx, s.client, s.chatID, s.messageID, renderChunks(content)[0])
+}
+
+// Finish 用第一段内容编辑占位消息,其余内容作为新消息发送
+func (s *messageStream) Finish(ctx context.Context, content string) error {
+	chunks := renderChunks(content)
+	if err := editMessage(ctx, s.client, s.chatID, s.messageID, chunks[0]); err != nil {
+		return err
+	}
+
+	for _, c := range chunks[1:] {
+		if _, err := sendMessage(ctx, s.client, s.chatID, c, 0); err != nil {
+			logx.Error("Failed to send message chunk: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// editMessage 以 HTML 格式编辑消息,HTML 超长或格式解析失败时降级为纯文本
+func editMessage(ctx context.Context, client *Client, chatID, messageID int64, c chunk) error {
+	if c.fitsHTML() {
+		err := client.EditMessageText(ctx, chatID, messageID, c.html, ParseModeHTML)
+		if !IsParseError(err) && !IsTooLong(err) {
+			return err
+		}
+		logx.Debug("Failed to send HTML, falling back to plain text: %v", err)
+	}
+	return client.EditMessageText(ctx, chatID, messageID, truncateRunes(c.markdown, maxMessageLength), "")
+}
+
+// sendMessage 以 HTML 格式发送消息,HTML 超长或格式解析失败时降级为纯文本
+func sendMessage(ctx context.Context, client *Client, chatID int64, c chunk, replyTo int64) (int64, error) {
+	if c.fitsHTML() {
+		id, err := client.SendMessage(ctx, chatID, c.html, ParseModeHTML, replyTo)
+		if !IsParseError(err) && !IsTooLong(err) {
+			return id, err
+		}
+		logx.Debug("Failed to send HTML, falling back to plain text: %v", err)
+	}
+	return client.SendMessage(ctx, chatID, truncateRunes(c.markdown, maxMessageLength), "", replyTo)
+}
+
+// truncateRunes 按字符数截断
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit])
+}
@@ -0,0 +1,90 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/eryajf/zenops/internal/config"
+	"github.com/eryajf/zenops/internal/imcp"
+)
+
+// apiCall 模拟服务收到的 Bot API 请求
+type apiCall struct {
+	method string
+	body   map[string]any
+}
+
+// fakeTelegram 模拟 Telegram Bot API,记录收到的请求
+type fakeTelegram struct {
+	mu    sync.Mutex
+	calls []apiCall
+}
+
+func (f *fakeTelegram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	f.mu.Lock()
+	f.calls = append(f.calls, apiCall{method: r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], body: body})
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":100,"chat":{"id":1}}}`))
+}
+
+// take 返回并清空已记录的请求
+func (f *fakeTelegram) take() []apiCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := f.calls
+	f.calls = nil
+	return calls
+}
+
+func TestHandleMessageAllowedChats(t *testing.T) {
+	fake := &fakeTelegram{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.Telegram = config.TelegramConfig{Enabled: true, BotToken: "test-token", APIURL: server.URL, AllowedChatIDs: []int64{-1001}}
+
+	h, err := NewMessageHandler(cfg, imcp.NewMCPServer(cfg))
+	if err != nil {
+		t.Fatalf("NewMessageHandler() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		chatID int64
+		want   string
+	}{
+		{name: "allowed", chatID: -1001, want: "42"},
+		{name: "unauthorized", chatID: -2002, want: "chat_id -2002"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &Message{MessageID: 7, From: &User{ID: 42}, Chat: Chat{ID: tt.chatID, Type: "group"}, Text: "/whoami"}
+			if err := h.HandleMessage(context.Background(), msg); err != nil {
+				t.Fatalf("HandleMessage() error = %v", err)
+			}
+
+			calls := fake.take()
+			if len(calls) != 1 || calls[0].method != "sendMessage" {
+				t.Fatalf("calls = %+v, want one sendMessage", calls)
+			}
+			if chatID, _ := calls[0].body["chat_id"].(float64); int64(chatID) != tt.chatID {
+				t.Errorf("chat_id = %v, want %d", calls[0].body["chat_id"], tt.chatID)
+			}
+			if text, _ := calls[0].body["text"].(string); !strings.Contains(text, tt.want) {
+				t.Errorf("text = %q, want it to contain %q", text, tt.want)
+			}
+		})
+	}
+}
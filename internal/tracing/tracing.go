@@ -0,0 +1,209 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 链路追踪: 基于 OpenTelemetry SDK 创建 span,以 OTLP/HTTP 导出,通过 W3C Trace Context 传递链路
+
+// instrumentationName 上报 span 使用的 instrumentation 名称
+const instrumentationName = "github.com/eryajf/zenops"
+
+// SpanKind span 类型
+type SpanKind = trace.SpanKind
+
+const (
+	SpanKindInternal = trace.SpanKindInternal
+	SpanKindServer   = trace.SpanKindServer
+	SpanKindClient   = trace.SpanKindClient
+)
+
+// Attr span 属性
+type Attr = attribute.KeyValue
+
+// String 字符串属性
+func String(key, value string) Attr {
+	return attribute.String(key, value)
+}
+
+// Int 整数属性
+func Int(key string, value int) Attr {
+	return attribute.Int(key, value)
+}
+
+// Bool 布尔属性
+func Bool(key string, value bool) Attr {
+	return attribute.Bool(key, value)
+}
+
+// Span 一次操作的耗时记录,nil Span 的所有方法都是空操作
+type Span struct {
+	span trace.Span
+}
+
+// SetAttributes 设置属性
+func (s *Span) SetAttributes(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.span.SetAttributes(attrs...)
+}
+
+// RecordError 记录错误并将 span 标记为失败,err 为 nil 时忽略
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End 结束 span 并提交导出
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.span.End()
+}
+
+// propagator W3C Trace Context 传播器
+var propagator = propagation.TraceContext{}
+
+var (
+	provider   *sdktrace.TracerProvider
+	providerMu sync.RWMutex
+)
+
+// currentProvider 返回当前的 TracerProvider,未启用时为 nil
+func currentProvider() *sdktrace.TracerProvider {
+	providerMu.RLock()
+	defer providerMu.RUnlock()
+	return provider
+}
+
+// Init 根据配置初始化链路追踪,未启用时所有 span 都是空操作
+func Init(cfg config.TracingConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Endpoint == "" {
+		return fmt.Errorf("tracing endpoint is required")
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "zenops"
+	}
+	ratio := cfg.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+
+	// endpoint 为 OTLP HTTP 地址(如 http://localhost:4318),未包含路径时补全 /v1/traces
+	url := strings.TrimRight(cfg.Endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(url)}
+	if len(cfg.Headers) > 0 {
+		options = append(options, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		// 延续上游的采样结果,新链路按 trace ID 比例采样
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+
+	providerMu.Lock()
+	provider = tp
+	providerMu.Unlock()
+
+	logx.Info("🔭 Tracing enabled, OTLP endpoint %s, service %s, sample ratio %.2f", url, serviceName, ratio)
+	return nil
+}
+
+// Shutdown 导出剩余的 span 并关闭追踪器
+func Shutdown(ctx context.Context) error {
+	providerMu.Lock()
+	tp := provider
+	provider = nil
+	providerMu.Unlock()
+
+	if tp == nil {
+		return nil
+	}
+	return tp.Shutdown(ctx)
+}
+
+// Start 创建子 span,父 span 从 context 中获取;未启用追踪时返回 nil Span
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	return StartWithKind(ctx, name, SpanKindInternal, attrs...)
+}
+
+// StartWithKind 创建指定类型的子 span
+func StartWithKind(ctx context.Context, name string, kind SpanKind, attrs ...Attr) (context.Context, *Span) {
+	tp := currentProvider()
+	if tp == nil {
+		return ctx, nil
+	}
+
+	ctx, span := tp.Tracer(instrumentationName).Start(ctx, name,
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(attrs...))
+	return ctx, &Span{span: span}
+}
+
+// Headers 返回需要注入到下游 HTTP 请求的链路请求头,签名与 mcp-go 的 HTTPHeaderFunc 一致
+func Headers(ctx context.Context) map[string]string {
+	if currentProvider() == nil {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Inject 将链路请求头写入 HTTP 请求头
+func Inject(ctx context.Context, header http.Header) {
+	if currentProvider() == nil {
+		return
+	}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Extract 从上游 HTTP 请求头中解析链路上下文,后续创建的 span 将延续上游链路
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// TraceID 返回 context 中的 trace ID,用于日志关联,没有时返回空字符串
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/eryajf/zenops/internal/config"
+)
+
+func TestPropagationAndExport(t *testing.T) {
+	var exports atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			exports.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	// 未启用时不创建 span,也不注入请求头
+	if _, span := Start(context.Background(), "disabled"); span != nil {
+		t.Fatal("Start() returned a span before Init")
+	}
+
+	if err := Init(config.TracingConfig{Enabled: true, Endpoint: collector.URL}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	upstream := http.Header{}
+	upstream.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+	ctx := Extract(context.Background(), upstream)
+	ctx, span := StartWithKind(ctx, "test.request", SpanKindServer, String("test.key", "value"))
+	if got := TraceID(ctx); got != traceID {
+		t.Errorf("TraceID() = %s, want upstream trace %s", got, traceID)
+	}
+
+	headers := Headers(ctx)
+	if !strings.HasPrefix(headers["traceparent"], "00-"+traceID+"-") || strings.Contains(headers["traceparent"], "00f067aa0ba902b7") {
+		t.Errorf("traceparent = %q, want upstream trace with the new span as parent", headers["traceparent"])
+	}
+	span.End()
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if exports.Load() == 0 {
+		t.Error("spans were not exported to the collector")
+	}
+}
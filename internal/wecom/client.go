@@ -20,6 +20,7 @@ type UserReq struct {
 	Msgid    string `json:"msgid"`
 	Aibotid  string `json:"aibotid"`
 	Chattype string `json:"chattype"`
+	Chatid   string `json:"chatid"` // 群聊 ID,单聊时为空
 	From     struct {
 		Userid string `json:"userid"`
 	} `json:"from"`
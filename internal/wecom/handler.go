@@ -2,26 +2,24 @@ package wecom
 
 import (
 	"context"
-	"fmt"
 	"strings"
 	"sync"
 	"time"
 
 	"cnb.cool/zhiqiangwang/pkg/logx"
+	"github.com/eryajf/zenops/internal/bot"
 	"github.com/eryajf/zenops/internal/config"
 	"github.com/eryajf/zenops/internal/imcp"
-	"github.com/eryajf/zenops/internal/llm"
+	"github.com/eryajf/zenops/internal/tracing"
 	"github.com/google/uuid"
 )
 
 // ConversationState 对话状态
 type ConversationState struct {
-	Question         string
-	Buffer           strings.Builder
-	NotificationChan chan string
-	IsVisited        bool
-	IsDone           bool
-	Mutex            sync.Mutex
+	Question string
+	Buffer   strings.Builder
+	IsDone   bool
+	Mutex    sync.Mutex
 }
 
 // MessageHandler 企业微信消息处理器
@@ -29,7 +27,7 @@ type MessageHandler struct {
 	config              *config.Config
 	Client              *AIBotClient // 导出以便外部访问
 	mcpServer           *imcp.MCPServer
-	llmClient           *llm.Client
+	core                *bot.Core
 	conversationManager sync.Map // 存储对话状态
 	msgIDCache          sync.Map // 消息ID缓存,用于去重
 }
@@ -41,23 +39,18 @@ func NewMessageHandler(cfg *config.Config, mcpServer *imcp.MCPServer) (*MessageH
 		return nil, err
 	}
 
-	// 初始化 LLM 客户端
-	var llmClient *llm.Client
-	if cfg.LLM.Enabled {
-		llmConfig := &llm.Config{
-			Model:   cfg.LLM.Model,
-			APIKey:  cfg.LLM.APIKey,
-			BaseURL: cfg.LLM.BaseURL,
-		}
-		llmClient = llm.NewClient(llmConfig, mcpServer)
-		logx.Info("LLM client initialized for Wecom, model %s", cfg.LLM.Model)
-	}
+	// 企业微信由客户端轮询拉取内容,流式更新只写入缓存,无需节流
+	core := bot.NewCore(cfg, mcpServer, bot.Options{
+		Platform:       "wecom",
+		DisplayName:    "企业微信",
+		UpdateInterval: 100 * time.Millisecond,
+	})
 
 	handler := &MessageHandler{
 		config:    cfg,
 		Client:    client,
 		mcpServer: mcpServer,
-		llmClient: llmClient,
+		core:      core,
 	}
 
 	// 启动消息缓存清理协程
@@ -76,15 +69,12 @@ func (h *MessageHandler) HandleTextMessage(ctx context.Context, req *UserReq) (s
 
 	// 创建对话状态
 	state := &ConversationState{
-		Question:         req.Text.Content,
-		NotificationChan: make(chan string),
-		IsVisited:        false,
-		IsDone:           false,
+		Question: req.Text.Content,
 	}
 	h.conversationManager.Store(conversationID, state)
 
-	// 异步处理消息 - 使用独立的 background context,避免请求 context 取消
-	go h.processMessage(context.Background(), req, conversationID, state)
+	// 异步处理消息 - 使用不会随请求取消的 context,保留链路信息
+	go h.processMessage(context.WithoutCancel(ctx), req, state)
 
 	// 立即返回初始响应
 	return h.Client.MakeStreamResp("", req.Msgid, "<think>正在思考您的问题,请稍候...</think>", false)
@@ -92,6 +82,11 @@ func (h *MessageHandler) HandleTextMessage(ctx context.Context, req *UserReq) (s
 
 // HandleStreamRequest 处理流式轮询请求
 func (h *MessageHandler) HandleStreamRequest(ctx context.Context, req *UserReq) (string, error) {
+	_, span := tracing.StartWithKind(ctx, "wecom.HandleStreamRequest", tracing.SpanKindServer,
+		tracing.String("bot.platform", "wecom"),
+		tracing.String("wecom.stream_id", req.Stream.Id))
+	defer span.End()
+
 	// 从缓存中获取对话ID
 	conversationIDVal, ok := h.msgIDCache.Load(req.Stream.Id)
 	if !ok {
@@ -126,79 +121,72 @@ func (h *MessageHandler) HandleStreamRequest(ctx context.Context, req *UserReq)
 }
 
 // processMessage 处理用户消息
-func (h *MessageHandler) processMessage(ctx context.Context, req *UserReq, conversationID string, state *ConversationState) {
-	userMessage := strings.TrimSpace(req.Text.Content)
-	if userMessage == "" {
-		state.Mutex.Lock()
-		state.IsDone = true
-		state.Mutex.Unlock()
-		return
+func (h *MessageHandler) processMessage(ctx context.Context, req *UserReq, state *ConversationState) {
+	msg := &bot.Message{
+		ConversationID: req.Chatid,
+		UserID:         req.From.Userid,
+		Text:           req.Text.Content,
 	}
 
-	logx.Info("Processing message from Wecom: user %s, message %s", req.From.Userid, userMessage)
-
-	// 特殊命令处理
-	if strings.Contains(userMessage, "帮助") || strings.Contains(userMessage, "help") {
-		h.sendHelpMessage(state)
-		return
+	if err := h.core.HandleMessage(ctx, msg, &replier{state: state}); err != nil {
+		logx.Error("Failed to handle Wecom message: %v", err)
 	}
 
-	// 如果启用了 LLM,使用 LLM 处理
-	if h.config.LLM.Enabled && h.llmClient != nil {
-		h.processLLMMessage(ctx, userMessage, state)
+	// 无论是否回复成功都标记完成,避免客户端一直轮询
+	state.finish("")
+}
+
+// set 替换缓存的回复内容
+func (s *ConversationState) set(content string) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	if s.IsDone {
 		return
 	}
-
-	// 否则返回默认消息
-	state.Mutex.Lock()
-	state.Buffer.WriteString("ZenOps 企业微信机器人已收到您的消息。\n\n")
-	state.Buffer.WriteString("当前未启用 LLM 对话功能,请联系管理员配置。")
-	state.IsDone = true
-	state.Mutex.Unlock()
+	s.Buffer.Reset()
+	s.Buffer.WriteString(content)
 }
 
-// processLLMMessage 使用 LLM 处理消息
-func (h *MessageHandler) processLLMMessage(ctx context.Context, userMessage string, state *ConversationState) {
-	// 调用 LLM 流式对话
-	responseCh, err := h.llmClient.ChatWithToolsAndStream(ctx, userMessage)
-	if err != nil {
-		logx.Error("Failed to call LLM: %v", err)
-		state.Mutex.Lock()
-		state.Buffer.WriteString(fmt.Sprintf("❌ LLM 调用失败: %v", err))
-		state.IsDone = true
-		state.Mutex.Unlock()
+// finish 写入最终内容并标记完成,content 为空时保留已有内容
+func (s *ConversationState) finish(content string) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	if s.IsDone {
 		return
 	}
-
-	// 流式接收并缓存响应
-	for event := range responseCh {
-		state.Mutex.Lock()
-		state.Buffer.WriteString(event)
-		if state.IsVisited {
-			select {
-			case state.NotificationChan <- event:
-			default:
-			}
-		}
-		state.Mutex.Unlock()
+	if content != "" {
+		s.Buffer.Reset()
+		s.Buffer.WriteString(content)
 	}
+	s.IsDone = true
+}
 
-	// 标记完成
-	state.Mutex.Lock()
-	state.IsDone = true
-	state.Mutex.Unlock()
-	close(state.NotificationChan)
+// replier 企业微信回复适配器: 回复内容写入对话状态,由客户端轮询拉取
+type replier struct {
+	state *ConversationState
+}
 
-	logx.Info("LLM conversation completed for Wecom")
+// Reply 写入完整回复
+func (r *replier) Reply(_ context.Context, content string) error {
+	r.state.finish(content)
+	return nil
 }
 
-// sendHelpMessage 发送帮助消息
-func (h *MessageHandler) sendHelpMessage(state *ConversationState) {
-	helpText := GetHelpMessage()
-	state.Mutex.Lock()
-	state.Buffer.WriteString(helpText)
-	state.IsDone = true
-	state.Mutex.Unlock()
+// StartStream 开始流式回复,对话状态本身即为流
+func (r *replier) StartStream(_ context.Context, _ string) (bot.Stream, error) {
+	return r, nil
+}
+
+// Update 更新中间内容
+func (r *replier) Update(_ context.Context, content string) error {
+	r.state.set(content)
+	return nil
+}
+
+// Finish 写入最终内容
+func (r *replier) Finish(_ context.Context, content string) error {
+	r.state.finish(content)
+	return nil
 }
 
 // startMessageCleanup 启动消息缓存清理协程
@@ -225,35 +213,3 @@ func (h *MessageHandler) startMessageCleanup() {
 		logx.Debug("Wecom message cleanup completed at %s", now.Format("2006-01-02 15:04:05"))
 	}
 }
-
-// GetHelpMessage 获取帮助信息
-func GetHelpMessage() string {
-	return `# ZenOps 企业微信机器人使用指南
-
-## 功能说明
-ZenOps 是一个运维工具集成平台,支持通过企业微信智能机器人与云平台交互。
-
-## 支持的功能
-
-### 1. LLM 智能对话
-直接发送问题,机器人会通过 AI 大模型为您解答。
-
-**示例:**
-• 帮我查询阿里云 ECS 列表
-• 列出腾讯云的 CVM 实例
-• 查看 Jenkins 最近的构建任务
-
-### 2. 云平台查询
-支持查询以下云平台资源:
-• **阿里云**: ECS、RDS 等
-• **腾讯云**: CVM、CDB 等
-• **Jenkins**: 构建任务、Job 状态等
-
-## 使用提示
-• 发送 "帮助" 或 "help" 查看此帮助信息
-• 私聊机器人即可使用
-
-## 技术支持
-如有问题,请联系运维团队。
-`
-}